@@ -6,7 +6,11 @@ import (
 	"time"
 
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito"
 	s3client "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/s3"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/calendly"
@@ -23,11 +27,16 @@ import (
 	file_converter "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/file_converter"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks"
 	frameworksClient "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/client"
+	controlRemediations "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/jira"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/knowbe4"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/onboarding"
 	penetrationtesting "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/penetration_testing"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/attestations"
+	policyControls "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/controls"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/questionnaires"
 	questionnairesClient "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/questionnaires/client"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/rapid7"
@@ -47,6 +56,7 @@ import (
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/db"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/health"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/health/check"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/jwt"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/log"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/module"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/grpc"
@@ -65,6 +75,7 @@ var apiCommand = &cobra.Command{
 				func(db *sql.DB) []check.Checker { return []check.Checker{check.NewSQLChecker(db)} },
 			),
 			db.Module,
+			jwt.Module,
 			httpTransport.Module,
 			transport.ModuleAPI,
 			stringsvc.ModuleHttpAPI,
@@ -80,6 +91,12 @@ var apiCommand = &cobra.Command{
 			log.Module,
 			salesforce.Module,
 			auth.Module,
+			apikeys.ModuleHttpAPI,
+			impersonation.ModuleHttpAPI,
+			sessions.Module,
+			sessions.ModuleHttpAPI,
+			audit.Module,
+			audit.ModuleHttpAPI,
 			cognito.Module,
 			applications.ModuleHttpAPI,
 			websites.ModuleHttpAPI,
@@ -90,6 +107,9 @@ var apiCommand = &cobra.Command{
 			signatures.ModuleHttpAPI,
 			ipranges.ModuleHttpAPI,
 			policies.ModuleHttpAPI,
+			attestations.ModuleHttpAPI,
+			exports.ModuleHttpAPI,
+			policyControls.ModuleHttpAPI,
 			vulnerability.ModuleHttpAPI,
 			file_converter.ModuleHttpAPI,
 			penetrationtesting.ModuleHttpAPI,
@@ -98,6 +118,8 @@ var apiCommand = &cobra.Command{
 			questionnairesClient.ModuleClient,
 			frameworks.ModuleHttpAPI,
 			frameworksClient.ModuleClient,
+			controlRemediations.ModuleHttpAPI,
+			snapshots.ModuleHttpAPI,
 			customer_success.ModuleHttpAPI,
 			calendly.Module,
 			cache.Module,
@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/catalog"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/service"
+	snapshotsEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/entities"
+	snapshotsRepository "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/repository"
+	snapshotsService "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/service"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/cfg"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/db"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// frameworksConfig is the part of the service config needed to import framework catalogs.
+type frameworksConfig struct {
+	DB db.Config
+}
+
+// Validate config
+func (c *frameworksConfig) Validate() error {
+	return c.DB.Validate()
+}
+
+var (
+	catalogFormat    string
+	catalogFramework string
+	catalogDryRun    bool
+	mappingsDryRun   bool
+	backfillFrom     string
+	backfillTo       string
+)
+
+var frameworksCommand = &cobra.Command{
+	Use:   "frameworks",
+	Short: "Manage frameworks and their controls",
+}
+
+var frameworksImportCommand = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import the controls of a framework from an OSCAL catalog or a CSV file",
+	Long: `Import the controls of a framework from a NIST OSCAL catalog in JSON or a CSV file.
+
+Controls are matched to the controls of the framework by control id: new ones are added, changed
+ones are updated and controls missing from the catalog are retired. The framework is created when
+there is none with the name.
+
+CSV files start with a header row naming the columns, in any order:
+  control_id      stable identifier of the control (required)
+  name            short name of the control, like 1 or OR-1
+  topic           title of the control
+  domain          domain of the control
+  best_practices  what the control requires
+  solution        how to implement the control
+  groups          groups or profiles of the control, separated by ';', like IG1;IG2`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := catalogFormat
+		if format == "" {
+			if format = catalog.FormatOf(args[0]); format == "" {
+				return errors.Errorf("cannot tell the format of %s, use --format", args[0])
+			}
+		}
+
+		if format == entities.CatalogFormatCSV && catalogFramework == "" {
+			return errors.New("--framework is required for csv catalogs")
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close() // nolint: errcheck
+
+		c, err := catalog.Parse(f, format, catalogFramework)
+		if err != nil {
+			return err
+		}
+
+		svc, err := newFrameworksService()
+		if err != nil {
+			return err
+		}
+
+		report, err := svc.ImportCatalog(context.Background(), c, catalogDryRun)
+		if err != nil {
+			return err
+		}
+
+		return printCatalogImportReport(report)
+	},
+}
+
+var frameworksImportMappingsCommand = &cobra.Command{
+	Use:   "import-mappings <file>",
+	Short: "Import the mappings between equivalent controls of different frameworks from a CSV file",
+	Long: `Import the mappings between equivalent controls of different frameworks from a CSV file.
+
+A remediated control satisfies the controls mapped to it in the other frameworks of a company.
+The mappings between the frameworks of the file are replaced by the ones of the file.
+
+CSV files start with a header row naming the columns, in any order:
+  framework          name of the framework of the control
+  control_id         control id of the control
+  mapped_framework   name of the framework of the equivalent control
+  mapped_control_id  control id of the equivalent control`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close() // nolint: errcheck
+
+		rows, err := catalog.ParseMappingsCSV(f)
+		if err != nil {
+			return err
+		}
+
+		svc, err := newFrameworksService()
+		if err != nil {
+			return err
+		}
+
+		report, err := svc.ImportControlMappings(context.Background(), rows, mappingsDryRun)
+		if err != nil {
+			return err
+		}
+
+		return printControlMappingImportReport(report)
+	},
+}
+
+var frameworksBackfillSnapshotsCommand = &cobra.Command{
+	Use:   "backfill-snapshots",
+	Short: "Build the daily compliance snapshots of past days from the timestamps of the data",
+	Long: `Build the daily compliance snapshots of every framework of every company for the days from
+--from to --to, yesterday by default, as the data stood at the end of each day.
+
+Only the current status of a remediation is stored, so a remediation changed after a day counts as
+not started on that day unless it was already completed. Days which already have a snapshot are
+kept, and the API records the snapshot of today.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, err := time.Parse(snapshotsEntities.DateLayout, backfillFrom)
+		if err != nil {
+			return errors.Errorf("--from should be a date like %s", snapshotsEntities.DateLayout)
+		}
+
+		to := time.Now().UTC().AddDate(0, 0, -1)
+		if backfillTo != "" {
+			if to, err = time.Parse(snapshotsEntities.DateLayout, backfillTo); err != nil {
+				return errors.Errorf("--to should be a date like %s", snapshotsEntities.DateLayout)
+			}
+		}
+
+		_, gormDB, err := newFrameworksDB()
+		if err != nil {
+			return err
+		}
+
+		svc := snapshotsService.New(snapshotsRepository.New(gormDB), zap.NewNop().Sugar())
+
+		report, err := svc.Backfill(context.Background(), from, to)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("days: %s to %s (%d), snapshots created: %d\n", report.From, report.To, report.Days, report.Created)
+
+		return nil
+	},
+}
+
+func newFrameworksDB() (*sql.DB, *gorm.DB, error) {
+	var config frameworksConfig
+
+	if err := cfg.Init("config", cfgFile, &config); err != nil {
+		return nil, nil, errors.Wrap(err, "init configs failed")
+	}
+
+	sqlDB, gormDB, err := db.New(&config.DB)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to init postgresql client")
+	}
+
+	return sqlDB, gormDB, nil
+}
+
+func newFrameworksService() (service.Service, error) {
+	sqlDB, gormDB, err := newFrameworksDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return service.New(repository.New(gormDB, sqlDB), nil, zap.NewNop().Sugar()), nil
+}
+
+func printCatalogImportReport(report *entities.CatalogImportReport) error {
+	framework := report.Framework
+	if report.NewFramework {
+		framework += " (new)"
+	}
+
+	fmt.Printf("framework: %s\n", framework)
+	fmt.Printf("added: %d, changed: %d, retired: %d, unchanged: %d\n", len(report.Added), len(report.Changed), len(report.Retired), report.Unchanged)
+
+	if report.DryRun {
+		fmt.Println("dry run, nothing was saved")
+	}
+
+	if len(report.Added)+len(report.Changed)+len(report.Retired) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join([]string{"CHANGE", "CONTROL ID", "NAME", "FIELDS"}, "\t"))
+
+	for _, group := range []struct {
+		change   string
+		controls []*entities.CatalogControlChange
+	}{
+		{"added", report.Added},
+		{"changed", report.Changed},
+		{"retired", report.Retired},
+	} {
+		for _, c := range group.controls {
+			fmt.Fprintln(w, strings.Join([]string{group.change, c.ControlId, c.Name, strings.Join(c.Fields, ", ")}, "\t"))
+		}
+	}
+
+	return w.Flush()
+}
+
+func printControlMappingImportReport(report *entities.ControlMappingImportReport) error {
+	fmt.Printf("added: %d, removed: %d, unchanged: %d\n", len(report.Added), len(report.Removed), report.Unchanged)
+
+	if report.DryRun {
+		fmt.Println("dry run, nothing was saved")
+	}
+
+	if len(report.Added)+len(report.Removed) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join([]string{"CHANGE", "FRAMEWORK", "CONTROL ID", "MAPPED FRAMEWORK", "MAPPED CONTROL ID"}, "\t"))
+
+	for _, group := range []struct {
+		change   string
+		mappings []*entities.ControlMappingRow
+	}{
+		{"added", report.Added},
+		{"removed", report.Removed},
+	} {
+		for _, m := range group.mappings {
+			fmt.Fprintln(w, strings.Join([]string{group.change, m.Framework, m.ControlId, m.MappedFramework, m.MappedControlId}, "\t"))
+		}
+	}
+
+	return w.Flush()
+}
+
+func init() {
+	frameworksImportCommand.Flags().StringVar(&catalogFormat, "format", "", fmt.Sprintf("format of the catalog, %s or %s, by default from the file extension", entities.CatalogFormatOSCAL, entities.CatalogFormatCSV))
+	frameworksImportCommand.Flags().StringVar(&catalogFramework, "framework", "", "name of the framework, by default the title of an OSCAL catalog")
+	frameworksImportCommand.Flags().BoolVar(&catalogDryRun, "dry-run", false, "report the changes without saving them")
+
+	frameworksImportMappingsCommand.Flags().BoolVar(&mappingsDryRun, "dry-run", false, "report the changes without saving them")
+
+	frameworksBackfillSnapshotsCommand.Flags().StringVar(&backfillFrom, "from", "", "first day to backfill, like 2023-01-01")
+	frameworksBackfillSnapshotsCommand.Flags().StringVar(&backfillTo, "to", "", "last day to backfill, yesterday by default")
+	_ = frameworksBackfillSnapshotsCommand.MarkFlagRequired("from") // nolint: errcheck
+
+	frameworksCommand.AddCommand(frameworksImportCommand)
+	frameworksCommand.AddCommand(frameworksImportMappingsCommand)
+	frameworksCommand.AddCommand(frameworksBackfillSnapshotsCommand)
+	rootCmd.AddCommand(frameworksCommand)
+}
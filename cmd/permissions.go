@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	authCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/cfg"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/db"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+// permissionsConfig is the part of the service config needed to load the permission policy.
+type permissionsConfig struct {
+	DB   db.Config
+	Auth authCfg.Config
+}
+
+// Validate config
+func (c *permissionsConfig) Validate() error {
+	if c.Auth.Permissions.Source == authCfg.PermissionSourceDB {
+		if err := c.DB.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return c.Auth.Validate()
+}
+
+var permissionsCommand = &cobra.Command{
+	Use:   "permissions",
+	Short: "Inspect the permission policy",
+}
+
+var permissionsLintCommand = &cobra.Command{
+	Use:          "lint",
+	Short:        "Print the effective permission matrix and report undefined subjects",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var config permissionsConfig
+
+		if err := cfg.Init("config", cfgFile, &config); err != nil {
+			return errors.Wrap(err, "init configs failed")
+		}
+
+		var gormDB *gorm.DB
+		if config.Auth.Permissions.Source == authCfg.PermissionSourceDB {
+			var err error
+			if _, gormDB, err = db.New(&config.DB); err != nil {
+				return errors.Wrap(err, "failed to init postgresql client")
+			}
+		}
+
+		policy, err := auth.LoadPolicy(context.Background(), config.Auth, gormDB)
+		if err != nil {
+			return err
+		}
+
+		printPermissionMatrix(policy)
+
+		features := make([]string, 0, len(policy.Features))
+		for feature := range policy.Features {
+			features = append(features, feature)
+		}
+
+		if gaps := permissions.Gaps(policy, features); len(gaps) > 0 {
+			fmt.Println()
+			for _, gap := range gaps {
+				fmt.Println(gap)
+			}
+
+			return errors.Errorf("%d undefined permission(s)", len(gaps))
+		}
+
+		return nil
+	},
+}
+
+func printPermissionMatrix(policy *permissions.Policy) {
+	fmt.Printf("permission policy version %d\n\n", policy.Version)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	header := []string{"FEATURE", "SUBJECT"}
+	for _, a := range permissions.Actions {
+		header = append(header, strings.ToUpper(string(a)))
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	engine := permissions.NewEngine(policy)
+	for _, feature := range sortedKeys(policy.Features) {
+		for _, subject := range permissions.Subjects() {
+			row := []string{feature, subject.Key()}
+			for _, a := range permissions.Actions {
+				mark := "-"
+				if engine.IsAllowed(feature, subject, a) {
+					mark = "x"
+				}
+				row = append(row, mark)
+			}
+			fmt.Fprintln(w, strings.Join(row, "\t"))
+		}
+	}
+
+	_ = w.Flush() // nolint: errcheck
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func init() {
+	permissionsCommand.AddCommand(permissionsLintCommand)
+	rootCmd.AddCommand(permissionsCommand)
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/webhooktokens/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/webhooktokens/service"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/cfg"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/db"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/jwt"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// tokenConfig is the part of the service config needed to manage webhook tokens.
+type tokenConfig struct {
+	DB  db.Config
+	JWT jwt.Config
+}
+
+// Validate config
+func (c *tokenConfig) Validate() error {
+	return cfg.ValidateConfigs(&c.DB, &c.JWT)
+}
+
+var (
+	tokenAudience    string
+	tokenDescription string
+	tokenExpiresIn   time.Duration
+)
+
+var tokenCommand = &cobra.Command{
+	Use:   "token",
+	Short: "Issue, list and revoke webhook tokens",
+}
+
+var tokenIssueCommand = &cobra.Command{
+	Use:          "issue",
+	Short:        "Issue a webhook token for an audience",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := newWebhookTokenService()
+		if err != nil {
+			return err
+		}
+
+		var expiresAt time.Time
+		if tokenExpiresIn > 0 {
+			expiresAt = time.Now().Add(tokenExpiresIn)
+		}
+
+		issued, err := svc.Issue(context.Background(), tokenAudience, tokenDescription, expiresAt)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("jti: %s\n", issued.Jti)
+		fmt.Println(issued.Token)
+
+		return nil
+	},
+}
+
+var tokenListCommand = &cobra.Command{
+	Use:          "list",
+	Short:        "List issued webhook tokens",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := newWebhookTokenService()
+		if err != nil {
+			return err
+		}
+
+		tokens, err := svc.List(context.Background())
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, strings.Join([]string{"JTI", "AUDIENCE", "KID", "ISSUED", "EXPIRES", "REVOKED", "DESCRIPTION"}, "\t"))
+
+		for _, t := range tokens {
+			fmt.Fprintln(w, strings.Join([]string{
+				t.Jti.String(),
+				t.Audience,
+				t.Kid,
+				t.IssuedAt.Format(time.RFC3339),
+				formatNullTime(t.ExpiresAt.Valid, t.ExpiresAt.Time),
+				formatNullTime(t.RevokedAt.Valid, t.RevokedAt.Time),
+				t.Description,
+			}, "\t"))
+		}
+
+		return w.Flush()
+	},
+}
+
+var tokenRevokeCommand = &cobra.Command{
+	Use:          "revoke <jti>",
+	Short:        "Revoke a webhook token",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		jti, err := uuid.Parse(args[0])
+		if err != nil {
+			return errors.Wrap(err, "invalid jti")
+		}
+
+		svc, err := newWebhookTokenService()
+		if err != nil {
+			return err
+		}
+
+		if err = svc.Revoke(context.Background(), jti); err != nil {
+			return err
+		}
+
+		fmt.Printf("revoked %s\n", jti)
+
+		return nil
+	},
+}
+
+func newWebhookTokenService() (service.Service, error) {
+	var config tokenConfig
+
+	if err := cfg.Init("config", cfgFile, &config); err != nil {
+		return nil, errors.Wrap(err, "init configs failed")
+	}
+
+	keyRing, err := jwt.NewKeyRing(config.JWT)
+	if err != nil {
+		return nil, err
+	}
+
+	_, gormDB, err := db.New(&config.DB)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init postgresql client")
+	}
+
+	return service.New(repository.New(gormDB), keyRing), nil
+}
+
+func formatNullTime(valid bool, t time.Time) string {
+	if !valid {
+		return "-"
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func init() {
+	tokenIssueCommand.Flags().StringVar(&tokenAudience, "audience", "", fmt.Sprintf("audience of the token, one of %s", strings.Join(jwt.Audiences, ", ")))
+	tokenIssueCommand.Flags().StringVar(&tokenDescription, "description", "", "what the token is used for")
+	tokenIssueCommand.Flags().DurationVar(&tokenExpiresIn, "expires-in", 0, "lifetime of the token, 0 for no expiry")
+	_ = tokenIssueCommand.MarkFlagRequired("audience") // nolint: errcheck
+
+	tokenCommand.AddCommand(tokenIssueCommand, tokenListCommand, tokenRevokeCommand)
+	rootCmd.AddCommand(tokenCommand)
+}
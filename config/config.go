@@ -3,18 +3,23 @@ package config
 import (
 	"strings"
 
+	authCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/config"
 	cognitoCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito/config"
 	s3 "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/s3/config"
 	calendly "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/calendly/config"
+	frameworksCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/config"
 	jira "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/jira/config"
+	policiesCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/config"
 	rapid7Config "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/rapid7/config"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/salesforce/config"
 	sesCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/ses/config"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/cfg"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/db"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/jwt"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/log"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/webhook"
 	"github.com/pkg/errors"
 	"go.uber.org/fx"
 )
@@ -35,6 +40,11 @@ type Config struct {
 	AccessControlAllowOrigins svcTransport.AccessControlAllowOrigins
 	Jira                      jira.Config
 	S3                        s3.Config
+	Auth                      authCfg.Config
+	JWT                       jwt.Config
+	Webhooks                  webhook.Config
+	Policies                  policiesCfg.Config
+	Frameworks                frameworksCfg.Config
 }
 
 // Validate config
@@ -42,7 +52,8 @@ func (c *Config) Validate() error {
 	var errs []string
 
 	validatables := []cfg.Validatable{
-		&c.Common, &c.Transport.GRPC, &c.Logger, &c.Salesforce, &c.Calendly, &c.Rapid7, &c.Ses, &c.Jira,
+		&c.Common, &c.Transport.GRPC, &c.Logger, &c.Salesforce, &c.Calendly, &c.Rapid7, &c.Ses, &c.Jira, &c.Auth, &c.JWT,
+		&c.Webhooks, &c.Policies, &c.Frameworks,
 	}
 
 	if err := cfg.ValidateConfigs(validatables...); err != nil {
@@ -1,169 +0,0 @@
-
-INSERT INTO public.framework_controls (
-        "framework_control_uuid",
-        "frameworks_uuid",
-        "topic",
-        "name",
-        "domain",
-        "best_practices",
-        "solution",
-        "groups",
-        "created_at",
-        "updated_at"
-    )
-VALUES
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Inventory and Control of Enterprise Assets', '1', 'Devices', 'Establish and Maintain Detailed Enterprise Asset Inventory', 'Establish and maintain an accurate, detailed, and up-to-date inventory of all enterprise assets with the potential to store or process data, to include: end-user devices (including portable and mobile), network devices, non-computing/IoT devices, and servers. Ensure the inventory records the network address (if static), hardware address, machine name, enterprise asset owner, department for each asset, and whether the asset has been approved to connect to the network. For mobile end-user devices, MDM type tools can support this process, where appropriate. This inventory includes assets connected to the infrastructure physically, virtually, remotely, and those within cloud environments. Additionally, it includes assets that are regularly connected to the enterprise’s network infrastructure, even if they are not under control of the enterprise. Review and update the inventory of all enterprise assets bi-annually, or more frequently.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Inventory and Control of Enterprise Assets', '1', 'Devices', 'Address Unauthorized Assets', 'Ensure that a process exists to address unauthorized assets on a weekly basis. The enterprise may choose to remove the asset from the network, deny the asset from connecting remotely to the network, or quarantine the asset.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Inventory and Control of Enterprise Assets', '1', 'Devices', 'Utilize an Active Discovery Tool', 'Utilize an active discovery tool to identify assets connected to the enterprise’s network. Configure the active discovery tool to execute daily, or more frequently.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Inventory and Control of Enterprise Assets', '1', 'Devices', 'Use Dynamic Host Configuration Protocol (DHCP) Logging to Update Enterprise Asset Inventory', 'Use DHCP logging on all DHCP servers or Internet Protocol (IP) address management tools to update the enterprise’s asset inventory. Review and use logs to update the enterprise’s asset inventory weekly, or more frequently.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Inventory and Control of Enterprise Assets', '1', 'Devices', 'Use a Passive Asset Discovery Tool', 'Use a passive discovery tool to identify assets connected to the enterprise’s network. Review and use scans to update the enterprise’s asset inventory at least weekly, or more frequently.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Inventory and Control of Software Assets', '2', 'Applications', 'Establish and Maintain a Software Inventory', 'Establish and maintain a detailed inventory of all licensed software installed on enterprise assets. The software inventory must document the title, publisher, initial install/use date, and business purpose for each entry; where appropriate, include the Uniform Resource Locator (URL), app store(s), version(s), deployment mechanism, and decommission date. Review and update the software inventory bi-annually, or more frequently.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Inventory and Control of Software Assets', '2', 'Applications', 'Ensure Authorized Software is Currently Supported', 'Ensure that only currently supported software is designated as authorized in the software inventory for enterprise assets. If software is unsupported, yet necessary for the fulfillment of the enterprise’s mission, document an exception detailing mitigating controls and residual risk acceptance. For any unsupported software without an exception documentation, designate as unauthorized. Review the software list to verify software support at least monthly, or more frequently.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Inventory and Control of Software Assets', '2', 'Applications', 'Address Unauthorized Software', 'Ensure that unauthorized software is either removed from use on enterprise assets or receives a documented exception. Review monthly, or more frequently.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Inventory and Control of Software Assets', '2', 'Applications', 'Utilize Automated Software Inventory Tools', 'Utilize software inventory tools, when possible, throughout the enterprise to automate the discovery and documentation of installed software.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Inventory and Control of Software Assets', '2', 'Applications', 'Allowlist Authorized Software', 'Use technical controls, such as application allowlisting, to ensure that only authorized software can execute or be accessed. Reassess bi-annually, or more frequently.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Inventory and Control of Software Assets', '2', 'Applications', 'Allowlist Authorized Libraries', 'Use technical controls to ensure that only authorized software libraries, such as specific .dll, .ocx, .so, etc., files, are allowed to load into a system process. Block unauthorized libraries from loading into a system process. Reassess bi-annually, or more frequently.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Inventory and Control of Software Assets', '2', 'Applications', 'Allowlist Authorized Scripts', 'Use technical controls, such as digital signatures and version control, to ensure that only authorized scripts, such as specific .ps1, .py, etc., files, are allowed to execute. Block unauthorized scripts from executing. Reassess bi-annually, or more frequently.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Protection', '3', 'Data', 'Establish and Maintain a Data Management Process', 'Establish and maintain a data management process. In the process, address data sensitivity, data owner, handling of data, data retention limits, and disposal requirements, based on sensitivity and retention standards for the enterprise. Review and update documentation annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Protection', '3', 'Data', 'Establish and Maintain a Data Inventory', 'Establish and maintain a data inventory, based on the enterprise’s data management process. Inventory sensitive data, at a minimum. Review and update inventory annually, at a minimum, with a priority on sensitive data.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Protection', '3', 'Data', 'Configure Data Access Control Lists', 'Configure data access control lists based on a user’s need to know. Apply data access control lists, also known as access permissions, to local and remote file systems, databases, and applications.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Protection', '3', 'Data', 'Enforce Data Retention', 'Retain data according to the enterprise’s data management process. Data retention must include both minimum and maximum timelines.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Protection', '3', 'Data', 'Securely Dispose of Data', 'Securely dispose of data as outlined in the enterprise’s data management process. Ensure the disposal process and method are commensurate with the data sensitivity.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Protection', '3', 'Devices', 'Encrypt Data on End-User Devices', 'Encrypt data on end-user devices containing sensitive data. Example implementations can include: Windows BitLocker®, Apple FileVault®, Linux® dm-crypt.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Protection', '3', 'Data', 'Establish and Maintain a Data Classification Scheme', 'Establish and maintain an overall data classification scheme for the enterprise. Enterprises may use labels, such as “Sensitive,” “Confidential,” and “Public,” and classify their data according to those labels. Review and update the classification scheme annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Protection', '3', 'Data', 'Document Data Flows', 'Document data flows. Data flow documentation includes service provider data flows and should be based on the enterprise’s data management process. Review and update documentation annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Protection', '3', 'Data', 'Encrypt Data on Removable Media', 'Encrypt data on removable media.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Protection', '3', 'Data', 'Encrypt Sensitive Data in Transit', 'Encrypt sensitive data in transit. Example implementations can include: Transport Layer Security (TLS) and Open Secure Shell (OpenSSH).', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Protection', '3', 'Data', 'Encrypt Sensitive Data at Rest', 'Encrypt sensitive data at rest on servers, applications, and databases containing sensitive data. Storage-layer encryption, also known as server-side encryption, meets the minimum requirement of this Safeguard. Additional encryption methods may include application-layer encryption, also known as client-side encryption, where access to the data storage device(s) does not permit access to the plain-text data.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Protection', '3', 'Network', 'Segment Data Processing and Storage Based on Sensitivity', 'Segment data processing and storage based on the sensitivity of the data. Do not process sensitive data on enterprise assets intended for lower sensitivity data.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Protection', '3', 'Data', 'Deploy a Data Loss Prevention Solution', 'Implement an automated tool, such as a host-based Data Loss Prevention (DLP) tool to identify all sensitive data stored, processed, or transmitted through enterprise assets, including those located onsite or at a remote service provider, and update the enterprise''s sensitive data inventory.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Protection', '3', 'Data', 'Log Sensitive Data Access', 'Log sensitive data access, including modification and disposal.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Secure Configuration of Enterprise Assets and Software', '4', 'Applications', 'Establish and Maintain a Secure Configuration Process', 'Establish and maintain a secure configuration process for enterprise assets (end-user devices, including portable and mobile, non-computing/IoT devices, and servers) and software (operating systems and applications). Review and update documentation annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Secure Configuration of Enterprise Assets and Software', '4', 'Network', 'Establish and Maintain a Secure Configuration Process for Network Infrastructure', 'Establish and maintain a secure configuration process for network devices. Review and update documentation annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Secure Configuration of Enterprise Assets and Software', '4', 'Users', 'Configure Automatic Session Locking on Enterprise Assets', 'Configure automatic session locking on enterprise assets after a defined period of inactivity. For general purpose operating systems, the period must not exceed 15 minutes. For mobile end-user devices, the period must not exceed 2 minutes.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Secure Configuration of Enterprise Assets and Software', '4', 'Devices', 'Implement and Manage a Firewall on Servers', 'Implement and manage a firewall on servers, where supported. Example implementations include a virtual firewall, operating system firewall, or a third-party firewall agent.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Secure Configuration of Enterprise Assets and Software', '4', 'Devices', 'Implement and Manage a Firewall on End-User Devices', 'Implement and manage a host-based firewall or port-filtering tool on end-user devices, with a default-deny rule that drops all traffic except those services and ports that are explicitly allowed.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Secure Configuration of Enterprise Assets and Software', '4', 'Network', 'Securely Manage Enterprise Assets and Software', 'Securely manage enterprise assets and software. Example implementations include managing configuration through version-controlled-infrastructure-as-code and accessing administrative interfaces over secure network protocols, such as Secure Shell (SSH) and Hypertext Transfer Protocol Secure (HTTPS). Do not use insecure management protocols, such as Telnet (Teletype Network) and HTTP, unless operationally essential.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Secure Configuration of Enterprise Assets and Software', '4', 'Users', 'Manage Default Accounts on Enterprise Assets and Software', 'Manage default accounts on enterprise assets and software, such as root, administrator, and other pre-configured vendor accounts. Example implementations can include: disabling default accounts or making them unusable.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Secure Configuration of Enterprise Assets and Software', '4', 'Devices', 'Uninstall or Disable Unnecessary Services on Enterprise Assets and Software', 'Uninstall or disable unnecessary services on enterprise assets and software, such as an unused file sharing service, web application module, or service function.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Secure Configuration of Enterprise Assets and Software', '4', 'Devices', 'Configure Trusted DNS Servers on Enterprise Assets', 'Configure trusted DNS servers on enterprise assets. Example implementations include: configuring assets to use enterprise-controlled DNS servers and/or reputable externally accessible DNS servers.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Secure Configuration of Enterprise Assets and Software', '4', 'Devices', 'Enforce Automatic Device Lockout on Portable End-User Devices', 'Enforce automatic device lockout following a predetermined threshold of local failed authentication attempts on portable end-user devices, where supported. For laptops, do not allow more than 20 failed authentication attempts; for tablets and smartphones, no more than 10 failed authentication attempts. Example implementations include Microsoft® InTune Device Lock and Apple® Configuration Profile maxFailedAttempts.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Secure Configuration of Enterprise Assets and Software', '4', 'Devices', 'Enforce Remote Wipe Capability on Portable End-User Devices', 'Remotely wipe enterprise data from enterprise-owned portable end-user devices when deemed appropriate such as lost or stolen devices, or when an individual no longer supports the enterprise.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Secure Configuration of Enterprise Assets and Software', '4', 'Devices', 'Separate Enterprise Workspaces on Mobile End-User Devices', 'Ensure separate enterprise workspaces are used on mobile end-user devices, where supported. Example implementations include using an Apple® Configuration Profile or Android™ Work Profile to separate enterprise applications and data from personal applications and data.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Account Management', '5', 'Users', 'Establish and Maintain an Inventory of Accounts', 'Establish and maintain an inventory of all accounts managed in the enterprise. The inventory must include both user and administrator accounts. The inventory, at a minimum, should contain the person’s name, username, start/stop dates, and department. Validate that all active accounts are authorized, on a recurring schedule at a minimum quarterly, or more frequently.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Account Management', '5', 'Users', 'Use Unique Passwords', 'Use unique passwords for all enterprise assets. Best practice implementation includes, at a minimum, an 8-character password for accounts using MFA and a 14-character password for accounts not using MFA.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Account Management', '5', 'Users', 'Disable Dormant Accounts', 'Delete or disable any dormant accounts after a period of 45 days of inactivity, where supported.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Account Management', '5', 'Users', 'Restrict Administrator Privileges to Dedicated Administrator Accounts', 'Restrict administrator privileges to dedicated administrator accounts on enterprise assets. Conduct general computing activities, such as internet browsing, email, and productivity suite use, from the user’s primary, non-privileged account.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Account Management', '5', 'Users', 'Establish and Maintain an Inventory of Service Accounts', 'Establish and maintain an inventory of service accounts. The inventory, at a minimum, must contain department owner, review date, and purpose. Perform service account reviews to validate that all active accounts are authorized, on a recurring schedule at a minimum quarterly, or more frequently.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Account Management', '5', 'Users', 'Centralize Account Management', 'Centralize account management through a directory or identity service.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Access Control Management', '6', 'Users', 'Establish an Access Granting Process', 'Establish and follow a process, preferably automated, for granting access to enterprise assets upon new hire, rights grant, or role change of a user.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Access Control Management', '6', 'Users', 'Establish an Access Revoking Process', 'Establish and follow a process, preferably automated, for revoking access to enterprise assets, through disabling accounts immediately upon termination, rights revocation, or role change of a user. Disabling accounts, instead of deleting accounts, may be necessary to preserve audit trails.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Access Control Management', '6', 'Users', 'Require MFA for Externally-Exposed Applications', 'Require all externally-exposed enterprise or third-party applications to enforce MFA, where supported. Enforcing MFA through a directory service or SSO provider is a satisfactory implementation of this Safeguard.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Access Control Management', '6', 'Users', 'Require MFA for Remote Network Access', 'Require MFA for remote network access.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Access Control Management', '6', 'Users', 'Require MFA for Administrative Access', 'Require MFA for all administrative access accounts, where supported, on all enterprise assets, whether managed on-site or through a third-party provider.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Access Control Management', '6', 'Users', 'Establish and Maintain an Inventory of Authentication and Authorization Systems', 'Establish and maintain an inventory of the enterprise’s authentication and authorization systems, including those hosted on-site or at a remote service provider. Review and update the inventory, at a minimum, annually, or more frequently.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Access Control Management', '6', 'Users', 'Centralize Access Control', 'Centralize access control for all enterprise assets through a directory service or SSO provider, where supported.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Access Control Management', '6', 'Data', 'Define and Maintain Role-Based Access Control', 'Define and maintain role-based access control, through determining and documenting the access rights necessary for each role within the enterprise to successfully carry out its assigned duties. Perform access control reviews of enterprise assets to validate that all privileges are authorized, on a recurring schedule at a minimum annually, or more frequently.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Continuous Vulnerability Management', '7', 'Applications', 'Establish and Maintain a Vulnerability Management Process', 'Establish and maintain a documented vulnerability management process for enterprise assets. Review and update documentation annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Continuous Vulnerability Management', '7', 'Applications', 'Establish and Maintain a Remediation Process', 'Establish and maintain a risk-based remediation strategy documented in a remediation process, with monthly, or more frequent, reviews.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Continuous Vulnerability Management', '7', 'Applications', 'Perform Automated Operating System Patch Management', 'Perform operating system updates on enterprise assets through automated patch management on a monthly, or more frequent, basis.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Continuous Vulnerability Management', '7', 'Applications', 'Perform Automated Application Patch Management', 'Perform application updates on enterprise assets through automated patch management on a monthly, or more frequent, basis.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Continuous Vulnerability Management', '7', 'Applications', 'Perform Automated Vulnerability Scans of Internal Enterprise Assets', 'Perform automated vulnerability scans of internal enterprise assets on a quarterly, or more frequent, basis. Conduct both authenticated and unauthenticated scans, using a SCAP-compliant vulnerability scanning tool.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Continuous Vulnerability Management', '7', 'Applications', 'Perform Automated Vulnerability Scans of Externally-Exposed Enterprise Assets', 'Perform automated vulnerability scans of externally-exposed enterprise assets using a SCAP-compliant vulnerability scanning tool. Perform scans on a monthly, or more frequent, basis.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Continuous Vulnerability Management', '7', 'Applications', 'Remediate Detected Vulnerabilities', 'Remediate detected vulnerabilities in software through processes and tooling on a monthly, or more frequent, basis, based on the remediation process.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Audit Log Management', '8', 'Network', 'Establish and Maintain an Audit Log Management Process', 'Establish and maintain an audit log management process that defines the enterprise’s logging requirements. At a minimum, address the collection, review, and retention of audit logs for enterprise assets. Review and update documentation annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Audit Log Management', '8', 'Network', 'Collect Audit Logs', 'Collect audit logs. Ensure that logging, per the enterprise’s audit log management process, has been enabled across enterprise assets.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Audit Log Management', '8', 'Network', 'Ensure Adequate Audit Log Storage', 'Ensure that logging destinations maintain adequate storage to comply with the enterprise’s audit log management process.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Audit Log Management', '8', 'Network', 'Standardize Time Synchronization', 'Standardize time synchronization. Configure at least two synchronized time sources across enterprise assets, where supported.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Audit Log Management', '8', 'Network', 'Collect Detailed Audit Logs', 'Configure detailed audit logging for enterprise assets containing sensitive data. Include event source, date, username, timestamp, source addresses, destination addresses, and other useful elements that could assist in a forensic investigation.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Audit Log Management', '8', 'Network', 'Collect DNS Query Audit Logs', 'Collect DNS query audit logs on enterprise assets, where appropriate and supported.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Audit Log Management', '8', 'Network', 'Collect URL Request Audit Logs', 'Collect URL request audit logs on enterprise assets, where appropriate and supported.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Audit Log Management', '8', 'Devices', 'Collect Command-Line Audit Logs', 'Collect command-line audit logs. Example implementations include collecting audit logs from PowerShell®, BASH™, and remote administrative terminals.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Audit Log Management', '8', 'Network', 'Centralize Audit Logs', 'Centralize, to the extent possible, audit log collection and retention across enterprise assets.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Audit Log Management', '8', 'Network', 'Retain Audit Logs', 'Retain audit logs across enterprise assets for a minimum of 90 days.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Audit Log Management', '8', 'Network', 'Conduct Audit Log Reviews', 'Conduct reviews of audit logs to detect anomalies or abnormal events that could indicate a potential threat. Conduct reviews on a weekly, or more frequent, basis.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Audit Log Management', '8', 'Data', 'Collect Service Provider Logs', 'Collect service provider logs, where supported. Example implementations include collecting authentication and authorization events, data creation and disposal events, and user management events.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Email and Web Browser Protections', '9', 'Applications', 'Ensure Use of Only Fully Supported Browsers and Email Clients', 'Ensure only fully supported browsers and email clients are allowed to execute in the enterprise, only using the latest version of browsers and email clients provided through the vendor.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Email and Web Browser Protections', '9', 'Network', 'Use DNS Filtering Services', 'Use DNS filtering services on all enterprise assets to block access to known malicious domains.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Email and Web Browser Protections', '9', 'Network', 'Maintain and Enforce Network-Based URL Filters', 'Enforce and update network-based URL filters to limit an enterprise asset from connecting to potentially malicious or unapproved websites. Example implementations include category-based filtering, reputation-based filtering, or through the use of block lists. Enforce filters for all enterprise assets.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Email and Web Browser Protections', '9', 'Applications', 'Restrict Unnecessary or Unauthorized Browser and Email Client Extensions', 'Restrict, either through uninstalling or disabling, any unauthorized or unnecessary browser or email client plugins, extensions, and add-on applications.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Email and Web Browser Protections', '9', 'Network', 'Implement DMARC', 'To lower the chance of spoofed or modified emails from valid domains, implement DMARC policy and verification, starting with implementing the Sender Policy Framework (SPF) and the DomainKeys Identified Mail (DKIM) standards.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Email and Web Browser Protections', '9', 'Network', 'Block Unnecessary File Types', 'Block unnecessary file types attempting to enter the enterprise’s email gateway.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Email and Web Browser Protections', '9', 'Network', 'Deploy and Maintain Email Server Anti-Malware Protections', 'Deploy and maintain email server anti-malware protections, such as attachment scanning and/or sandboxing.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Malware Defenses', '10', 'Devices', 'Deploy and Maintain Anti-Malware Software', 'Deploy and maintain anti-malware software on all enterprise assets.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Malware Defenses', '10', 'Devices', 'Configure Automatic Anti-Malware Signature Updates', 'Configure automatic updates for anti-malware signature files on all enterprise assets.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Malware Defenses', '10', 'Devices', 'Disable Autorun and Autoplay for Removable Media', 'Disable autorun and autoplay auto-execute functionality for removable media.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Malware Defenses', '10', 'Devices', 'Configure Automatic Anti-Malware Scanning of Removable Media', 'Configure anti-malware software to automatically scan removable media.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Malware Defenses', '10', 'Devices', 'Enable Anti-Exploitation Features', 'Enable anti-exploitation features on enterprise assets and software, where possible, such as Microsoft® Data Execution Prevention (DEP), Windows® Defender Exploit Guard (WDEG), or Apple® System Integrity Protection (SIP) and Gatekeeper™.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Malware Defenses', '10', 'Devices', 'Centrally Manage Anti-Malware Software', 'Centrally manage anti-malware software.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Malware Defenses', '10', 'Devices', 'Use Behavior-Based Anti-Malware Software', 'Use behavior-based anti-malware software.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Recovery', '11', 'Data', 'Establish and Maintain a Data Recovery Process', 'Establish and maintain a data recovery process. In the process, address the scope of data recovery activities, recovery prioritization, and the security of backup data. Review and update documentation annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Recovery', '11', 'Data', 'Perform Automated Backups', 'Perform automated backups of in-scope enterprise assets. Run backups weekly, or more frequently, based on the sensitivity of the data.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Recovery', '11', 'Data', 'Protect Recovery Data', 'Protect recovery data with equivalent controls to the original data. Reference encryption or data separation, based on requirements.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Recovery', '11', 'Data', 'Establish and Maintain an Isolated Instance of Recovery Data', 'Establish and maintain an isolated instance of recovery data. Example implementations include, version controlling backup destinations through offline, cloud, or off-site systems or services.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Data Recovery', '11', 'Data', 'Test Data Recovery', 'Test backup recovery quarterly, or more frequently, for a sampling of in-scope enterprise assets.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Infrastructure Management', '12', 'Network', 'Ensure Network Infrastructure is Up-to-Date', 'Ensure network infrastructure is kept up-to-date. Example implementations include running the latest stable release of software and/or using currently supported network-as-a-service (NaaS) offerings. Review software versions monthly, or more frequently, to verify software support.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Infrastructure Management', '12', 'Network', 'Establish and Maintain a Secure Network Architecture', 'Establish and maintain a secure network architecture. A secure network architecture must address segmentation, least privilege, and availability, at a minimum.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Infrastructure Management', '12', 'Network', 'Securely Manage Network Infrastructure', 'Securely manage network infrastructure. Example implementations include version-controlled-infrastructure-as-code, and the use of secure network protocols, such as SSH and HTTPS.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Infrastructure Management', '12', 'Network', 'Establish and Maintain Architecture Diagram(s)', 'Establish and maintain architecture diagram(s) and/or other network system documentation. Review and update documentation annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Infrastructure Management', '12', 'Network', 'Centralize Network Authentication, Authorization, and Auditing (AAA)', 'Centralize network AAA.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Infrastructure Management', '12', 'Network', 'Use of Secure Network Management and Communication Protocols', 'Use secure network management and communication protocols (e.g., 802.1X, Wi-Fi Protected Access 2 (WPA2) Enterprise or greater).', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Infrastructure Management', '12', 'Devices', 'Ensure Remote Devices Utilize a VPN and are Connecting to an Enterprise’s AAA Infrastructure', 'Require users to authenticate to enterprise-managed VPN and authentication services prior to accessing enterprise resources on end-user devices.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Infrastructure Management', '12', 'Devices', 'Establish and Maintain Dedicated Computing Resources for All Administrative Work', 'Establish and maintain dedicated computing resources, either physically or logically separated, for all administrative tasks or tasks requiring administrative access. The computing resources should be segmented from the enterprise''s primary network and not be allowed internet access.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Monitoring and Defense', '13', 'Network', 'Centralize Security Event Alerting', 'Centralize security event alerting across enterprise assets for log correlation and analysis. Best practice implementation requires the use of a SIEM, which includes vendor-defined event correlation alerts. A log analytics platform configured with security-relevant correlation alerts also satisfies this Safeguard.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Monitoring and Defense', '13', 'Devices', 'Deploy a Host-Based Intrusion Detection Solution', 'Deploy a host-based intrusion detection solution on enterprise assets, where appropriate and/or supported.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Monitoring and Defense', '13', 'Network', 'Deploy a Network Intrusion Detection Solution', 'Deploy a network intrusion detection solution on enterprise assets, where appropriate. Example implementations include the use of a Network Intrusion Detection System (NIDS) or equivalent cloud service provider (CSP) service.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Monitoring and Defense', '13', 'Network', 'Perform Traffic Filtering Between Network Segments', 'Perform traffic filtering between network segments, where appropriate.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Monitoring and Defense', '13', 'Devices', 'Manage Access Control for Remote Assets', 'Manage access control for assets remotely connecting to enterprise resources. Determine amount of access to enterprise resources based on: up-to-date anti-malware software installed, configuration compliance with the enterprise’s secure configuration process, and ensuring the operating system and applications are up-to-date.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Monitoring and Defense', '13', 'Network', 'Collect Network Traffic Flow Logs', 'Collect network traffic flow logs and/or network traffic to review and alert upon from network devices.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Monitoring and Defense', '13', 'Devices', 'Deploy a Host-Based Intrusion Prevention Solution', 'Deploy a host-based intrusion prevention solution on enterprise assets, where appropriate and/or supported. Example implementations include use of an Endpoint Detection and Response (EDR) client or host-based IPS agent.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Monitoring and Defense', '13', 'Network', 'Deploy a Network Intrusion Prevention Solution', 'Deploy a network intrusion prevention solution, where appropriate. Example implementations include the use of a Network Intrusion Prevention System (NIPS) or equivalent CSP service.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Monitoring and Defense', '13', 'Devices', 'Deploy Port-Level Access Control', 'Deploy port-level access control. Port-level access control utilizes 802.1x, or similar network access control protocols, such as certificates, and may incorporate user and/or device authentication.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Monitoring and Defense', '13', 'Network', 'Perform Application Layer Filtering', 'Perform application layer filtering. Example implementations include a filtering proxy, application layer firewall, or gateway.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Network Monitoring and Defense', '13', 'Network', 'Tune Security Event Alerting Thresholds', 'Tune security event alerting thresholds monthly, or more frequently.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Security Awareness and Skills Training', '14', 'N/A', 'Establish and Maintain a Security Awareness Program', 'Establish and maintain a security awareness program. The purpose of a security awareness program is to educate the enterprise’s workforce on how to interact with enterprise assets and data in a secure manner. Conduct training at hire and, at a minimum, annually. Review and update content annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Security Awareness and Skills Training', '14', 'N/A', 'Train Workforce Members to Recognize Social Engineering Attacks', 'Train workforce members to recognize social engineering attacks, such as phishing, pre-texting, and tailgating.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Security Awareness and Skills Training', '14', 'N/A', 'Train Workforce Members on Authentication Best Practices', 'Train workforce members on authentication best practices. Example topics include MFA, password composition, and credential management.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Security Awareness and Skills Training', '14', 'N/A', 'Train Workforce on Data Handling Best Practices', 'Train workforce members on how to identify and properly store, transfer, archive, and destroy sensitive data. This also includes training workforce members on clear screen and desk best practices, such as locking their screen when they step away from their enterprise asset, erasing physical and virtual whiteboards at the end of meetings, and storing data and assets securely.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Security Awareness and Skills Training', '14', 'N/A', 'Train Workforce Members on Causes of Unintentional Data Exposure', 'Train workforce members to be aware of causes for unintentional data exposure. Example topics include mis-delivery of sensitive data, losing a portable end-user device, or publishing data to unintended audiences.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Security Awareness and Skills Training', '14', 'N/A', 'Train Workforce Members on Recognizing and Reporting Security Incidents', 'Train workforce members to be able to recognize a potential incident and be able to report such an incident.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Security Awareness and Skills Training', '14', 'N/A', 'Train Workforce on How to Identify and Report if Their Enterprise Assets are Missing Security Updates', 'Train workforce to understand how to verify and report out-of-date software patches or any failures in automated processes and tools. Part of this training should include notifying IT personnel of any failures in automated processes and tools.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Security Awareness and Skills Training', '14', 'N/A', 'Train Workforce on the Dangers of Connecting to and Transmitting Enterprise Data Over Insecure Networks', 'Train workforce members on the dangers of connecting to, and transmitting data over, insecure networks for enterprise activities. If the enterprise has remote workers, training must include guidance to ensure that all users securely configure their home network infrastructure.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Security Awareness and Skills Training', '14', 'N/A', 'Conduct Role-Specific Security Awareness and Skills Training', 'Conduct role-specific security awareness and skills training. Example implementations include secure system administration courses for IT professionals, OWASP® Top 10 vulnerability awareness and prevention training for web application developers, and advanced social engineering awareness training for high-profile roles.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Service Provider Management', '15', 'N/A', 'Establish and Maintain an Inventory of Service Providers', 'Establish and maintain an inventory of service providers. The inventory is to list all known service providers, include classification(s), and designate an enterprise contact for each service provider. Review and update the inventory annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Service Provider Management', '15', 'N/A', 'Establish and Maintain a Service Provider Management Policy', 'Establish and maintain a service provider management policy. Ensure the policy addresses the classification, inventory, assessment, monitoring, and decommissioning of service providers. Review and update the policy annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Service Provider Management', '15', 'N/A', 'Classify Service Providers', 'Classify service providers. Classification consideration may include one or more characteristics, such as data sensitivity, data volume, availability requirements, applicable regulations, inherent risk, and mitigated risk. Update and review classifications annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Service Provider Management', '15', 'N/A', 'Ensure Service Provider Contracts Include Security Requirements', 'Ensure service provider contracts include security requirements. Example requirements may include minimum security program requirements, security incident and/or data breach notification and response, data encryption requirements, and data disposal commitments. These security requirements must be consistent with the enterprise’s service provider management policy. Review service provider contracts annually to ensure contracts are not missing security requirements.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Service Provider Management', '15', 'N/A', 'Assess Service Providers', 'Assess service providers consistent with the enterprise’s service provider management policy. Assessment scope may vary based on classification(s), and may include review of standardized assessment reports, such as Service Organization Control 2 (SOC 2) and Payment Card Industry (PCI) Attestation of Compliance (AoC), customized questionnaires, or other appropriately rigorous processes. Reassess service providers annually, at a minimum, or with new and renewed contracts.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Service Provider Management', '15', 'Data', 'Monitor Service Providers', 'Monitor service providers consistent with the enterprise’s service provider management policy. Monitoring may include periodic reassessment of service provider compliance, monitoring service provider release notes, and dark web monitoring.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Service Provider Management', '15', 'Data', 'Securely Decommission Service Providers', 'Securely decommission service providers. Example considerations include user and service account deactivation, termination of data flows, and secure disposal of enterprise data within service provider systems.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Application Software Security', '16', 'Applications', 'Establish and Maintain a Secure Application Development Process', 'Establish and maintain a secure application development process. In the process, address such items as: secure application design standards, secure coding practices, developer training, vulnerability management, security of third-party code, and application security testing procedures. Review and update documentation annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Application Software Security', '16', 'Applications', 'Establish and Maintain a Process to Accept and Address Software Vulnerabilities', 'Establish and maintain a process to accept and address reports of software vulnerabilities, including providing a means for external entities to report. The process is to include such items as: a vulnerability handling policy that identifies reporting process, responsible party for handling vulnerability reports, and a process for intake, assignment, remediation, and remediation testing. As part of the process, use a vulnerability tracking system that includes severity ratings, and metrics for measuring timing for identification, analysis, and remediation of vulnerabilities. Review and update documentation annually, or when significant enterprise changes occur that could impact this Safeguard.
-
-Third-party application developers need to consider this an externally-facing policy that helps to set expectations for outside stakeholders.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Application Software Security', '16', 'Applications', 'Perform Root Cause Analysis on Security Vulnerabilities', 'Perform root cause analysis on security vulnerabilities. When reviewing vulnerabilities, root cause analysis is the task of evaluating underlying issues that create vulnerabilities in code, and allows development teams to move beyond just fixing individual vulnerabilities as they arise.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Application Software Security', '16', 'Applications', 'Establish and Manage an Inventory of Third-Party Software Components', 'Establish and manage an updated inventory of third-party components used in development, often referred to as a “bill of materials,” as well as components slated for future use. This inventory is to include any risks that each third-party component could pose. Evaluate the list at least monthly to identify any changes or updates to these components, and validate that the component is still supported.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Application Software Security', '16', 'Applications', 'Use Up-to-Date and Trusted Third-Party Software Components', 'Use up-to-date and trusted third-party software components. When possible, choose established and proven frameworks and libraries that provide adequate security. Acquire these components from trusted sources or evaluate the software for vulnerabilities before use.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Application Software Security', '16', 'Applications', 'Establish and Maintain a Severity Rating System and Process for Application Vulnerabilities', 'Establish and maintain a severity rating system and process for application vulnerabilities that facilitates prioritizing the order in which discovered vulnerabilities are fixed. This process includes setting a minimum level of security acceptability for releasing code or applications. Severity ratings bring a systematic way of triaging vulnerabilities that improves risk management and helps ensure the most severe bugs are fixed first. Review and update the system and process annually.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Application Software Security', '16', 'Applications', 'Use Standard Hardening Configuration Templates for Application Infrastructure', 'Use standard, industry-recommended hardening configuration templates for application infrastructure components. This includes underlying servers, databases, and web servers, and applies to cloud containers, Platform as a Service (PaaS) components, and SaaS components. Do not allow in-house developed software to weaken configuration hardening.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Application Software Security', '16', 'Applications', 'Separate Production and Non-Production Systems', 'Maintain separate environments for production and non-production systems.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Application Software Security', '16', 'Applications', 'Train Developers in Application Security Concepts and Secure Coding', 'Ensure that all software development personnel receive training in writing secure code for their specific development environment and responsibilities. Training can include general security principles and application security standard practices. Conduct training at least annually and design in a way to promote security within the development team, and build a culture of security among the developers.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Application Software Security', '16', 'Applications', 'Apply Secure Design Principles in Application Architectures', 'Apply secure design principles in application architectures. Secure design principles include the concept of least privilege and enforcing mediation to validate every operation that the user makes, promoting the concept of "never trust user input." Examples include ensuring that explicit error checking is performed and documented for all input, including for size, data type, and acceptable ranges or formats. Secure design also means minimizing the application infrastructure attack surface, such as turning off unprotected ports and services, removing unnecessary programs and files, and renaming or removing default accounts.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Application Software Security', '16', 'Applications', 'Leverage Vetted Modules or Services for Application Security Components', 'Leverage vetted modules or services for application security components, such as identity management, encryption, and auditing and logging. Using platform features in critical security functions will reduce developers’ workload and minimize the likelihood of design or implementation errors. Modern operating systems provide effective mechanisms for identification, authentication, and authorization and make those mechanisms available to applications. Use only standardized, currently accepted, and extensively reviewed encryption algorithms. Operating systems also provide mechanisms to create and maintain secure audit logs.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Application Software Security', '16', 'Applications', 'Implement Code-Level Security Checks', 'Apply static and dynamic analysis tools within the application life cycle to verify that secure coding practices are being followed.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Application Software Security', '16', 'Applications', 'Conduct Application Penetration Testing', 'Conduct application penetration testing. For critical applications, authenticated penetration testing is better suited to finding business logic vulnerabilities than code scanning and automated security testing. Penetration testing relies on the skill of the tester to manually manipulate an application as an authenticated and unauthenticated user.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Application Software Security', '16', 'Applications', 'Conduct Threat Modeling', 'Conduct threat modeling. Threat modeling is the process of identifying and addressing application security design flaws within a design, before code is created. It is conducted through specially trained individuals who evaluate the application design and gauge security risks for each entry point and access level. The goal is to map out the application, architecture, and infrastructure in a structured way to understand its weaknesses.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Incident Response Management', '17', 'N/A', 'Designate Personnel to Manage Incident Handling', 'Designate one key person, and at least one backup, who will manage the enterprise’s incident handling process. Management personnel are responsible for the coordination and documentation of incident response and recovery efforts and can consist of employees internal to the enterprise, third-party vendors, or a hybrid approach. If using a third-party vendor, designate at least one person internal to the enterprise to oversee any third-party work. Review annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Incident Response Management', '17', 'N/A', 'Establish and Maintain Contact Information for Reporting Security Incidents', 'Establish and maintain contact information for parties that need to be informed of security incidents. Contacts may include internal staff, third-party vendors, law enforcement, cyber insurance providers, relevant government agencies, Information Sharing and Analysis Center (ISAC) partners, or other stakeholders. Verify contacts annually to ensure that information is up-to-date.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Incident Response Management', '17', 'N/A', 'Establish and Maintain an Enterprise Process for Reporting Incidents', 'Establish and maintain an enterprise process for the workforce to report security incidents. The process includes reporting timeframe, personnel to report to, mechanism for reporting, and the minimum information to be reported. Ensure the process is publicly available to all of the workforce. Review annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG1, IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Incident Response Management', '17', 'N/A', 'Establish and Maintain an Incident Response Process', 'Establish and maintain an incident response process that addresses roles and responsibilities, compliance requirements, and a communication plan. Review annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Incident Response Management', '17', 'N/A', 'Assign Key Roles and Responsibilities', 'Assign key roles and responsibilities for incident response, including staff from legal, IT, information security, facilities, public relations, human resources, incident responders, and analysts, as applicable. Review annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Incident Response Management', '17', 'N/A', 'Define Mechanisms for Communicating During Incident Response', 'Determine which primary and secondary mechanisms will be used to communicate and report during a security incident. Mechanisms can include phone calls, emails, or letters. Keep in mind that certain mechanisms, such as emails, can be affected during a security incident. Review annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Incident Response Management', '17', 'N/A', 'Conduct Routine Incident Response Exercises', 'Plan and conduct routine incident response exercises and scenarios for key personnel involved in the incident response process to prepare for responding to real-world incidents. Exercises need to test communication channels, decision making, and workflows. Conduct testing on an annual basis, at a minimum.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Incident Response Management', '17', 'N/A', 'Conduct Post-Incident Reviews', 'Conduct post-incident reviews. Post-incident reviews help prevent incident recurrence through identifying lessons learned and follow-up action.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Incident Response Management', '17', 'N/A', 'Establish and Maintain Security Incident Thresholds', 'Establish and maintain security incident thresholds, including, at a minimum, differentiating between an incident and an event. Examples can include: abnormal activity, security vulnerability, security weakness, data breach, privacy incident, etc. Review annually, or when significant enterprise changes occur that could impact this Safeguard.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Penetration Testing', '18', 'N/A', 'Establish and Maintain a Penetration Testing Program', 'Establish and maintain a penetration testing program appropriate to the size, complexity, and maturity of the enterprise. Penetration testing program characteristics include scope, such as network, web application, Application Programming Interface (API), hosted services, and physical premise controls; frequency; limitations, such as acceptable hours, and excluded attack types; point of contact information; remediation, such as how findings will be routed internally; and retrospective requirements.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Penetration Testing', '18', 'Network', 'Perform Periodic External Penetration Tests', 'Perform periodic external penetration tests based on program requirements, no less than annually. External penetration testing must include enterprise and environmental reconnaissance to detect exploitable information. Penetration testing requires specialized skills and experience and must be conducted through a qualified party. The testing may be clear box or opaque box.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Penetration Testing', '18', 'Network', 'Remediate Penetration Test Findings', 'Remediate penetration test findings based on the enterprise’s policy for remediation scope and prioritization.', '{IG2, IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Penetration Testing', '18', 'Network', 'Validate Security Measures', 'Validate security measures after each penetration test. If deemed necessary, modify rulesets and capabilities to detect the techniques used during testing.', '{IG3}', current_timestamp, current_timestamp),
-      (gen_random_uuid(), (select frameworks_uuid from frameworks where name = 'CIS'), 'Penetration Testing', '18', 'N/A', 'Perform Periodic Internal Penetration Tests', 'Perform periodic internal penetration tests based on program requirements, no less than annually. The testing may be clear box or opaque box.', '{IG3}', current_timestamp, current_timestamp);
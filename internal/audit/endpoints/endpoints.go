@@ -0,0 +1,38 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
+)
+
+type Endpoints struct {
+	ListEventsEndpoint   endpoint.Endpoint
+	ExportEventsEndpoint endpoint.Endpoint
+}
+
+// New returns new endpoints
+func New(svc service.Service) *Endpoints {
+	return &Endpoints{
+		ListEventsEndpoint:   makeListEventsEndpoint(svc),
+		ExportEventsEndpoint: makeExportEventsEndpoint(svc),
+	}
+}
+
+func makeListEventsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.ListEventsRequest) //nolint:errcheck
+
+		return svc.ListEvents(ctx, req)
+	}
+}
+
+func makeExportEventsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.ExportEventsRequest) //nolint:errcheck
+
+		return svc.ExportEvents(ctx, req)
+	}
+}
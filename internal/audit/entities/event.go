@@ -0,0 +1,186 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Snapshot is the JSON representation of an entity.
+type Snapshot map[string]interface{}
+
+// Value simply returns the JSON-encoded representation of the snapshot.
+func (a Snapshot) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(a)
+}
+
+// Scan decodes a JSON-encoded value into the snapshot.
+func (a *Snapshot) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &a)
+}
+
+// FieldChange of a top-level field of an entity.
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Diff maps the changed fields of an entity to their change.
+type Diff map[string]FieldChange
+
+// Value simply returns the JSON-encoded representation of the diff.
+func (a Diff) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(a)
+}
+
+// Scan decodes a JSON-encoded value into the diff.
+func (a *Diff) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &a)
+}
+
+// Event is a change made to an entity. ImpersonatorUuid is set when an engineering or csc
+// user made the change as the actor.
+type Event struct {
+	EventUuid        uuid.UUID  `json:"event_uuid" gorm:"column:event_uuid"`
+	ActorUuid        *uuid.UUID `json:"actor_uuid" gorm:"column:actor_uuid"`
+	ActorEmail       string     `json:"actor_email" gorm:"column:actor_email"`
+	ImpersonatorUuid *uuid.UUID `json:"impersonator_uuid,omitempty" gorm:"column:impersonator_uuid"`
+	CompanyUuid      *uuid.UUID `json:"company_uuid" gorm:"column:company_uuid"`
+	EntityType       string     `json:"entity_type" gorm:"column:entity_type"`
+	EntityID         string     `json:"entity_id" gorm:"column:entity_id"`
+	Action           string     `json:"action" gorm:"column:action"`
+	Before           Snapshot   `json:"before,omitempty" gorm:"column:before"`
+	After            Snapshot   `json:"after,omitempty" gorm:"column:after"`
+	Diff             Diff       `json:"diff,omitempty" gorm:"column:diff"`
+	RequestID        string     `json:"request_id" gorm:"column:request_id"`
+	ClientIP         string     `json:"client_ip" gorm:"column:client_ip"`
+	Method           string     `json:"method" gorm:"column:method"`
+	Path             string     `json:"path" gorm:"column:path"`
+	CreatedAt        time.Time  `json:"created_at" gorm:"column:created_at;default:now()"`
+}
+
+func (m *Event) TableName() string {
+	return "audit_events"
+}
+
+// Change made to an entity, reported by a repository hook or the endpoint middleware.
+// Before and After are the entity before and after the change, nil when it did not exist.
+// CompanyUuid defaults to the company of the request.
+type Change struct {
+	EntityType  string
+	EntityID    string
+	CompanyUuid uuid.UUID
+	Action      string
+	Before      interface{}
+	After       interface{}
+}
+
+// NewChange of the entity identified by id in the company, reported by a repository hook. The
+// action is a create when before is nil, a delete when after is nil and an update otherwise.
+func NewChange(entityType, id string, companyUuid uuid.UUID, before, after interface{}) *Change {
+	action := ActionUpdate
+	switch {
+	case isNil(before):
+		action = ActionCreate
+	case isNil(after):
+		action = ActionDelete
+	}
+
+	return &Change{
+		EntityType:  entityType,
+		EntityID:    id,
+		CompanyUuid: companyUuid,
+		Action:      action,
+		Before:      before,
+		After:       after,
+	}
+}
+
+// isNil is true for nil and for nil pointers held by the interface.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// EventFilter narrows the events of a company. Zero values match everything.
+type EventFilter struct {
+	CompanyUuid uuid.UUID
+	ActorUuid   uuid.UUID
+	EntityType  string
+	EntityID    string
+	Action      string
+	RequestID   string
+	From        *time.Time
+	To          *time.Time
+}
+
+type ListEventsRequest struct {
+	Filter   EventFilter
+	Page     int
+	PageSize int
+}
+
+type ListEventsResponse struct {
+	Events   []*Event `json:"events"`
+	Page     int      `json:"page"`
+	PageSize int      `json:"page_size"`
+	Total    int64    `json:"total"`
+}
+
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+)
+
+type ExportEventsRequest struct {
+	Filter EventFilter
+	Format string
+}
+
+// ExportEventsResponse is a file with the events matching the filter.
+type ExportEventsResponse struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
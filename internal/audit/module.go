@@ -0,0 +1,60 @@
+// Package audit records the changes made to entities and lets company admins and
+// engineering users query them.
+package audit
+
+import (
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/endpoints"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ModuleServiceParams for audit.
+type ModuleServiceParams struct {
+	fx.In
+
+	DB     *gorm.DB
+	Logger *zap.SugaredLogger
+}
+
+// NewService for audit. It is provided on its own, so the auth middleware and the
+// repositories of other modules can record changes.
+// nolint:gocritic
+func NewService(p ModuleServiceParams) (service.Service, service.Recorder) {
+	svc := service.New(repository.New(p.DB), p.Logger)
+
+	return svc, svc
+}
+
+// ModuleParams for audit.
+type ModuleParams struct {
+	fx.In
+
+	HTTPServer   *httpTransport.Server
+	APPTransport svcTransport.Client
+	AuthClient   auth.Client
+	Service      service.Service
+}
+
+// NewModule for audit.
+// nolint:gocritic
+func NewModule(p ModuleParams) error {
+	eps := endpoints.New(p.Service)
+
+	http.RegisterTransport(p.HTTPServer, eps, p.AuthClient, p.APPTransport)
+
+	return nil
+}
+
+var (
+	// Module for uber fx.
+	Module = fx.Options(fx.Provide(NewService))
+	// ModuleHttpAPI for uber fx.
+	ModuleHttpAPI = fx.Options(fx.Invoke(NewModule))
+)
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/entities"
+	"gorm.io/gorm"
+)
+
+// Repository for audit events.
+type Repository interface {
+	CreateEvent(ctx context.Context, event *entities.Event) error
+	// ListEvents matching the filter, latest first, along with the number of all matching events.
+	ListEvents(ctx context.Context, filter *entities.EventFilter, offset, limit int) ([]*entities.Event, int64, error)
+}
+
+// New repository for audit.
+func New(db *gorm.DB) Repository {
+	repo := &sqlRepository{db}
+
+	return repo
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/entities"
+	"gorm.io/gorm"
+)
+
+type sqlRepository struct {
+	gormDB *gorm.DB
+}
+
+func (s *sqlRepository) CreateEvent(ctx context.Context, event *entities.Event) error {
+	return s.gormDB.WithContext(ctx).Create(event).Error
+}
+
+func (s *sqlRepository) ListEvents(ctx context.Context, filter *entities.EventFilter, offset, limit int) ([]*entities.Event, int64, error) {
+	query := s.gormDB.WithContext(ctx).Model(&entities.Event{}).
+		Where("company_uuid = ?", filter.CompanyUuid)
+
+	if filter.ActorUuid != uuid.Nil {
+		query = query.Where("actor_uuid = ?", filter.ActorUuid)
+	}
+
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []*entities.Event
+
+	err := query.Order("created_at desc").Offset(offset).Limit(limit).Find(&events).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/meta"
+)
+
+type trackerKey struct{}
+
+// tracker tells whether a repository hook recorded a change during the request.
+type tracker struct {
+	recorded int32
+}
+
+func withTracker(ctx context.Context) (context.Context, *tracker) {
+	t := &tracker{}
+
+	return context.WithValue(ctx, trackerKey{}, t), t
+}
+
+func markRecorded(ctx context.Context) {
+	if t, ok := ctx.Value(trackerKey{}).(*tracker); ok {
+		atomic.StoreInt32(&t.recorded, 1)
+	}
+}
+
+func (s *service) Middleware(featureName string, action permissions.Action) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		switch action {
+		case permissions.ActionCreate, permissions.ActionUpdate, permissions.ActionDelete:
+		default:
+			return next
+		}
+
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			ctx, t := withTracker(ctx)
+
+			resp, err := next(ctx, req)
+			if err != nil || atomic.LoadInt32(&t.recorded) == 1 {
+				return resp, err
+			}
+
+			change := &entities.Change{EntityType: featureName, EntityID: entityID(ctx), Action: string(action)}
+			if action != permissions.ActionDelete {
+				change.After = req
+			}
+
+			s.Record(ctx, change)
+
+			return resp, err
+		}
+	}
+}
+
+// entityID is the path parameter of the request naming the entity acted on: the last one in
+// the path besides the company and the acting user.
+func entityID(ctx context.Context) string {
+	params := meta.PathParams(ctx)
+	path := meta.RequestPath(ctx)
+
+	_, actsOnUser := params["req_user_id"]
+
+	id, at := "", -1
+	for name, value := range params {
+		switch {
+		case name == "company_id", name == "req_user_id", name == "user_id" && !actsOnUser:
+			continue
+		}
+
+		if i := strings.LastIndex(path, "/"+value); i > at {
+			id, at = value, i
+		}
+	}
+
+	return id
+}
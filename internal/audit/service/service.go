@@ -0,0 +1,260 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/repository"
+	authMeta "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/meta"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/meta"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+	// maxExportEvents bounds an export; narrower filters export older events.
+	maxExportEvents = 10000
+	// maxSnapshotSize bounds the JSON of a snapshot, so uploads don't end up in the audit log.
+	maxSnapshotSize = 64 * 1024
+)
+
+// Recorder records changes made to entities. Repositories call it once a change is stored;
+// failures are logged and never fail the change.
+type Recorder interface {
+	Record(ctx context.Context, change *entities.Change)
+}
+
+// Service for audit events.
+type Service interface {
+	Recorder
+	// Middleware records a successful mutating action of the endpoint unless a repository
+	// hook recorded the change already. Read and export actions are not recorded.
+	Middleware(featureName string, action permissions.Action) endpoint.Middleware
+	ListEvents(ctx context.Context, req *entities.ListEventsRequest) (*entities.ListEventsResponse, error)
+	ExportEvents(ctx context.Context, req *entities.ExportEventsRequest) (*entities.ExportEventsResponse, error)
+}
+
+type service struct {
+	repo   repository.Repository
+	logger *zap.SugaredLogger
+}
+
+// New service for audit.
+func New(repo repository.Repository, logger *zap.SugaredLogger) Service {
+	return &service{repo, logger}
+}
+
+func (s *service) Record(ctx context.Context, change *entities.Change) {
+	markRecorded(ctx)
+
+	event := newEvent(ctx, change)
+	if err := s.repo.CreateEvent(ctx, event); err != nil {
+		s.logger.Errorf("failed to record %s of %s %s: %v", event.Action, event.EntityType, event.EntityID, err)
+	}
+}
+
+// newEvent of the change made by the context user in the current request.
+func newEvent(ctx context.Context, change *entities.Change) *entities.Event {
+	event := &entities.Event{
+		EventUuid:  uuid.New(),
+		EntityType: change.EntityType,
+		EntityID:   change.EntityID,
+		Action:     change.Action,
+		Before:     snapshot(change.Before),
+		After:      snapshot(change.After),
+		RequestID:  meta.RequestID(ctx),
+		ClientIP:   meta.ClientIP(ctx),
+		Method:     meta.RequestMethod(ctx),
+		Path:       meta.RequestPath(ctx),
+		CreatedAt:  time.Now(),
+	}
+	event.Diff = diff(event.Before, event.After)
+
+	user := authMeta.User(ctx)
+	if user != nil {
+		event.ActorUuid = &user.Uuid
+		event.ActorEmail = user.Email
+	}
+
+	if impersonation := authMeta.Impersonation(ctx); impersonation != nil {
+		event.ImpersonatorUuid = &impersonation.ImpersonatorUuid
+	}
+
+	companyUuid := change.CompanyUuid
+	if companyUuid == uuid.Nil {
+		companyUuid, _ = uuid.Parse(meta.PathParams(ctx)["company_id"])
+	}
+
+	if companyUuid == uuid.Nil && user != nil && user.Company != nil {
+		companyUuid = user.Company.Uuid
+	}
+
+	if companyUuid != uuid.Nil {
+		event.CompanyUuid = &companyUuid
+	}
+
+	return event
+}
+
+// snapshot of v as a JSON object. Values that aren't objects are kept under "value".
+func snapshot(v interface{}) entities.Snapshot {
+	if v == nil {
+		return nil
+	}
+
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return entities.Snapshot{"error": err.Error()}
+	}
+
+	if len(b) > maxSnapshotSize {
+		return entities.Snapshot{"truncated": true}
+	}
+
+	var s entities.Snapshot
+	if err = json.Unmarshal(b, &s); err != nil {
+		var value interface{}
+		_ = json.Unmarshal(b, &value) // nolint: errcheck
+
+		return entities.Snapshot{"value": value}
+	}
+
+	return s
+}
+
+// diff of the top-level fields of the snapshots.
+func diff(before, after entities.Snapshot) entities.Diff {
+	d := entities.Diff{}
+
+	for k, b := range before {
+		if a, ok := after[k]; !ok || !reflect.DeepEqual(a, b) {
+			d[k] = entities.FieldChange{Before: b, After: after[k]}
+		}
+	}
+
+	for k, a := range after {
+		if _, ok := before[k]; !ok {
+			d[k] = entities.FieldChange{After: a}
+		}
+	}
+
+	if len(d) == 0 {
+		return nil
+	}
+
+	return d
+}
+
+func (s *service) ListEvents(ctx context.Context, req *entities.ListEventsRequest) (*entities.ListEventsResponse, error) {
+	page := req.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	if pageSize > maxPageSize {
+		return nil, &appError.ErrValidation{Message: fmt.Sprintf("page_size should be at most %d", maxPageSize)}
+	}
+
+	events, total, err := s.repo.ListEvents(ctx, &req.Filter, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.ListEventsResponse{Events: events, Page: page, PageSize: pageSize, Total: total}, nil
+}
+
+func (s *service) ExportEvents(ctx context.Context, req *entities.ExportEventsRequest) (*entities.ExportEventsResponse, error) {
+	switch req.Format {
+	case entities.ExportFormatCSV, entities.ExportFormatJSON, "":
+	default:
+		return nil, &appError.ErrValidation{Message: "format should be either csv or json"}
+	}
+
+	events, _, err := s.repo.ListEvents(ctx, &req.Filter, 0, maxExportEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	fileName := fmt.Sprintf("audit-events-%s-%s", req.Filter.CompanyUuid, time.Now().Format("20060102"))
+
+	switch req.Format {
+	case entities.ExportFormatJSON:
+		content, err := json.Marshal(events)
+		if err != nil {
+			return nil, err
+		}
+
+		return &entities.ExportEventsResponse{FileName: fileName + ".json", ContentType: "application/json", Content: content}, nil
+	default:
+		content, err := eventsCSV(events)
+		if err != nil {
+			return nil, err
+		}
+
+		return &entities.ExportEventsResponse{FileName: fileName + ".csv", ContentType: "text/csv", Content: content}, nil
+	}
+}
+
+var csvHeader = []string{
+	"created_at", "actor_uuid", "actor_email", "impersonator_uuid", "company_uuid", "entity_type", "entity_id",
+	"action", "diff", "request_id", "client_ip", "method", "path",
+}
+
+func eventsCSV(events []*entities.Event) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := csv.NewWriter(&buf)
+	if err := w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+
+	for _, e := range events {
+		var d []byte
+		if e.Diff != nil {
+			var err error
+			if d, err = json.Marshal(e.Diff); err != nil {
+				return nil, err
+			}
+		}
+
+		row := []string{
+			e.CreatedAt.UTC().Format(time.RFC3339), uuidString(e.ActorUuid), e.ActorEmail, uuidString(e.ImpersonatorUuid),
+			uuidString(e.CompanyUuid), e.EntityType, e.EntityID, e.Action, string(d), e.RequestID, e.ClientIP, e.Method, e.Path,
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+
+	return buf.Bytes(), w.Error()
+}
+
+func uuidString(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+
+	return id.String()
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/entity"
+	authMeta "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/meta"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/meta"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+)
+
+type ipRange struct {
+	Uuid   string `json:"uuid"`
+	Status string `json:"status"`
+	Ranges string `json:"ranges"`
+}
+
+func TestRecord(t *testing.T) {
+	Convey("Given a change recorded by a repository hook", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, zap.NewNop().Sugar())
+
+		user := &entity.User{Uuid: uuid.New(), Email: "admin@customer"}
+		impersonatorUuid := uuid.New()
+		companyUuid := uuid.New()
+
+		ctx := authMeta.WithUser(context.Background(), user)
+		ctx = authMeta.WithImpersonation(ctx, &entity.Impersonation{ImpersonatorUuid: impersonatorUuid})
+		ctx = meta.WithPathParams(ctx, map[string]string{"company_id": companyUuid.String()})
+		ctx = meta.WithRequestID(ctx, "request-1")
+		ctx = meta.WithClientIP(ctx, "10.0.0.1")
+
+		Convey("The event holds the actor, the impersonator, the request and the changed fields", func() {
+			repo.EXPECT().CreateEvent(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *entities.Event) error {
+				So(*event.ActorUuid, ShouldEqual, user.Uuid)
+				So(event.ActorEmail, ShouldEqual, user.Email)
+				So(*event.ImpersonatorUuid, ShouldEqual, impersonatorUuid)
+				So(*event.CompanyUuid, ShouldEqual, companyUuid)
+				So(event.RequestID, ShouldEqual, "request-1")
+				So(event.ClientIP, ShouldEqual, "10.0.0.1")
+				So(event.Diff, ShouldResemble, entities.Diff{
+					"status": entities.FieldChange{Before: "Active", After: "Inactive"},
+				})
+
+				return nil
+			})
+
+			svc.Record(ctx, &entities.Change{
+				EntityType: "ip_range",
+				EntityID:   "1",
+				Action:     entities.ActionUpdate,
+				Before:     &ipRange{Uuid: "1", Status: "Active", Ranges: "10.0.0.0/8"},
+				After:      &ipRange{Uuid: "1", Status: "Inactive", Ranges: "10.0.0.0/8"},
+			})
+		})
+
+		Convey("Deleted entities have every field removed in the diff", func() {
+			repo.EXPECT().CreateEvent(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, event *entities.Event) error {
+				So(event.Action, ShouldEqual, entities.ActionDelete)
+				So(event.After, ShouldBeNil)
+				So(event.Diff, ShouldHaveLength, 3)
+				So(event.Diff["ranges"], ShouldResemble, entities.FieldChange{Before: "10.0.0.0/8"})
+
+				return nil
+			})
+
+			var deleted *ipRange
+			svc.Record(ctx, entities.NewChange("ip_range", "1", companyUuid, &ipRange{Uuid: "1", Status: "Active", Ranges: "10.0.0.0/8"}, deleted))
+		})
+
+		Convey("A failing insert doesn't fail the change", func() {
+			repo.EXPECT().CreateEvent(ctx, gomock.Any()).Return(errors.New("connection refused"))
+
+			So(func() { svc.Record(ctx, &entities.Change{Action: entities.ActionCreate, After: "value"}) }, ShouldNotPanic)
+		})
+	})
+}
+
+func TestSnapshot(t *testing.T) {
+	Convey("Values that aren't objects are kept under value", t, func() {
+		So(snapshot([]string{"a"}), ShouldResemble, entities.Snapshot{"value": []interface{}{"a"}})
+	})
+
+	Convey("Large values are truncated", t, func() {
+		So(snapshot(strings.Repeat("a", maxSnapshotSize)), ShouldResemble, entities.Snapshot{"truncated": true})
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	Convey("Given an endpoint wrapped by the audit middleware", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, zap.NewNop().Sugar())
+
+		userUuid, policyUuid := uuid.New(), uuid.New()
+		companyUuid := uuid.New()
+
+		ctx := meta.WithPathParams(context.Background(), map[string]string{
+			"company_id": companyUuid.String(),
+			"user_id":    userUuid.String(),
+			"policy_id":  policyUuid.String(),
+		})
+		ctx = meta.WithRequestPath(ctx, "/companies/"+companyUuid.String()+"/users/"+userUuid.String()+"/settings/policy/"+policyUuid.String())
+
+		Convey("A successful update is recorded with the request as the entity after the change", func() {
+			repo.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, event *entities.Event) error {
+				So(event.EntityType, ShouldEqual, "policy")
+				So(event.EntityID, ShouldEqual, policyUuid.String())
+				So(event.Action, ShouldEqual, entities.ActionUpdate)
+				So(event.After, ShouldResemble, entities.Snapshot{"status": "Approved"})
+
+				return nil
+			})
+
+			ep := svc.Middleware("policy", permissions.ActionUpdate)(func(context.Context, interface{}) (interface{}, error) {
+				return nil, nil
+			})
+
+			_, err := ep(ctx, map[string]string{"status": "Approved"})
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Changes recorded by a repository hook are not recorded again", func() {
+			repo.EXPECT().CreateEvent(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+			ep := svc.Middleware("policy", permissions.ActionDelete)(func(ctx context.Context, _ interface{}) (interface{}, error) {
+				svc.Record(ctx, &entities.Change{EntityType: "policy", Action: entities.ActionDelete})
+
+				return nil, nil
+			})
+
+			_, err := ep(ctx, nil)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Failed and read-only actions are not recorded", func() {
+			failing := svc.Middleware("policy", permissions.ActionCreate)(func(context.Context, interface{}) (interface{}, error) {
+				return nil, errors.New("failed")
+			})
+			_, err := failing(ctx, nil)
+			So(err, ShouldNotBeNil)
+
+			reading := svc.Middleware("policy", permissions.ActionRead)(func(context.Context, interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			_, err = reading(ctx, nil)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("The user acted on is the entity of the company user endpoints", func() {
+			otherUuid := uuid.New()
+			ctx := meta.WithPathParams(context.Background(), map[string]string{
+				"company_id":  companyUuid.String(),
+				"req_user_id": userUuid.String(),
+				"user_id":     otherUuid.String(),
+			})
+			ctx = meta.WithRequestPath(ctx, "/companies/"+companyUuid.String()+"/users/"+userUuid.String()+"/settings/users/"+otherUuid.String())
+
+			So(entityID(ctx), ShouldEqual, otherUuid.String())
+		})
+	})
+}
+
+func TestListEvents(t *testing.T) {
+	Convey("Given the events of a company", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, zap.NewNop().Sugar())
+		ctx := context.Background()
+		filter := entities.EventFilter{CompanyUuid: uuid.New()}
+
+		Convey("Pages default to the first page of 50 events", func() {
+			repo.EXPECT().ListEvents(ctx, &filter, 0, defaultPageSize).Return(nil, int64(0), nil)
+
+			resp, err := svc.ListEvents(ctx, &entities.ListEventsRequest{Filter: filter})
+			So(err, ShouldBeNil)
+			So(resp.Page, ShouldEqual, 1)
+			So(resp.PageSize, ShouldEqual, defaultPageSize)
+		})
+
+		Convey("Later pages are offset", func() {
+			repo.EXPECT().ListEvents(ctx, &filter, 20, 10).Return(nil, int64(25), nil)
+
+			resp, err := svc.ListEvents(ctx, &entities.ListEventsRequest{Filter: filter, Page: 3, PageSize: 10})
+			So(err, ShouldBeNil)
+			So(resp.Total, ShouldEqual, 25)
+		})
+
+		Convey("Pages larger than the maximum are rejected", func() {
+			_, err := svc.ListEvents(ctx, &entities.ListEventsRequest{Filter: filter, PageSize: maxPageSize + 1})
+
+			var validationErr *appError.ErrValidation
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+		})
+	})
+}
+
+func TestExportEvents(t *testing.T) {
+	Convey("Given the events of a company", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, zap.NewNop().Sugar())
+		ctx := context.Background()
+		filter := entities.EventFilter{CompanyUuid: uuid.New()}
+
+		actorUuid := uuid.New()
+		events := []*entities.Event{{
+			ActorUuid:  &actorUuid,
+			ActorEmail: "admin@customer",
+			EntityType: "policy",
+			EntityID:   "1",
+			Action:     entities.ActionUpdate,
+			Diff:       entities.Diff{"status": entities.FieldChange{Before: "Draft", After: "Approved"}},
+			CreatedAt:  time.Date(2023, 3, 27, 9, 0, 0, 0, time.UTC),
+		}}
+
+		Convey("They are exported as CSV by default", func() {
+			repo.EXPECT().ListEvents(ctx, &filter, 0, maxExportEvents).Return(events, int64(1), nil)
+
+			resp, err := svc.ExportEvents(ctx, &entities.ExportEventsRequest{Filter: filter})
+			So(err, ShouldBeNil)
+			So(resp.ContentType, ShouldEqual, "text/csv")
+			So(resp.FileName, ShouldEndWith, ".csv")
+
+			records, err := csv.NewReader(strings.NewReader(string(resp.Content))).ReadAll()
+			So(err, ShouldBeNil)
+			So(records, ShouldHaveLength, 2)
+			So(records[0], ShouldResemble, csvHeader)
+			So(records[1][0], ShouldEqual, "2023-03-27T09:00:00Z")
+			So(records[1][1], ShouldEqual, actorUuid.String())
+			So(records[1][8], ShouldEqual, `{"status":{"before":"Draft","after":"Approved"}}`)
+		})
+
+		Convey("Unknown formats are rejected", func() {
+			_, err := svc.ExportEvents(ctx, &entities.ExportEventsRequest{Filter: filter, Format: "xml"})
+
+			var validationErr *appError.ErrValidation
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+		})
+	})
+}
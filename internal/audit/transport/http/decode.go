@@ -0,0 +1,106 @@
+// Package http for audit.
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/entities"
+	httpError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors/http"
+)
+
+// decodeFilter of the company in the path from the query parameters.
+func decodeFilter(r *http.Request) (*entities.EventFilter, error) {
+	companyUUID, err := uuid.Parse(mux.Vars(r)["company_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("company_id")
+	}
+
+	q := r.URL.Query()
+	filter := &entities.EventFilter{
+		CompanyUuid: companyUUID,
+		EntityType:  q.Get("entity_type"),
+		EntityID:    q.Get("entity_id"),
+		Action:      q.Get("action"),
+		RequestID:   q.Get("request_id"),
+	}
+
+	if v := q.Get("actor_uuid"); v != "" {
+		if filter.ActorUuid, err = uuid.Parse(v); err != nil {
+			return nil, httpError.NewErrBadOrInvalidPathParameter("actor_uuid")
+		}
+	}
+
+	if filter.From, err = decodeTime(q, "from"); err != nil {
+		return nil, err
+	}
+
+	if filter.To, err = decodeTime(q, "to"); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+// decodeTime accepts RFC 3339 timestamps and dates.
+func decodeTime(q url.Values, name string) (*time.Time, error) {
+	v := q.Get(name)
+	if v == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return &t, nil
+		}
+	}
+
+	return nil, httpError.NewErrBadOrInvalidPathParameter(name)
+}
+
+func decodeInt(q url.Values, name string) (int, error) {
+	v := q.Get(name)
+	if v == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.Atoi(v)
+	if err != nil || i < 0 {
+		return 0, httpError.NewErrBadOrInvalidPathParameter(name)
+	}
+
+	return i, nil
+}
+
+func decodeListEventsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	filter, err := decodeFilter(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &entities.ListEventsRequest{Filter: *filter}
+
+	if req.Page, err = decodeInt(r.URL.Query(), "page"); err != nil {
+		return nil, err
+	}
+
+	if req.PageSize, err = decodeInt(r.URL.Query(), "page_size"); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func decodeExportEventsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	filter, err := decodeFilter(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.ExportEventsRequest{Filter: *filter, Format: r.URL.Query().Get("format")}, nil
+}
@@ -0,0 +1,72 @@
+// Package http for audit.
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	goKitEndpoint "github.com/go-kit/kit/endpoint"
+	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/endpoints"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+)
+
+// RegisterTransport for http.
+func RegisterTransport(
+	server *httpTransport.Server,
+	ep *endpoints.Endpoints,
+	authClient auth.Client,
+	svcTransportClient svcTransport.Client,
+) {
+	registerListEvents(server, ep.ListEventsEndpoint, authClient, svcTransportClient)
+	registerExportEvents(server, ep.ExportEventsEndpoint, authClient, svcTransportClient)
+}
+
+func registerListEvents(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/audit/events"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "audit-log", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeListEventsRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerExportEvents(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/audit/events/export"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "audit-log", permissions.ActionExport)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encodeExportResponse, []string{method})
+	handler := getHandler(securedEp, decodeExportEventsRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func encodeExportResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	file := response.(*entities.ExportEventsResponse) //nolint:errcheck
+
+	w.Header().Set("Content-Disposition", "attachment; filename="+strconv.Quote(file.FileName))
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(file.Content)))
+	_, err := w.Write(file.Content)
+
+	return err
+}
+
+func getHandler(ep goKitEndpoint.Endpoint, dec goKitHTTPTransport.DecodeRequestFunc, enc goKitHTTPTransport.EncodeResponseFunc, atc svcTransport.Client, method string) *goKitHTTPTransport.Server {
+	return goKitHTTPTransport.NewServer(
+		ep,
+		dec,
+		enc,
+		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
+		goKitHTTPTransport.ServerErrorHandler(atc.LogErrorHandler()),
+	)
+}
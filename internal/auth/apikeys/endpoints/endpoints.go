@@ -0,0 +1,48 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/service"
+)
+
+type Endpoints struct {
+	CreateAPIKeyEndpoint endpoint.Endpoint
+	ListAPIKeysEndpoint  endpoint.Endpoint
+	RevokeAPIKeyEndpoint endpoint.Endpoint
+}
+
+// New returns new endpoints
+func New(svc service.Service) *Endpoints {
+	return &Endpoints{
+		CreateAPIKeyEndpoint: makeCreateAPIKeyEndpoint(svc),
+		ListAPIKeysEndpoint:  makeListAPIKeysEndpoint(svc),
+		RevokeAPIKeyEndpoint: makeRevokeAPIKeyEndpoint(svc),
+	}
+}
+
+func makeCreateAPIKeyEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.CreateAPIKeyRequest) //nolint:errcheck
+
+		return svc.CreateAPIKey(ctx, req)
+	}
+}
+
+func makeListAPIKeysEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.ListAPIKeysRequest) //nolint:errcheck
+
+		return svc.ListAPIKeys(ctx, req)
+	}
+}
+
+func makeRevokeAPIKeyEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.RevokeAPIKeyRequest) //nolint:errcheck
+		err := svc.RevokeAPIKey(ctx, req)
+		return "", err
+	}
+}
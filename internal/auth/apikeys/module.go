@@ -0,0 +1,42 @@
+package apikeys
+
+import (
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/endpoints"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/service"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// ModuleParams for api keys.
+type ModuleParams struct {
+	fx.In
+
+	DB           *gorm.DB
+	HTTPServer   *httpTransport.Server
+	APPTransport svcTransport.Client
+	AuthClient   auth.Client
+	Engine       permissions.Engine
+}
+
+// NewModule for api keys.
+// nolint:gocritic
+func NewModule(p ModuleParams) error {
+	repo := repository.New(p.DB)
+	svc := service.New(repo, p.Engine)
+	eps := endpoints.New(svc)
+
+	http.RegisterTransport(p.HTTPServer, eps, p.AuthClient, p.APPTransport)
+
+	return nil
+}
+
+var (
+	// ModuleHttpAPI for uber fx.
+	ModuleHttpAPI = fx.Options(fx.Invoke(NewModule))
+)
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/entities"
+	"gorm.io/gorm"
+)
+
+// Repository for api keys.
+type Repository interface {
+	Create(ctx context.Context, key *entities.APIKey) (*entities.APIKey, error)
+	List(ctx context.Context, companyUuid uuid.UUID) ([]*entities.APIKey, error)
+	FindByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error)
+	Revoke(ctx context.Context, companyUuid, apiKeyUuid, revokedBy uuid.UUID) error
+	UpdateLastUsed(ctx context.Context, apiKeyUuid uuid.UUID, t time.Time) error
+	// GetCompanyType of the company the key is created for.
+	GetCompanyType(ctx context.Context, companyUuid uuid.UUID) (string, error)
+}
+
+// New repository for api keys.
+func New(db *gorm.DB) Repository {
+	repo := &sqlRepository{db}
+
+	return repo
+}
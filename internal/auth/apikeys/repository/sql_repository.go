@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"gorm.io/gorm"
+)
+
+type sqlRepository struct {
+	gormDB *gorm.DB
+}
+
+func (s *sqlRepository) Create(ctx context.Context, key *entities.APIKey) (*entities.APIKey, error) {
+	err := s.gormDB.WithContext(ctx).Create(key).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (s *sqlRepository) List(ctx context.Context, companyUuid uuid.UUID) ([]*entities.APIKey, error) {
+	var keys []*entities.APIKey
+
+	err := s.gormDB.WithContext(ctx).Model(&entities.APIKey{}).
+		Order("created_at desc").
+		Find(&keys, "company_uuid = ?", companyUuid).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (s *sqlRepository) FindByPrefix(ctx context.Context, prefix string) (*entities.APIKey, error) {
+	var key *entities.APIKey
+
+	result := s.gormDB.WithContext(ctx).Model(&entities.APIKey{}).
+		Find(&key, "prefix = ?", prefix)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &appError.ErrNotFound{Message: "api key not found"}
+	}
+
+	return key, nil
+}
+
+func (s *sqlRepository) Revoke(ctx context.Context, companyUuid, apiKeyUuid, revokedBy uuid.UUID) error {
+	result := s.gormDB.WithContext(ctx).Model(&entities.APIKey{}).
+		Where("api_key_uuid = ? AND company_uuid = ? AND revoked_at IS NULL", apiKeyUuid, companyUuid).
+		Updates(map[string]interface{}{
+			"revoked_at": time.Now(),
+			"revoked_by": revokedBy,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return &appError.ErrNotFound{Message: "api key not found"}
+	}
+
+	return nil
+}
+
+func (s *sqlRepository) UpdateLastUsed(ctx context.Context, apiKeyUuid uuid.UUID, t time.Time) error {
+	return s.gormDB.WithContext(ctx).Model(&entities.APIKey{}).
+		Where("api_key_uuid = ?", apiKeyUuid).
+		Update("last_used_at", t).Error
+}
+
+func (s *sqlRepository) GetCompanyType(ctx context.Context, companyUuid uuid.UUID) (string, error) {
+	var types []string
+
+	err := s.gormDB.WithContext(ctx).Table("public.companies").
+		Where("company_uuid = ?", companyUuid).
+		Limit(1).
+		Pluck("type", &types).Error
+	if err != nil {
+		return "", err
+	}
+
+	if len(types) == 0 {
+		return "", &appError.ErrNotFound{Message: "company not found"}
+	}
+
+	return types[0], nil
+}
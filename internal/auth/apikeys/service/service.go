@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/repository"
+	authErrors "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	authMeta "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/meta"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+)
+
+const (
+	keyPrefix = "rdk"
+
+	// MaxLifetime of an api key.
+	MaxLifetime = 365 * 24 * time.Hour
+)
+
+// Service for api keys.
+type Service interface {
+	CreateAPIKey(ctx context.Context, req *entities.CreateAPIKeyRequest) (*entities.CreateAPIKeyResponse, error)
+	ListAPIKeys(ctx context.Context, req *entities.ListAPIKeysRequest) ([]*entities.APIKey, error)
+	RevokeAPIKey(ctx context.Context, req *entities.RevokeAPIKeyRequest) error
+	Authenticate(ctx context.Context, key string) (*entities.APIKey, error)
+}
+
+type service struct {
+	repo   repository.Repository
+	engine permissions.Engine
+}
+
+// New service for api keys.
+func New(repo repository.Repository, engine permissions.Engine) Service {
+	return &service{repo, engine}
+}
+
+func (s *service) CreateAPIKey(ctx context.Context, req *entities.CreateAPIKeyRequest) (*entities.CreateAPIKeyResponse, error) {
+	if err := s.validate(ctx, req.Body); err != nil {
+		return nil, err
+	}
+
+	user := authMeta.User(ctx)
+	if user == nil || user.Company == nil {
+		return nil, authErrors.ErrNoPermission
+	}
+
+	// the key acts in the company it is created for, which may not be the current one of the creator
+	companyType, err := s.repo.GetCompanyType(ctx, req.CompanyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := randomHex(4)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := randomHex(24)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("%s_%s_%s", keyPrefix, prefix, secret)
+
+	apiKey, err := s.repo.Create(ctx, &entities.APIKey{
+		ApiKeyUuid:  uuid.New(),
+		CompanyUuid: req.CompanyUuid,
+		CompanyType: companyType,
+		Name:        req.Body.Name,
+		Prefix:      prefix,
+		KeyHash:     hash(key),
+		Scopes:      req.Body.Scopes,
+		ExpiresAt:   req.Body.ExpiresAt,
+		CreatedBy:   req.UserUuid,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.CreateAPIKeyResponse{APIKey: apiKey, Key: key}, nil
+}
+
+func (s *service) ListAPIKeys(ctx context.Context, req *entities.ListAPIKeysRequest) ([]*entities.APIKey, error) {
+	return s.repo.List(ctx, req.CompanyUuid)
+}
+
+func (s *service) RevokeAPIKey(ctx context.Context, req *entities.RevokeAPIKeyRequest) error {
+	return s.repo.Revoke(ctx, req.CompanyUuid, req.ApiKeyUuid, req.UserUuid)
+}
+
+// Authenticate returns the active api key matching key, or ErrInvalidAPIKey.
+func (s *service) Authenticate(ctx context.Context, key string) (*entities.APIKey, error) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != keyPrefix {
+		return nil, authErrors.ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.repo.FindByPrefix(ctx, parts[1])
+	if err != nil {
+		if appError.IsNotFoundError(err) {
+			return nil, authErrors.ErrInvalidAPIKey
+		}
+
+		return nil, err
+	}
+
+	now := time.Now()
+	if subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(hash(key))) != 1 || !apiKey.IsActive(now) {
+		return nil, authErrors.ErrInvalidAPIKey
+	}
+
+	if err = s.repo.UpdateLastUsed(ctx, apiKey.ApiKeyUuid, now); err != nil {
+		return nil, err
+	}
+
+	return apiKey, nil
+}
+
+// validate checks the key is named, expires within MaxLifetime and only has scopes
+// the creating user is allowed themselves.
+func (s *service) validate(ctx context.Context, body *entities.CreateAPIKeyRequestBody) error {
+	if strings.TrimSpace(body.Name) == "" {
+		return &appError.ErrValidation{Message: "name is required"}
+	}
+
+	now := time.Now()
+	if !body.ExpiresAt.After(now) || body.ExpiresAt.After(now.Add(MaxLifetime)) {
+		return &appError.ErrValidation{Message: "expires_at should be in the future and within a year"}
+	}
+
+	if len(body.Scopes) == 0 {
+		return &appError.ErrValidation{Message: "at least one scope is required"}
+	}
+
+	subject := authMeta.PermissionSubject(ctx)
+	features := s.engine.Policy().Features
+
+	for _, scope := range body.Scopes {
+		feature, action, ok := strings.Cut(scope, ":")
+		if _, defined := features[feature]; !ok || !defined || !permissions.Action(action).Valid() {
+			return &appError.ErrValidation{Message: fmt.Sprintf("invalid scope %q", scope)}
+		}
+
+		if subject == nil || !s.engine.IsAllowed(feature, *subject, permissions.Action(action)) {
+			return &appError.ErrValidation{Message: fmt.Sprintf("scope %q exceeds your permissions", scope)}
+		}
+	}
+
+	return nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/entity"
+	authErrors "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	authMeta "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/meta"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func testEngine() permissions.Engine {
+	p, _ := permissions.Parse([]byte(`
+version: 1
+grants:
+  rw: [read, create, update, delete]
+features:
+  tech-info:
+    customer_customer_admin: rw
+  policies-procedures:
+    customer_customer_admin: [read]
+`))
+
+	return permissions.NewEngine(p)
+}
+
+func adminContext() context.Context {
+	ctx := authMeta.WithUser(context.Background(), &entity.User{
+		Uuid:    uuid.New(),
+		Company: &entity.Company{Uuid: uuid.New(), Type: "customer", UserRole: "admin"},
+	})
+
+	return authMeta.WithPermissionSubject(ctx, &permissions.Subject{CompanyType: "customer", Group: "customer", Role: "admin"})
+}
+
+func Test_service_CreateAPIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repository.NewMockRepository(ctrl)
+	svc := New(repo, testEngine())
+	ctx := adminContext()
+
+	Convey("Given scopes within the permissions of the admin", t, func() {
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, k *entities.APIKey) (*entities.APIKey, error) {
+			return k, nil
+		})
+
+		req := &entities.CreateAPIKeyRequest{
+			CompanyUuid: uuid.New(),
+			UserUuid:    uuid.New(),
+			Body: &entities.CreateAPIKeyRequestBody{
+				Name:      "ci",
+				Scopes:    []string{"tech-info:create", "policies-procedures:read"},
+				ExpiresAt: time.Now().Add(24 * time.Hour),
+			},
+		}
+
+		repo.EXPECT().GetCompanyType(gomock.Any(), req.CompanyUuid).Return("customer", nil)
+
+		Convey("Call the CreateAPIKey function from the engineering company of the creator", func() {
+			user := *authMeta.User(ctx)
+			user.Company = &entity.Company{Uuid: uuid.New(), Type: "engineering", UserRole: "admin"}
+
+			res, err := svc.CreateAPIKey(authMeta.WithUser(ctx, &user), req)
+			Convey("The plain key is returned once and only its hash is stored", func() {
+				So(err, ShouldBeNil)
+				So(res.Key, ShouldStartWith, "rdk_"+res.Prefix+"_")
+				So(res.KeyHash, ShouldEqual, hash(res.Key))
+			})
+
+			Convey("The key has the type of the company it is created for", func() {
+				So(res.CompanyType, ShouldEqual, "customer")
+			})
+		})
+	})
+
+	Convey("Given scopes exceeding the permissions of the admin", t, func() {
+		req := &entities.CreateAPIKeyRequest{
+			Body: &entities.CreateAPIKeyRequestBody{
+				Name:      "ci",
+				Scopes:    []string{"policies-procedures:create"},
+				ExpiresAt: time.Now().Add(24 * time.Hour),
+			},
+		}
+
+		Convey("Call the CreateAPIKey function", func() {
+			_, err := svc.CreateAPIKey(ctx, req)
+			Convey("A validation error should be returned", func() {
+				So(appError.IsValidationError(err), ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given an expiry beyond a year", t, func() {
+		req := &entities.CreateAPIKeyRequest{
+			Body: &entities.CreateAPIKeyRequestBody{
+				Name:      "ci",
+				Scopes:    []string{"tech-info:read"},
+				ExpiresAt: time.Now().Add(2 * MaxLifetime),
+			},
+		}
+
+		Convey("Call the CreateAPIKey function", func() {
+			_, err := svc.CreateAPIKey(ctx, req)
+			Convey("A validation error should be returned", func() {
+				So(appError.IsValidationError(err), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func Test_service_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repository.NewMockRepository(ctrl)
+	svc := New(repo, testEngine())
+	ctx := context.Background()
+
+	key := "rdk_0a1b2c3d_secret"
+	stored := &entities.APIKey{ApiKeyUuid: uuid.New(), Prefix: "0a1b2c3d", KeyHash: hash(key), ExpiresAt: time.Now().Add(time.Hour)}
+
+	Convey("Given an active key", t, func() {
+		repo.EXPECT().FindByPrefix(gomock.Any(), "0a1b2c3d").Return(stored, nil)
+		repo.EXPECT().UpdateLastUsed(gomock.Any(), stored.ApiKeyUuid, gomock.Any()).Return(nil)
+
+		Convey("Call the Authenticate function", func() {
+			actual, err := svc.Authenticate(ctx, key)
+			Convey("The stored key should be returned", func() {
+				So(err, ShouldBeNil)
+				So(actual, ShouldEqual, stored)
+			})
+		})
+	})
+
+	Convey("Given a wrong secret, a revoked key or a malformed key", t, func() {
+		revoked := *stored
+		revoked.RevokedAt = nullable.NewNullTime(time.Now())
+
+		repo.EXPECT().FindByPrefix(gomock.Any(), "0a1b2c3d").Return(stored, nil)
+		repo.EXPECT().FindByPrefix(gomock.Any(), "0a1b2c3d").Return(&revoked, nil)
+
+		Convey("Call the Authenticate function", func() {
+			_, err := svc.Authenticate(ctx, "rdk_0a1b2c3d_other")
+			So(err, ShouldEqual, authErrors.ErrInvalidAPIKey)
+
+			_, err = svc.Authenticate(ctx, key)
+			So(err, ShouldEqual, authErrors.ErrInvalidAPIKey)
+
+			_, err = svc.Authenticate(ctx, "not-a-key")
+			So(err, ShouldEqual, authErrors.ErrInvalidAPIKey)
+		})
+	})
+}
@@ -0,0 +1,79 @@
+// Package http for api keys.
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/entities"
+	httpError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors/http"
+	"github.com/pkg/errors"
+)
+
+func decodeCompanyAndUser(r *http.Request) (uuid.UUID, uuid.UUID, error) {
+	params := mux.Vars(r)
+	compUUID, err := uuid.Parse(params["company_id"])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, httpError.NewErrBadOrInvalidPathParameter("company_id")
+	}
+
+	userUUID, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, httpError.NewErrBadOrInvalidPathParameter("user_id")
+	}
+
+	return compUUID, userUUID, nil
+}
+
+func decodeCreateAPIKeyRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	compUUID, userUUID, err := decodeCompanyAndUser(r)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &entities.CreateAPIKeyRequestBody{}
+	if err = json.NewDecoder(r.Body).Decode(body); err != nil {
+		return nil, errors.WithMessage(httpError.ErrBadRequestBody, err.Error())
+	}
+
+	defer r.Body.Close()
+
+	return &entities.CreateAPIKeyRequest{
+		CompanyUuid: compUUID,
+		UserUuid:    userUUID,
+		Body:        body,
+	}, nil
+}
+
+func decodeListAPIKeysRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	compUUID, userUUID, err := decodeCompanyAndUser(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.ListAPIKeysRequest{
+		CompanyUuid: compUUID,
+		UserUuid:    userUUID,
+	}, nil
+}
+
+func decodeRevokeAPIKeyRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	compUUID, userUUID, err := decodeCompanyAndUser(r)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeyUUID, err := uuid.Parse(mux.Vars(r)["api_key_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("api_key_id")
+	}
+
+	return &entities.RevokeAPIKeyRequest{
+		CompanyUuid: compUUID,
+		UserUuid:    userUUID,
+		ApiKeyUuid:  apiKeyUUID,
+	}, nil
+}
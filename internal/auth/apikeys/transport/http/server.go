@@ -0,0 +1,68 @@
+// Package http for api keys.
+package http
+
+import (
+	goKitEndpoint "github.com/go-kit/kit/endpoint"
+	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/endpoints"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+)
+
+// RegisterTransport for http.
+func RegisterTransport(
+	server *httpTransport.Server,
+	ep *endpoints.Endpoints,
+	authClient auth.Client,
+	svcTransportClient svcTransport.Client,
+) {
+	registerCreateAPIKey(server, ep.CreateAPIKeyEndpoint, authClient, svcTransportClient)
+	registerListAPIKeys(server, ep.ListAPIKeysEndpoint, authClient, svcTransportClient)
+	registerRevokeAPIKey(server, ep.RevokeAPIKeyEndpoint, authClient, svcTransportClient)
+}
+
+func registerCreateAPIKey(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/api-keys"
+	method := "POST"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "api-keys", permissions.ActionCreate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeCreateAPIKeyRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerListAPIKeys(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/api-keys"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "api-keys", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeListAPIKeysRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerRevokeAPIKey(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/api-keys/{api_key_id}"
+	method := "DELETE"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "api-keys", permissions.ActionDelete)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeRevokeAPIKeyRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func getHandler(ep goKitEndpoint.Endpoint, dec goKitHTTPTransport.DecodeRequestFunc, enc goKitHTTPTransport.EncodeResponseFunc, atc svcTransport.Client, method string) *goKitHTTPTransport.Server {
+	return goKitHTTPTransport.NewServer(
+		ep,
+		dec,
+		enc,
+		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
+		goKitHTTPTransport.ServerErrorHandler(atc.LogErrorHandler()),
+	)
+}
@@ -2,27 +2,36 @@
 package auth
 
 import (
+	"context"
+
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
+	audit "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/endpoint"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 )
 
 // Client exposed by auth.
 type Client interface {
-	SecureServiceWithCognitoEndpoint(ept goKitEndpoint.Endpoint, featureName, method string) goKitEndpoint.Endpoint
+	SecureServiceWithCognitoEndpoint(ept goKitEndpoint.Endpoint, featureName string, action permissions.Action) goKitEndpoint.Endpoint
+	SecureServiceWithAPIKeyEndpoint(ept goKitEndpoint.Endpoint, featureName string, action permissions.Action) goKitEndpoint.Endpoint
 	SecureServiceWithRedesignEndpoint(ept goKitEndpoint.Endpoint) goKitEndpoint.Endpoint
-	SecureServiceWithRedesignWebhookEndpoint(ept goKitEndpoint.Endpoint) goKitEndpoint.Endpoint
+	SecureServiceWithRedesignWebhookEndpoint(ept goKitEndpoint.Endpoint, audience string) goKitEndpoint.Endpoint
+	Authorize(ctx context.Context, featureName string, action permissions.Action) error
 }
 
-func newClient(mw endpoint.Middleware) Client {
-	return &client{mw}
+func newClient(mw endpoint.Middleware, registry permissions.Registry, auditService audit.Service) Client {
+	return &client{mw, registry, auditService}
 }
 
 type client struct {
-	mw endpoint.Middleware
+	mw           endpoint.Middleware
+	registry     permissions.Registry
+	auditService audit.Service
 }
 
-func (c *client) SecureServiceWithRedesignWebhookEndpoint(ept goKitEndpoint.Endpoint) goKitEndpoint.Endpoint {
-	return goKitEndpoint.Chain(c.mw.SecureServiceWithRedesignWebhookEndpoint())(ept)
+// SecureServiceWithRedesignWebhookEndpoint wraps endpoint with middleware accepting webhook tokens issued for the audience
+func (c *client) SecureServiceWithRedesignWebhookEndpoint(ept goKitEndpoint.Endpoint, audience string) goKitEndpoint.Endpoint {
+	return goKitEndpoint.Chain(c.mw.SecureServiceWithRedesignWebhookEndpoint(audience))(ept)
 }
 
 func (c *client) SecureServiceWithRedesignEndpoint(ept goKitEndpoint.Endpoint) goKitEndpoint.Endpoint {
@@ -30,7 +39,29 @@ func (c *client) SecureServiceWithRedesignEndpoint(ept goKitEndpoint.Endpoint) g
 
 }
 
-// SecureServiceWithCognitoEndpoint wraps endpoint with middleware to retrieve user info and checks only keycloak token
-func (c *client) SecureServiceWithCognitoEndpoint(ept goKitEndpoint.Endpoint, featureName, method string) goKitEndpoint.Endpoint {
-	return goKitEndpoint.Chain(c.mw.SecureServiceWithCognitoEndpoint(featureName, method))(ept)
+// SecureServiceWithCognitoEndpoint wraps endpoint with middleware to retrieve user info and checks the action is allowed on the feature.
+// Mutating actions are recorded in the audit log.
+func (c *client) SecureServiceWithCognitoEndpoint(ept goKitEndpoint.Endpoint, featureName string, action permissions.Action) goKitEndpoint.Endpoint {
+	c.registry.Register(featureName, action)
+
+	return goKitEndpoint.Chain(
+		c.mw.SecureServiceWithCognitoEndpoint(featureName, action),
+		c.auditService.Middleware(featureName, action),
+	)(ept)
+}
+
+// SecureServiceWithAPIKeyEndpoint wraps endpoint with middleware accepting api keys scoped to the action on the feature,
+// besides cognito tokens. Mutating actions are recorded in the audit log.
+func (c *client) SecureServiceWithAPIKeyEndpoint(ept goKitEndpoint.Endpoint, featureName string, action permissions.Action) goKitEndpoint.Endpoint {
+	c.registry.Register(featureName, action)
+
+	return goKitEndpoint.Chain(
+		c.mw.SecureServiceWithAPIKeyEndpoint(featureName, action),
+		c.auditService.Middleware(featureName, action),
+	)(ept)
+}
+
+// Authorize checks that the caller of a secured endpoint is also allowed to perform action on the feature
+func (c *client) Authorize(ctx context.Context, featureName string, action permissions.Action) error {
+	return c.mw.Authorize(ctx, featureName, action)
 }
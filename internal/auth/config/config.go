@@ -0,0 +1,135 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	// PermissionSourceFile loads the permission policy from a yaml file.
+	PermissionSourceFile = "file"
+	// PermissionSourceDB loads the latest permission policy from the permission_policies table.
+	PermissionSourceDB = "db"
+
+	// ProviderTypeCognito verifies tokens issued by the configured Cognito user pool.
+	ProviderTypeCognito = "cognito"
+	// ProviderTypeOIDC verifies tokens of any OpenID Connect issuer against its JWKS.
+	ProviderTypeOIDC = "oidc"
+)
+
+// Config for auth.
+type Config struct {
+	Permissions   PermissionsConfig
+	Identity      IdentityConfig
+	Impersonation ImpersonationConfig
+	Sessions      SessionsConfig
+}
+
+// PermissionsConfig tells where the permission policy is loaded from.
+// An empty File uses the permissions.yml embedded in the binary.
+type PermissionsConfig struct {
+	Source string
+	File   string
+}
+
+// IdentityConfig lists the identity providers whose tokens are accepted.
+// Without providers only Cognito is used.
+type IdentityConfig struct {
+	Providers []ProviderConfig
+}
+
+// ProviderConfig of an identity provider. Issuer, Audience, JWKSURL, JWKSFile and Claims
+// apply to oidc providers; Claims maps user fields (user_uuid, company_uuid, ...) to
+// claim names and falls back to the claim names Cognito uses.
+// AllowedDomains and CompanyUuids make an oidc provider a customer IdP: it only signs in the
+// customer users with an email of the domains, acting in the companies.
+type ProviderConfig struct {
+	Name           string
+	Type           string
+	Issuer         string
+	Audience       string
+	JWKSURL        string
+	JWKSFile       string
+	Claims         map[string]string
+	AllowedDomains []string
+	CompanyUuids   []string
+}
+
+// ImpersonationConfig bounds impersonation sessions. Zero values fall back to
+// 30 minutes by default and 2 hours at most.
+type ImpersonationConfig struct {
+	DefaultDuration time.Duration
+	MaxDuration     time.Duration
+}
+
+// SessionsConfig for session revocation. Revocations made on another instance are seen
+// once the denylist is refreshed; a zero interval falls back to 30 seconds.
+type SessionsConfig struct {
+	DenylistRefreshInterval time.Duration
+}
+
+// Validate config
+func (c *Config) Validate() error {
+	var errs []string
+
+	switch c.Permissions.Source {
+	case "", PermissionSourceFile, PermissionSourceDB:
+	default:
+		errs = append(errs, "Permissions source should be either file or db")
+	}
+
+	names := map[string]bool{}
+	for _, p := range c.Identity.Providers {
+		if p.Name == "" {
+			errs = append(errs, "Identity provider name shouldn't be empty")
+		} else if names[p.Name] {
+			errs = append(errs, fmt.Sprintf("Identity provider %s is defined twice", p.Name))
+		}
+		names[p.Name] = true
+
+		switch p.Type {
+		case ProviderTypeCognito:
+			if len(p.AllowedDomains) > 0 || len(p.CompanyUuids) > 0 {
+				errs = append(errs, fmt.Sprintf("Identity provider %s of type cognito can't be scoped", p.Name))
+			}
+		case ProviderTypeOIDC:
+			if p.Issuer == "" {
+				errs = append(errs, fmt.Sprintf("Identity provider %s issuer shouldn't be empty", p.Name))
+			}
+
+			if p.Audience == "" {
+				errs = append(errs, fmt.Sprintf("Identity provider %s audience shouldn't be empty", p.Name))
+			}
+
+			if (p.JWKSURL == "") == (p.JWKSFile == "") {
+				errs = append(errs, fmt.Sprintf("Identity provider %s needs either a JWKS url or a JWKS file", p.Name))
+			}
+
+			for _, companyUuid := range p.CompanyUuids {
+				if _, err := uuid.Parse(companyUuid); err != nil {
+					errs = append(errs, fmt.Sprintf("Identity provider %s company uuid %s is not a uuid", p.Name, companyUuid))
+				}
+			}
+		default:
+			errs = append(errs, fmt.Sprintf("Identity provider %s type should be either cognito or oidc", p.Name))
+		}
+	}
+
+	if c.Impersonation.MaxDuration > 0 && c.Impersonation.DefaultDuration > c.Impersonation.MaxDuration {
+		errs = append(errs, "Impersonation default duration shouldn't exceed the max duration")
+	}
+
+	if c.Sessions.DenylistRefreshInterval < 0 {
+		errs = append(errs, "Sessions denylist refresh interval shouldn't be negative")
+	}
+
+	if len(errs) > 0 {
+		return errors.Errorf(strings.Join(errs, ","))
+	}
+
+	return nil
+}
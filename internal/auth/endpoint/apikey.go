@@ -0,0 +1,114 @@
+package endpoint
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/entity"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	authMeta "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/meta"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	userEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/meta"
+)
+
+// SecureServiceWithAPIKeyEndpoint authorizes requests carrying an api key with the key's scopes,
+// and falls back to the cognito token when no api key is sent.
+//
+// A key acts as the user who created it: the action has to be in the key's scopes, still be
+// allowed to the creator by the permission policy, and the request has to stay within the key's company.
+func (s *middleware) SecureServiceWithAPIKeyEndpoint(featureName string, action permissions.Action) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		cognitoEp := s.SecureServiceWithCognitoEndpoint(featureName, action)(next)
+
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			key := meta.APIKey(ctx)
+			if key == "" {
+				return cognitoEp(ctx, req)
+			}
+
+			apiKey, err := s.apiKeyService.Authenticate(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+
+			if !apiKey.HasScope(featureName, string(action)) {
+				return nil, errors.ErrNoPermission
+			}
+
+			user, err := s.apiKeyUser(ctx, apiKey)
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = authMeta.WithUser(ctx, user)
+
+			subject := &permissions.Subject{
+				CompanyType: user.Company.Type,
+				Group:       user.UserGroup,
+				Role:        user.Company.UserRole,
+			}
+			if !s.isAccessAllowed(featureName, subject, action) {
+				return nil, errors.ErrNoPermission
+			}
+
+			if err = s.guardAPIKeyTenant(ctx, apiKey, user); err != nil {
+				return nil, err
+			}
+
+			ctx = authMeta.WithPermissionSubject(ctx, subject)
+
+			return next(ctx, req)
+		}
+	}
+}
+
+// apiKeyUser returns the creator of the key with their current role in the key's company.
+// Keys of users who are no longer active members of the company stop working.
+func (s *middleware) apiKeyUser(ctx context.Context, apiKey *entities.APIKey) (*entity.User, error) {
+	user, err := s.userClient.GetUserByUuid(ctx, apiKey.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	companyUser, err := s.userClient.GetCompanyUser(ctx, apiKey.CreatedBy, apiKey.CompanyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if companyUser == nil || companyUser.Status != userEntities.CompanyUserStatusActive {
+		return nil, errors.ErrInvalidAPIKey
+	}
+
+	return &entity.User{
+		Uuid:      user.UserUuid,
+		Username:  user.Username,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+		Phone:     user.Phone,
+		UserGroup: user.Group,
+		Company: &entity.Company{
+			Uuid:     apiKey.CompanyUuid,
+			UserRole: companyUser.Role,
+			Type:     apiKey.CompanyType,
+		},
+	}, nil
+}
+
+// guardAPIKeyTenant keeps the key within its company, on top of the membership checks of guardTenant.
+func (s *middleware) guardAPIKeyTenant(ctx context.Context, apiKey *entities.APIKey, user *entity.User) error {
+	if v, ok := meta.PathParams(ctx)[companyIDParam]; ok {
+		companyUUID, err := uuid.Parse(v)
+		if err != nil || companyUUID != apiKey.CompanyUuid {
+			return errors.ErrCrossTenantAccess
+		}
+	}
+
+	return s.guardTenant(ctx, &userEntities.GetUserCompanyInfoByUserIdResponse{
+		UserUuid: user.Uuid,
+		Group:    user.UserGroup,
+	})
+}
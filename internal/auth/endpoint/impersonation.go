@@ -0,0 +1,88 @@
+package endpoint
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/entity"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	impersonationEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/entities"
+	authMeta "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/meta"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/meta"
+)
+
+// impersonate serves the request as the customer user of an impersonation session started by
+// the context user. Read-only sessions only allow read and export actions. The request is
+// written to the audit trail of the session whatever its outcome.
+func (s *middleware) impersonate(
+	ctx context.Context,
+	sessionID string,
+	featureName string,
+	action permissions.Action,
+	serve func(ctx context.Context) (interface{}, error),
+) (resp interface{}, err error) {
+	impersonator := authMeta.User(ctx)
+
+	sessionUuid, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, errors.ErrInvalidImpersonation
+	}
+
+	session, err := s.impersonationService.Resolve(ctx, sessionUuid, impersonator.Uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		log := &impersonationEntities.AuditLog{
+			SessionUuid:      session.SessionUuid,
+			ImpersonatorUuid: session.ImpersonatorUuid,
+			UserUuid:         session.UserUuid,
+			CompanyUuid:      session.CompanyUuid,
+			Feature:          featureName,
+			Action:           string(action),
+			Method:           meta.RequestMethod(ctx),
+			Path:             meta.RequestPath(ctx),
+			RequestID:        meta.RequestID(ctx),
+			ClientIP:         meta.ClientIP(ctx),
+		}
+		if err != nil {
+			log.Error = err.Error()
+		}
+
+		if recordErr := s.impersonationService.Record(ctx, log); recordErr != nil {
+			s.logger.Errorf("failed to record request of impersonation session %s: %v", session.SessionUuid, recordErr)
+		}
+	}()
+
+	if session.ReadOnly && action != permissions.ActionRead && action != permissions.ActionExport {
+		return nil, errors.ErrImpersonationReadOnly
+	}
+
+	target, err := s.userClient.GetUserByUuid(ctx, session.UserUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = authMeta.WithUser(ctx, &entity.User{
+		Uuid:      target.UserUuid,
+		Username:  target.Username,
+		FirstName: target.FirstName,
+		LastName:  target.LastName,
+		Email:     target.Email,
+		Phone:     target.Phone,
+		UserGroup: target.Group,
+		Company:   &entity.Company{Uuid: session.CompanyUuid},
+	})
+	ctx = authMeta.WithImpersonation(ctx, &entity.Impersonation{
+		SessionUuid:       session.SessionUuid,
+		ImpersonatorUuid:  impersonator.Uuid,
+		ImpersonatorEmail: impersonator.Email,
+		CompanyUuid:       session.CompanyUuid,
+		ReadOnly:          session.ReadOnly,
+		ExpiresAt:         session.ExpiresAt,
+	})
+
+	return serve(ctx)
+}
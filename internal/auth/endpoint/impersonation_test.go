@@ -0,0 +1,126 @@
+package endpoint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/entity"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	impersonationEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/entities"
+	impersonation "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/service"
+	authMeta "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/meta"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/userclient"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/meta"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+)
+
+type sessionService struct {
+	impersonation.Service
+	session *impersonationEntities.Session
+	logs    []*impersonationEntities.AuditLog
+}
+
+func (s *sessionService) Resolve(_ context.Context, sessionUuid, impersonatorUuid uuid.UUID) (*impersonationEntities.Session, error) {
+	if sessionUuid != s.session.SessionUuid || impersonatorUuid != s.session.ImpersonatorUuid {
+		return nil, errors.ErrInvalidImpersonation
+	}
+
+	return s.session, nil
+}
+
+func (s *sessionService) Record(_ context.Context, log *impersonationEntities.AuditLog) error {
+	s.logs = append(s.logs, log)
+
+	return nil
+}
+
+type targetClient struct {
+	userclient.Client
+	user *entities.User
+}
+
+func (c *targetClient) GetUserByUuid(_ context.Context, _ uuid.UUID) (*entities.User, error) {
+	return c.user, nil
+}
+
+func TestMiddleware_impersonate(t *testing.T) {
+	Convey("Given a read-only session of an engineer acting as a customer user", t, func() {
+		engineer := &entity.User{Uuid: uuid.New(), Email: "engineer@redesign", UserGroup: "engineer"}
+		customer := &entities.User{UserUuid: uuid.New(), Email: "admin@customer", Group: "customer"}
+		session := &impersonationEntities.Session{
+			SessionUuid:      uuid.New(),
+			ImpersonatorUuid: engineer.Uuid,
+			UserUuid:         customer.UserUuid,
+			CompanyUuid:      uuid.New(),
+			ReadOnly:         true,
+			ExpiresAt:        time.Now().Add(time.Hour),
+		}
+
+		sessions := &sessionService{session: session}
+		m := &middleware{
+			userClient:           &targetClient{user: customer},
+			impersonationService: sessions,
+			logger:               zap.NewNop().Sugar(),
+		}
+
+		ctx := authMeta.WithUser(context.Background(), engineer)
+		ctx = meta.WithRequestMethod(ctx, "GET")
+		ctx = meta.WithRequestPath(ctx, "/users/me")
+
+		var served context.Context
+		serve := func(ctx context.Context) (interface{}, error) {
+			served = ctx
+			return "ok", nil
+		}
+
+		Convey("Reads are served as the customer user and audited", func() {
+			resp, err := m.impersonate(ctx, session.SessionUuid.String(), "all", permissions.ActionRead, serve)
+			So(err, ShouldBeNil)
+			So(resp, ShouldEqual, "ok")
+
+			So(authMeta.User(served).Uuid, ShouldEqual, customer.UserUuid)
+			So(authMeta.User(served).Company.Uuid, ShouldEqual, session.CompanyUuid)
+			So(authMeta.Impersonation(served).ImpersonatorEmail, ShouldEqual, engineer.Email)
+
+			So(sessions.logs, ShouldHaveLength, 1)
+			So(sessions.logs[0].Path, ShouldEqual, "/users/me")
+			So(sessions.logs[0].Error, ShouldBeEmpty)
+		})
+
+		Convey("Writes are rejected and the attempt is audited", func() {
+			_, err := m.impersonate(ctx, session.SessionUuid.String(), "policies-procedures", permissions.ActionUpdate, serve)
+			So(err, ShouldEqual, errors.ErrImpersonationReadOnly)
+			So(served, ShouldBeNil)
+
+			So(sessions.logs, ShouldHaveLength, 1)
+			So(sessions.logs[0].Action, ShouldEqual, "update")
+			So(sessions.logs[0].Error, ShouldEqual, errors.ErrImpersonationReadOnly.Error())
+		})
+
+		Convey("Writes are served when the session has write access", func() {
+			session.ReadOnly = false
+
+			_, err := m.impersonate(ctx, session.SessionUuid.String(), "policies-procedures", permissions.ActionUpdate, serve)
+			So(err, ShouldBeNil)
+			So(served, ShouldNotBeNil)
+		})
+
+		Convey("Sessions of another user are rejected", func() {
+			other := authMeta.WithUser(context.Background(), &entity.User{Uuid: uuid.New()})
+
+			_, err := m.impersonate(other, session.SessionUuid.String(), "all", permissions.ActionRead, serve)
+			So(err, ShouldEqual, errors.ErrInvalidImpersonation)
+			So(sessions.logs, ShouldBeEmpty)
+		})
+
+		Convey("Malformed session ids are rejected", func() {
+			_, err := m.impersonate(ctx, "nope", "all", permissions.ActionRead, serve)
+			So(err, ShouldEqual, errors.ErrInvalidImpersonation)
+		})
+	})
+}
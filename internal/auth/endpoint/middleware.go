@@ -4,15 +4,20 @@ package endpoint
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"strings"
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/google/uuid"
+	apiKeys "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/service"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/entity"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/identity"
+	impersonation "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/service"
 	authMeta "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/meta"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
-	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito"
+	sessions "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/service"
+	webhookTokens "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/webhooktokens/service"
+	userEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/userclient"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/jwt"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/meta"
@@ -21,27 +26,46 @@ import (
 
 // Middleware is a main middleware
 type Middleware interface {
-	SecureServiceWithCognitoEndpoint(featureName, method string) endpoint.Middleware
+	SecureServiceWithCognitoEndpoint(featureName string, action permissions.Action) endpoint.Middleware
+	SecureServiceWithAPIKeyEndpoint(featureName string, action permissions.Action) endpoint.Middleware
 	SecureServiceWithRedesignEndpoint() endpoint.Middleware
-	SecureServiceWithRedesignWebhookEndpoint() endpoint.Middleware
+	SecureServiceWithRedesignWebhookEndpoint(audience string) endpoint.Middleware
+	Authorize(ctx context.Context, featureName string, action permissions.Action) error
 }
 
 // New returns new middleware
-func New(cognitoClient cognito.Client, userClient userclient.Client, logger *zap.SugaredLogger) Middleware {
-	return &middleware{cognitoClient, userClient, logger}
+func New(
+	identityProvider identity.Provider,
+	userClient userclient.Client,
+	engine permissions.Engine,
+	apiKeyService apiKeys.Service,
+	webhookTokenService webhookTokens.Service,
+	impersonationService impersonation.Service,
+	sessionService sessions.Service,
+	keyRing *jwt.KeyRing,
+	logger *zap.SugaredLogger,
+) Middleware {
+	return &middleware{identityProvider, userClient, engine, apiKeyService, webhookTokenService, impersonationService, sessionService, keyRing, logger}
 }
 
 type middleware struct {
-	cognitoClient cognito.Client
-	userClient    userclient.Client
-	logger        *zap.SugaredLogger
+	identityProvider     identity.Provider
+	userClient           userclient.Client
+	engine               permissions.Engine
+	apiKeyService        apiKeys.Service
+	webhookTokenService  webhookTokens.Service
+	impersonationService impersonation.Service
+	sessionService       sessions.Service
+	keyRing              *jwt.KeyRing
+	logger               *zap.SugaredLogger
 }
 
-func (s *middleware) SecureServiceWithRedesignWebhookEndpoint() endpoint.Middleware {
+// SecureServiceWithRedesignWebhookEndpoint accepts webhook tokens issued for the audience and not revoked
+func (s *middleware) SecureServiceWithRedesignWebhookEndpoint(audience string) endpoint.Middleware {
 	return func(next endpoint.Endpoint) endpoint.Endpoint {
 		return func(ctx context.Context, req interface{}) (interface{}, error) {
 			token := meta.RedesignWebhookToken(ctx)
-			err := jwt.VerifyWebhookToken(token)
+			_, err := s.webhookTokenService.Verify(ctx, token, audience)
 			if err != nil {
 				return nil, err
 			}
@@ -55,7 +79,7 @@ func (s *middleware) SecureServiceWithRedesignEndpoint() endpoint.Middleware {
 	return func(next endpoint.Endpoint) endpoint.Endpoint {
 		return func(ctx context.Context, req interface{}) (interface{}, error) {
 			token := meta.RedesignToken(ctx)
-			claims, err := jwt.VerifyToken(token)
+			claims, err := s.keyRing.VerifyToken(token)
 			if err != nil {
 				return nil, err
 			}
@@ -70,49 +94,46 @@ func (s *middleware) SecureServiceWithRedesignEndpoint() endpoint.Middleware {
 	}
 }
 
-// SecureServiceWithCognitoEndpoint try to authorize by service cognito token
-func (s *middleware) SecureServiceWithCognitoEndpoint(featureName, method string) endpoint.Middleware {
+// SecureServiceWithCognitoEndpoint try to authorize by the user token of any configured identity provider.
+// Tokens of revoked sessions are rejected; the company, role and group of the user come from
+// the database rather than the token claims.
+func (s *middleware) SecureServiceWithCognitoEndpoint(featureName string, action permissions.Action) endpoint.Middleware {
 	return func(next endpoint.Endpoint) endpoint.Endpoint {
 		return func(ctx context.Context, req interface{}) (interface{}, error) {
 			token := meta.RawToken(ctx)
-			claims, err := s.cognitoClient.VerifyToken(ctx, token)
+			userIdentity, err := s.identityProvider.VerifyToken(ctx, token)
 			if err != nil {
 				return nil, err
 			}
 
-			isFirstLogin, err := strconv.ParseBool(claims.RedesignUserIsFirstLogin)
-			if err != nil {
+			if err = s.sessionService.Check(ctx, sessionOf(userIdentity, token, meta.ClientIP(ctx))); err != nil {
 				return nil, err
 			}
 
-			ctx = authMeta.WithUser(ctx, &entity.User{
-				Uuid:         uuid.MustParse(claims.RedesignUserUserUuid),
-				Username:     claims.RedesignUserUsername,
-				FirstName:    claims.RedesignUserFirstName,
-				LastName:     claims.RedesignUserLastName,
-				Email:        claims.RedesignUserEmail,
-				Phone:        claims.RedesignUserPhone,
-				UserGroup:    claims.RedesignUserGroup,
-				IsFirstLogin: isFirstLogin,
-				Company: &entity.Company{
-					Uuid:         uuid.MustParse(claims.RedesignCompanyCompanyUuid),
-					Name:         claims.RedesignCompanyName,
-					UserRole:     claims.RedesignCompanyUserRole,
-					Type:         claims.RedesignCompanyType,
-					IndustryType: claims.RedesignCompanyIndustryType,
-					ExternalId:   claims.RedesignCompanyExternalId,
-				},
-			})
+			ctx = authMeta.WithUser(ctx, userIdentity.User)
+			if userIdentity.Scope != nil {
+				ctx = authMeta.WithIdentityScope(ctx, userIdentity.Scope)
+			}
 
-			userCompanyInfo, err := s.userClient.GetContextUserCompanyInfoInternal(ctx)
-			if err != nil {
-				return nil, err
+			if sessionID := meta.ImpersonationSession(ctx); sessionID != "" {
+				// only internal users impersonate, and customer IdPs never sign them in
+				if userIdentity.Scope != nil {
+					return nil, errors.ErrNoPermission
+				}
+
+				return s.impersonate(ctx, sessionID, featureName, action, func(ctx context.Context) (interface{}, error) {
+					ctx, err := s.authorizeUser(ctx, featureName, action)
+					if err != nil {
+						return nil, err
+					}
+
+					return next(ctx, req)
+				})
 			}
 
-			permissionPath := fmt.Sprintf("%s_%s_%s", userCompanyInfo.Company.Type, userCompanyInfo.Group, userCompanyInfo.Company.UserRole)
-			isAllowed := s.isAccessAllowed(featureName, permissionPath, method)
-			if !isAllowed {
-				return nil, errors.ErrNoPermission
+			ctx, err = s.authorizeUser(ctx, featureName, action)
+			if err != nil {
+				return nil, err
 			}
 
 			return next(ctx, req)
@@ -120,21 +141,93 @@ func (s *middleware) SecureServiceWithCognitoEndpoint(featureName, method string
 	}
 }
 
-func (s *middleware) isAccessAllowed(featureName, permissionPath, method string) bool {
-	s.logger.Info(fmt.Sprintf("checking access with featureName=%s and permissionPath=%s", featureName, permissionPath))
-	permission := permissions.Permissions[featureName][permissionPath]
-	switch permission {
-	case "ro":
-		if method == "GET" {
-			return true
+// authorizeUser checks the action on the feature for the context user and resolves the
+// request path parameters against its companies.
+func (s *middleware) authorizeUser(ctx context.Context, featureName string, action permissions.Action) (context.Context, error) {
+	userCompanyInfo, err := s.userClient.GetContextUserCompanyInfoInternal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkIdentityScope(ctx, userCompanyInfo); err != nil {
+		return nil, err
+	}
+
+	subject := &permissions.Subject{
+		CompanyType: userCompanyInfo.Company.Type,
+		Group:       userCompanyInfo.Group,
+		Role:        userCompanyInfo.Company.UserRole,
+	}
+	if !s.isAccessAllowed(featureName, subject, action) {
+		return nil, errors.ErrNoPermission
+	}
+
+	if err = s.guardTenant(ctx, userCompanyInfo); err != nil {
+		return nil, err
+	}
+
+	ctx = authMeta.WithUser(ctx, trustedUser(authMeta.User(ctx), userCompanyInfo))
+
+	return authMeta.WithPermissionSubject(ctx, subject), nil
+}
+
+// checkIdentityScope rejects the users a customer IdP signed in whose group or company in the
+// database is out of its scope, like an internal user sharing the email domain of the customer.
+func checkIdentityScope(ctx context.Context, info *userEntities.GetUserCompanyInfoByUserIdResponse) error {
+	scope := authMeta.IdentityScope(ctx)
+	if scope == nil {
+		return nil
+	}
+
+	var companyUuid uuid.UUID
+	if info.Company != nil {
+		companyUuid = info.Company.CompanyUuid
+	}
+
+	if !scope.Allows(authMeta.User(ctx).Email, info.Group, companyUuid) {
+		return errors.ErrNoPermission
+	}
+
+	return nil
+}
+
+// trustedUser replaces the group and company the token claims with those in the database,
+// so a stale token can't keep a role or company the user no longer has.
+func trustedUser(user *entity.User, info *userEntities.GetUserCompanyInfoByUserIdResponse) *entity.User {
+	trusted := *user
+	trusted.UserGroup = info.Group
+
+	if info.Company != nil {
+		trusted.Company = &entity.Company{
+			Uuid:       info.Company.CompanyUuid,
+			Name:       info.Company.Name,
+			UserRole:   info.Company.UserRole,
+			Type:       info.Company.Type,
+			ExternalId: info.Company.ExternalId,
+		}
+
+		if info.Company.IndustryType != nil {
+			trusted.Company.IndustryType = strings.Join(*info.Company.IndustryType, ",")
 		}
-	case "rw":
-		return true
-	case "na":
-		return false
 	}
 
-	return false
+	return &trusted
+}
+
+// Authorize checks an additional action for the subject resolved by SecureServiceWithCognitoEndpoint
+func (s *middleware) Authorize(ctx context.Context, featureName string, action permissions.Action) error {
+	subject := authMeta.PermissionSubject(ctx)
+	if subject == nil || !s.isAccessAllowed(featureName, subject, action) {
+		return errors.ErrNoPermission
+	}
+
+	return nil
+}
+
+func (s *middleware) isAccessAllowed(featureName string, subject *permissions.Subject, action permissions.Action) bool {
+	s.logger.Info(fmt.Sprintf("checking access with featureName=%s, permissionPath=%s and action=%s", featureName, subject.Key(), action))
+
+	return s.engine.IsAllowed(featureName, *subject, action)
 }
 
 // func (s *middleware) isAccessAllowed(ctx context.Context, role string, group string, allowedRolesAndGroups []string) bool {
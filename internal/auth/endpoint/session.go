@@ -0,0 +1,28 @@
+package endpoint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/identity"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/entities"
+)
+
+// sessionOf the verified token. Tokens without a jti claim are told apart by their hash.
+func sessionOf(userIdentity *identity.Identity, token, clientIP string) *entities.Session {
+	sessionID := userIdentity.TokenID
+	if sessionID == "" {
+		sum := sha256.Sum256([]byte(token))
+		sessionID = hex.EncodeToString(sum[:])
+	}
+
+	return &entities.Session{
+		SessionID: sessionID,
+		UserUuid:  userIdentity.User.Uuid,
+		Issuer:    userIdentity.Issuer,
+		Subject:   userIdentity.Subject,
+		ClientIP:  clientIP,
+		IssuedAt:  userIdentity.IssuedAt,
+		ExpiresAt: userIdentity.ExpiresAt,
+	}
+}
@@ -0,0 +1,109 @@
+package endpoint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/entity"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/identity"
+	authMeta "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/meta"
+	companyEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company/entities"
+	userEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSessionOf(t *testing.T) {
+	Convey("Given a verified token", t, func() {
+		userIdentity := &identity.Identity{
+			Issuer:    "https://issuer",
+			Subject:   "sub",
+			IssuedAt:  time.Now(),
+			ExpiresAt: time.Now().Add(time.Hour),
+			User:      &entity.User{Uuid: uuid.New()},
+		}
+
+		Convey("The session is keyed by the jti claim", func() {
+			userIdentity.TokenID = "jti-1"
+
+			session := sessionOf(userIdentity, "token", "10.0.0.1")
+			So(session.SessionID, ShouldEqual, "jti-1")
+			So(session.UserUuid, ShouldEqual, userIdentity.User.Uuid)
+			So(session.ClientIP, ShouldEqual, "10.0.0.1")
+		})
+
+		Convey("Tokens without a jti claim are keyed by their hash", func() {
+			first := sessionOf(userIdentity, "token-1", "")
+			second := sessionOf(userIdentity, "token-2", "")
+
+			So(first.SessionID, ShouldHaveLength, 64)
+			So(first.SessionID, ShouldNotEqual, second.SessionID)
+		})
+	})
+}
+
+func TestTrustedUser(t *testing.T) {
+	Convey("Given a token claiming a role and company the user no longer has", t, func() {
+		user := &entity.User{
+			Uuid:      uuid.New(),
+			Email:     "user@customer",
+			UserGroup: "superadmin",
+			Company:   &entity.Company{Uuid: uuid.New(), UserRole: "admin", Type: "engineering"},
+		}
+		industryType := companyEntities.IndustryType{"finance", "health"}
+		info := &userEntities.GetUserCompanyInfoByUserIdResponse{
+			UserUuid: user.Uuid,
+			Group:    "customer",
+			Company: &companyEntities.Company{
+				CompanyUuid:  uuid.New(),
+				Name:         "Customer",
+				UserRole:     "user",
+				Type:         "customer",
+				IndustryType: &industryType,
+			},
+		}
+
+		Convey("The group and company come from the database", func() {
+			trusted := trustedUser(user, info)
+
+			So(trusted.Email, ShouldEqual, user.Email)
+			So(trusted.UserGroup, ShouldEqual, "customer")
+			So(trusted.Company.Uuid, ShouldEqual, info.Company.CompanyUuid)
+			So(trusted.Company.UserRole, ShouldEqual, "user")
+			So(trusted.Company.Type, ShouldEqual, "customer")
+			So(trusted.Company.IndustryType, ShouldEqual, "finance,health")
+
+			So(user.UserGroup, ShouldEqual, "superadmin")
+		})
+	})
+}
+
+func TestCheckIdentityScope(t *testing.T) {
+	Convey("Given a user signed in by a customer IdP", t, func() {
+		company := uuid.New()
+		ctx := authMeta.WithUser(context.Background(), &entity.User{Uuid: uuid.New(), Email: "user@customer.com"})
+		ctx = authMeta.WithIdentityScope(ctx, &entity.IdentityScope{AllowedDomains: []string{"customer.com"}, CompanyUuids: []uuid.UUID{company}})
+
+		info := func(group string, companyUuid uuid.UUID) *userEntities.GetUserCompanyInfoByUserIdResponse {
+			return &userEntities.GetUserCompanyInfoByUserIdResponse{Group: group, Company: &companyEntities.Company{CompanyUuid: companyUuid}}
+		}
+
+		Convey("Customer users of the company are accepted", func() {
+			So(checkIdentityScope(ctx, info("customer", company)), ShouldBeNil)
+		})
+
+		Convey("Internal users are rejected whatever the token claimed", func() {
+			So(checkIdentityScope(ctx, info("engineer", company)), ShouldEqual, errors.ErrNoPermission)
+		})
+
+		Convey("Other companies are rejected", func() {
+			So(checkIdentityScope(ctx, info("customer", uuid.New())), ShouldEqual, errors.ErrNoPermission)
+		})
+
+		Convey("Users of the trusted providers are not scoped", func() {
+			So(checkIdentityScope(context.Background(), info("engineer", uuid.New())), ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,88 @@
+package endpoint
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/constants"
+	userEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/meta"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	companyIDParam     = "company_id"
+	userIDParam        = "user_id"
+	requestUserIDParam = "req_user_id"
+)
+
+// guardTenant resolves the company_id and user_id path parameters of the request against
+// company_users membership of the context user.
+//
+// The acting user (req_user_id when the route has one, otherwise user_id) has to be the
+// context user, and the context user has to be an active member of company_id. Superadmins
+// may access every company; engineers and csc only the companies they are assigned to.
+// When the route acts on another user (req_user_id), that user has to be a member of company_id too,
+// pending members included so their invites can be managed.
+func (s *middleware) guardTenant(ctx context.Context, info *userEntities.GetUserCompanyInfoByUserIdResponse) error {
+	params := meta.PathParams(ctx)
+	if len(params) == 0 {
+		return nil
+	}
+
+	actorParam := userIDParam
+	if _, ok := params[requestUserIDParam]; ok {
+		actorParam = requestUserIDParam
+	}
+
+	if v, ok := params[actorParam]; ok {
+		actorUUID, err := uuid.Parse(v)
+		if err != nil || actorUUID != info.UserUuid {
+			return errors.ErrCrossTenantAccess
+		}
+	}
+
+	v, ok := params[companyIDParam]
+	if !ok {
+		return nil
+	}
+
+	companyUUID, err := uuid.Parse(v)
+	if err != nil {
+		return errors.ErrCrossTenantAccess
+	}
+
+	if info.Group != constants.UserGroupSuperadmin {
+		if err = s.checkCompanyMember(ctx, info.UserUuid, companyUUID, userEntities.CompanyUserStatusActive); err != nil {
+			return err
+		}
+	}
+
+	if actorParam == requestUserIDParam {
+		targetUUID, err := uuid.Parse(params[userIDParam])
+		if err != nil {
+			return errors.ErrCrossTenantAccess
+		}
+
+		return s.checkCompanyMember(ctx, targetUUID, companyUUID, userEntities.CompanyUserStatusActive, userEntities.CompanyUserStatusPending)
+	}
+
+	return nil
+}
+
+// checkCompanyMember checks the user is a member of the company with one of the statuses.
+func (s *middleware) checkCompanyMember(ctx context.Context, userUUID, companyUUID uuid.UUID, statuses ...string) error {
+	companyUser, err := s.userClient.GetCompanyUser(ctx, userUUID, companyUUID)
+	if err != nil {
+		return err
+	}
+
+	if companyUser == nil || !slices.Contains(statuses, companyUser.Status) {
+		s.logger.Warnf("denied cross tenant access of user %s to company %s", userUUID, companyUUID)
+
+		return errors.ErrCrossTenantAccess
+	}
+
+	return nil
+}
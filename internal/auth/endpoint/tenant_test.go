@@ -0,0 +1,92 @@
+package endpoint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/userclient"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/meta"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+)
+
+type membershipClient struct {
+	userclient.Client
+	links map[[2]uuid.UUID]string
+}
+
+func (c *membershipClient) GetCompanyUser(_ context.Context, userUUID, companyUUID uuid.UUID) (*entities.CompanyUser, error) {
+	status, ok := c.links[[2]uuid.UUID{userUUID, companyUUID}]
+	if !ok {
+		return nil, nil
+	}
+
+	return &entities.CompanyUser{UserUuid: userUUID, CompanyUuid: companyUUID, Status: status}, nil
+}
+
+func TestMiddleware_guardTenant(t *testing.T) {
+	Convey("Given a customer, an engineer and a superadmin", t, func() {
+		customer, engineer, superadmin, colleague := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+		own, other := uuid.New(), uuid.New()
+
+		client := &membershipClient{links: map[[2]uuid.UUID]string{
+			{customer, own}:  "ACTIVE",
+			{colleague, own}: "ACTIVE",
+			{engineer, own}:  "ACTIVE",
+		}}
+		m := &middleware{userClient: client, logger: zap.NewNop().Sugar()}
+
+		request := func(params map[string]string) context.Context {
+			return meta.WithPathParams(context.Background(), params)
+		}
+
+		Convey("Members can access their company as themselves", func() {
+			ctx := request(map[string]string{"company_id": own.String(), "user_id": customer.String()})
+			So(m.guardTenant(ctx, &entities.GetUserCompanyInfoByUserIdResponse{UserUuid: customer, Group: "customer"}), ShouldBeNil)
+		})
+
+		Convey("Acting as another user is rejected", func() {
+			ctx := request(map[string]string{"company_id": own.String(), "user_id": colleague.String()})
+			So(m.guardTenant(ctx, &entities.GetUserCompanyInfoByUserIdResponse{UserUuid: customer, Group: "customer"}), ShouldEqual, errors.ErrCrossTenantAccess)
+		})
+
+		Convey("Another company is rejected, also for engineers not assigned to it", func() {
+			ctx := request(map[string]string{"company_id": other.String(), "user_id": customer.String()})
+			So(m.guardTenant(ctx, &entities.GetUserCompanyInfoByUserIdResponse{UserUuid: customer, Group: "customer"}), ShouldEqual, errors.ErrCrossTenantAccess)
+
+			ctx = request(map[string]string{"company_id": other.String(), "user_id": engineer.String()})
+			So(m.guardTenant(ctx, &entities.GetUserCompanyInfoByUserIdResponse{UserUuid: engineer, Group: "engineer"}), ShouldEqual, errors.ErrCrossTenantAccess)
+		})
+
+		Convey("Superadmins can access every company", func() {
+			ctx := request(map[string]string{"company_id": other.String(), "user_id": superadmin.String()})
+			So(m.guardTenant(ctx, &entities.GetUserCompanyInfoByUserIdResponse{UserUuid: superadmin, Group: "superadmin"}), ShouldBeNil)
+		})
+
+		Convey("The target user of req_user_id routes has to belong to the company", func() {
+			ctx := request(map[string]string{"company_id": own.String(), "req_user_id": customer.String(), "user_id": colleague.String()})
+			So(m.guardTenant(ctx, &entities.GetUserCompanyInfoByUserIdResponse{UserUuid: customer, Group: "customer"}), ShouldBeNil)
+
+			ctx = request(map[string]string{"company_id": own.String(), "req_user_id": customer.String(), "user_id": superadmin.String()})
+			So(m.guardTenant(ctx, &entities.GetUserCompanyInfoByUserIdResponse{UserUuid: customer, Group: "customer"}), ShouldEqual, errors.ErrCrossTenantAccess)
+		})
+
+		Convey("Inactive members are rejected", func() {
+			client.links[[2]uuid.UUID{customer, own}] = "INACTIVE"
+			ctx := request(map[string]string{"company_id": own.String(), "user_id": customer.String()})
+			So(m.guardTenant(ctx, &entities.GetUserCompanyInfoByUserIdResponse{UserUuid: customer, Group: "customer"}), ShouldEqual, errors.ErrCrossTenantAccess)
+		})
+
+		Convey("Pending members are rejected, but their invites can be managed", func() {
+			client.links[[2]uuid.UUID{colleague, own}] = "PENDING"
+			ctx := request(map[string]string{"company_id": own.String(), "user_id": colleague.String()})
+			So(m.guardTenant(ctx, &entities.GetUserCompanyInfoByUserIdResponse{UserUuid: colleague, Group: "customer"}), ShouldEqual, errors.ErrCrossTenantAccess)
+
+			ctx = request(map[string]string{"company_id": own.String(), "req_user_id": customer.String(), "user_id": colleague.String()})
+			So(m.guardTenant(ctx, &entities.GetUserCompanyInfoByUserIdResponse{UserUuid: customer, Group: "customer"}), ShouldBeNil)
+		})
+	})
+}
@@ -1,6 +1,13 @@
 package entity
 
-import "github.com/google/uuid"
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/constants"
+	"golang.org/x/exp/slices"
+)
 
 type User struct {
 	Uuid         uuid.UUID
@@ -22,3 +29,37 @@ type Company struct {
 	IndustryType string
 	ExternalId   string
 }
+
+// Impersonation marks a request made by an engineering or csc user acting as the context user.
+type Impersonation struct {
+	SessionUuid       uuid.UUID `json:"session_uuid"`
+	ImpersonatorUuid  uuid.UUID `json:"impersonator_uuid"`
+	ImpersonatorEmail string    `json:"impersonator_email"`
+	CompanyUuid       uuid.UUID `json:"company_uuid"`
+	ReadOnly          bool      `json:"read_only"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+// IdentityScope restricts the users a customer identity provider can sign in. Empty lists don't
+// restrict, internal users (superadmin, engineer and csc) are never in scope.
+type IdentityScope struct {
+	AllowedDomains []string
+	CompanyUuids   []uuid.UUID
+}
+
+// Allows the user of the group with the email, acting in the company.
+func (s *IdentityScope) Allows(email, group string, companyUuid uuid.UUID) bool {
+	switch group {
+	case constants.UserGroupSuperadmin, constants.UserGroupEngineer, constants.UserGroupCsc:
+		return false
+	}
+
+	if len(s.AllowedDomains) > 0 {
+		at := strings.LastIndex(email, "@")
+		if at < 0 || !slices.Contains(s.AllowedDomains, strings.ToLower(email[at+1:])) {
+			return false
+		}
+	}
+
+	return len(s.CompanyUuids) == 0 || slices.Contains(s.CompanyUuids, companyUuid)
+}
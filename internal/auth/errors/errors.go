@@ -7,6 +7,16 @@ import (
 var (
 	// ErrNoPermission represents access denied error
 	ErrNoPermission = errors.New("not enough permission")
+	// ErrCrossTenantAccess represents access to a company or user the caller does not belong to
+	ErrCrossTenantAccess = errors.New("access to this company is not permitted")
+	// ErrInvalidAPIKey represents an unknown, expired or revoked api key
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	// ErrInvalidImpersonation represents an unknown, ended or expired impersonation session, or one of another user
+	ErrInvalidImpersonation = errors.New("impersonation session is invalid or has expired")
+	// ErrImpersonationReadOnly represents a write attempted under a read-only impersonation session
+	ErrImpersonationReadOnly = errors.New("impersonation session is read-only")
+	// ErrInvalidToken represents an identity token no configured provider accepts
+	ErrInvalidToken = errors.New("token is invalid")
 )
 
 // IsNoPermissionError checks if it's any of the above auth errors.
@@ -14,9 +24,19 @@ func IsNoPermissionError(err error) bool {
 	cause := errors.Cause(err)
 
 	switch cause {
-	case ErrNoPermission:
+	case ErrNoPermission, ErrCrossTenantAccess, ErrInvalidImpersonation, ErrImpersonationReadOnly:
 		return true
 	default:
 		return false
 	}
 }
+
+// IsInvalidAPIKeyError checks if the api key was not accepted.
+func IsInvalidAPIKeyError(err error) bool {
+	return errors.Cause(err) == ErrInvalidAPIKey
+}
+
+// IsInvalidTokenError checks if the identity token was not accepted.
+func IsInvalidTokenError(err error) bool {
+	return errors.Cause(err) == ErrInvalidToken
+}
@@ -0,0 +1,79 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/entity"
+	authErrors "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito"
+	cognitoCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito/config"
+	"github.com/pkg/errors"
+)
+
+type cognitoProvider struct {
+	issuer string
+	client cognito.Client
+}
+
+func newCognitoProvider(cfg cognitoCfg.Config, client cognito.Client) Provider {
+	return &cognitoProvider{
+		issuer: fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s", cfg.AwsRegion, cfg.UserPoolID),
+		client: client,
+	}
+}
+
+func (p *cognitoProvider) Issuer() string {
+	return p.issuer
+}
+
+func (p *cognitoProvider) VerifyToken(ctx context.Context, token string) (*Identity, error) {
+	claims, err := p.client.VerifyToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	isFirstLogin, err := strconv.ParseBool(claims.RedesignUserIsFirstLogin)
+	if err != nil {
+		return nil, errors.Wrap(authErrors.ErrInvalidToken, "is_first_login claim is not a boolean")
+	}
+
+	userUuid, err := uuid.Parse(claims.RedesignUserUserUuid)
+	if err != nil {
+		return nil, errors.Wrap(authErrors.ErrInvalidToken, "user_uuid claim is not a uuid")
+	}
+
+	companyUuid, err := uuid.Parse(claims.RedesignCompanyCompanyUuid)
+	if err != nil {
+		return nil, errors.Wrap(authErrors.ErrInvalidToken, "company_uuid claim is not a uuid")
+	}
+
+	return &Identity{
+		Issuer:    claims.Issuer,
+		Subject:   claims.Subject,
+		TokenID:   claims.Id,
+		IssuedAt:  time.Unix(claims.IssuedAt, 0),
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0),
+		User: &entity.User{
+			Uuid:         userUuid,
+			Username:     claims.RedesignUserUsername,
+			FirstName:    claims.RedesignUserFirstName,
+			LastName:     claims.RedesignUserLastName,
+			Email:        claims.RedesignUserEmail,
+			Phone:        claims.RedesignUserPhone,
+			UserGroup:    claims.RedesignUserGroup,
+			IsFirstLogin: isFirstLogin,
+			Company: &entity.Company{
+				Uuid:         companyUuid,
+				Name:         claims.RedesignCompanyName,
+				UserRole:     claims.RedesignCompanyUserRole,
+				Type:         claims.RedesignCompanyType,
+				IndustryType: claims.RedesignCompanyIndustryType,
+				ExternalId:   claims.RedesignCompanyExternalId,
+			},
+		},
+	}, nil
+}
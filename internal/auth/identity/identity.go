@@ -0,0 +1,89 @@
+// Package identity verifies user tokens issued by the configured identity providers.
+package identity
+
+import (
+	"context"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/entity"
+	authErrors "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito"
+	cognitoCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito/config"
+	"github.com/pkg/errors"
+)
+
+// Identity is the verified user of a token.
+type Identity struct {
+	Issuer    string
+	Subject   string
+	TokenID   string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+	User      *entity.User
+	// Scope of the customer IdP which issued the token, nil for the trusted providers.
+	Scope *entity.IdentityScope
+}
+
+// Provider verifies tokens of one issuer.
+type Provider interface {
+	Issuer() string
+	VerifyToken(ctx context.Context, token string) (*Identity, error)
+}
+
+// New provider for the configured identity providers. Tokens are routed to a provider by
+// their iss claim; without configured providers only Cognito is used.
+func New(cfg config.IdentityConfig, cognitoConfig cognitoCfg.Config, cognitoClient cognito.Client) (Provider, error) {
+	if len(cfg.Providers) == 0 {
+		return newCognitoProvider(cognitoConfig, cognitoClient), nil
+	}
+
+	providers := make([]Provider, 0, len(cfg.Providers))
+	for _, p := range cfg.Providers {
+		switch p.Type {
+		case config.ProviderTypeCognito:
+			providers = append(providers, newCognitoProvider(cognitoConfig, cognitoClient))
+		case config.ProviderTypeOIDC:
+			provider, err := newOIDCProvider(p)
+			if err != nil {
+				return nil, errors.Wrapf(err, "identity provider %s", p.Name)
+			}
+
+			providers = append(providers, provider)
+		default:
+			return nil, errors.Errorf("identity provider %s has unknown type %q", p.Name, p.Type)
+		}
+	}
+
+	if len(providers) == 1 {
+		return providers[0], nil
+	}
+
+	return &router{providers}, nil
+}
+
+type router struct {
+	providers []Provider
+}
+
+// Issuer of the router is empty, it serves the issuers of its providers.
+func (r *router) Issuer() string {
+	return ""
+}
+
+func (r *router) VerifyToken(ctx context.Context, token string) (*Identity, error) {
+	claims := jwtgo.MapClaims{}
+	if _, _, err := new(jwtgo.Parser).ParseUnverified(token, claims); err != nil {
+		return nil, authErrors.ErrInvalidToken
+	}
+
+	issuer, _ := claims["iss"].(string)
+	for _, p := range r.providers {
+		if p.Issuer() == issuer {
+			return p.VerifyToken(ctx, token)
+		}
+	}
+
+	return nil, errors.Wrapf(authErrors.ErrInvalidToken, "unknown issuer %q", issuer)
+}
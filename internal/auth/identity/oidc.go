@@ -0,0 +1,263 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/entity"
+	authErrors "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	"github.com/pkg/errors"
+)
+
+// User fields a claim can be mapped to.
+const (
+	ClaimUserUuid            = "user_uuid"
+	ClaimUsername            = "username"
+	ClaimFirstName           = "first_name"
+	ClaimLastName            = "last_name"
+	ClaimEmail               = "email"
+	ClaimPhone               = "phone"
+	ClaimUserGroup           = "user_group"
+	ClaimIsFirstLogin        = "is_first_login"
+	ClaimCompanyUuid         = "company_uuid"
+	ClaimCompanyName         = "company_name"
+	ClaimCompanyType         = "company_type"
+	ClaimCompanyUserRole     = "company_user_role"
+	ClaimCompanyExternalId   = "company_external_id"
+	ClaimCompanyIndustryType = "company_industry_type"
+)
+
+// defaultClaims are the claim names Cognito tokens carry.
+var defaultClaims = map[string]string{
+	ClaimUserUuid:            "redesign_user:user_uuid",
+	ClaimUsername:            "redesign_user:username",
+	ClaimFirstName:           "redesign_user:first_name",
+	ClaimLastName:            "redesign_user:last_name",
+	ClaimEmail:               "redesign_user:email",
+	ClaimPhone:               "redesign_user:phone",
+	ClaimUserGroup:           "redesign_user:user_group",
+	ClaimIsFirstLogin:        "redesign_user:is_first_login",
+	ClaimCompanyUuid:         "redesign_company:company_uuid",
+	ClaimCompanyName:         "redesign_company:name",
+	ClaimCompanyType:         "redesign_company:type",
+	ClaimCompanyUserRole:     "redesign_company:user_role",
+	ClaimCompanyExternalId:   "redesign_company:external_id",
+	ClaimCompanyIndustryType: "redesign_company:industry_type",
+}
+
+// jwksRefreshInterval is how often a remote JWKS is refetched.
+const jwksRefreshInterval = 15 * time.Minute
+
+type oidcProvider struct {
+	issuer   string
+	audience string
+	keys     jwk.Set
+	claims   map[string]string
+	scope    *entity.IdentityScope
+}
+
+func newOIDCProvider(cfg config.ProviderConfig) (Provider, error) {
+	claims := make(map[string]string, len(defaultClaims))
+	for field, claim := range defaultClaims {
+		claims[field] = claim
+	}
+
+	for field, claim := range cfg.Claims {
+		if _, ok := defaultClaims[field]; !ok {
+			return nil, errors.Errorf("unknown claim mapping %q", field)
+		}
+
+		claims[field] = claim
+	}
+
+	var keys jwk.Set
+	if cfg.JWKSFile != "" {
+		set, err := jwk.ReadFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read JWKS file")
+		}
+
+		keys = set
+	} else {
+		cache := jwk.NewCache(context.Background())
+		if err := cache.Register(cfg.JWKSURL, jwk.WithRefreshInterval(jwksRefreshInterval)); err != nil {
+			return nil, errors.Wrap(err, "failed to register JWKS url")
+		}
+
+		keys = jwk.NewCachedSet(cache, cfg.JWKSURL)
+	}
+
+	var scope *entity.IdentityScope
+	if len(cfg.AllowedDomains) > 0 || len(cfg.CompanyUuids) > 0 {
+		scope = &entity.IdentityScope{}
+		for _, domain := range cfg.AllowedDomains {
+			scope.AllowedDomains = append(scope.AllowedDomains, strings.ToLower(domain))
+		}
+
+		for _, companyUuid := range cfg.CompanyUuids {
+			id, err := uuid.Parse(companyUuid)
+			if err != nil {
+				return nil, errors.Wrapf(err, "company uuid %s", companyUuid)
+			}
+
+			scope.CompanyUuids = append(scope.CompanyUuids, id)
+		}
+	}
+
+	return &oidcProvider{
+		issuer:   cfg.Issuer,
+		audience: cfg.Audience,
+		keys:     keys,
+		claims:   claims,
+		scope:    scope,
+	}, nil
+}
+
+func (p *oidcProvider) Issuer() string {
+	return p.issuer
+}
+
+func (p *oidcProvider) VerifyToken(ctx context.Context, token string) (*Identity, error) {
+	claims := jwtgo.MapClaims{}
+
+	parsed, err := jwtgo.ParseWithClaims(token, claims, p.key)
+	if err != nil || !parsed.Valid {
+		return nil, errors.Wrap(authErrors.ErrInvalidToken, fmt.Sprint(err))
+	}
+
+	if iss, _ := claims["iss"].(string); iss != p.issuer {
+		return nil, errors.Wrapf(authErrors.ErrInvalidToken, "unexpected issuer %q", iss)
+	}
+
+	if !hasAudience(claims["aud"], p.audience) {
+		return nil, errors.Wrap(authErrors.ErrInvalidToken, "unexpected audience")
+	}
+
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, errors.Wrap(authErrors.ErrInvalidToken, "exp claim is missing")
+	}
+
+	user, err := p.user(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	// the claims are checked here, the user and company they resolve to once authorized
+	if p.scope != nil && !p.scope.Allows(user.Email, user.UserGroup, user.Company.Uuid) {
+		return nil, errors.Wrapf(authErrors.ErrInvalidToken, "user %s is out of the scope of the issuer", user.Email)
+	}
+
+	identity := &Identity{Issuer: p.issuer, ExpiresAt: time.Unix(int64(exp), 0), User: user, Scope: p.scope}
+	identity.Subject, _ = claims["sub"].(string)
+	identity.TokenID, _ = claims["jti"].(string)
+	if iat, ok := claims["iat"].(float64); ok {
+		identity.IssuedAt = time.Unix(int64(iat), 0)
+	}
+
+	return identity, nil
+}
+
+// key looks the signing key up by the kid header. Only asymmetric algorithms are
+// accepted, as the keys are public.
+func (p *oidcProvider) key(token *jwtgo.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwtgo.SigningMethodRSA, *jwtgo.SigningMethodECDSA, *jwtgo.SigningMethodRSAPSS:
+	default:
+		return nil, errors.Errorf("unexpected signing method %v", token.Header["alg"])
+	}
+
+	var key jwk.Key
+	if kid, ok := token.Header["kid"].(string); ok {
+		if key, ok = p.keys.LookupKeyID(kid); !ok {
+			return nil, errors.Errorf("key %v not found", kid)
+		}
+	} else if p.keys.Len() == 1 {
+		key, _ = p.keys.Key(0)
+	} else {
+		return nil, errors.New("kid header not found")
+	}
+
+	if alg := key.Algorithm().String(); alg != "" && alg != token.Method.Alg() {
+		return nil, errors.Errorf("key %v is not for %v", key.KeyID(), token.Method.Alg())
+	}
+
+	var raw interface{}
+	if err := key.Raw(&raw); err != nil {
+		return nil, errors.Wrap(err, "failed to create token key")
+	}
+
+	return raw, nil
+}
+
+func (p *oidcProvider) user(claims jwtgo.MapClaims) (*entity.User, error) {
+	str := func(field string) string {
+		switch v := claims[p.claims[field]].(type) {
+		case nil:
+			return ""
+		case string:
+			return v
+		default:
+			return fmt.Sprint(v)
+		}
+	}
+
+	userUuid, err := uuid.Parse(str(ClaimUserUuid))
+	if err != nil {
+		return nil, errors.Wrapf(authErrors.ErrInvalidToken, "%s claim is not a uuid", p.claims[ClaimUserUuid])
+	}
+
+	companyUuid, err := uuid.Parse(str(ClaimCompanyUuid))
+	if err != nil {
+		return nil, errors.Wrapf(authErrors.ErrInvalidToken, "%s claim is not a uuid", p.claims[ClaimCompanyUuid])
+	}
+
+	var isFirstLogin bool
+	if v := str(ClaimIsFirstLogin); v != "" {
+		if isFirstLogin, err = strconv.ParseBool(v); err != nil {
+			return nil, errors.Wrapf(authErrors.ErrInvalidToken, "%s claim is not a boolean", p.claims[ClaimIsFirstLogin])
+		}
+	}
+
+	return &entity.User{
+		Uuid:         userUuid,
+		Username:     str(ClaimUsername),
+		FirstName:    str(ClaimFirstName),
+		LastName:     str(ClaimLastName),
+		Email:        str(ClaimEmail),
+		Phone:        str(ClaimPhone),
+		UserGroup:    str(ClaimUserGroup),
+		IsFirstLogin: isFirstLogin,
+		Company: &entity.Company{
+			Uuid:         companyUuid,
+			Name:         str(ClaimCompanyName),
+			UserRole:     str(ClaimCompanyUserRole),
+			Type:         str(ClaimCompanyType),
+			IndustryType: str(ClaimCompanyIndustryType),
+			ExternalId:   str(ClaimCompanyExternalId),
+		},
+	}, nil
+}
+
+// hasAudience checks the aud claim, which is either a string or a list of strings.
+func hasAudience(aud interface{}, audience string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == audience
+	case []interface{}:
+		for _, a := range v {
+			if s, ok := a.(string); ok && s == audience {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,231 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/config"
+	authErrors "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	testIssuer   = "https://idp.local"
+	testAudience = "redesign-api"
+	testKid      = "local-1"
+)
+
+// writeJWKS writes the public part of key as a JWKS file and returns its path.
+func writeJWKS(t *testing.T, key *rsa.PrivateKey) string {
+	pub, err := jwk.FromRaw(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_ = pub.Set(jwk.KeyIDKey, testKid)
+
+	set := jwk.NewSet()
+	_ = set.AddKey(pub)
+
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func signToken(key *rsa.PrivateKey, claims jwtgo.MapClaims) string {
+	token := jwtgo.NewWithClaims(jwtgo.SigningMethodRS256, claims)
+	token.Header["kid"] = testKid
+
+	signed, _ := token.SignedString(key)
+
+	return signed
+}
+
+func TestOIDCProvider(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	userUuid, companyUuid := uuid.New(), uuid.New()
+
+	validClaims := func() jwtgo.MapClaims {
+		return jwtgo.MapClaims{
+			"iss":                   testIssuer,
+			"aud":                   testAudience,
+			"sub":                   "user-1",
+			"jti":                   "token-1",
+			"exp":                   time.Now().Add(time.Hour).Unix(),
+			"uid":                   userUuid.String(),
+			"org":                   companyUuid.String(),
+			"email":                 "user@local",
+			"first_login":           false,
+			"redesign_company:type": "customer",
+		}
+	}
+
+	provider, err := newOIDCProvider(config.ProviderConfig{
+		Name:     "local",
+		Type:     config.ProviderTypeOIDC,
+		Issuer:   testIssuer,
+		Audience: testAudience,
+		JWKSFile: writeJWKS(t, key),
+		Claims: map[string]string{
+			ClaimUserUuid:     "uid",
+			ClaimCompanyUuid:  "org",
+			ClaimEmail:        "email",
+			ClaimIsFirstLogin: "first_login",
+		},
+	})
+
+	Convey("Given an oidc provider with a JWKS file and a claim mapping", t, func() {
+		So(err, ShouldBeNil)
+
+		Convey("A valid token maps its claims to the user", func() {
+			identity, err := provider.VerifyToken(context.Background(), signToken(key, validClaims()))
+			So(err, ShouldBeNil)
+			So(identity.Subject, ShouldEqual, "user-1")
+			So(identity.TokenID, ShouldEqual, "token-1")
+			So(identity.User.Uuid, ShouldEqual, userUuid)
+			So(identity.User.Email, ShouldEqual, "user@local")
+			So(identity.User.IsFirstLogin, ShouldBeFalse)
+			So(identity.User.Company.Uuid, ShouldEqual, companyUuid)
+			So(identity.User.Company.Type, ShouldEqual, "customer")
+		})
+
+		Convey("An audience list containing the audience is accepted", func() {
+			claims := validClaims()
+			claims["aud"] = []string{"other", testAudience}
+
+			_, err := provider.VerifyToken(context.Background(), signToken(key, claims))
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Tokens are rejected when", func() {
+			cases := map[string]func(jwtgo.MapClaims){
+				"the issuer differs":         func(c jwtgo.MapClaims) { c["iss"] = "https://other.local" },
+				"the audience differs":       func(c jwtgo.MapClaims) { c["aud"] = "other" },
+				"they are expired":           func(c jwtgo.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() },
+				"they have no expiry":        func(c jwtgo.MapClaims) { delete(c, "exp") },
+				"the user uuid is malformed": func(c jwtgo.MapClaims) { c["uid"] = "not-a-uuid" },
+			}
+
+			for name, tamper := range cases {
+				claims := validClaims()
+				tamper(claims)
+
+				_, err := provider.VerifyToken(context.Background(), signToken(key, claims))
+				SoMsg(name, authErrors.IsInvalidTokenError(err), ShouldBeTrue)
+			}
+		})
+
+		Convey("Tokens signed by another key are rejected", func() {
+			other, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+			_, err := provider.VerifyToken(context.Background(), signToken(other, validClaims()))
+			So(authErrors.IsInvalidTokenError(err), ShouldBeTrue)
+		})
+
+		Convey("HMAC tokens are rejected", func() {
+			token, _ := jwtgo.NewWithClaims(jwtgo.SigningMethodHS256, validClaims()).SignedString([]byte("secret"))
+
+			_, err := provider.VerifyToken(context.Background(), token)
+			So(authErrors.IsInvalidTokenError(err), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given a customer IdP scoped to a domain and a company", t, func() {
+		scoped, err := newOIDCProvider(config.ProviderConfig{
+			Issuer:         testIssuer,
+			Audience:       testAudience,
+			JWKSFile:       writeJWKS(t, key),
+			Claims:         map[string]string{ClaimUserUuid: "uid", ClaimCompanyUuid: "org", ClaimEmail: "email", ClaimUserGroup: "group"},
+			AllowedDomains: []string{"Local"},
+			CompanyUuids:   []string{companyUuid.String()},
+		})
+		So(err, ShouldBeNil)
+
+		Convey("Its users are verified along with its scope", func() {
+			identity, err := scoped.VerifyToken(context.Background(), signToken(key, validClaims()))
+			So(err, ShouldBeNil)
+			So(identity.Scope.CompanyUuids, ShouldResemble, []uuid.UUID{companyUuid})
+		})
+
+		Convey("Tokens are rejected when", func() {
+			cases := map[string]func(jwtgo.MapClaims){
+				"the email domain is not allowed": func(c jwtgo.MapClaims) { c["email"] = "user@other" },
+				"the company is not allowed":      func(c jwtgo.MapClaims) { c["org"] = uuid.NewString() },
+				"they claim an internal group":    func(c jwtgo.MapClaims) { c["group"] = "superadmin" },
+			}
+
+			for name, tamper := range cases {
+				claims := validClaims()
+				tamper(claims)
+
+				_, err := scoped.VerifyToken(context.Background(), signToken(key, claims))
+				SoMsg(name, authErrors.IsInvalidTokenError(err), ShouldBeTrue)
+			}
+		})
+	})
+
+	Convey("An unknown claim mapping fails the provider", t, func() {
+		_, err := newOIDCProvider(config.ProviderConfig{
+			Issuer:   testIssuer,
+			Audience: testAudience,
+			JWKSFile: writeJWKS(t, key),
+			Claims:   map[string]string{"nickname": "nick"},
+		})
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestRouter(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	local, _ := newOIDCProvider(config.ProviderConfig{
+		Issuer:   testIssuer,
+		Audience: testAudience,
+		JWKSFile: writeJWKS(t, key),
+	})
+	r := &router{[]Provider{local}}
+
+	Convey("Given a router over a local provider", t, func() {
+		claims := jwtgo.MapClaims{
+			"iss":                           testIssuer,
+			"aud":                           testAudience,
+			"exp":                           time.Now().Add(time.Hour).Unix(),
+			"redesign_user:user_uuid":       uuid.NewString(),
+			"redesign_company:company_uuid": uuid.NewString(),
+		}
+
+		Convey("Tokens of the local issuer reach the provider", func() {
+			_, err := r.VerifyToken(context.Background(), signToken(key, claims))
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Tokens of an unknown issuer are rejected", func() {
+			claims["iss"] = "https://unknown.local"
+
+			_, err := r.VerifyToken(context.Background(), signToken(key, claims))
+			So(authErrors.IsInvalidTokenError(err), ShouldBeTrue)
+		})
+
+		Convey("Garbage is rejected", func() {
+			_, err := r.VerifyToken(context.Background(), "garbage")
+			So(authErrors.IsInvalidTokenError(err), ShouldBeTrue)
+		})
+	})
+}
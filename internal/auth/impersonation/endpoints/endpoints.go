@@ -0,0 +1,58 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/service"
+)
+
+type Endpoints struct {
+	StartSessionEndpoint  endpoint.Endpoint
+	ListSessionsEndpoint  endpoint.Endpoint
+	EndSessionEndpoint    endpoint.Endpoint
+	ListAuditLogsEndpoint endpoint.Endpoint
+}
+
+// New returns new endpoints
+func New(svc service.Service) *Endpoints {
+	return &Endpoints{
+		StartSessionEndpoint:  makeStartSessionEndpoint(svc),
+		ListSessionsEndpoint:  makeListSessionsEndpoint(svc),
+		EndSessionEndpoint:    makeEndSessionEndpoint(svc),
+		ListAuditLogsEndpoint: makeListAuditLogsEndpoint(svc),
+	}
+}
+
+func makeStartSessionEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.StartSessionRequest) //nolint:errcheck
+
+		return svc.StartSession(ctx, req)
+	}
+}
+
+func makeListSessionsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.ListSessionsRequest) //nolint:errcheck
+
+		return svc.ListSessions(ctx, req)
+	}
+}
+
+func makeEndSessionEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.EndSessionRequest) //nolint:errcheck
+		err := svc.EndSession(ctx, req)
+		return "", err
+	}
+}
+
+func makeListAuditLogsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.ListAuditLogsRequest) //nolint:errcheck
+
+		return svc.ListAuditLogs(ctx, req)
+	}
+}
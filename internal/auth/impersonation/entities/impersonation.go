@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+)
+
+// Session lets an engineering or csc user act as a customer user of a company until it
+// expires or is ended. Read-only sessions may only perform read actions.
+type Session struct {
+	SessionUuid      uuid.UUID         `json:"session_uuid" gorm:"column:session_uuid"`
+	ImpersonatorUuid uuid.UUID         `json:"impersonator_uuid" gorm:"column:impersonator_uuid"`
+	UserUuid         uuid.UUID         `json:"user_uuid" gorm:"column:user_uuid"`
+	CompanyUuid      uuid.UUID         `json:"company_uuid" gorm:"column:company_uuid"`
+	Reason           string            `json:"reason" gorm:"column:reason"`
+	ReadOnly         bool              `json:"read_only" gorm:"column:read_only"`
+	ExpiresAt        time.Time         `json:"expires_at" gorm:"column:expires_at"`
+	EndedAt          nullable.NullTime `json:"ended_at" gorm:"column:ended_at"`
+	CreatedAt        time.Time         `json:"created_at" gorm:"column:created_at;default:now()"`
+}
+
+func (m *Session) TableName() string {
+	return "impersonation_sessions"
+}
+
+// IsActive tells whether the session is neither ended nor expired at t.
+func (m *Session) IsActive(t time.Time) bool {
+	return !m.EndedAt.Valid && t.Before(m.ExpiresAt)
+}
+
+// AuditLog is a request made under an impersonation session. Error is empty when the
+// request succeeded.
+type AuditLog struct {
+	AuditLogUuid     uuid.UUID `json:"audit_log_uuid" gorm:"column:audit_log_uuid"`
+	SessionUuid      uuid.UUID `json:"session_uuid" gorm:"column:session_uuid"`
+	ImpersonatorUuid uuid.UUID `json:"impersonator_uuid" gorm:"column:impersonator_uuid"`
+	UserUuid         uuid.UUID `json:"user_uuid" gorm:"column:user_uuid"`
+	CompanyUuid      uuid.UUID `json:"company_uuid" gorm:"column:company_uuid"`
+	Feature          string    `json:"feature" gorm:"column:feature"`
+	Action           string    `json:"action" gorm:"column:action"`
+	Method           string    `json:"method" gorm:"column:method"`
+	Path             string    `json:"path" gorm:"column:path"`
+	RequestID        string    `json:"request_id" gorm:"column:request_id"`
+	ClientIP         string    `json:"client_ip" gorm:"column:client_ip"`
+	Error            string    `json:"error,omitempty" gorm:"column:error"`
+	CreatedAt        time.Time `json:"created_at" gorm:"column:created_at;default:now()"`
+}
+
+func (m *AuditLog) TableName() string {
+	return "impersonation_audit_logs"
+}
+
+type StartSessionRequestBody struct {
+	CompanyUuid uuid.UUID `json:"company_uuid"`
+	UserUuid    uuid.UUID `json:"user_uuid"`
+	Reason      string    `json:"reason"`
+	// DurationMinutes defaults to the configured default duration.
+	DurationMinutes int `json:"duration_minutes"`
+	// ReadOnly defaults to true. Sessions with write access need the update action on impersonation.
+	ReadOnly *bool `json:"read_only"`
+}
+
+type StartSessionRequest struct {
+	Body *StartSessionRequestBody
+}
+
+type ListSessionsRequest struct {
+	ActiveOnly bool
+}
+
+type EndSessionRequest struct {
+	SessionUuid uuid.UUID
+}
+
+type ListAuditLogsRequest struct {
+	SessionUuid uuid.UUID
+}
@@ -0,0 +1,46 @@
+package impersonation
+
+import (
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/endpoints"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/service"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/userclient"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// ModuleParams for impersonation.
+type ModuleParams struct {
+	fx.In
+
+	Config       config.Config
+	DB           *gorm.DB
+	HTTPServer   *httpTransport.Server
+	APPTransport svcTransport.Client
+	AuthClient   auth.Client
+	UserClient   userclient.Client
+	Engine       permissions.Engine
+}
+
+// NewModule for impersonation.
+// nolint:gocritic
+func NewModule(p ModuleParams) error {
+	repo := repository.New(p.DB)
+	svc := service.New(repo, p.UserClient, p.Engine, p.Config.Impersonation)
+	eps := endpoints.New(svc)
+
+	http.RegisterTransport(p.HTTPServer, eps, p.AuthClient, p.APPTransport)
+
+	return nil
+}
+
+var (
+	// ModuleHttpAPI for uber fx.
+	ModuleHttpAPI = fx.Options(fx.Invoke(NewModule))
+)
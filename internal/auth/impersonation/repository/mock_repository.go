@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/entities"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAuditLog mocks base method.
+func (m *MockRepository) CreateAuditLog(ctx context.Context, log *entities.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditLog", ctx, log)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuditLog indicates an expected call of CreateAuditLog.
+func (mr *MockRepositoryMockRecorder) CreateAuditLog(ctx, log interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditLog", reflect.TypeOf((*MockRepository)(nil).CreateAuditLog), ctx, log)
+}
+
+// CreateSession mocks base method.
+func (m *MockRepository) CreateSession(ctx context.Context, session *entities.Session) (*entities.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, session)
+	ret0, _ := ret[0].(*entities.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockRepositoryMockRecorder) CreateSession(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockRepository)(nil).CreateSession), ctx, session)
+}
+
+// EndSession mocks base method.
+func (m *MockRepository) EndSession(ctx context.Context, sessionUuid uuid.UUID, t time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndSession", ctx, sessionUuid, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EndSession indicates an expected call of EndSession.
+func (mr *MockRepositoryMockRecorder) EndSession(ctx, sessionUuid, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndSession", reflect.TypeOf((*MockRepository)(nil).EndSession), ctx, sessionUuid, t)
+}
+
+// FindSession mocks base method.
+func (m *MockRepository) FindSession(ctx context.Context, sessionUuid uuid.UUID) (*entities.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSession", ctx, sessionUuid)
+	ret0, _ := ret[0].(*entities.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindSession indicates an expected call of FindSession.
+func (mr *MockRepositoryMockRecorder) FindSession(ctx, sessionUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSession", reflect.TypeOf((*MockRepository)(nil).FindSession), ctx, sessionUuid)
+}
+
+// ListAuditLogs mocks base method.
+func (m *MockRepository) ListAuditLogs(ctx context.Context, sessionUuid uuid.UUID) ([]*entities.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditLogs", ctx, sessionUuid)
+	ret0, _ := ret[0].([]*entities.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditLogs indicates an expected call of ListAuditLogs.
+func (mr *MockRepositoryMockRecorder) ListAuditLogs(ctx, sessionUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditLogs", reflect.TypeOf((*MockRepository)(nil).ListAuditLogs), ctx, sessionUuid)
+}
+
+// ListSessions mocks base method.
+func (m *MockRepository) ListSessions(ctx context.Context, impersonatorUuid uuid.UUID, activeAt *time.Time) ([]*entities.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, impersonatorUuid, activeAt)
+	ret0, _ := ret[0].([]*entities.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockRepositoryMockRecorder) ListSessions(ctx, impersonatorUuid, activeAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockRepository)(nil).ListSessions), ctx, impersonatorUuid, activeAt)
+}
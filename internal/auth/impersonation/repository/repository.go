@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/entities"
+	"gorm.io/gorm"
+)
+
+// Repository for impersonation sessions and their audit trail.
+type Repository interface {
+	CreateSession(ctx context.Context, session *entities.Session) (*entities.Session, error)
+	FindSession(ctx context.Context, sessionUuid uuid.UUID) (*entities.Session, error)
+	ListSessions(ctx context.Context, impersonatorUuid uuid.UUID, activeAt *time.Time) ([]*entities.Session, error)
+	EndSession(ctx context.Context, sessionUuid uuid.UUID, t time.Time) error
+	CreateAuditLog(ctx context.Context, log *entities.AuditLog) error
+	ListAuditLogs(ctx context.Context, sessionUuid uuid.UUID) ([]*entities.AuditLog, error)
+}
+
+// New repository for impersonation.
+func New(db *gorm.DB) Repository {
+	repo := &sqlRepository{db}
+
+	return repo
+}
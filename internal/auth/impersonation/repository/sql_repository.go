@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"gorm.io/gorm"
+)
+
+type sqlRepository struct {
+	gormDB *gorm.DB
+}
+
+func (s *sqlRepository) CreateSession(ctx context.Context, session *entities.Session) (*entities.Session, error) {
+	err := s.gormDB.WithContext(ctx).Create(session).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (s *sqlRepository) FindSession(ctx context.Context, sessionUuid uuid.UUID) (*entities.Session, error) {
+	var session *entities.Session
+
+	result := s.gormDB.WithContext(ctx).Model(&entities.Session{}).
+		Find(&session, "session_uuid = ?", sessionUuid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &appError.ErrNotFound{Message: "impersonation session not found"}
+	}
+
+	return session, nil
+}
+
+// ListSessions of the impersonator, or of everyone for uuid.Nil. A non-nil activeAt
+// leaves out sessions ended or expired by then.
+func (s *sqlRepository) ListSessions(ctx context.Context, impersonatorUuid uuid.UUID, activeAt *time.Time) ([]*entities.Session, error) {
+	var sessions []*entities.Session
+
+	query := s.gormDB.WithContext(ctx).Model(&entities.Session{}).Order("created_at desc")
+	if impersonatorUuid != uuid.Nil {
+		query = query.Where("impersonator_uuid = ?", impersonatorUuid)
+	}
+
+	if activeAt != nil {
+		query = query.Where("ended_at IS NULL AND expires_at > ?", *activeAt)
+	}
+
+	if err := query.Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (s *sqlRepository) EndSession(ctx context.Context, sessionUuid uuid.UUID, t time.Time) error {
+	result := s.gormDB.WithContext(ctx).Model(&entities.Session{}).
+		Where("session_uuid = ? AND ended_at IS NULL", sessionUuid).
+		Update("ended_at", t)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return &appError.ErrNotFound{Message: "impersonation session not found"}
+	}
+
+	return nil
+}
+
+func (s *sqlRepository) CreateAuditLog(ctx context.Context, log *entities.AuditLog) error {
+	return s.gormDB.WithContext(ctx).Create(log).Error
+}
+
+func (s *sqlRepository) ListAuditLogs(ctx context.Context, sessionUuid uuid.UUID) ([]*entities.AuditLog, error) {
+	var logs []*entities.AuditLog
+
+	err := s.gormDB.WithContext(ctx).Model(&entities.AuditLog{}).
+		Order("created_at asc").
+		Find(&logs, "session_uuid = ?", sessionUuid).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
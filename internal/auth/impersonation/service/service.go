@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/config"
+	authEntity "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/entity"
+	authErrors "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/repository"
+	authMeta "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/meta"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/constants"
+	userEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+	userRepository "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/userclient"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+)
+
+const (
+	// Feature securing impersonation. Sessions with write access need its update action.
+	Feature = "impersonation"
+
+	defaultDuration = 30 * time.Minute
+	maxDuration     = 2 * time.Hour
+)
+
+// Service for impersonation sessions.
+type Service interface {
+	StartSession(ctx context.Context, req *entities.StartSessionRequest) (*entities.Session, error)
+	ListSessions(ctx context.Context, req *entities.ListSessionsRequest) ([]*entities.Session, error)
+	EndSession(ctx context.Context, req *entities.EndSessionRequest) error
+	ListAuditLogs(ctx context.Context, req *entities.ListAuditLogsRequest) ([]*entities.AuditLog, error)
+	// Resolve returns the active session of the impersonator.
+	Resolve(ctx context.Context, sessionUuid, impersonatorUuid uuid.UUID) (*entities.Session, error)
+	Record(ctx context.Context, log *entities.AuditLog) error
+}
+
+type service struct {
+	repo       repository.Repository
+	userClient userclient.Client
+	engine     permissions.Engine
+	cfg        config.ImpersonationConfig
+}
+
+// New service for impersonation.
+func New(repo repository.Repository, userClient userclient.Client, engine permissions.Engine, cfg config.ImpersonationConfig) Service {
+	if cfg.DefaultDuration <= 0 {
+		cfg.DefaultDuration = defaultDuration
+	}
+
+	if cfg.MaxDuration <= 0 {
+		cfg.MaxDuration = maxDuration
+	}
+
+	return &service{repo, userClient, engine, cfg}
+}
+
+func (s *service) StartSession(ctx context.Context, req *entities.StartSessionRequest) (*entities.Session, error) {
+	impersonator := authMeta.User(ctx)
+	if impersonator == nil {
+		return nil, authErrors.ErrNoPermission
+	}
+
+	body := req.Body
+	if body.Reason == "" {
+		return nil, &appError.ErrValidation{Message: "reason is required"}
+	}
+
+	duration := s.cfg.DefaultDuration
+	if body.DurationMinutes != 0 {
+		duration = time.Duration(body.DurationMinutes) * time.Minute
+	}
+
+	if duration <= 0 || duration > s.cfg.MaxDuration {
+		return nil, &appError.ErrValidation{Message: "duration_minutes should be between 1 and " + s.cfg.MaxDuration.String()}
+	}
+
+	readOnly := body.ReadOnly == nil || *body.ReadOnly
+	if !readOnly {
+		subject := authMeta.PermissionSubject(ctx)
+		if subject == nil || !s.engine.IsAllowed(Feature, *subject, permissions.ActionUpdate) {
+			return nil, authErrors.ErrNoPermission
+		}
+	}
+
+	if body.UserUuid == impersonator.Uuid {
+		return nil, &appError.ErrValidation{Message: "users can't impersonate themselves"}
+	}
+
+	if err := s.validateImpersonator(ctx, impersonator, body.CompanyUuid); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateTarget(ctx, body.UserUuid, body.CompanyUuid); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	return s.repo.CreateSession(ctx, &entities.Session{
+		SessionUuid:      uuid.New(),
+		ImpersonatorUuid: impersonator.Uuid,
+		UserUuid:         body.UserUuid,
+		CompanyUuid:      body.CompanyUuid,
+		Reason:           body.Reason,
+		ReadOnly:         readOnly,
+		ExpiresAt:        now.Add(duration),
+		CreatedAt:        now,
+	})
+}
+
+// validateImpersonator checks the engineer or csc user is assigned to the company, superadmins
+// impersonate in every company.
+func (s *service) validateImpersonator(ctx context.Context, impersonator *authEntity.User, companyUuid uuid.UUID) error {
+	if impersonator.UserGroup == constants.UserGroupSuperadmin {
+		return nil
+	}
+
+	companyUser, err := s.userClient.GetCompanyUser(ctx, impersonator.Uuid, companyUuid)
+	if err != nil {
+		return err
+	}
+
+	if companyUser == nil || companyUser.Status != userEntities.CompanyUserStatusActive {
+		return authErrors.ErrNoPermission
+	}
+
+	return nil
+}
+
+// validateTarget checks the user is a customer user and an active member of the company.
+func (s *service) validateTarget(ctx context.Context, userUuid, companyUuid uuid.UUID) error {
+	target, err := s.userClient.GetUserByUuid(ctx, userUuid)
+	if err != nil {
+		if userRepository.IsUserNotFoundError(err) {
+			return &appError.ErrNotFound{Message: "user not found"}
+		}
+
+		return err
+	}
+
+	switch target.Group {
+	case constants.UserGroupSuperadmin, constants.UserGroupEngineer, constants.UserGroupCsc:
+		return &appError.ErrValidation{Message: "only customer users can be impersonated"}
+	}
+
+	companyUser, err := s.userClient.GetCompanyUser(ctx, userUuid, companyUuid)
+	if err != nil {
+		return err
+	}
+
+	if companyUser == nil || companyUser.Status != userEntities.CompanyUserStatusActive {
+		return &appError.ErrValidation{Message: "user is not an active member of the company"}
+	}
+
+	return nil
+}
+
+// ListSessions of the caller; superadmins see the sessions of everyone.
+func (s *service) ListSessions(ctx context.Context, req *entities.ListSessionsRequest) ([]*entities.Session, error) {
+	user := authMeta.User(ctx)
+	if user == nil {
+		return nil, authErrors.ErrNoPermission
+	}
+
+	impersonatorUuid := user.Uuid
+	if user.UserGroup == constants.UserGroupSuperadmin {
+		impersonatorUuid = uuid.Nil
+	}
+
+	var activeAt *time.Time
+	if req.ActiveOnly {
+		now := time.Now()
+		activeAt = &now
+	}
+
+	return s.repo.ListSessions(ctx, impersonatorUuid, activeAt)
+}
+
+func (s *service) EndSession(ctx context.Context, req *entities.EndSessionRequest) error {
+	if _, err := s.ownSession(ctx, req.SessionUuid); err != nil {
+		return err
+	}
+
+	return s.repo.EndSession(ctx, req.SessionUuid, time.Now())
+}
+
+func (s *service) ListAuditLogs(ctx context.Context, req *entities.ListAuditLogsRequest) ([]*entities.AuditLog, error) {
+	if _, err := s.ownSession(ctx, req.SessionUuid); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListAuditLogs(ctx, req.SessionUuid)
+}
+
+// ownSession returns the session when the caller started it or is a superadmin.
+func (s *service) ownSession(ctx context.Context, sessionUuid uuid.UUID) (*entities.Session, error) {
+	user := authMeta.User(ctx)
+	if user == nil {
+		return nil, authErrors.ErrNoPermission
+	}
+
+	session, err := s.repo.FindSession(ctx, sessionUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.ImpersonatorUuid != user.Uuid && user.UserGroup != constants.UserGroupSuperadmin {
+		return nil, &appError.ErrNotFound{Message: "impersonation session not found"}
+	}
+
+	return session, nil
+}
+
+func (s *service) Resolve(ctx context.Context, sessionUuid, impersonatorUuid uuid.UUID) (*entities.Session, error) {
+	session, err := s.repo.FindSession(ctx, sessionUuid)
+	if err != nil {
+		if appError.IsNotFoundError(err) {
+			return nil, authErrors.ErrInvalidImpersonation
+		}
+
+		return nil, err
+	}
+
+	if session.ImpersonatorUuid != impersonatorUuid || !session.IsActive(time.Now()) {
+		return nil, authErrors.ErrInvalidImpersonation
+	}
+
+	return session, nil
+}
+
+func (s *service) Record(ctx context.Context, log *entities.AuditLog) error {
+	if log.AuditLogUuid == uuid.Nil {
+		log.AuditLogUuid = uuid.New()
+	}
+
+	if log.CreatedAt.IsZero() {
+		log.CreatedAt = time.Now()
+	}
+
+	return s.repo.CreateAuditLog(ctx, log)
+}
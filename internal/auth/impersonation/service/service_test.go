@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/entity"
+	authErrors "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/repository"
+	authMeta "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/meta"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	userEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/userclient"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func testEngine() permissions.Engine {
+	p, _ := permissions.Parse([]byte(`
+version: 1
+features:
+  impersonation:
+    engineering_engineer_engineer: [read, create, delete]
+    engineering_superadmin_superadmin: [read, create, update, delete]
+`))
+
+	return permissions.NewEngine(p)
+}
+
+type usersClient struct {
+	userclient.Client
+	users   map[uuid.UUID]*userEntities.User
+	members map[[2]uuid.UUID]string
+}
+
+func (c *usersClient) GetUserByUuid(_ context.Context, userUUID uuid.UUID) (*userEntities.User, error) {
+	return c.users[userUUID], nil
+}
+
+func (c *usersClient) GetCompanyUser(_ context.Context, userUUID, companyUUID uuid.UUID) (*userEntities.CompanyUser, error) {
+	status, ok := c.members[[2]uuid.UUID{userUUID, companyUUID}]
+	if !ok {
+		return nil, nil
+	}
+
+	return &userEntities.CompanyUser{UserUuid: userUUID, CompanyUuid: companyUUID, Status: status}, nil
+}
+
+func staffContext(user *entity.User, group string) context.Context {
+	ctx := authMeta.WithUser(context.Background(), user)
+
+	return authMeta.WithPermissionSubject(ctx, &permissions.Subject{CompanyType: "engineering", Group: group, Role: group})
+}
+
+func TestStartSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	company, other := uuid.New(), uuid.New()
+	engineer := &entity.User{Uuid: uuid.New(), UserGroup: "engineer"}
+	customer := &userEntities.User{UserUuid: uuid.New(), Group: "customer"}
+	colleague := &userEntities.User{UserUuid: uuid.New(), Group: "csc"}
+	users := &usersClient{
+		users: map[uuid.UUID]*userEntities.User{customer.UserUuid: customer, colleague.UserUuid: colleague},
+		members: map[[2]uuid.UUID]string{
+			{customer.UserUuid, company}:  "ACTIVE",
+			{colleague.UserUuid, company}: "ACTIVE",
+			{engineer.Uuid, company}:      "ACTIVE",
+			{engineer.Uuid, other}:        "ACTIVE",
+		},
+	}
+
+	repo := repository.NewMockRepository(ctrl)
+	svc := New(repo, users, testEngine(), config.ImpersonationConfig{})
+
+	ctx := staffContext(engineer, "engineer")
+
+	body := func() *entities.StartSessionRequestBody {
+		return &entities.StartSessionRequestBody{CompanyUuid: company, UserUuid: customer.UserUuid, Reason: "ticket 42"}
+	}
+
+	Convey("Given an engineer starting a session as a customer user", t, func() {
+		Convey("The session is read-only and lasts the default duration", func() {
+			repo.EXPECT().CreateSession(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, s *entities.Session) (*entities.Session, error) { return s, nil })
+
+			session, err := svc.StartSession(ctx, &entities.StartSessionRequest{Body: body()})
+			So(err, ShouldBeNil)
+			So(session.ReadOnly, ShouldBeTrue)
+			So(session.ImpersonatorUuid, ShouldEqual, engineer.Uuid)
+			So(session.ExpiresAt, ShouldHappenWithin, time.Minute, time.Now().Add(defaultDuration))
+		})
+
+		Convey("A reason is required", func() {
+			b := body()
+			b.Reason = ""
+
+			_, err := svc.StartSession(ctx, &entities.StartSessionRequest{Body: b})
+			So(appError.IsValidationError(err), ShouldBeTrue)
+
+			users.members[[2]uuid.UUID{customer.UserUuid, other}] = "PENDING"
+			_, err = svc.StartSession(ctx, &entities.StartSessionRequest{Body: b})
+			So(appError.IsValidationError(err), ShouldBeTrue)
+		})
+
+		Convey("The duration can't exceed the max duration", func() {
+			b := body()
+			b.DurationMinutes = int(maxDuration/time.Minute) + 1
+
+			_, err := svc.StartSession(ctx, &entities.StartSessionRequest{Body: b})
+			So(appError.IsValidationError(err), ShouldBeTrue)
+		})
+
+		Convey("Write access needs the update action", func() {
+			b := body()
+			readOnly := false
+			b.ReadOnly = &readOnly
+
+			_, err := svc.StartSession(ctx, &entities.StartSessionRequest{Body: b})
+			So(err, ShouldEqual, authErrors.ErrNoPermission)
+
+			repo.EXPECT().CreateSession(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, s *entities.Session) (*entities.Session, error) { return s, nil })
+
+			superadmin := staffContext(&entity.User{Uuid: uuid.New(), UserGroup: "superadmin"}, "superadmin")
+			session, err := svc.StartSession(superadmin, &entities.StartSessionRequest{Body: b})
+			So(err, ShouldBeNil)
+			So(session.ReadOnly, ShouldBeFalse)
+		})
+
+		Convey("Staff users can't be impersonated", func() {
+			b := body()
+			b.UserUuid = colleague.UserUuid
+
+			_, err := svc.StartSession(ctx, &entities.StartSessionRequest{Body: b})
+			So(appError.IsValidationError(err), ShouldBeTrue)
+		})
+
+		Convey("The user has to be an active member of the company", func() {
+			b := body()
+			b.CompanyUuid = other
+
+			_, err := svc.StartSession(ctx, &entities.StartSessionRequest{Body: b})
+			So(appError.IsValidationError(err), ShouldBeTrue)
+		})
+
+		Convey("The engineer has to be assigned to the company", func() {
+			b := body()
+			b.CompanyUuid = uuid.New()
+			users.members[[2]uuid.UUID{customer.UserUuid, b.CompanyUuid}] = "ACTIVE"
+
+			_, err := svc.StartSession(ctx, &entities.StartSessionRequest{Body: b})
+			So(err, ShouldEqual, authErrors.ErrNoPermission)
+		})
+	})
+}
+
+func TestResolve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repository.NewMockRepository(ctrl)
+	svc := New(repo, &usersClient{}, testEngine(), config.ImpersonationConfig{})
+
+	impersonator := uuid.New()
+	session := &entities.Session{SessionUuid: uuid.New(), ImpersonatorUuid: impersonator, ExpiresAt: time.Now().Add(time.Hour)}
+
+	Convey("Given a session", t, func() {
+		Convey("It resolves for its impersonator", func() {
+			repo.EXPECT().FindSession(gomock.Any(), session.SessionUuid).Return(session, nil)
+
+			got, err := svc.Resolve(context.Background(), session.SessionUuid, impersonator)
+			So(err, ShouldBeNil)
+			So(got, ShouldEqual, session)
+		})
+
+		Convey("It doesn't resolve for anyone else", func() {
+			repo.EXPECT().FindSession(gomock.Any(), session.SessionUuid).Return(session, nil)
+
+			_, err := svc.Resolve(context.Background(), session.SessionUuid, uuid.New())
+			So(err, ShouldEqual, authErrors.ErrInvalidImpersonation)
+		})
+
+		Convey("It doesn't resolve once ended", func() {
+			ended := *session
+			ended.EndedAt = nullable.NewNullTime(time.Now())
+			repo.EXPECT().FindSession(gomock.Any(), session.SessionUuid).Return(&ended, nil)
+
+			_, err := svc.Resolve(context.Background(), session.SessionUuid, impersonator)
+			So(err, ShouldEqual, authErrors.ErrInvalidImpersonation)
+		})
+	})
+}
@@ -0,0 +1,73 @@
+// Package http for impersonation.
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	httpError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors/http"
+	"github.com/pkg/errors"
+)
+
+func decodeStartSessionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	body := &entities.StartSessionRequestBody{}
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		return nil, errors.WithMessage(httpError.ErrBadRequestBody, err.Error())
+	}
+
+	defer r.Body.Close()
+
+	if body.CompanyUuid == uuid.Nil || body.UserUuid == uuid.Nil {
+		return nil, &appError.ErrValidation{Message: "company_uuid and user_uuid are required"}
+	}
+
+	return &entities.StartSessionRequest{Body: body}, nil
+}
+
+func decodeListSessionsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := &entities.ListSessionsRequest{}
+
+	if v := r.URL.Query().Get("active"); v != "" {
+		active, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, httpError.NewErrBadOrInvalidPathParameter("active")
+		}
+
+		req.ActiveOnly = active
+	}
+
+	return req, nil
+}
+
+func decodeSessionID(r *http.Request) (uuid.UUID, error) {
+	sessionUUID, err := uuid.Parse(mux.Vars(r)["session_id"])
+	if err != nil {
+		return uuid.Nil, httpError.NewErrBadOrInvalidPathParameter("session_id")
+	}
+
+	return sessionUUID, nil
+}
+
+func decodeEndSessionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	sessionUUID, err := decodeSessionID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.EndSessionRequest{SessionUuid: sessionUUID}, nil
+}
+
+func decodeListAuditLogsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	sessionUUID, err := decodeSessionID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.ListAuditLogsRequest{SessionUuid: sessionUUID}, nil
+}
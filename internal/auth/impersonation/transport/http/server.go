@@ -0,0 +1,80 @@
+// Package http for impersonation.
+package http
+
+import (
+	goKitEndpoint "github.com/go-kit/kit/endpoint"
+	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/endpoints"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+)
+
+// RegisterTransport for http.
+func RegisterTransport(
+	server *httpTransport.Server,
+	ep *endpoints.Endpoints,
+	authClient auth.Client,
+	svcTransportClient svcTransport.Client,
+) {
+	registerStartSession(server, ep.StartSessionEndpoint, authClient, svcTransportClient)
+	registerListSessions(server, ep.ListSessionsEndpoint, authClient, svcTransportClient)
+	registerEndSession(server, ep.EndSessionEndpoint, authClient, svcTransportClient)
+	registerListAuditLogs(server, ep.ListAuditLogsEndpoint, authClient, svcTransportClient)
+}
+
+func registerStartSession(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/impersonation/sessions"
+	method := "POST"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "impersonation", permissions.ActionCreate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeStartSessionRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerListSessions(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/impersonation/sessions"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "impersonation", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeListSessionsRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerEndSession(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/impersonation/sessions/{session_id}"
+	method := "DELETE"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "impersonation", permissions.ActionDelete)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeEndSessionRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerListAuditLogs(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/impersonation/sessions/{session_id}/audit"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "impersonation", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeListAuditLogsRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func getHandler(ep goKitEndpoint.Endpoint, dec goKitHTTPTransport.DecodeRequestFunc, enc goKitHTTPTransport.EncodeResponseFunc, atc svcTransport.Client, method string) *goKitHTTPTransport.Server {
+	return goKitHTTPTransport.NewServer(
+		ep,
+		dec,
+		enc,
+		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
+		goKitHTTPTransport.ServerErrorHandler(atc.LogErrorHandler()),
+	)
+}
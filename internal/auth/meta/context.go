@@ -4,11 +4,17 @@ import (
 	"context"
 
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/entity"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 )
 
 type contextKey string
 
-var contextKeyUsername = contextKey("cognitoUsername")
+var (
+	contextKeyUsername          = contextKey("cognitoUsername")
+	contextKeyPermissionSubject = contextKey("permissionSubject")
+	contextKeyImpersonation     = contextKey("impersonation")
+	contextKeyIdentityScope     = contextKey("identityScope")
+)
 
 // User extracts user from context
 func User(ctx context.Context) *entity.User {
@@ -23,3 +29,45 @@ func User(ctx context.Context) *entity.User {
 func WithUser(ctx context.Context, user *entity.User) context.Context {
 	return context.WithValue(ctx, contextKeyUsername, user)
 }
+
+// PermissionSubject extracts the resolved permission subject from context
+func PermissionSubject(ctx context.Context) *permissions.Subject {
+	if subject, ok := ctx.Value(contextKeyPermissionSubject).(*permissions.Subject); ok {
+		return subject
+	}
+
+	return nil
+}
+
+// WithPermissionSubject injects the resolved permission subject to the context
+func WithPermissionSubject(ctx context.Context, subject *permissions.Subject) context.Context {
+	return context.WithValue(ctx, contextKeyPermissionSubject, subject)
+}
+
+// Impersonation extracts the impersonation session the request is made under, if any
+func Impersonation(ctx context.Context) *entity.Impersonation {
+	if impersonation, ok := ctx.Value(contextKeyImpersonation).(*entity.Impersonation); ok {
+		return impersonation
+	}
+
+	return nil
+}
+
+// WithImpersonation injects the impersonation session to the context
+func WithImpersonation(ctx context.Context, impersonation *entity.Impersonation) context.Context {
+	return context.WithValue(ctx, contextKeyImpersonation, impersonation)
+}
+
+// IdentityScope extracts the scope of the customer IdP which signed the user in, if any
+func IdentityScope(ctx context.Context) *entity.IdentityScope {
+	if scope, ok := ctx.Value(contextKeyIdentityScope).(*entity.IdentityScope); ok {
+		return scope
+	}
+
+	return nil
+}
+
+// WithIdentityScope injects the scope of the customer IdP to the context
+func WithIdentityScope(ctx context.Context, scope *entity.IdentityScope) context.Context {
+	return context.WithValue(ctx, contextKeyIdentityScope, scope)
+}
@@ -2,30 +2,127 @@
 package auth
 
 import (
+	"context"
+	"strings"
+
+	auditService "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
+	apiKeysRepository "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/repository"
+	apiKeysService "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/apikeys/service"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/config"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/endpoint"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/identity"
+	impersonationRepository "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/repository"
+	impersonationService "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/impersonation/service"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	sessionsService "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/service"
+	webhookTokensRepository "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/webhooktokens/repository"
+	webhookTokensService "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/webhooktokens/service"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito"
+	cognitoCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito/config"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/userclient"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/jwt"
+	"github.com/pkg/errors"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 // ModuleParams for auth.
 type ModuleParams struct {
 	fx.In
+
+	Config        config.Config
+	DB            *gorm.DB
+	CognitoConfig cognitoCfg.Config
 	CognitoClient cognito.Client
 	UserClient    userclient.Client
+	SessionSvc    sessionsService.Service
+	AuditSvc      auditService.Service
+	KeyRing       *jwt.KeyRing
 	Logger        *zap.SugaredLogger
 }
 
+// ModuleResult of auth.
+type ModuleResult struct {
+	fx.Out
+
+	Client   Client
+	Engine   permissions.Engine
+	Registry permissions.Registry
+}
+
 // NewModule for auth.
 // nolint:gocritic
-func NewModule(p ModuleParams) Client {
-	mw := endpoint.New(p.CognitoClient, p.UserClient, p.Logger)
+func NewModule(p ModuleParams) (ModuleResult, error) {
+	policy, err := LoadPolicy(context.Background(), p.Config, p.DB)
+	if err != nil {
+		return ModuleResult{}, err
+	}
+
+	p.Logger.Infof("loaded permission policy version %d", policy.Version)
+
+	engine := permissions.NewEngine(policy)
+	registry := permissions.NewRegistry()
+	apiKeySvc := apiKeysService.New(apiKeysRepository.New(p.DB), engine)
+	webhookTokenSvc := webhookTokensService.New(webhookTokensRepository.New(p.DB), p.KeyRing)
+	identityProvider, err := identity.New(p.Config.Identity, p.CognitoConfig, p.CognitoClient)
+	if err != nil {
+		return ModuleResult{}, err
+	}
+
+	impersonationSvc := impersonationService.New(impersonationRepository.New(p.DB), p.UserClient, engine, p.Config.Impersonation)
+	mw := endpoint.New(identityProvider, p.UserClient, engine, apiKeySvc, webhookTokenSvc, impersonationSvc, p.SessionSvc, p.KeyRing, p.Logger)
+
+	return ModuleResult{
+		Client:   newClient(mw, registry, p.AuditSvc),
+		Engine:   engine,
+		Registry: registry,
+	}, nil
+}
+
+// LoadPolicy loads the permission policy from the configured source.
+func LoadPolicy(ctx context.Context, cfg config.Config, db *gorm.DB) (*permissions.Policy, error) {
+	var source permissions.Source
+	if cfg.Permissions.Source == config.PermissionSourceDB {
+		source = permissions.NewSQLSource(db)
+	} else {
+		source = permissions.NewFileSource(cfg.Permissions.File)
+	}
+
+	return source.Load(ctx)
+}
+
+// ValidateParams for the permission check on start.
+type ValidateParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Engine    permissions.Engine
+	Registry  permissions.Registry
+}
+
+// ValidatePermissions fails the start when a feature secured by a transport is missing
+// from the permission policy for any subject. Transports register on invoke, so the
+// check runs in the start hook once all of them are in.
+// nolint:gocritic
+func ValidatePermissions(p ValidateParams) {
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			var features []string
+			for feature := range p.Registry.Features() {
+				features = append(features, feature)
+			}
+
+			if gaps := permissions.Gaps(p.Engine.Policy(), features); len(gaps) > 0 {
+				return errors.Errorf("permission policy is incomplete: %s", strings.Join(gaps, ", "))
+			}
 
-	return newClient(mw)
+			return nil
+		},
+	})
 }
 
 var (
 	// Module for uber fx.
-	Module = fx.Options(fx.Provide(NewModule))
+	Module = fx.Options(fx.Provide(NewModule), fx.Invoke(ValidatePermissions))
 )
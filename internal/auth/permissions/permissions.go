@@ -1,3 +1,192 @@
+// Package permissions contains the permission policy model and the engine which evaluates it.
 package permissions
 
-var Permissions map[string]map[string]string
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPolicy holds the raw permissions.yml embedded in the binary.
+var DefaultPolicy []byte
+
+// Action which can be performed on a feature.
+type Action string
+
+const (
+	ActionRead    Action = "read"
+	ActionCreate  Action = "create"
+	ActionUpdate  Action = "update"
+	ActionDelete  Action = "delete"
+	ActionApprove Action = "approve"
+	ActionExport  Action = "export"
+)
+
+// Actions lists every known action in a stable order.
+var Actions = []Action{ActionRead, ActionCreate, ActionUpdate, ActionDelete, ActionApprove, ActionExport}
+
+// Valid checks if action is one of the known actions.
+func (a Action) Valid() bool {
+	for _, action := range Actions {
+		if a == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Subject is the caller the permission is evaluated for.
+type Subject struct {
+	CompanyType string
+	Group       string
+	Role        string
+}
+
+// Key returns the permission matrix key in the <companyType>_<group>_<role> format.
+func (s Subject) Key() string {
+	return fmt.Sprintf("%s_%s_%s", s.CompanyType, s.Group, s.Role)
+}
+
+// Rule is the set of actions granted to a subject on a feature. In yaml it is either
+// the name of a grant or an explicit list of actions.
+type Rule struct {
+	Grant   string
+	Actions []Action
+}
+
+// UnmarshalYAML accepts both scalar grant names and sequences of actions.
+func (r *Rule) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		return value.Decode(&r.Grant)
+	case yaml.SequenceNode:
+		return value.Decode(&r.Actions)
+	default:
+		return errors.Errorf("line %d: rule should be a grant name or a list of actions", value.Line)
+	}
+}
+
+// MarshalYAML writes the rule back in the form it was defined.
+func (r Rule) MarshalYAML() (interface{}, error) {
+	if r.Grant != "" {
+		return r.Grant, nil
+	}
+
+	return r.Actions, nil
+}
+
+// Policy is the versioned permission matrix.
+type Policy struct {
+	Version  int                        `yaml:"version"`
+	Grants   map[string][]Action        `yaml:"grants"`
+	Features map[string]map[string]Rule `yaml:"features"`
+}
+
+// Parse decodes and validates a yaml policy document.
+func Parse(data []byte) (*Policy, error) {
+	p := &Policy{}
+
+	if err := yaml.Unmarshal(data, p); err != nil {
+		return nil, errors.Wrap(err, "decoding permission policy")
+	}
+
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Validate checks that every grant exists and every action is known.
+func (p *Policy) Validate() error {
+	var errs []string
+
+	if p.Version <= 0 {
+		errs = append(errs, "version should be greater than 0")
+	}
+
+	for name, actions := range p.Grants {
+		for _, a := range actions {
+			if !a.Valid() {
+				errs = append(errs, fmt.Sprintf("grant %s: unknown action %q", name, a))
+			}
+		}
+	}
+
+	for feature, rules := range p.Features {
+		for key, rule := range rules {
+			if rule.Grant != "" {
+				if _, ok := p.Grants[rule.Grant]; !ok {
+					errs = append(errs, fmt.Sprintf("%s.%s: unknown grant %q", feature, key, rule.Grant))
+				}
+
+				continue
+			}
+
+			for _, a := range rule.Actions {
+				if !a.Valid() {
+					errs = append(errs, fmt.Sprintf("%s.%s: unknown action %q", feature, key, a))
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return errors.Errorf("invalid permission policy: %s", strings.Join(errs, ","))
+	}
+
+	return nil
+}
+
+// Resolve returns the actions granted by a rule.
+func (p *Policy) Resolve(rule Rule) []Action {
+	if rule.Grant != "" {
+		return p.Grants[rule.Grant]
+	}
+
+	return rule.Actions
+}
+
+// Engine evaluates a permission policy.
+type Engine interface {
+	IsAllowed(feature string, subject Subject, action Action) bool
+	Policy() *Policy
+}
+
+// NewEngine compiles the policy into a lookup table.
+func NewEngine(p *Policy) Engine {
+	matrix := make(map[string]map[string]map[Action]bool, len(p.Features))
+
+	for feature, rules := range p.Features {
+		matrix[feature] = make(map[string]map[Action]bool, len(rules))
+
+		for key, rule := range rules {
+			allowed := make(map[Action]bool)
+			for _, a := range p.Resolve(rule) {
+				allowed[a] = true
+			}
+
+			matrix[feature][key] = allowed
+		}
+	}
+
+	return &engine{policy: p, matrix: matrix}
+}
+
+type engine struct {
+	policy *Policy
+	matrix map[string]map[string]map[Action]bool
+}
+
+func (e *engine) IsAllowed(feature string, subject Subject, action Action) bool {
+	return e.matrix[feature][subject.Key()][action]
+}
+
+func (e *engine) Policy() *Policy {
+	return e.policy
+}
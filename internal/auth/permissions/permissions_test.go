@@ -0,0 +1,103 @@
+package permissions
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse(t *testing.T) {
+	Convey("Given a policy with grants and explicit actions", t, func() {
+		data := []byte(`
+version: 1
+grants:
+  ro: [read]
+features:
+  policies:
+    customer_customer_admin: ro
+    customer_customer_user: [read, update]
+`)
+		Convey("Call the Parse function", func() {
+			p, err := Parse(data)
+			Convey("Rules should resolve to their actions", func() {
+				So(err, ShouldBeNil)
+				So(p.Resolve(p.Features["policies"]["customer_customer_admin"]), ShouldResemble, []Action{ActionRead})
+				So(p.Resolve(p.Features["policies"]["customer_customer_user"]), ShouldResemble, []Action{ActionRead, ActionUpdate})
+			})
+		})
+	})
+
+	Convey("Given a policy with an unknown grant and action", t, func() {
+		data := []byte(`
+version: 1
+grants:
+  ro: [read, view]
+features:
+  policies:
+    customer_customer_admin: rw
+`)
+		Convey("Call the Parse function", func() {
+			_, err := Parse(data)
+			Convey("Error should list every problem", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, `unknown grant "rw"`)
+				So(err.Error(), ShouldContainSubstring, `unknown action "view"`)
+			})
+		})
+	})
+}
+
+func TestEngine_IsAllowed(t *testing.T) {
+	Convey("Given the permissions.yml shipped with the service", t, func() {
+		data, err := os.ReadFile("permissions.yml")
+		So(err, ShouldBeNil)
+
+		p, err := Parse(data)
+		So(err, ShouldBeNil)
+
+		e := NewEngine(p)
+
+		Convey("A customer user can edit policies but not approve them", func() {
+			user := Subject{CompanyType: "customer", Group: "customer", Role: "user"}
+			So(e.IsAllowed("policies-procedures", user, ActionUpdate), ShouldBeTrue)
+			So(e.IsAllowed("policies-procedures", user, ActionApprove), ShouldBeFalse)
+		})
+
+		Convey("A csc can acknowledge service evidence but not delete reports", func() {
+			csc := Subject{CompanyType: "customer", Group: "csc", Role: "csc"}
+			So(e.IsAllowed("customer-success", csc, ActionApprove), ShouldBeTrue)
+			So(e.IsAllowed("customer-success", csc, ActionDelete), ShouldBeFalse)
+		})
+
+		Convey("Unknown features and subjects are denied", func() {
+			admin := Subject{CompanyType: "customer", Group: "customer", Role: "admin"}
+			So(e.IsAllowed("unknown-feature", admin, ActionRead), ShouldBeFalse)
+			So(e.IsAllowed("dashboard", Subject{}, ActionRead), ShouldBeFalse)
+		})
+	})
+}
+
+func TestGaps(t *testing.T) {
+	Convey("Given a policy missing a feature and a subject", t, func() {
+		p := &Policy{Features: map[string]map[string]Rule{"policies": {"customer_customer_admin": {Grant: "rw"}}}}
+
+		r := NewRegistry()
+		r.Register("policies", ActionRead)
+		r.Register("audit", ActionRead)
+
+		features := make([]string, 0)
+		for feature := range r.Features() {
+			features = append(features, feature)
+		}
+
+		Convey("Call the Gaps function", func() {
+			gaps := Gaps(p, features)
+			Convey("Both gaps should be reported", func() {
+				So(gaps, ShouldContain, "feature audit is not defined")
+				So(gaps, ShouldContain, "policies.customer_customer_user is not defined")
+				So(gaps, ShouldNotContain, "policies.customer_customer_admin is not defined")
+			})
+		})
+	})
+}
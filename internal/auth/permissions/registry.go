@@ -0,0 +1,98 @@
+package permissions
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	// CompanyTypes known to the permission matrix.
+	CompanyTypes = []string{"customer", "engineering"}
+	// Groups known to the permission matrix.
+	Groups = []string{"customer", "superadmin", "engineer", "csc"}
+	// Roles known to the permission matrix.
+	Roles = []string{"admin", "user", "csc", "superadmin", "engineer"}
+)
+
+// Subjects returns every company type, group and role combination in matrix order.
+func Subjects() []Subject {
+	subjects := make([]Subject, 0, len(CompanyTypes)*len(Groups)*len(Roles))
+
+	for _, ct := range CompanyTypes {
+		for _, g := range Groups {
+			for _, r := range Roles {
+				subjects = append(subjects, Subject{CompanyType: ct, Group: g, Role: r})
+			}
+		}
+	}
+
+	return subjects
+}
+
+// Registry collects the features and actions secured by the transports.
+type Registry interface {
+	Register(feature string, action Action)
+	Features() map[string][]Action
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() Registry {
+	return &registry{features: make(map[string]map[Action]bool)}
+}
+
+type registry struct {
+	mu       sync.Mutex
+	features map[string]map[Action]bool
+}
+
+func (r *registry) Register(feature string, action Action) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.features[feature]; !ok {
+		r.features[feature] = make(map[Action]bool)
+	}
+
+	r.features[feature][action] = true
+}
+
+func (r *registry) Features() map[string][]Action {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	features := make(map[string][]Action, len(r.features))
+	for feature, actions := range r.features {
+		for _, a := range Actions {
+			if actions[a] {
+				features[feature] = append(features[feature], a)
+			}
+		}
+	}
+
+	return features
+}
+
+// Gaps lists the features, and the subjects of those features, which the policy does not define.
+func Gaps(p *Policy, features []string) []string {
+	var gaps []string
+
+	sorted := append([]string(nil), features...)
+	sort.Strings(sorted)
+
+	for _, feature := range sorted {
+		rules, ok := p.Features[feature]
+		if !ok {
+			gaps = append(gaps, fmt.Sprintf("feature %s is not defined", feature))
+			continue
+		}
+
+		for _, subject := range Subjects() {
+			if _, ok := rules[subject.Key()]; !ok {
+				gaps = append(gaps, fmt.Sprintf("%s.%s is not defined", feature, subject.Key()))
+			}
+		}
+	}
+
+	return gaps
+}
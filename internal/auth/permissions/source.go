@@ -0,0 +1,84 @@
+package permissions
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// Source loads a permission policy.
+type Source interface {
+	Load(ctx context.Context) (*Policy, error)
+}
+
+// NewFileSource returns a source reading the policy from path, or from DefaultPolicy when path is empty.
+func NewFileSource(path string) Source {
+	return &fileSource{path}
+}
+
+type fileSource struct {
+	path string
+}
+
+func (s *fileSource) Load(_ context.Context) (*Policy, error) {
+	if s.path == "" {
+		return Parse(DefaultPolicy)
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading permission policy file")
+	}
+
+	return Parse(data)
+}
+
+// PolicyRecord is a stored version of the permission policy.
+type PolicyRecord struct {
+	Version   int       `gorm:"column:version"`
+	Document  string    `gorm:"column:document"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	CreatedBy uuid.UUID `gorm:"column:created_by"`
+}
+
+func (m *PolicyRecord) TableName() string {
+	return "permission_policies"
+}
+
+// NewSQLSource returns a source reading the latest policy version from the database.
+func NewSQLSource(db *gorm.DB) Source {
+	return &sqlSource{db}
+}
+
+type sqlSource struct {
+	gormDB *gorm.DB
+}
+
+func (s *sqlSource) Load(ctx context.Context) (*Policy, error) {
+	var record PolicyRecord
+
+	result := s.gormDB.WithContext(ctx).Model(&PolicyRecord{}).
+		Order("version desc").
+		Limit(1).
+		Find(&record)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, errors.New("no permission policy found in permission_policies")
+	}
+
+	p, err := Parse([]byte(record.Document))
+	if err != nil {
+		return nil, errors.Wrapf(err, "permission policy version %d", record.Version)
+	}
+
+	p.Version = record.Version
+
+	return p, nil
+}
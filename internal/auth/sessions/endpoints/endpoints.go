@@ -0,0 +1,48 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/service"
+)
+
+type Endpoints struct {
+	ListUserSessionsEndpoint   endpoint.Endpoint
+	RevokeUserSessionsEndpoint endpoint.Endpoint
+	RevokeSessionEndpoint      endpoint.Endpoint
+}
+
+// New returns new endpoints
+func New(svc service.Service) *Endpoints {
+	return &Endpoints{
+		ListUserSessionsEndpoint:   makeListUserSessionsEndpoint(svc),
+		RevokeUserSessionsEndpoint: makeRevokeUserSessionsEndpoint(svc),
+		RevokeSessionEndpoint:      makeRevokeSessionEndpoint(svc),
+	}
+}
+
+func makeListUserSessionsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.ListUserSessionsRequest) //nolint:errcheck
+
+		return svc.ListUserSessions(ctx, req)
+	}
+}
+
+func makeRevokeUserSessionsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.RevokeUserSessionsRequest) //nolint:errcheck
+		err := svc.RevokeUserSessions(ctx, req)
+		return "", err
+	}
+}
+
+func makeRevokeSessionEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.RevokeSessionRequest) //nolint:errcheck
+		err := svc.RevokeSession(ctx, req)
+		return "", err
+	}
+}
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+)
+
+// Session is a user token seen by the API. SessionID is the jti claim of the token, or a
+// hash of the token when the issuer sets no jti.
+type Session struct {
+	SessionID string            `json:"session_id" gorm:"column:session_id"`
+	UserUuid  uuid.UUID         `json:"user_uuid" gorm:"column:user_uuid"`
+	Issuer    string            `json:"issuer" gorm:"column:issuer"`
+	Subject   string            `json:"subject" gorm:"column:subject"`
+	ClientIP  string            `json:"client_ip" gorm:"column:client_ip"`
+	IssuedAt  time.Time         `json:"issued_at" gorm:"column:issued_at"`
+	ExpiresAt time.Time         `json:"expires_at" gorm:"column:expires_at"`
+	RevokedAt nullable.NullTime `json:"revoked_at" gorm:"column:revoked_at"`
+	RevokedBy *uuid.UUID        `json:"revoked_by,omitempty" gorm:"column:revoked_by"`
+	CreatedAt time.Time         `json:"created_at" gorm:"column:created_at;default:now()"`
+}
+
+func (m *Session) TableName() string {
+	return "user_sessions"
+}
+
+// Revocation signs a user out of every session issued until RevokedBefore.
+type Revocation struct {
+	UserUuid      uuid.UUID  `json:"user_uuid" gorm:"column:user_uuid"`
+	RevokedBefore time.Time  `json:"revoked_before" gorm:"column:revoked_before"`
+	RevokedBy     *uuid.UUID `json:"revoked_by,omitempty" gorm:"column:revoked_by"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"column:created_at;default:now()"`
+}
+
+func (m *Revocation) TableName() string {
+	return "user_session_revocations"
+}
+
+// Denylist holds the revoked sessions that have not expired yet and the user revocations.
+type Denylist struct {
+	Sessions    map[string]time.Time
+	Revocations map[uuid.UUID]time.Time
+}
+
+// Request Types. CompanyUuid is the company the sessions are managed from, nil when the API
+// revokes them itself like on removing the user from a company.
+
+type ListUserSessionsRequest struct {
+	CompanyUuid uuid.UUID
+	UserUuid    uuid.UUID
+	ActiveOnly  bool
+}
+
+type RevokeUserSessionsRequest struct {
+	CompanyUuid uuid.UUID
+	UserUuid    uuid.UUID
+}
+
+type RevokeSessionRequest struct {
+	CompanyUuid uuid.UUID
+	UserUuid    uuid.UUID
+	SessionID   string
+}
@@ -0,0 +1,59 @@
+// Package sessions tracks the user sessions seen by the API and revokes them.
+package sessions
+
+import (
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/endpoints"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/service"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// ModuleServiceParams for sessions.
+type ModuleServiceParams struct {
+	fx.In
+
+	Config        config.Config
+	DB            *gorm.DB
+	CognitoClient cognito.Client
+}
+
+// NewService for sessions. It is provided on its own, so both the auth middleware and the
+// user service can depend on it.
+// nolint:gocritic
+func NewService(p ModuleServiceParams) service.Service {
+	return service.New(repository.New(p.DB), p.CognitoClient, p.Config.Sessions)
+}
+
+// ModuleParams for sessions.
+type ModuleParams struct {
+	fx.In
+
+	HTTPServer   *httpTransport.Server
+	APPTransport svcTransport.Client
+	AuthClient   auth.Client
+	Service      service.Service
+}
+
+// NewModule for sessions.
+// nolint:gocritic
+func NewModule(p ModuleParams) error {
+	eps := endpoints.New(p.Service)
+
+	http.RegisterTransport(p.HTTPServer, eps, p.AuthClient, p.APPTransport)
+
+	return nil
+}
+
+var (
+	// Module for uber fx.
+	Module = fx.Options(fx.Provide(NewService))
+	// ModuleHttpAPI for uber fx.
+	ModuleHttpAPI = fx.Options(fx.Invoke(NewModule))
+)
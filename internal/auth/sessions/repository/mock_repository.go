@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/entities"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindCompanyUserGroup mocks base method.
+func (m *MockRepository) FindCompanyUserGroup(ctx context.Context, userUuid, companyUuid uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCompanyUserGroup", ctx, userUuid, companyUuid)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCompanyUserGroup indicates an expected call of FindCompanyUserGroup.
+func (mr *MockRepositoryMockRecorder) FindCompanyUserGroup(ctx, userUuid, companyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCompanyUserGroup", reflect.TypeOf((*MockRepository)(nil).FindCompanyUserGroup), ctx, userUuid, companyUuid)
+}
+
+// FindUserEmail mocks base method.
+func (m *MockRepository) FindUserEmail(ctx context.Context, userUuid uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUserEmail", ctx, userUuid)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUserEmail indicates an expected call of FindUserEmail.
+func (mr *MockRepositoryMockRecorder) FindUserEmail(ctx, userUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUserEmail", reflect.TypeOf((*MockRepository)(nil).FindUserEmail), ctx, userUuid)
+}
+
+// ListSessions mocks base method.
+func (m *MockRepository) ListSessions(ctx context.Context, userUuid uuid.UUID, activeAt *time.Time) ([]*entities.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, userUuid, activeAt)
+	ret0, _ := ret[0].([]*entities.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockRepositoryMockRecorder) ListSessions(ctx, userUuid, activeAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockRepository)(nil).ListSessions), ctx, userUuid, activeAt)
+}
+
+// LoadDenylist mocks base method.
+func (m *MockRepository) LoadDenylist(ctx context.Context, t time.Time) (*entities.Denylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadDenylist", ctx, t)
+	ret0, _ := ret[0].(*entities.Denylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadDenylist indicates an expected call of LoadDenylist.
+func (mr *MockRepositoryMockRecorder) LoadDenylist(ctx, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadDenylist", reflect.TypeOf((*MockRepository)(nil).LoadDenylist), ctx, t)
+}
+
+// RevokeSession mocks base method.
+func (m *MockRepository) RevokeSession(ctx context.Context, userUuid uuid.UUID, sessionID string, revokedBy *uuid.UUID, t time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, userUuid, sessionID, revokedBy, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockRepositoryMockRecorder) RevokeSession(ctx, userUuid, sessionID, revokedBy, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockRepository)(nil).RevokeSession), ctx, userUuid, sessionID, revokedBy, t)
+}
+
+// RevokeUser mocks base method.
+func (m *MockRepository) RevokeUser(ctx context.Context, revocation *entities.Revocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUser", ctx, revocation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUser indicates an expected call of RevokeUser.
+func (mr *MockRepositoryMockRecorder) RevokeUser(ctx, revocation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUser", reflect.TypeOf((*MockRepository)(nil).RevokeUser), ctx, revocation)
+}
+
+// TrackSession mocks base method.
+func (m *MockRepository) TrackSession(ctx context.Context, session *entities.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TrackSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TrackSession indicates an expected call of TrackSession.
+func (mr *MockRepositoryMockRecorder) TrackSession(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TrackSession", reflect.TypeOf((*MockRepository)(nil).TrackSession), ctx, session)
+}
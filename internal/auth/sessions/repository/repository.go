@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/entities"
+	"gorm.io/gorm"
+)
+
+// Repository for user sessions and their revocations.
+type Repository interface {
+	// TrackSession stores the session unless it is known already.
+	TrackSession(ctx context.Context, session *entities.Session) error
+	ListSessions(ctx context.Context, userUuid uuid.UUID, activeAt *time.Time) ([]*entities.Session, error)
+	RevokeSession(ctx context.Context, userUuid uuid.UUID, sessionID string, revokedBy *uuid.UUID, t time.Time) error
+	// RevokeUser stores the revocation and marks the sessions of the user issued until then as revoked.
+	RevokeUser(ctx context.Context, revocation *entities.Revocation) error
+	// LoadDenylist returns the revoked sessions that did not expire by t and all user revocations.
+	LoadDenylist(ctx context.Context, t time.Time) (*entities.Denylist, error)
+	FindUserEmail(ctx context.Context, userUuid uuid.UUID) (string, error)
+	// FindCompanyUserGroup returns the group of the user when it belongs to the company.
+	FindCompanyUserGroup(ctx context.Context, userUuid, companyUuid uuid.UUID) (string, error)
+}
+
+// New repository for sessions.
+func New(db *gorm.DB) Repository {
+	repo := &sqlRepository{db}
+
+	return repo
+}
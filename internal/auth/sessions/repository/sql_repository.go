@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sqlRepository struct {
+	gormDB *gorm.DB
+}
+
+func (s *sqlRepository) TrackSession(ctx context.Context, session *entities.Session) error {
+	return s.gormDB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(session).Error
+}
+
+// ListSessions of the user, latest first. A non-nil activeAt leaves out sessions revoked or
+// expired by then.
+func (s *sqlRepository) ListSessions(ctx context.Context, userUuid uuid.UUID, activeAt *time.Time) ([]*entities.Session, error) {
+	var sessions []*entities.Session
+
+	query := s.gormDB.WithContext(ctx).Model(&entities.Session{}).
+		Where("user_uuid = ?", userUuid).
+		Order("created_at desc")
+
+	if activeAt != nil {
+		query = query.Where("revoked_at IS NULL AND expires_at > ?", *activeAt)
+	}
+
+	if err := query.Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (s *sqlRepository) RevokeSession(ctx context.Context, userUuid uuid.UUID, sessionID string, revokedBy *uuid.UUID, t time.Time) error {
+	result := s.gormDB.WithContext(ctx).Model(&entities.Session{}).
+		Where("session_id = ? AND user_uuid = ? AND revoked_at IS NULL", sessionID, userUuid).
+		Updates(map[string]interface{}{"revoked_at": t, "revoked_by": revokedBy})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return &appError.ErrNotFound{Message: "session not found"}
+	}
+
+	return nil
+}
+
+func (s *sqlRepository) RevokeUser(ctx context.Context, revocation *entities.Revocation) error {
+	return s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_uuid"}},
+			DoUpdates: clause.AssignmentColumns([]string{"revoked_before", "revoked_by", "created_at"}),
+		}).Create(revocation).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&entities.Session{}).
+			Where("user_uuid = ? AND revoked_at IS NULL AND issued_at <= ?", revocation.UserUuid, revocation.RevokedBefore).
+			Updates(map[string]interface{}{"revoked_at": revocation.CreatedAt, "revoked_by": revocation.RevokedBy}).Error
+	})
+}
+
+func (s *sqlRepository) LoadDenylist(ctx context.Context, t time.Time) (*entities.Denylist, error) {
+	var sessions []*entities.Session
+
+	err := s.gormDB.WithContext(ctx).Model(&entities.Session{}).
+		Select("session_id", "expires_at").
+		Where("revoked_at IS NOT NULL AND expires_at > ?", t).
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var revocations []*entities.Revocation
+
+	err = s.gormDB.WithContext(ctx).Model(&entities.Revocation{}).
+		Select("user_uuid", "revoked_before").
+		Find(&revocations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	denylist := &entities.Denylist{
+		Sessions:    make(map[string]time.Time, len(sessions)),
+		Revocations: make(map[uuid.UUID]time.Time, len(revocations)),
+	}
+
+	for _, session := range sessions {
+		denylist.Sessions[session.SessionID] = session.ExpiresAt
+	}
+
+	for _, revocation := range revocations {
+		denylist.Revocations[revocation.UserUuid] = revocation.RevokedBefore
+	}
+
+	return denylist, nil
+}
+
+func (s *sqlRepository) FindUserEmail(ctx context.Context, userUuid uuid.UUID) (string, error) {
+	var emails []string
+
+	err := s.gormDB.WithContext(ctx).Table("users").
+		Where("user_uuid = ?", userUuid).
+		Pluck("email", &emails).Error
+	if err != nil {
+		return "", err
+	}
+
+	if len(emails) == 0 {
+		return "", &appError.ErrNotFound{Message: "user not found"}
+	}
+
+	return emails[0], nil
+}
+
+func (s *sqlRepository) FindCompanyUserGroup(ctx context.Context, userUuid, companyUuid uuid.UUID) (string, error) {
+	var groups []string
+
+	err := s.gormDB.WithContext(ctx).Table("users u").
+		Joins("join company_users cu on cu.user_uuid = u.user_uuid").
+		Where("u.user_uuid = ? AND cu.company_uuid = ?", userUuid, companyUuid).
+		Pluck("u.user_group", &groups).Error
+	if err != nil {
+		return "", err
+	}
+
+	if len(groups) == 0 {
+		return "", &appError.ErrNotFound{Message: "user not found"}
+	}
+
+	return groups[0], nil
+}
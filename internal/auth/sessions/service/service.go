@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/config"
+	authErrors "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	authMeta "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/meta"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/constants"
+	"github.com/pkg/errors"
+)
+
+const defaultRefreshInterval = 30 * time.Second
+
+// Service for user sessions. Revocations are checked against a denylist cached in memory,
+// so checking a session costs no database round trip until the denylist is refreshed.
+type Service interface {
+	// Check fails with ErrInvalidToken when the session is revoked and tracks sessions
+	// seen for the first time.
+	Check(ctx context.Context, session *entities.Session) error
+	ListUserSessions(ctx context.Context, req *entities.ListUserSessionsRequest) ([]*entities.Session, error)
+	// RevokeUserSessions revokes every session of the user issued until now and signs the
+	// user out of Cognito, so the refresh tokens can't issue new ones.
+	RevokeUserSessions(ctx context.Context, req *entities.RevokeUserSessionsRequest) error
+	RevokeSession(ctx context.Context, req *entities.RevokeSessionRequest) error
+}
+
+type service struct {
+	repo          repository.Repository
+	cognitoClient cognito.Client
+	cfg           config.SessionsConfig
+	now           func() time.Time
+
+	refreshMu sync.Mutex
+	mu        sync.RWMutex
+	loadedAt  time.Time
+	denylist  *entities.Denylist
+	// seen sessions are tracked already and mapped to their expiry.
+	seen map[string]time.Time
+}
+
+// New service for sessions.
+func New(repo repository.Repository, cognitoClient cognito.Client, cfg config.SessionsConfig) Service {
+	if cfg.DenylistRefreshInterval <= 0 {
+		cfg.DenylistRefreshInterval = defaultRefreshInterval
+	}
+
+	return &service{
+		repo:          repo,
+		cognitoClient: cognitoClient,
+		cfg:           cfg,
+		now:           time.Now,
+		seen:          map[string]time.Time{},
+	}
+}
+
+func (s *service) Check(ctx context.Context, session *entities.Session) error {
+	if err := s.refresh(ctx); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	_, revoked := s.denylist.Sessions[session.SessionID]
+	revokedBefore, userRevoked := s.denylist.Revocations[session.UserUuid]
+	_, seen := s.seen[session.SessionID]
+	s.mu.RUnlock()
+
+	if revoked || (userRevoked && !session.IssuedAt.After(revokedBefore)) {
+		return errors.Wrap(authErrors.ErrInvalidToken, "session is revoked")
+	}
+
+	if seen {
+		return nil
+	}
+
+	if session.CreatedAt.IsZero() {
+		session.CreatedAt = s.now()
+	}
+
+	if err := s.repo.TrackSession(ctx, session); err != nil {
+		return errors.Wrap(err, "tracking session")
+	}
+
+	s.mu.Lock()
+	s.seen[session.SessionID] = session.ExpiresAt
+	s.mu.Unlock()
+
+	return nil
+}
+
+// refresh loads the denylist when it is older than the refresh interval. A stale denylist
+// is kept when loading fails, so only the first load fails the check.
+func (s *service) refresh(ctx context.Context) error {
+	s.mu.RLock()
+	fresh := s.denylist != nil && s.now().Sub(s.loadedAt) < s.cfg.DenylistRefreshInterval
+	s.mu.RUnlock()
+
+	if fresh {
+		return nil
+	}
+
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	s.mu.RLock()
+	fresh = s.denylist != nil && s.now().Sub(s.loadedAt) < s.cfg.DenylistRefreshInterval
+	s.mu.RUnlock()
+
+	if fresh {
+		return nil
+	}
+
+	now := s.now()
+
+	denylist, err := s.repo.LoadDenylist(ctx, now)
+	if err != nil {
+		s.mu.RLock()
+		stale := s.denylist != nil
+		s.mu.RUnlock()
+
+		if stale {
+			return nil
+		}
+
+		return errors.Wrap(err, "loading session denylist")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.denylist = denylist
+	s.loadedAt = now
+
+	for id, expiresAt := range s.seen {
+		if !now.Before(expiresAt) {
+			delete(s.seen, id)
+		}
+	}
+
+	return nil
+}
+
+// invalidate makes the next check reload the denylist, so revocations made here apply at
+// once on this instance.
+func (s *service) invalidate() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+func (s *service) ListUserSessions(ctx context.Context, req *entities.ListUserSessionsRequest) ([]*entities.Session, error) {
+	if err := s.checkManaged(ctx, req.CompanyUuid, req.UserUuid); err != nil {
+		return nil, err
+	}
+
+	var activeAt *time.Time
+	if req.ActiveOnly {
+		now := s.now()
+		activeAt = &now
+	}
+
+	return s.repo.ListSessions(ctx, req.UserUuid, activeAt)
+}
+
+func (s *service) RevokeUserSessions(ctx context.Context, req *entities.RevokeUserSessionsRequest) error {
+	if err := s.checkManaged(ctx, req.CompanyUuid, req.UserUuid); err != nil {
+		return err
+	}
+
+	// tokens carry their issue time in seconds; one issued in the second of the
+	// revocation is revoked too.
+	now := s.now().Truncate(time.Second)
+
+	err := s.repo.RevokeUser(ctx, &entities.Revocation{
+		UserUuid:      req.UserUuid,
+		RevokedBefore: now,
+		RevokedBy:     actorUuid(ctx),
+		CreatedAt:     now,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.invalidate()
+
+	email, err := s.repo.FindUserEmail(ctx, req.UserUuid)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(s.cognitoClient.SignOutUser(ctx, email), "signing out of cognito")
+}
+
+func (s *service) RevokeSession(ctx context.Context, req *entities.RevokeSessionRequest) error {
+	if err := s.checkManaged(ctx, req.CompanyUuid, req.UserUuid); err != nil {
+		return err
+	}
+
+	if err := s.repo.RevokeSession(ctx, req.UserUuid, req.SessionID, actorUuid(ctx), s.now()); err != nil {
+		return err
+	}
+
+	s.invalidate()
+
+	return nil
+}
+
+// checkManaged checks the sessions of the user can be managed from the company. Sessions aren't
+// bound to a company, so only those of its customer users are; superadmins manage every user.
+func (s *service) checkManaged(ctx context.Context, companyUuid, userUuid uuid.UUID) error {
+	if companyUuid == uuid.Nil {
+		return nil
+	}
+
+	if user := authMeta.User(ctx); user != nil && user.UserGroup == constants.UserGroupSuperadmin {
+		return nil
+	}
+
+	group, err := s.repo.FindCompanyUserGroup(ctx, userUuid, companyUuid)
+	if err != nil {
+		return err
+	}
+
+	switch group {
+	case constants.UserGroupSuperadmin, constants.UserGroupEngineer, constants.UserGroupCsc:
+		return authErrors.ErrNoPermission
+	}
+
+	return nil
+}
+
+func actorUuid(ctx context.Context) *uuid.UUID {
+	user := authMeta.User(ctx)
+	if user == nil {
+		return nil
+	}
+
+	return &user.Uuid
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/entity"
+	authErrors "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	authMeta "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/meta"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type cognitoClient struct {
+	cognito.Client
+	signedOut []string
+}
+
+func (c *cognitoClient) SignOutUser(_ context.Context, username string) error {
+	c.signedOut = append(c.signedOut, username)
+
+	return nil
+}
+
+func emptyDenylist() *entities.Denylist {
+	return &entities.Denylist{Sessions: map[string]time.Time{}, Revocations: map[uuid.UUID]time.Time{}}
+}
+
+func TestCheck(t *testing.T) {
+	Convey("Given the session service", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, &cognitoClient{}, config.SessionsConfig{DenylistRefreshInterval: time.Minute}).(*service)
+
+		now := time.Date(2023, 3, 25, 10, 0, 0, 0, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		ctx := context.Background()
+		session := &entities.Session{
+			SessionID: "jti-1",
+			UserUuid:  uuid.New(),
+			IssuedAt:  now.Add(-time.Minute),
+			ExpiresAt: now.Add(time.Hour),
+		}
+
+		Convey("A new session is tracked once and the denylist is loaded once per interval", func() {
+			repo.EXPECT().LoadDenylist(ctx, now).Return(emptyDenylist(), nil).Times(1)
+			repo.EXPECT().TrackSession(ctx, session).Return(nil).Times(1)
+
+			So(svc.Check(ctx, session), ShouldBeNil)
+			So(svc.Check(ctx, session), ShouldBeNil)
+		})
+
+		Convey("The denylist is reloaded after the interval", func() {
+			repo.EXPECT().LoadDenylist(ctx, gomock.Any()).Return(emptyDenylist(), nil).Times(2)
+			repo.EXPECT().TrackSession(ctx, session).Return(nil)
+
+			So(svc.Check(ctx, session), ShouldBeNil)
+
+			now = now.Add(2 * time.Minute)
+			So(svc.Check(ctx, session), ShouldBeNil)
+		})
+
+		Convey("A revoked session is rejected", func() {
+			denylist := emptyDenylist()
+			denylist.Sessions[session.SessionID] = session.ExpiresAt
+			repo.EXPECT().LoadDenylist(ctx, now).Return(denylist, nil)
+
+			err := svc.Check(ctx, session)
+			So(errors.Cause(err), ShouldEqual, authErrors.ErrInvalidToken)
+		})
+
+		Convey("Sessions issued until the user revocation are rejected and later ones accepted", func() {
+			denylist := emptyDenylist()
+			denylist.Revocations[session.UserUuid] = now.Add(-time.Minute)
+			repo.EXPECT().LoadDenylist(ctx, now).Return(denylist, nil)
+
+			err := svc.Check(ctx, session)
+			So(errors.Cause(err), ShouldEqual, authErrors.ErrInvalidToken)
+
+			later := *session
+			later.SessionID = "jti-2"
+			later.IssuedAt = now
+			repo.EXPECT().TrackSession(ctx, &later).Return(nil)
+
+			So(svc.Check(ctx, &later), ShouldBeNil)
+		})
+
+		Convey("A stale denylist is kept when reloading fails", func() {
+			repo.EXPECT().LoadDenylist(ctx, gomock.Any()).Return(emptyDenylist(), nil)
+			repo.EXPECT().TrackSession(ctx, session).Return(nil)
+			So(svc.Check(ctx, session), ShouldBeNil)
+
+			now = now.Add(2 * time.Minute)
+			repo.EXPECT().LoadDenylist(ctx, now).Return(nil, errors.New("connection refused"))
+			So(svc.Check(ctx, session), ShouldBeNil)
+		})
+
+		Convey("The first load failing fails the check", func() {
+			repo.EXPECT().LoadDenylist(ctx, now).Return(nil, errors.New("connection refused"))
+
+			So(svc.Check(ctx, session), ShouldNotBeNil)
+		})
+	})
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	Convey("Given an admin revoking the sessions of a user", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		cognitoClient := &cognitoClient{}
+		svc := New(repo, cognitoClient, config.SessionsConfig{}).(*service)
+
+		now := time.Date(2023, 3, 25, 10, 0, 0, 500, time.UTC)
+		svc.now = func() time.Time { return now }
+
+		admin := &entity.User{Uuid: uuid.New()}
+		ctx := authMeta.WithUser(context.Background(), admin)
+		userUuid := uuid.New()
+
+		repo.EXPECT().RevokeUser(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, revocation *entities.Revocation) error {
+			So(revocation.UserUuid, ShouldEqual, userUuid)
+			So(revocation.RevokedBefore, ShouldEqual, now.Truncate(time.Second))
+			So(*revocation.RevokedBy, ShouldEqual, admin.Uuid)
+
+			return nil
+		})
+		repo.EXPECT().FindUserEmail(ctx, userUuid).Return("user@customer", nil)
+
+		Convey("The user is signed out of Cognito and the denylist is reloaded on the next check", func() {
+			repo.EXPECT().LoadDenylist(gomock.Any(), gomock.Any()).Return(emptyDenylist(), nil).Times(2)
+			repo.EXPECT().TrackSession(gomock.Any(), gomock.Any()).Return(nil)
+
+			So(svc.Check(context.Background(), &entities.Session{SessionID: "jti-1", ExpiresAt: now.Add(time.Hour)}), ShouldBeNil)
+
+			err := svc.RevokeUserSessions(ctx, &entities.RevokeUserSessionsRequest{UserUuid: userUuid})
+			So(err, ShouldBeNil)
+			So(cognitoClient.signedOut, ShouldResemble, []string{"user@customer"})
+
+			So(svc.Check(context.Background(), &entities.Session{SessionID: "jti-1", ExpiresAt: now.Add(time.Hour)}), ShouldBeNil)
+		})
+	})
+}
+
+func TestManageFromCompany(t *testing.T) {
+	Convey("Given a company admin managing sessions from their company", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, &cognitoClient{}, config.SessionsConfig{})
+
+		ctx := authMeta.WithUser(context.Background(), &entity.User{Uuid: uuid.New(), UserGroup: "customer"})
+		companyUuid, userUuid := uuid.New(), uuid.New()
+
+		Convey("The sessions of its customer users can be revoked", func() {
+			repo.EXPECT().FindCompanyUserGroup(ctx, userUuid, companyUuid).Return("customer", nil)
+			repo.EXPECT().RevokeSession(ctx, userUuid, "jti-1", gomock.Any(), gomock.Any()).Return(nil)
+
+			err := svc.RevokeSession(ctx, &entities.RevokeSessionRequest{CompanyUuid: companyUuid, UserUuid: userUuid, SessionID: "jti-1"})
+			So(err, ShouldBeNil)
+		})
+
+		Convey("The sessions of an engineer assigned to the company can't be listed nor revoked", func() {
+			repo.EXPECT().FindCompanyUserGroup(ctx, userUuid, companyUuid).Return("engineer", nil).Times(2)
+
+			_, err := svc.ListUserSessions(ctx, &entities.ListUserSessionsRequest{CompanyUuid: companyUuid, UserUuid: userUuid})
+			So(err, ShouldEqual, authErrors.ErrNoPermission)
+
+			err = svc.RevokeUserSessions(ctx, &entities.RevokeUserSessionsRequest{CompanyUuid: companyUuid, UserUuid: userUuid})
+			So(err, ShouldEqual, authErrors.ErrNoPermission)
+		})
+
+		Convey("Users of other companies are not found", func() {
+			repo.EXPECT().FindCompanyUserGroup(ctx, userUuid, companyUuid).Return("", &appError.ErrNotFound{Message: "user not found"})
+
+			err := svc.RevokeUserSessions(ctx, &entities.RevokeUserSessionsRequest{CompanyUuid: companyUuid, UserUuid: userUuid})
+			So(appError.IsNotFoundError(err), ShouldBeTrue)
+		})
+	})
+}
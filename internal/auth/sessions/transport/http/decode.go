@@ -0,0 +1,70 @@
+// Package http for sessions.
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/entities"
+	httpError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors/http"
+)
+
+func decodeCompanyAndUserID(r *http.Request) (uuid.UUID, uuid.UUID, error) {
+	companyUUID, err := uuid.Parse(mux.Vars(r)["company_id"])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, httpError.NewErrBadOrInvalidPathParameter("company_id")
+	}
+
+	userUUID, err := uuid.Parse(mux.Vars(r)["user_id"])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, httpError.NewErrBadOrInvalidPathParameter("user_id")
+	}
+
+	return companyUUID, userUUID, nil
+}
+
+func decodeListUserSessionsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	companyUUID, userUUID, err := decodeCompanyAndUserID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &entities.ListUserSessionsRequest{CompanyUuid: companyUUID, UserUuid: userUUID}
+
+	if v := r.URL.Query().Get("active"); v != "" {
+		active, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, httpError.NewErrBadOrInvalidPathParameter("active")
+		}
+
+		req.ActiveOnly = active
+	}
+
+	return req, nil
+}
+
+func decodeRevokeUserSessionsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	companyUUID, userUUID, err := decodeCompanyAndUserID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.RevokeUserSessionsRequest{CompanyUuid: companyUUID, UserUuid: userUUID}, nil
+}
+
+func decodeRevokeSessionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	companyUUID, userUUID, err := decodeCompanyAndUserID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID := mux.Vars(r)["session_id"]
+	if sessionID == "" {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("session_id")
+	}
+
+	return &entities.RevokeSessionRequest{CompanyUuid: companyUUID, UserUuid: userUUID, SessionID: sessionID}, nil
+}
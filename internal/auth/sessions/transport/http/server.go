@@ -0,0 +1,68 @@
+// Package http for sessions.
+package http
+
+import (
+	goKitEndpoint "github.com/go-kit/kit/endpoint"
+	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/endpoints"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+)
+
+// RegisterTransport for http.
+func RegisterTransport(
+	server *httpTransport.Server,
+	ep *endpoints.Endpoints,
+	authClient auth.Client,
+	svcTransportClient svcTransport.Client,
+) {
+	registerListUserSessions(server, ep.ListUserSessionsEndpoint, authClient, svcTransportClient)
+	registerRevokeUserSessions(server, ep.RevokeUserSessionsEndpoint, authClient, svcTransportClient)
+	registerRevokeSession(server, ep.RevokeSessionEndpoint, authClient, svcTransportClient)
+}
+
+func registerListUserSessions(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{req_user_id}/settings/users/{user_id}/sessions"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "user-sessions", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeListUserSessionsRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerRevokeUserSessions(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{req_user_id}/settings/users/{user_id}/sessions"
+	method := "DELETE"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "user-sessions", permissions.ActionDelete)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeRevokeUserSessionsRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerRevokeSession(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{req_user_id}/settings/users/{user_id}/sessions/{session_id}"
+	method := "DELETE"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "user-sessions", permissions.ActionDelete)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeRevokeSessionRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func getHandler(ep goKitEndpoint.Endpoint, dec goKitHTTPTransport.DecodeRequestFunc, enc goKitHTTPTransport.EncodeResponseFunc, atc svcTransport.Client, method string) *goKitHTTPTransport.Server {
+	return goKitHTTPTransport.NewServer(
+		ep,
+		dec,
+		enc,
+		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
+		goKitHTTPTransport.ServerErrorHandler(atc.LogErrorHandler()),
+	)
+}
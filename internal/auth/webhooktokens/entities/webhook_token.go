@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+)
+
+// WebhookToken is the record of an issued webhook token, used to list and revoke it by jti.
+type WebhookToken struct {
+	Jti         uuid.UUID         `json:"jti" gorm:"column:jti"`
+	Audience    string            `json:"audience" gorm:"column:audience"`
+	Description string            `json:"description" gorm:"column:description"`
+	Kid         string            `json:"kid" gorm:"column:kid"`
+	IssuedAt    time.Time         `json:"issued_at" gorm:"column:issued_at;default:now()"`
+	ExpiresAt   nullable.NullTime `json:"expires_at" gorm:"column:expires_at"`
+	RevokedAt   nullable.NullTime `json:"revoked_at" gorm:"column:revoked_at"`
+}
+
+func (m *WebhookToken) TableName() string {
+	return "webhook_tokens"
+}
+
+// IssuedWebhookToken carries the signed token, which is not stored.
+type IssuedWebhookToken struct {
+	*WebhookToken
+	Token string `json:"token"`
+}
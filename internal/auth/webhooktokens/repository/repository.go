@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/webhooktokens/entities"
+	"gorm.io/gorm"
+)
+
+// Repository for webhook tokens.
+type Repository interface {
+	Create(ctx context.Context, token *entities.WebhookToken) error
+	List(ctx context.Context) ([]*entities.WebhookToken, error)
+	FindByJti(ctx context.Context, jti uuid.UUID) (*entities.WebhookToken, error)
+	Revoke(ctx context.Context, jti uuid.UUID) error
+}
+
+// New repository for webhook tokens.
+func New(db *gorm.DB) Repository {
+	repo := &sqlRepository{db}
+
+	return repo
+}
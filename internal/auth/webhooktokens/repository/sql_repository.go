@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/webhooktokens/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"gorm.io/gorm"
+)
+
+type sqlRepository struct {
+	gormDB *gorm.DB
+}
+
+func (s *sqlRepository) Create(ctx context.Context, token *entities.WebhookToken) error {
+	return s.gormDB.WithContext(ctx).Create(token).Error
+}
+
+func (s *sqlRepository) List(ctx context.Context) ([]*entities.WebhookToken, error) {
+	var tokens []*entities.WebhookToken
+
+	err := s.gormDB.WithContext(ctx).Model(&entities.WebhookToken{}).
+		Order("issued_at desc").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+func (s *sqlRepository) FindByJti(ctx context.Context, jti uuid.UUID) (*entities.WebhookToken, error) {
+	var token *entities.WebhookToken
+
+	result := s.gormDB.WithContext(ctx).Model(&entities.WebhookToken{}).
+		Find(&token, "jti = ?", jti)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &appError.ErrNotFound{Message: "webhook token not found"}
+	}
+
+	return token, nil
+}
+
+func (s *sqlRepository) Revoke(ctx context.Context, jti uuid.UUID) error {
+	result := s.gormDB.WithContext(ctx).Model(&entities.WebhookToken{}).
+		Where("jti = ? AND revoked_at IS NULL", jti).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return &appError.ErrNotFound{Message: "webhook token not found"}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/webhooktokens/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/webhooktokens/repository"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/jwt"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func testKeyRing() *jwt.KeyRing {
+	ring, _ := jwt.NewKeyRing(jwt.Config{
+		SigningKeyID: "test",
+		Keys:         []jwt.KeyConfig{{ID: "test", Algorithm: jwt.AlgorithmHS256, Secret: "secret"}},
+	})
+
+	return ring
+}
+
+func TestIssue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repository.NewMockRepository(ctrl)
+	svc := New(repo, testKeyRing())
+
+	Convey("Issuing a token for an unknown audience fails validation", t, func() {
+		_, err := svc.Issue(context.Background(), "unknown", "", time.Time{})
+		So(appError.IsValidationError(err), ShouldBeTrue)
+	})
+
+	Convey("Issuing a salesforce token records its jti and signing key", t, func() {
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+		issued, err := svc.Issue(context.Background(), jwt.AudienceSalesforce, "sf prod", time.Time{})
+		So(err, ShouldBeNil)
+		So(issued.Token, ShouldNotBeEmpty)
+		So(issued.Kid, ShouldEqual, "test")
+		So(issued.ExpiresAt.Valid, ShouldBeFalse)
+	})
+}
+
+func TestVerify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repository.NewMockRepository(ctrl)
+	svc := New(repo, testKeyRing())
+
+	var record *entities.WebhookToken
+	repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, t *entities.WebhookToken) error {
+		record = t
+		return nil
+	})
+
+	issued, _ := svc.Issue(context.Background(), jwt.AudienceSalesforce, "", time.Time{})
+
+	Convey("Given an issued salesforce token", t, func() {
+		Convey("It verifies for the salesforce audience", func() {
+			repo.EXPECT().FindByJti(gomock.Any(), record.Jti).Return(record, nil)
+
+			got, err := svc.Verify(context.Background(), issued.Token, jwt.AudienceSalesforce)
+			So(err, ShouldBeNil)
+			So(got.Jti, ShouldEqual, record.Jti)
+		})
+
+		Convey("It is rejected for another audience", func() {
+			_, err := svc.Verify(context.Background(), issued.Token, jwt.AudienceInternalMeetings)
+			So(err, ShouldEqual, jwt.ErrInvalidToken)
+		})
+
+		Convey("It is rejected once revoked", func() {
+			revoked := *record
+			revoked.RevokedAt.Time, revoked.RevokedAt.Valid = time.Now(), true
+			repo.EXPECT().FindByJti(gomock.Any(), record.Jti).Return(&revoked, nil)
+
+			_, err := svc.Verify(context.Background(), issued.Token, jwt.AudienceSalesforce)
+			So(err, ShouldEqual, jwt.ErrInvalidToken)
+		})
+
+		Convey("It is rejected when its jti was never recorded", func() {
+			repo.EXPECT().FindByJti(gomock.Any(), record.Jti).Return(nil, &appError.ErrNotFound{Message: "webhook token not found"})
+
+			_, err := svc.Verify(context.Background(), issued.Token, jwt.AudienceSalesforce)
+			So(err, ShouldEqual, jwt.ErrInvalidToken)
+		})
+	})
+}
+
+func TestVerifyLegacy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repository.NewMockRepository(ctrl)
+	ring, _ := jwt.NewKeyRing(jwt.Config{
+		SigningKeyID:             "test",
+		Keys:                     []jwt.KeyConfig{{ID: "test", Algorithm: jwt.AlgorithmHS256, Secret: "secret"}, {ID: "legacy", Algorithm: jwt.AlgorithmHS256, Secret: "legacy-secret"}},
+		LegacyKeyID:              "legacy",
+		LegacyWebhookTokensUntil: time.Now().AddDate(0, 1, 0).Format("2006-01-02"),
+	})
+	svc := New(repo, ring)
+
+	Convey("A webhook token signed before the key ring is accepted without a record until the cutoff", t, func() {
+		token, _ := jwtgo.NewWithClaims(jwtgo.SigningMethodHS256, jwtgo.MapClaims{"exp": 0}).SignedString([]byte("legacy-secret"))
+
+		got, err := svc.Verify(context.Background(), token, jwt.AudienceSalesforce)
+		So(err, ShouldBeNil)
+		So(got.Audience, ShouldEqual, jwt.AudienceSalesforce)
+	})
+}
+
+func TestRevoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repository.NewMockRepository(ctrl)
+	svc := New(repo, testKeyRing())
+
+	Convey("Revoke delegates to the repository", t, func() {
+		jti := uuid.New()
+		repo.EXPECT().Revoke(gomock.Any(), jti).Return(nil)
+		So(svc.Revoke(context.Background(), jti), ShouldBeNil)
+	})
+}
@@ -12,6 +12,7 @@ type Client interface {
 	GetClaimsFromIDToken(ctx context.Context, idToken string) (*entities.AWSCognitoIDTokenClaims, error)
 	InviteUser(ctx context.Context, details entities.InviteUserDetails, resend bool) error
 	UpdateUserAttributes(ctx context.Context, details map[string]string) error
+	SignOutUser(ctx context.Context, username string) error
 }
 
 func newClient(svc service.Service) Client {
@@ -37,3 +38,7 @@ func (l *localClient) InviteUser(ctx context.Context, details entities.InviteUse
 func (l *localClient) UpdateUserAttributes(ctx context.Context, details map[string]string) error {
 	return l.svc.UpdateUserAttributes(ctx, details)
 }
+
+func (l *localClient) SignOutUser(ctx context.Context, username string) error {
+	return l.svc.SignOutUser(ctx, username)
+}
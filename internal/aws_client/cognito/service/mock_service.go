@@ -9,7 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
-	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito/entities"
+	entities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito/entities"
 )
 
 // MockService is a mock of Service interface.
@@ -64,6 +64,20 @@ func (mr *MockServiceMockRecorder) InviteUser(ctx, details, resend interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteUser", reflect.TypeOf((*MockService)(nil).InviteUser), ctx, details, resend)
 }
 
+// SignOutUser mocks base method.
+func (m *MockService) SignOutUser(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignOutUser", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SignOutUser indicates an expected call of SignOutUser.
+func (mr *MockServiceMockRecorder) SignOutUser(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignOutUser", reflect.TypeOf((*MockService)(nil).SignOutUser), ctx, username)
+}
+
 // UpdateUserAttributes mocks base method.
 func (m *MockService) UpdateUserAttributes(ctx context.Context, details map[string]string) error {
 	m.ctrl.T.Helper()
@@ -23,6 +23,7 @@ type Service interface {
 	GetClaimsFromIDToken(ctx context.Context, idToken string) (*entities.AWSCognitoIDTokenClaims, error)
 	InviteUser(ctx context.Context, details entities.InviteUserDetails, resend bool) error
 	UpdateUserAttributes(ctx context.Context, details map[string]string) error
+	SignOutUser(ctx context.Context, username string) error
 }
 
 type service struct {
@@ -207,6 +208,31 @@ func (s *service) UpdateUserAttributes(ctx context.Context, details map[string]s
 	return nil
 }
 
+// SignOutUser signs the user out of all devices. Its refresh tokens are revoked, so no new
+// tokens are issued until the user signs in again. Users unknown to the pool are ignored.
+func (s *service) SignOutUser(ctx context.Context, username string) error {
+	sess := session.Must(session.NewSessionWithOptions(session.Options{
+		Config: aws.Config{
+			Region:                        aws.String(s.config.AwsRegion),
+			MaxRetries:                    aws.Int(3),
+			CredentialsChainVerboseErrors: aws.Bool(true),
+		},
+	}))
+
+	cognitoClient := cognitoidentityprovider.New(sess)
+	_, err := cognitoClient.AdminUserGlobalSignOutWithContext(ctx, &cognitoidentityprovider.AdminUserGlobalSignOutInput{
+		UserPoolId: aws.String(s.config.UserPoolID),
+		Username:   aws.String(username),
+	})
+
+	var notFound *cognitoidentityprovider.UserNotFoundException
+	if err != nil && !errors.As(err, &notFound) {
+		return err
+	}
+
+	return nil
+}
+
 func New(config config.Config) *service {
 	return &service{config: config}
 }
@@ -9,6 +9,7 @@ import (
 
 type Client interface {
 	UploadFilesFromFileHeaders(ctx context.Context, batchUploadObjects []entities.BatchUploadObject) error
+	UploadFile(ctx context.Context, key, fileName string, data []byte) error
 	DownloadFile(ctx context.Context, key string) ([]byte, error)
 	DeleteFile(ctx context.Context, key string) error
 }
@@ -25,6 +26,10 @@ func (l *localClient) UploadFilesFromFileHeaders(ctx context.Context, batchUploa
 	return l.svc.UploadFilesFromFileHeaders(ctx, batchUploadObjects)
 }
 
+func (l *localClient) UploadFile(ctx context.Context, key, fileName string, data []byte) error {
+	return l.svc.UploadFile(ctx, key, fileName, data)
+}
+
 func (l *localClient) DownloadFile(ctx context.Context, key string) ([]byte, error) {
 	return l.svc.DownloadFile(ctx, key)
 }
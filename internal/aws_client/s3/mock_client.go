@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./client.go
+
+// Package s3client is a generated GoMock package.
+package s3client
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/s3/entities"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// DeleteFile mocks base method.
+func (m *MockClient) DeleteFile(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFile", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFile indicates an expected call of DeleteFile.
+func (mr *MockClientMockRecorder) DeleteFile(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockClient)(nil).DeleteFile), ctx, key)
+}
+
+// DownloadFile mocks base method.
+func (m *MockClient) DownloadFile(ctx context.Context, key string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadFile", ctx, key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DownloadFile indicates an expected call of DownloadFile.
+func (mr *MockClientMockRecorder) DownloadFile(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadFile", reflect.TypeOf((*MockClient)(nil).DownloadFile), ctx, key)
+}
+
+// UploadFile mocks base method.
+func (m *MockClient) UploadFile(ctx context.Context, key, fileName string, data []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadFile", ctx, key, fileName, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadFile indicates an expected call of UploadFile.
+func (mr *MockClientMockRecorder) UploadFile(ctx, key, fileName, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockClient)(nil).UploadFile), ctx, key, fileName, data)
+}
+
+// UploadFilesFromFileHeaders mocks base method.
+func (m *MockClient) UploadFilesFromFileHeaders(ctx context.Context, batchUploadObjects []entities.BatchUploadObject) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadFilesFromFileHeaders", ctx, batchUploadObjects)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadFilesFromFileHeaders indicates an expected call of UploadFilesFromFileHeaders.
+func (mr *MockClientMockRecorder) UploadFilesFromFileHeaders(ctx, batchUploadObjects interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFilesFromFileHeaders", reflect.TypeOf((*MockClient)(nil).UploadFilesFromFileHeaders), ctx, batchUploadObjects)
+}
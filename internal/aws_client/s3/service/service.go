@@ -18,6 +18,7 @@ import (
 
 type Service interface {
 	UploadFilesFromFileHeaders(ctx context.Context, batchUploadObjects []entities.BatchUploadObject) error
+	UploadFile(ctx context.Context, key, fileName string, data []byte) error
 	DownloadFile(ctx context.Context, key string) ([]byte, error)
 	DeleteFile(ctx context.Context, key string) error
 }
@@ -78,6 +79,25 @@ func (s service) UploadFilesFromFileHeaders(ctx context.Context, batchUploadObje
 	return nil
 }
 
+func (s service) UploadFile(ctx context.Context, key, fileName string, data []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	_, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		ACL:                aws.String("private"),
+		Body:               bytes.NewReader(data),
+		Bucket:             aws.String(s.config.BucketName),
+		Key:                aws.String(key),
+		ContentType:        aws.String(http.DetectContentType(data)),
+		ContentDisposition: aws.String(fmt.Sprintf("attachment; filename=\"%s\"", fileName)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload file, %v", err)
+	}
+
+	return nil
+}
+
 func (s service) DownloadFile(ctx context.Context, key string) ([]byte, error) {
 	buf := aws.NewWriteAtBuffer([]byte{})
 
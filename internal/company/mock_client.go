@@ -79,6 +79,20 @@ func (mr *MockClientMockRecorder) DeleteCompanySubscription(ctx, companyUuid, su
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompanySubscription", reflect.TypeOf((*MockClient)(nil).DeleteCompanySubscription), ctx, companyUuid, subscriptionId)
 }
 
+// FetchCompanySubscriptionFromSF mocks base method.
+func (m *MockClient) FetchCompanySubscriptionFromSF(ctx context.Context, companyUuid *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCompanySubscriptionFromSF", ctx, companyUuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FetchCompanySubscriptionFromSF indicates an expected call of FetchCompanySubscriptionFromSF.
+func (mr *MockClientMockRecorder) FetchCompanySubscriptionFromSF(ctx, companyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCompanySubscriptionFromSF", reflect.TypeOf((*MockClient)(nil).FetchCompanySubscriptionFromSF), ctx, companyUuid)
+}
+
 // FindByExternalId mocks base method.
 func (m *MockClient) FindByExternalId(ctx context.Context, req *entities.FindCompanyByExternalIdRequest) (*entities.FindCompanyByExternalIdResponse, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +138,66 @@ func (mr *MockClientMockRecorder) GetAllCompanies(ctx, req interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCompanies", reflect.TypeOf((*MockClient)(nil).GetAllCompanies), ctx, req)
 }
 
+// GetAllSubscriptionsByCompany mocks base method.
+func (m *MockClient) GetAllSubscriptionsByCompany(ctx context.Context, companyUuid *uuid.UUID) ([]entities.CompanySubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllSubscriptionsByCompany", ctx, companyUuid)
+	ret0, _ := ret[0].([]entities.CompanySubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllSubscriptionsByCompany indicates an expected call of GetAllSubscriptionsByCompany.
+func (mr *MockClientMockRecorder) GetAllSubscriptionsByCompany(ctx, companyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllSubscriptionsByCompany", reflect.TypeOf((*MockClient)(nil).GetAllSubscriptionsByCompany), ctx, companyUuid)
+}
+
+// GetConsultingHoursSubscriptions mocks base method.
+func (m *MockClient) GetConsultingHoursSubscriptions(ctx context.Context, companyUuid *uuid.UUID) ([]entities.CompanySubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsultingHoursSubscriptions", ctx, companyUuid)
+	ret0, _ := ret[0].([]entities.CompanySubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsultingHoursSubscriptions indicates an expected call of GetConsultingHoursSubscriptions.
+func (mr *MockClientMockRecorder) GetConsultingHoursSubscriptions(ctx, companyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsultingHoursSubscriptions", reflect.TypeOf((*MockClient)(nil).GetConsultingHoursSubscriptions), ctx, companyUuid)
+}
+
+// GetServiceReviewSubscriptions mocks base method.
+func (m *MockClient) GetServiceReviewSubscriptions(ctx context.Context, companyUuid *uuid.UUID) ([]entities.CompanySubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceReviewSubscriptions", ctx, companyUuid)
+	ret0, _ := ret[0].([]entities.CompanySubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceReviewSubscriptions indicates an expected call of GetServiceReviewSubscriptions.
+func (mr *MockClientMockRecorder) GetServiceReviewSubscriptions(ctx, companyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceReviewSubscriptions", reflect.TypeOf((*MockClient)(nil).GetServiceReviewSubscriptions), ctx, companyUuid)
+}
+
+// GetSubscriptionByName mocks base method.
+func (m *MockClient) GetSubscriptionByName(ctx context.Context, companyUuid *uuid.UUID, subscriptionName string) (*entities.CompanySubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionByName", ctx, companyUuid, subscriptionName)
+	ret0, _ := ret[0].(*entities.CompanySubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionByName indicates an expected call of GetSubscriptionByName.
+func (mr *MockClientMockRecorder) GetSubscriptionByName(ctx, companyUuid, subscriptionName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionByName", reflect.TypeOf((*MockClient)(nil).GetSubscriptionByName), ctx, companyUuid, subscriptionName)
+}
+
 // GetUserCompaniesByUserUuid mocks base method.
 func (m *MockClient) GetUserCompaniesByUserUuid(ctx context.Context, id *entities.GetCompaniesByUserIdRequest) (*entities.GetCompaniesByUserIdResponse, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,25 @@
+package address
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/address/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/address/service"
+)
+
+type Client interface {
+	GetPrimaryAddress(ctx context.Context, companyUUID *uuid.UUID) (*entities.CompanyAddressW, error)
+}
+
+func NewClient(svc service.Service) Client {
+	return &localClient{svc}
+}
+
+type localClient struct {
+	svc service.Service
+}
+
+func (l *localClient) GetPrimaryAddress(ctx context.Context, companyUUID *uuid.UUID) (*entities.CompanyAddressW, error) {
+	return l.svc.GetPrimaryAddress(ctx, companyUUID)
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
 )
 
+const StatusActive = "Active"
+
 type CompanyAddress struct {
 	CompanyAddressUuid uuid.UUID          `json:"company_address_uuid"`
 	CompanyUuid        uuid.UUID          `json:"company_uuid"`
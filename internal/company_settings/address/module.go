@@ -7,6 +7,7 @@ import (
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_tech_info/wireless"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/onboarding"
 
+	audit "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/address/endpoints"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/address/repository"
@@ -30,21 +31,24 @@ type ModuleParams struct {
 	IpRangesClient   ipranges.Client
 	WirelessClient   wireless.Client
 	OnboardingClient onboarding.Client
+	AuditRecorder    audit.Recorder
 }
 
 // NewModule for redesign.
 // nolint:gocritic
-func NewModule(p ModuleParams) error {
-	repo := repository.New(p.DB, p.GormDB)
+func NewModule(p ModuleParams) (Client, error) {
+	repo := repository.New(p.DB, p.GormDB, p.AuditRecorder)
 	svc := service.New(repo, p.IpRangesClient, p.WirelessClient, p.OnboardingClient)
 	eps := endpoints.New(svc)
 
 	http.RegisterTransport(p.HTTPServer, eps, p.AuthClient, p.APPTransport)
 
-	return nil
+	client := NewClient(svc)
+
+	return client, nil
 }
 
 var (
 	// ModuleHttpAPI for uber fx.
-	ModuleHttpAPI = fx.Options(fx.Invoke(NewModule))
+	ModuleHttpAPI = fx.Options(fx.Provide(NewModule))
 )
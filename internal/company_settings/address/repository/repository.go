@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/google/uuid"
+	audit "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/address/entities"
 	"gorm.io/gorm"
 )
@@ -23,9 +24,9 @@ type Repository interface {
 	GetFacilitiesByAddress(ctx context.Context, addressUuid *uuid.UUID) ([]*CompanyFacility, error)
 }
 
-// New repository for websites.
-func New(db *sql.DB, gormDb *gorm.DB) Repository {
-	repo := &sqlRepository{db, gormDb}
+// New repository for company addresses. Changes to addresses are recorded in the audit log.
+func New(db *sql.DB, gormDb *gorm.DB, auditRecorder audit.Recorder) Repository {
+	repo := &sqlRepository{db, gormDb, auditRecorder}
 
 	return repo
 }
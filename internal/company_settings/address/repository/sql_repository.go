@@ -9,6 +9,8 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
+	auditEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/entities"
+	audit "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/address/entities"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/db"
 	tErrors "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
@@ -25,6 +27,7 @@ const (
 type sqlRepository struct {
 	db     *sql.DB
 	gormDB *gorm.DB
+	audit  audit.Recorder
 }
 
 func (s *sqlRepository) GetFacilitiesByAddress(ctx context.Context, addressUuid *uuid.UUID) ([]*CompanyFacility, error) {
@@ -72,6 +75,11 @@ func (s *sqlRepository) GetFacilities(ctx context.Context, companyUUID *uuid.UUI
 }
 
 func (s *sqlRepository) UpdateCompanyAddressPatch(ctx context.Context, userUUID, addressUuid *uuid.UUID, req *entities.UpdateCompanyAddressPatchRequestBody) error {
+	before, err := s.GetAddressById(ctx, addressUuid)
+	if err != nil {
+		return err
+	}
+
 	result := s.gormDB.WithContext(ctx).Model(&CompanyAddress{}).
 		WithContext(ctx).
 		Where("company_address_uuid = ?", addressUuid).
@@ -98,6 +106,13 @@ func (s *sqlRepository) UpdateCompanyAddressPatch(ctx context.Context, userUUID,
 		return &tErrors.ErrNotFound{Message: "company address not found"}
 	}
 
+	after, err := s.GetAddressById(ctx, addressUuid)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, auditEntities.NewChange("address", after.CompanyAddressUuid.String(), after.CompanyUuid, before, after))
+
 	return nil
 }
 
@@ -317,6 +332,13 @@ func (s *sqlRepository) UpdateAddress(ctx context.Context, address *entities.Com
 		return nil, txCErr
 	}
 
+	after, err := s.GetAddressById(ctx, &address.CompanyAddressUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, auditEntities.NewChange("address", after.CompanyAddressUuid.String(), after.CompanyUuid, oldAddress, after))
+
 	return address, nil
 }
 
@@ -410,6 +432,8 @@ func (s *sqlRepository) CreateAddress(ctx context.Context, address *entities.Com
 		return nil, cErr
 	}
 
+	s.audit.Record(ctx, auditEntities.NewChange("address", address.CompanyAddressUuid.String(), address.CompanyUuid, nil, address))
+
 	return address, nil
 }
 
@@ -520,6 +544,11 @@ func (s *sqlRepository) GetAddresses(ctx context.Context, companyUUID, userUUID
 }
 
 func (s *sqlRepository) DeleteAddress(ctx context.Context, addressUuid *uuid.UUID) (*uuid.UUID, error) {
+	before, err := s.GetAddressById(ctx, addressUuid)
+	if err != nil {
+		return nil, err
+	}
+
 	tx, err := s.db.Begin()
 	if err != nil {
 		return nil, err
@@ -557,6 +586,10 @@ func (s *sqlRepository) DeleteAddress(ctx context.Context, addressUuid *uuid.UUI
 		return &uuid.Nil, err
 	}
 
+	if before != nil {
+		s.audit.Record(ctx, auditEntities.NewChange("address", before.CompanyAddressUuid.String(), before.CompanyUuid, before, nil))
+	}
+
 	return addressUuid, nil
 }
 
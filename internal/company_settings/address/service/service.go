@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/address/entities"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/address/repository"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
 )
 
 type Service interface {
@@ -20,6 +21,8 @@ type Service interface {
 	DeleteAddress(ctx context.Context, companyUuid, userUuid, addressUuid *uuid.UUID) error
 	UpdateCompanyAddressPatch(ctx context.Context, companyUuid, userUUID, addressUuid *uuid.UUID, req *entities.UpdateCompanyAddressPatchRequestBody) (*entities.UpdateCompanyAddressPatchResponse, error)
 	GetFacilities(ctx context.Context, companyUUID, userUUID *uuid.UUID, query, status string) ([]*repository.CompanyFacility, error)
+	// GetPrimaryAddress of the company, the first of its active addresses.
+	GetPrimaryAddress(ctx context.Context, companyUUID *uuid.UUID) (*entities.CompanyAddressW, error)
 }
 
 type service struct {
@@ -114,6 +117,22 @@ func (s *service) GetAddresses(ctx context.Context, companyUUID, userUUID *uuid.
 	return s.repo.GetAddresses(ctx, companyUUID, userUUID)
 }
 
+func (s *service) GetPrimaryAddress(ctx context.Context, companyUUID *uuid.UUID) (*entities.CompanyAddressW, error) {
+	addresses, err := s.repo.GetAddresses(ctx, companyUUID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// addresses are listed latest first
+	for i := len(addresses) - 1; i >= 0; i-- {
+		if addresses[i].Status == entities.StatusActive {
+			return addresses[i], nil
+		}
+	}
+
+	return nil, &appError.ErrNotFound{Message: "company address not found"}
+}
+
 func New(repo repository.Repository, ipRangesClient ipranges.Client, wirelessClient wireless.Client, onboardingClient onboarding.Client) Service {
 	return &service{
 		repo:             repo,
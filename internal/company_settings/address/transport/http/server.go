@@ -5,6 +5,7 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/address/endpoints"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
@@ -30,7 +31,7 @@ func RegisterTransport(
 func registerGetCompanyAddresses(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/addresses"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", permissions.ActionRead)
 	handler := getHandler(securedEp, decodeGetCompanyAddressesRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -40,7 +41,7 @@ func registerGetCompanyAddresses(server *httpTransport.Server, ep goKitEndpoint.
 func registerCreateCompanyAddress(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/address"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", permissions.ActionCreate)
 	handler := getHandler(securedEp, decodeCreateCompanyAddressRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -50,7 +51,7 @@ func registerCreateCompanyAddress(server *httpTransport.Server, ep goKitEndpoint
 func registerUpdateCompanyAddress(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/address/{address_id}"
 	method := "PUT"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", permissions.ActionUpdate)
 	handler := getHandler(securedEp, decodeUpdateCompanyAddressRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -60,7 +61,7 @@ func registerUpdateCompanyAddress(server *httpTransport.Server, ep goKitEndpoint
 func registerDeleteCompanyAddress(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/address/{address_id}"
 	method := "DELETE"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", permissions.ActionDelete)
 	handler := getHandler(securedEp, decodeDeleteCompanyAddressRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -70,7 +71,7 @@ func registerDeleteCompanyAddress(server *httpTransport.Server, ep goKitEndpoint
 func registerUpdateCompanyAddressPatch(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/address/{address_id}"
 	method := "PATCH"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", permissions.ActionUpdate)
 	handler := getHandler(securedEp, decodeUpdateCompanyAddressPatchRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -80,7 +81,7 @@ func registerUpdateCompanyAddressPatch(server *httpTransport.Server, ep goKitEnd
 func registerGetCompanyFacilities(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/facilities"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", permissions.ActionRead)
 	handler := getHandler(securedEp, decodeGetCompanyFacilitiesRequest, atc, method)
 
 	server.Handle(method, path, handler)
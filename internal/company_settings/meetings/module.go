@@ -12,6 +12,7 @@ import (
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/onboarding"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/webhook"
 	"go.uber.org/fx"
 	"gorm.io/gorm"
 )
@@ -27,6 +28,7 @@ type ModuleParams struct {
 	AuthClient       auth.Client
 	CalendlyClient   calendly.Client
 	OnboardingClient onboarding.Client
+	WebhookConfig    webhook.Config
 }
 
 // NewModule for redesign.
@@ -36,7 +38,7 @@ func NewModule(p ModuleParams) error {
 	svc := service.New(repo, p.CalendlyClient, p.OnboardingClient)
 	eps := endpoints.New(svc)
 
-	http.RegisterTransport(p.HTTPServer, eps, p.AuthClient, p.APPTransport)
+	http.RegisterTransport(p.HTTPServer, eps, p.AuthClient, p.APPTransport, p.WebhookConfig)
 
 	return nil
 }
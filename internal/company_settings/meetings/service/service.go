@@ -3,7 +3,6 @@ package service
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strings"
 
@@ -13,6 +12,7 @@ import (
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/meetings/repository"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/onboarding"
 	onboardingEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/onboarding/entities"
+	appErr "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
 	"github.com/opentracing/opentracing-go/log"
 )
@@ -78,57 +78,38 @@ func (s *service) CreateMeetingFromCalendly(ctx context.Context) error {
 }
 
 func (s *service) CalendlyWebhook(ctx context.Context, data *entities.WebhookData) error {
-	utmContent := data.Payload.Tracking.UtmContent
-	if strings.TrimSpace(utmContent) == "" {
-		return errors.New("utm_content is not set. please set utm_content ex: company_uuid:<company_uuid>;user_uuid:<user_uuid>")
-	}
-
-	utmContentParts := strings.Split(utmContent, ";")
-	if len(utmContentParts) < 2 {
-		return errors.New("utm_content is not set. please set utm_content ex: company_uuid:<company_uuid>;user_uuid:<user_uuid>")
-	}
-
-	companyUuidParts := strings.Split(utmContentParts[0], ":")
-	if len(companyUuidParts) < 2 {
-		return errors.New("company_uuid is not set properly. please set utm_content properly. ex: company_uuid:<company_uuid>;user_uuid:<user_uuid>")
-	}
-
-	companyUuidStr := companyUuidParts[1]
-	companyUuid, err := uuid.Parse(companyUuidStr)
+	companyUuid, userUuid, err := parseUtmContent(data.Payload.Tracking.UtmContent)
 	if err != nil {
-		return errors.New("invalid company_uuid. uuid parse error")
+		return err
 	}
 
-	userUuidParts := strings.Split(utmContentParts[1], ":")
-	if len(userUuidParts) < 2 {
-		return errors.New("user_uuid is not set properly. please set utm_content properly. ex: company_uuid:<company_uuid>;user_uuid:<user_uuid>")
+	eventId, err := uriID(data.Payload.Event, "scheduled_events/")
+	if err != nil {
+		return err
 	}
 
-	userUuidStr := userUuidParts[1]
-	userUuid, err := uuid.Parse(userUuidStr)
+	eventUuid, err := uuid.Parse(eventId)
 	if err != nil {
-		return errors.New("invalid user_uuid. uuid parse error")
+		return &appErr.ErrValidation{Message: "invalid scheduled event uri"}
 	}
 
-	eventId := strings.Split(data.Payload.Event, "scheduled_events/")[1]
 	event, err := s.calendlyClient.GetScheduledEvent(ctx, eventId)
 	if err != nil {
 		log.Error(err)
 		return err
 	}
 
-	meetingUuidStr := strings.Split(event.EventType, "event_types/")[1]
-	meetingUuid, err := uuid.Parse(meetingUuidStr)
+	meetingUuidStr, err := uriID(event.EventType, "event_types/")
 	if err != nil {
 		return err
 	}
 
-	meeting, err := s.repo.GetMeetingByUuid(ctx, &meetingUuid)
+	meetingUuid, err := uuid.Parse(meetingUuidStr)
 	if err != nil {
 		return err
 	}
 
-	eventUuid, err := uuid.Parse(eventId)
+	meeting, err := s.repo.GetMeetingByUuid(ctx, &meetingUuid)
 	if err != nil {
 		return err
 	}
@@ -156,6 +137,50 @@ func (s *service) CalendlyWebhook(ctx context.Context, data *entities.WebhookDat
 	return nil
 }
 
+// parseUtmContent of the scheduling link, "company_uuid:<company_uuid>;user_uuid:<user_uuid>".
+func parseUtmContent(utmContent string) (companyUuid, userUuid uuid.UUID, err error) {
+	const format = "please set utm_content ex: company_uuid:<company_uuid>;user_uuid:<user_uuid>"
+
+	if strings.TrimSpace(utmContent) == "" {
+		return uuid.Nil, uuid.Nil, &appErr.ErrValidation{Message: "utm_content is not set. " + format}
+	}
+
+	utmContentParts := strings.Split(utmContent, ";")
+	if len(utmContentParts) < 2 {
+		return uuid.Nil, uuid.Nil, &appErr.ErrValidation{Message: "utm_content is not set. " + format}
+	}
+
+	_, companyUuidStr, ok := strings.Cut(utmContentParts[0], ":")
+	if !ok {
+		return uuid.Nil, uuid.Nil, &appErr.ErrValidation{Message: "company_uuid is not set properly. " + format}
+	}
+
+	if companyUuid, err = uuid.Parse(companyUuidStr); err != nil {
+		return uuid.Nil, uuid.Nil, &appErr.ErrValidation{Message: "invalid company_uuid. uuid parse error"}
+	}
+
+	_, userUuidStr, ok := strings.Cut(utmContentParts[1], ":")
+	if !ok {
+		return uuid.Nil, uuid.Nil, &appErr.ErrValidation{Message: "user_uuid is not set properly. " + format}
+	}
+
+	if userUuid, err = uuid.Parse(userUuidStr); err != nil {
+		return uuid.Nil, uuid.Nil, &appErr.ErrValidation{Message: "invalid user_uuid. uuid parse error"}
+	}
+
+	return companyUuid, userUuid, nil
+}
+
+// uriID is the segment of the Calendly uri following the collection, e.g. scheduled_events/.
+func uriID(uri, collection string) (string, error) {
+	_, id, ok := strings.Cut(uri, collection)
+	if !ok || id == "" {
+		return "", &appErr.ErrValidation{Message: fmt.Sprintf("invalid %s uri %q", strings.TrimSuffix(collection, "/"), uri)}
+	}
+
+	return id, nil
+}
+
 func (s *service) GetMeetings(ctx context.Context, companyUUID, userUUID *uuid.UUID) ([]*entities.Meetings, error) {
 	return s.repo.GetMeetings(ctx, companyUUID, userUUID)
 }
@@ -4,30 +4,18 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/meetings/entities"
 	httpError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/webhook"
 	"github.com/pkg/errors"
 )
 
-type RequestBodyType interface {
-	entities.WebhookData
-}
-
-func decodeBodyFromRequest[T RequestBodyType](req *T, r *http.Request) error {
-	err := json.NewDecoder(r.Body).Decode(req)
-	if err != nil {
-		return errors.WithMessage(httpError.ErrBadRequestBody, err.Error())
-	}
-
-	defer r.Body.Close()
-
-	return nil
-}
-
 func decodeGetMeetingsRequest(_ context.Context, r *http.Request) (interface{}, error) {
 	params := mux.Vars(r)
 	compUUID, err := uuid.Parse(params["company_id"])
@@ -68,14 +56,28 @@ func decodeGetCompanyMeetingsRequest(_ context.Context, r *http.Request) (interf
 	return req, nil
 }
 
-func decodeCalendlyWebhookRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	webhookData := &entities.WebhookData{}
-	err := decodeBodyFromRequest(webhookData, r)
-	if err != nil {
-		return nil, err
-	}
+// decodeCalendlyWebhookRequest decodes deliveries signed with the Calendly webhook signing key
+// within the tolerance.
+func decodeCalendlyWebhookRequest(cfg webhook.SigningConfig) func(context.Context, *http.Request) (interface{}, error) {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		defer r.Body.Close()
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.WithMessage(httpError.ErrBadRequestBody, err.Error())
+		}
 
-	return webhookData, nil
+		if err = webhook.VerifyCalendly(cfg, r.Header, body, time.Now()); err != nil {
+			return nil, err
+		}
+
+		webhookData := &entities.WebhookData{}
+		if err = json.Unmarshal(body, webhookData); err != nil {
+			return nil, errors.WithMessage(httpError.ErrBadRequestBody, err.Error())
+		}
+
+		return webhookData, nil
+	}
 }
 
 func decodeCreateMeetingFromCalendlysRequest(ctx context.Context, r *http.Request) (interface{}, error) {
@@ -5,10 +5,13 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/meetings/endpoints"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/jwt"
 	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/webhook"
 )
 
 // RegisterTransport for http.
@@ -17,17 +20,18 @@ func RegisterTransport(
 	ep *endpoints.Endpoints,
 	authClient auth.Client,
 	svcTransportClient svcTransport.Client,
+	webhookConfig webhook.Config,
 ) {
 	registerGetMeetings(server, ep.GetMeetingsEndpoint, authClient, svcTransportClient)
 	registerGetCompanyMeetings(server, ep.GetCompanyMeetingsEndpoint, authClient, svcTransportClient)
-	registerCalendlyWebhook(server, ep.CalendlyWebhookEndpoint, authClient, svcTransportClient)
+	registerCalendlyWebhook(server, ep.CalendlyWebhookEndpoint, webhookConfig.Calendly, svcTransportClient)
 	registerCreateMeetingFromCalendly(server, ep.CreateMeetingFromCalendlyEndpoint, authClient, svcTransportClient)
 }
 
 func registerGetMeetings(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/meetings"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", permissions.ActionRead)
 	handler := getHandler(securedEp, decodeGetMeetingsRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -37,17 +41,17 @@ func registerGetMeetings(server *httpTransport.Server, ep goKitEndpoint.Endpoint
 func registerGetCompanyMeetings(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/meetings"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", permissions.ActionRead)
 	handler := getHandler(securedEp, decodeGetCompanyMeetingsRequest, atc, method)
 
 	server.Handle(method, path, handler)
 	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
 }
 
-func registerCalendlyWebhook(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+func registerCalendlyWebhook(server *httpTransport.Server, ep goKitEndpoint.Endpoint, cfg webhook.SigningConfig, atc svcTransport.Client) {
 	path := "/companies/settings/meetings/webhook"
 	method := "POST"
-	handler := getHandler(ep, decodeCalendlyWebhookRequest, atc, method)
+	handler := getHandler(ep, decodeCalendlyWebhookRequest(cfg), atc, method)
 
 	server.Handle(method, path, handler)
 	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
@@ -56,7 +60,7 @@ func registerCalendlyWebhook(server *httpTransport.Server, ep goKitEndpoint.Endp
 func registerCreateMeetingFromCalendly(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/internal/meetings/create"
 	method := "GET"
-	handler := getHandler(authClient.SecureServiceWithRedesignEndpoint(ep), decodeCreateMeetingFromCalendlysRequest, atc, method)
+	handler := getHandler(authClient.SecureServiceWithRedesignWebhookEndpoint(ep, jwt.AudienceInternalMeetings), decodeCreateMeetingFromCalendlysRequest, atc, method)
 
 	server.Handle(method, path, handler)
 	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
@@ -15,6 +15,7 @@ import (
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/userclient"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/cfg"
 	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/webhook"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -35,6 +36,7 @@ type ModuleParams struct {
 	SalesforceClient salesforce.Client
 	CommonConfig     cfg.Config
 	Logger           *zap.SugaredLogger
+	WebhookConfig    webhook.Config
 }
 
 // NewModule for redesign.
@@ -44,7 +46,7 @@ func NewModule(p ModuleParams) error {
 	svc := service.New(repo, p.UserClient, p.CompanyClient, p.OnboardingClient, p.SalesforceClient, p.CommonConfig, p.Logger)
 	eps := endpoints.New(svc)
 
-	http.RegisterTransport(p.HTTPServer, eps, p.AuthClient, p.APPTransport)
+	http.RegisterTransport(p.HTTPServer, eps, p.AuthClient, p.APPTransport, p.WebhookConfig)
 
 	return nil
 }
@@ -49,26 +49,43 @@ type service struct {
 
 func (s *service) Webhook(ctx context.Context, data *entities.DocusignWebhookData) error {
 	if data.Event == "envelope-completed" {
-		var companyUUID, signatureUUID, userUUID uuid.UUID
-		var name, env string
+		fields := map[string]string{}
 		for _, t := range data.Data.EnvelopeSummary.CustomFields.TextCustomFields {
-			switch t.Name {
-			case "CompanyUUID":
-				companyUUID = uuid.MustParse(t.Value)
-			case "SignatureUUID":
-				signatureUUID = uuid.MustParse(t.Value)
-			case "UserUUID":
-				userUUID = uuid.MustParse(t.Value)
-			case "E":
-				env = t.Value
-			}
+			fields[t.Name] = t.Value
 		}
 
 		// return if the environment not matched with running environment
-		if env != s.commonConfig.Env {
+		if fields["E"] != s.commonConfig.Env {
 			return nil
 		}
 
+		companyUUID, err := parseCustomField(fields, "CompanyUUID")
+		if err != nil {
+			return err
+		}
+
+		signatureUUID, err := parseCustomField(fields, "SignatureUUID")
+		if err != nil {
+			return err
+		}
+
+		userUUID, err := parseCustomField(fields, "UserUUID")
+		if err != nil {
+			return err
+		}
+
+		// the envelope is signed for a company of ours only.
+		company, err := s.companyClient.FindByUUID(ctx, &companyEntities.GetCompanyByIdRequest{CompanyUuid: companyUUID})
+		if err != nil {
+			return err
+		}
+
+		if company == nil {
+			return &appErr.ErrValidation{Message: "unknown company in CompanyUUID custom field"}
+		}
+
+		var name string
+
 		for _, e := range data.Data.EnvelopeSummary.EnvelopeDocuments {
 			// the documentID is created by the docusign for the document which needs to be signed.
 			// Since we expect to add only one document to the docusign template,
@@ -79,7 +96,7 @@ func (s *service) Webhook(ctx context.Context, data *entities.DocusignWebhookDat
 			}
 		}
 
-		_, err := s.repo.CreateSignatures(ctx, &entities.CompanySignatures{
+		_, err = s.repo.CreateSignatures(ctx, &entities.CompanySignatures{
 			SignatureUuid: signatureUUID,
 			CompanyUuid:   companyUUID,
 			Name:          name,
@@ -98,6 +115,16 @@ func (s *service) Webhook(ctx context.Context, data *entities.DocusignWebhookDat
 	return nil
 }
 
+// parseCustomField of the envelope as a uuid.
+func parseCustomField(fields map[string]string, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(fields[name])
+	if err != nil || id == uuid.Nil {
+		return uuid.Nil, &appErr.ErrValidation{Message: fmt.Sprintf("invalid %s custom field", name)}
+	}
+
+	return id, nil
+}
+
 func (s *service) ViewDocument(ctx context.Context, companyUUID, signatureUuid *uuid.UUID) (string, error) {
 	cs, err := s.repo.GetCompanySignature(ctx, companyUUID, signatureUuid)
 	if err != nil {
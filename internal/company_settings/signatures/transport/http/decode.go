@@ -4,17 +4,20 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/signatures/entities"
 	httpError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/webhook"
 	"github.com/pkg/errors"
 )
 
 type RequestBodyType interface {
-	entities.UpdateStatusRequestBody | entities.DocusignWebhookData
+	entities.UpdateStatusRequestBody
 }
 
 func decodeBodyFromRequest[T RequestBodyType](req *T, r *http.Request) error {
@@ -97,12 +100,30 @@ func decodeViewDocumentRequest(_ context.Context, r *http.Request) (interface{},
 	}, nil
 }
 
-func decodeWebhookRequest(_ context.Context, r *http.Request) (interface{}, error) {
-	webhookData := &entities.DocusignWebhookData{}
-	err := decodeBodyFromRequest(webhookData, r)
-	if err != nil {
-		return nil, err
-	}
+// decodeWebhookRequest of DocuSign Connect. Only signed deliveries generated within the
+// tolerance are decoded.
+func decodeWebhookRequest(cfg webhook.SigningConfig) func(context.Context, *http.Request) (interface{}, error) {
+	return func(_ context.Context, r *http.Request) (interface{}, error) {
+		defer r.Body.Close()
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, errors.WithMessage(httpError.ErrBadRequestBody, err.Error())
+		}
 
-	return webhookData, nil
+		if err = webhook.VerifyDocuSign(cfg, r.Header, body); err != nil {
+			return nil, err
+		}
+
+		webhookData := &entities.DocusignWebhookData{}
+		if err = json.Unmarshal(body, webhookData); err != nil {
+			return nil, errors.WithMessage(httpError.ErrBadRequestBody, err.Error())
+		}
+
+		if err = webhook.CheckTimestamp(cfg, webhookData.GeneratedDateTime, time.Now()); err != nil {
+			return nil, err
+		}
+
+		return webhookData, nil
+	}
 }
@@ -5,10 +5,12 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/signatures/endpoints"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
 	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/webhook"
 )
 
 // RegisterTransport for http.
@@ -17,17 +19,18 @@ func RegisterTransport(
 	ep *endpoints.Endpoints,
 	authClient auth.Client,
 	svcTransportClient svcTransport.Client,
+	webhookConfig webhook.Config,
 ) {
 	registerGetAddresses(server, ep.GetSignaturesEndpoint, authClient, svcTransportClient)
 	registerUpdateStatus(server, ep.UpdateStatusEndpoint, authClient, svcTransportClient)
 	registerViewDocument(server, ep.ViewSignaturesDocumentEndpoint, authClient, svcTransportClient)
-	registerWebhook(server, ep.WebhookEndpoint, authClient, svcTransportClient)
+	registerWebhook(server, ep.WebhookEndpoint, webhookConfig.DocuSign, svcTransportClient)
 }
 
 func registerGetAddresses(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/signatures"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", permissions.ActionRead)
 	handler := getHandler(securedEp, decodeGetSignaturesRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -37,7 +40,7 @@ func registerGetAddresses(server *httpTransport.Server, ep goKitEndpoint.Endpoin
 func registerUpdateStatus(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/signatures/{company_signature_uuid}"
 	method := "PATCH"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", permissions.ActionUpdate)
 	handler := getHandler(securedEp, decodeUpdateStatusRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -60,10 +63,10 @@ func registerViewDocument(server *httpTransport.Server, ep goKitEndpoint.Endpoin
 	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
 }
 
-func registerWebhook(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+func registerWebhook(server *httpTransport.Server, ep goKitEndpoint.Endpoint, cfg webhook.SigningConfig, atc svcTransport.Client) {
 	path := "/docusign/webhook"
 	method := "POST"
-	handler := getHandler(ep, decodeWebhookRequest, atc, method)
+	handler := getHandler(ep, decodeWebhookRequest(cfg), atc, method)
 
 	server.Handle(method, path, handler)
 	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
@@ -5,6 +5,7 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/websites/endpoints"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
@@ -27,7 +28,7 @@ func RegisterTransport(
 func registerCreateWebsite(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/website"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", permissions.ActionCreate)
 	handler := getHandler(securedEp, decodeCreateWebsiteRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -37,7 +38,7 @@ func registerCreateWebsite(server *httpTransport.Server, ep goKitEndpoint.Endpoi
 func registerGetAllWebsites(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/website"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", permissions.ActionRead)
 	handler := getHandler(securedEp, decodeGetAllWebsitesRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -47,7 +48,7 @@ func registerGetAllWebsites(server *httpTransport.Server, ep goKitEndpoint.Endpo
 func registerUpdateWebsite(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/website/{website_id}"
 	method := "PUT"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", permissions.ActionUpdate)
 	handler := getHandler(securedEp, decodeUpdateWebsiteRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -57,7 +58,7 @@ func registerUpdateWebsite(server *httpTransport.Server, ep goKitEndpoint.Endpoi
 func registerDeleteWebsite(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/website/{website_id}"
 	method := "DELETE"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "company-settings", permissions.ActionDelete)
 	handler := getHandler(securedEp, decodeDeleteWebsiteRequest, atc, method)
 
 	server.Handle(method, path, handler)
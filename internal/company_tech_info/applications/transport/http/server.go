@@ -5,6 +5,7 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_tech_info/applications/endpoints"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
@@ -33,7 +34,7 @@ func RegisterTransport(
 func registerCreateApplication(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/application"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionCreate)
 	handler := getHandler(securedEp, decodeCreateApplicationRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -43,7 +44,7 @@ func registerCreateApplication(server *httpTransport.Server, ep goKitEndpoint.En
 func registerGetAllApplications(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/applications"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionRead)
 	handler := getHandler(securedEp, decodeGetAllApplicationsRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -53,7 +54,7 @@ func registerGetAllApplications(server *httpTransport.Server, ep goKitEndpoint.E
 func registerUpdateApplication(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/application/{application_id}"
 	method := "PUT"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionUpdate)
 	handler := getHandler(securedEp, decodeUpdateApplicationRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -63,7 +64,7 @@ func registerUpdateApplication(server *httpTransport.Server, ep goKitEndpoint.En
 func registerUpdateApplicationPatch(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/application/{application_id}"
 	method := "PATCH"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionUpdate)
 	handler := getHandler(securedEp, decodeUpdateApplicationPatchRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -73,7 +74,7 @@ func registerUpdateApplicationPatch(server *httpTransport.Server, ep goKitEndpoi
 func registerDeleteApplication(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/application/{application_id}"
 	method := "DELETE"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionDelete)
 	handler := getHandler(securedEp, decodeDeleteApplicationRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -83,7 +84,7 @@ func registerDeleteApplication(server *httpTransport.Server, ep goKitEndpoint.En
 func registerCreateApplicationEnv(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/application/{application_id}/env"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionCreate)
 	handler := getHandler(securedEp, decodeCreateApplicationEnvRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -93,7 +94,7 @@ func registerCreateApplicationEnv(server *httpTransport.Server, ep goKitEndpoint
 func registerUpdateApplicationEnv(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/application/{application_id}/env/{env_id}"
 	method := "PUT"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionUpdate)
 	handler := getHandler(securedEp, decodeUpdateApplicationEnvRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -103,7 +104,7 @@ func registerUpdateApplicationEnv(server *httpTransport.Server, ep goKitEndpoint
 func registerUpdateApplicationEnvPatch(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/application/{application_id}/env/{env_id}"
 	method := "PATCH"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionUpdate)
 	handler := getHandler(securedEp, decodeUpdateApplicationEnvPatchRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -113,7 +114,7 @@ func registerUpdateApplicationEnvPatch(server *httpTransport.Server, ep goKitEnd
 func registerDeleteApplicationEnv(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/application/{application_id}/env/{env_id}"
 	method := "DELETE"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionDelete)
 	handler := getHandler(securedEp, decodeDeleteApplicationEnvRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -5,6 +5,7 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_tech_info/externalinfra/endpoints"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
@@ -27,7 +28,7 @@ func RegisterTransport(
 func registerCreateTechInfoExternalInfra(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/external/infra"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionCreate)
 	handler := getHandler(securedEp, decodeCreateTechInfoExternalInfraRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -37,7 +38,7 @@ func registerCreateTechInfoExternalInfra(server *httpTransport.Server, ep goKitE
 func registerGetAllTechInfoExternalInfras(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/external/infra"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionRead)
 	handler := getHandler(securedEp, decodeGetAllTechInfoExternalInfrasRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -47,7 +48,7 @@ func registerGetAllTechInfoExternalInfras(server *httpTransport.Server, ep goKit
 func registerUpdateTechInfoExternalInfra(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/external/infra/{external_infra_id}"
 	method := "PUT"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionUpdate)
 	handler := getHandler(securedEp, decodeUpdateTechInfoExternalInfraRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -57,7 +58,7 @@ func registerUpdateTechInfoExternalInfra(server *httpTransport.Server, ep goKitE
 func registerDeleteTechInfoExternalInfra(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/external/infra/{external_infra_id}"
 	method := "DELETE"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionDelete)
 	handler := getHandler(securedEp, decodeDeleteTechInfoExternalInfraRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -1,6 +1,7 @@
 package ipranges //nolint: predeclared
 
 import (
+	audit "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_tech_info/ipranges/endpoints"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_tech_info/ipranges/repository"
@@ -24,12 +25,13 @@ type ModuleParams struct {
 	AuthClient       auth.Client
 	OnboardingClient onboarding.Client
 	JiraClient       jira.Client
+	AuditRecorder    audit.Recorder
 }
 
 // NewModule for redesign.
 // nolint:gocritic
 func NewModule(p ModuleParams) (Client, error) {
-	repo := repository.New(p.DB)
+	repo := repository.New(p.DB, p.AuditRecorder)
 	svc := service.New(repo, p.OnboardingClient, p.JiraClient)
 	eps := endpoints.New(svc)
 
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	audit "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_tech_info/ipranges/entities"
 	"gorm.io/gorm"
 )
@@ -19,9 +20,9 @@ type Repository interface {
 	GetTechInfoIpRangeByUuid(ctx context.Context, ipRangeUuid *uuid.UUID) (*entities.TechInfoIpRanges, error)
 }
 
-// New repository for tech_info_applications.
-func New(db *gorm.DB) Repository {
-	repo := &sqlRepository{db}
+// New repository for tech_info_ip_ranges. Changes to ip ranges are recorded in the audit log.
+func New(db *gorm.DB, auditRecorder audit.Recorder) Repository {
+	repo := &sqlRepository{db, auditRecorder}
 
 	return repo
 }
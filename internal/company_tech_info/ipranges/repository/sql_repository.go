@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	auditEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/entities"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_tech_info/ipranges/entities"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/db"
 	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
@@ -12,11 +13,13 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/google/uuid"
+	audit "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
 	"gorm.io/gorm"
 )
 
 type sqlRepository struct {
 	gormDB *gorm.DB
+	audit  audit.Recorder
 }
 
 func (s *sqlRepository) UpdateTechInfoIpRangeStatusByFacilities(ctx context.Context, userUUID *uuid.UUID, facilityUuids []uuid.UUID, status string) error {
@@ -44,6 +47,15 @@ func (s *sqlRepository) UpdateTechInfoIpRangeStatusByFacilities(ctx context.Cont
 }
 
 func (s *sqlRepository) DeleteTechInfoIpRange(ctx context.Context, ipRangeUuid *uuid.UUID) (*uuid.UUID, error) {
+	before, err := s.GetTechInfoIpRangeByUuid(ctx, ipRangeUuid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &appError.ErrNotFound{Message: "ip range not found"}
+		}
+
+		return nil, err
+	}
+
 	result := s.gormDB.WithContext(ctx).
 		Delete(&entities.TechInfoIpRanges{}, "tech_info_ip_range_uuid = ?", ipRangeUuid)
 	if result.Error != nil {
@@ -54,10 +66,21 @@ func (s *sqlRepository) DeleteTechInfoIpRange(ctx context.Context, ipRangeUuid *
 		return nil, &appError.ErrNotFound{Message: "ip range not found"}
 	}
 
+	s.audit.Record(ctx, auditEntities.NewChange("ip_range", before.TechInfoIpRangeUuid.String(), before.CompanyUuid, before, nil))
+
 	return ipRangeUuid, nil
 }
 
 func (s *sqlRepository) UpdateTechInfoIpRangePatch(ctx context.Context, userUUID, ipRangeUuid *uuid.UUID, req *entities.UpdateTechInfoIpRangePatchRequestBody) error {
+	before, err := s.GetTechInfoIpRangeByUuid(ctx, ipRangeUuid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &appError.ErrNotFound{Message: "ip range not found"}
+		}
+
+		return err
+	}
+
 	result := s.gormDB.WithContext(ctx).Model(&entities.TechInfoIpRanges{}).
 		WithContext(ctx).
 		Where("tech_info_ip_range_uuid = ?", ipRangeUuid).
@@ -78,10 +101,26 @@ func (s *sqlRepository) UpdateTechInfoIpRangePatch(ctx context.Context, userUUID
 		return result.Error
 	}
 
+	after, err := s.GetTechInfoIpRangeByUuid(ctx, ipRangeUuid)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, auditEntities.NewChange("ip_range", after.TechInfoIpRangeUuid.String(), after.CompanyUuid, before, after))
+
 	return nil
 }
 
 func (s *sqlRepository) UpdateTechInfoIpRange(ctx context.Context, ipRange *entities.TechInfoIpRanges) (*entities.TechInfoIpRanges, error) {
+	before, err := s.GetTechInfoIpRangeByUuid(ctx, &ipRange.TechInfoIpRangeUuid)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &appError.ErrNotFound{Message: "ip range not found"}
+		}
+
+		return nil, err
+	}
+
 	ipRange.UpdatedAt = nullable.NewNullTime(time.Now())
 
 	updateData := map[string]interface{}{
@@ -119,7 +158,14 @@ func (s *sqlRepository) UpdateTechInfoIpRange(ctx context.Context, ipRange *enti
 		return nil, &appError.ErrNotFound{Message: "ip range not found"}
 	}
 
-	return s.GetTechInfoIpRangeByUuid(ctx, &ipRange.TechInfoIpRangeUuid)
+	after, err := s.GetTechInfoIpRangeByUuid(ctx, &ipRange.TechInfoIpRangeUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, auditEntities.NewChange("ip_range", after.TechInfoIpRangeUuid.String(), after.CompanyUuid, before, after))
+
+	return after, nil
 }
 
 func (s *sqlRepository) CreateTechInfoIpRange(ctx context.Context, ipRange *entities.TechInfoIpRanges) (*entities.TechInfoIpRanges, error) {
@@ -149,7 +195,14 @@ func (s *sqlRepository) CreateTechInfoIpRange(ctx context.Context, ipRange *enti
 		return nil, err
 	}
 
-	return s.GetTechInfoIpRangeByUuid(ctx, &ipRange.TechInfoIpRangeUuid)
+	created, err := s.GetTechInfoIpRangeByUuid(ctx, &ipRange.TechInfoIpRangeUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, auditEntities.NewChange("ip_range", created.TechInfoIpRangeUuid.String(), created.CompanyUuid, nil, created))
+
+	return created, nil
 }
 
 func (s *sqlRepository) GetAllTechInfoIpRange(ctx context.Context, companyUuid *uuid.UUID) ([]*entities.TechInfoIpRanges, error) {
@@ -5,6 +5,7 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_tech_info/ipranges/endpoints"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
@@ -28,7 +29,7 @@ func RegisterTransport(
 func registerGetAllTechInfoIpRange(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/ips"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionRead)
 	handler := getHandler(securedEp, decodeGetAllTechInfoIpRangeRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -38,7 +39,7 @@ func registerGetAllTechInfoIpRange(server *httpTransport.Server, ep goKitEndpoin
 func registerCreateTechInfoIpRange(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/ip"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionCreate)
 	handler := getHandler(securedEp, decodeCreateTechInfoIpRangeRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -48,7 +49,7 @@ func registerCreateTechInfoIpRange(server *httpTransport.Server, ep goKitEndpoin
 func registerUpdateTechInfoIpRange(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/ip/{ip_range_id}"
 	method := "PUT"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionUpdate)
 	handler := getHandler(securedEp, decodeUpdateTechInfoIpRangeRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -58,7 +59,7 @@ func registerUpdateTechInfoIpRange(server *httpTransport.Server, ep goKitEndpoin
 func registerUpdateTechInfoIpRangePatch(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/ip/{ip_range_id}"
 	method := "PATCH"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionUpdate)
 	handler := getHandler(securedEp, decodeUpdateTechInfoIpRangePatchRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -68,7 +69,7 @@ func registerUpdateTechInfoIpRangePatch(server *httpTransport.Server, ep goKitEn
 func registerDeleteTechInfoIpRange(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/ip/{ip_range_id}"
 	method := "DELETE"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionDelete)
 	handler := getHandler(securedEp, decodeDeleteTechInfoIpRangeRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -5,6 +5,7 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_tech_info/wireless/endpoints"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
@@ -28,7 +29,7 @@ func RegisterTransport(
 func registerCreateTechInfoWireless(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/wireless"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionCreate)
 	handler := getHandler(securedEp, decodeCreateTechInfoWirelessRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -38,7 +39,7 @@ func registerCreateTechInfoWireless(server *httpTransport.Server, ep goKitEndpoi
 func registerGetAllTechInfoWirelesss(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/wireless"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionRead)
 	handler := getHandler(securedEp, decodeGetAllTechInfoWirelesssRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -48,7 +49,7 @@ func registerGetAllTechInfoWirelesss(server *httpTransport.Server, ep goKitEndpo
 func registerUpdateTechInfoWireless(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/wireless/{wireless_id}"
 	method := "PUT"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionUpdate)
 	handler := getHandler(securedEp, decodeUpdateTechInfoWirelessRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -58,7 +59,7 @@ func registerUpdateTechInfoWireless(server *httpTransport.Server, ep goKitEndpoi
 func registerDeleteTechInfoWireless(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/wireless/{wireless_id}"
 	method := "DELETE"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionDelete)
 	handler := getHandler(securedEp, decodeDeleteTechInfoWirelessRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -68,7 +69,7 @@ func registerDeleteTechInfoWireless(server *httpTransport.Server, ep goKitEndpoi
 func registerUpdateTechInfoWirelessPatch(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/assessments/wireless/{wireless_id}"
 	method := "PATCH"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "tech-info", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "tech-info", permissions.ActionUpdate)
 	handler := getHandler(securedEp, decodeUpdateTechInfoWirelessPatchRequest, atc, method)
 
 	server.Handle(method, path, handler)
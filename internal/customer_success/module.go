@@ -1,6 +1,7 @@
 package customer_success
 
 import (
+	audit "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
 	s3client "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/s3"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company"
@@ -30,12 +31,13 @@ type ModuleParams struct {
 	SFConfig         config.Config
 	S3client         s3client.Client
 	JiraClient       jira.Client
+	AuditRecorder    audit.Recorder
 }
 
 // NewModule for redesign.
 // nolint:gocritic
 func NewModule(p ModuleParams) error {
-	repo := repository.New(p.GormDB)
+	repo := repository.New(p.GormDB, p.AuditRecorder)
 	svc := service.New(repo, p.JiraClient, p.SalesforceClient, p.CompanyClient, p.S3client, p.SFConfig)
 	eps := endpoints.New(svc)
 
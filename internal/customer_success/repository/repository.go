@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	audit "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
 	companyEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company/entities"
 	"gorm.io/gorm"
 )
@@ -17,9 +18,9 @@ type Repository interface {
 	UpdateEvidence(ctx context.Context, evidenceUuid *uuid.UUID, updateData map[string]interface{}) error
 }
 
-// New repository for company.
-func New(gormdb *gorm.DB) Repository {
-	repo := &sqlRepository{gormdb}
+// New repository for company. Evidence updates, such as acknowledgments, are recorded in the audit log.
+func New(gormdb *gorm.DB, auditRecorder audit.Recorder) Repository {
+	repo := &sqlRepository{gormdb, auditRecorder}
 
 	return repo
 }
@@ -6,6 +6,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
+	auditEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/entities"
+	audit "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
 	companyEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company/entities"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
@@ -13,9 +15,15 @@ import (
 
 type sqlRepository struct {
 	gormDB *gorm.DB
+	audit  audit.Recorder
 }
 
 func (r *sqlRepository) UpdateEvidence(ctx context.Context, evidenceUuid *uuid.UUID, updateData map[string]interface{}) error {
+	before, err := r.GetEvidenceByEvidenceId(ctx, evidenceUuid)
+	if err != nil {
+		return err
+	}
+
 	result := r.gormDB.WithContext(ctx).
 		Model(&companyEntities.ServiceEvidence{}).
 		Where("service_evidences_uuid = ? ", evidenceUuid).
@@ -24,6 +32,19 @@ func (r *sqlRepository) UpdateEvidence(ctx context.Context, evidenceUuid *uuid.U
 		return result.Error
 	}
 
+	after, err := r.GetEvidenceByEvidenceId(ctx, evidenceUuid)
+	if err != nil {
+		return err
+	}
+
+	r.audit.Record(ctx, &auditEntities.Change{
+		EntityType: "service_evidence",
+		EntityID:   evidenceUuid.String(),
+		Action:     auditEntities.ActionUpdate,
+		Before:     before,
+		After:      after,
+	})
+
 	return nil
 }
 
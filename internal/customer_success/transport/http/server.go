@@ -4,6 +4,7 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/customer_success/endpoints"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
@@ -36,7 +37,7 @@ func RegisterTransport(
 func registerGetSubscriptions(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/subscriptions"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetSubscriptionsRequest, encoder, atc, method)
 
@@ -47,7 +48,7 @@ func registerGetSubscriptions(server *httpTransport.Server, ep goKitEndpoint.End
 func registerGetSubscriptionPlans(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/subscriptions/plan"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetSubscriptionsPlansRequest, encoder, atc, method)
 
@@ -58,7 +59,7 @@ func registerGetSubscriptionPlans(server *httpTransport.Server, ep goKitEndpoint
 func registerUploadReports(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/subscriptions/{service_name}/reports"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", permissions.ActionCreate)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeUploadReportsRequest, encoder, atc, method)
 
@@ -69,7 +70,7 @@ func registerUploadReports(server *httpTransport.Server, ep goKitEndpoint.Endpoi
 func registerDownloadServiceReport(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/subscriptions/{service_name}/reports/{report_name}"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", permissions.ActionExport)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.DownloadFileResponse, []string{method})
 	handler := getHandler(securedEp, decodeDownloadReportRequest, encoder, atc, method)
 
@@ -80,7 +81,7 @@ func registerDownloadServiceReport(server *httpTransport.Server, ep goKitEndpoin
 func registerDeleteServiceReport(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/subscriptions/{service_name}/reports/{report_name}"
 	method := "DELETE"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", permissions.ActionDelete)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeDeleteReportRequest, encoder, atc, method)
 
@@ -91,7 +92,7 @@ func registerDeleteServiceReport(server *httpTransport.Server, ep goKitEndpoint.
 func registerGetConsultingHours(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/consulting/hours"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetConsultingHoursRequest, encoder, atc, method)
 
@@ -102,7 +103,7 @@ func registerGetConsultingHours(server *httpTransport.Server, ep goKitEndpoint.E
 func registerGetServiceReview(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/services-review"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetServiceReviewRequest, encoder, atc, method)
 
@@ -113,7 +114,7 @@ func registerGetServiceReview(server *httpTransport.Server, ep goKitEndpoint.End
 func registerUpdateServiceReviewStatus(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/services-review/{evidence_id}/acknowledge"
 	method := "PATCH"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", permissions.ActionApprove)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 
 	handler := getHandler(securedEp, decodeUpdateServiceReviewStatusRequest, encoder, atc, method)
@@ -124,7 +125,7 @@ func registerUpdateServiceReviewStatus(server *httpTransport.Server, ep goKitEnd
 func registerUploadEvidenceReports(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/services-review/{service_id}/evidence/reports"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "customer-success", permissions.ActionCreate)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeUploadEvidencesRequest, encoder, atc, method)
 
@@ -146,7 +147,7 @@ func registerDownloadEvidenceReport(server *httpTransport.Server, ep goKitEndpoi
 func registerAddEvidenceReports(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/services-review/{service_id}/evidence/{evidence_id}/reports"
 	method := "PUT"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "customer-success", permissions.ActionUpdate)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeAddEvidenceReportsRequest, encoder, atc, method)
 
@@ -157,7 +158,7 @@ func registerAddEvidenceReports(server *httpTransport.Server, ep goKitEndpoint.E
 func registerDeleteServiceEvidenceReport(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/services-review/{service_id}/evidence/{evidence_id}/reports/{report_id}"
 	method := "DELETE"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", permissions.ActionDelete)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeDeleteServiceEvidenceReportRequest, encoder, atc, method)
 
@@ -168,7 +169,7 @@ func registerDeleteServiceEvidenceReport(server *httpTransport.Server, ep goKitE
 func registerGetConsumedHours(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/consulting/consumed-hour"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "customer-success", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetConsumedHourRequest, encoder, atc, method)
 
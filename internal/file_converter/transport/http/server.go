@@ -5,6 +5,7 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/file_converter/endpoints"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
@@ -25,7 +26,7 @@ func RegisterTransport(
 func registerDocx2Html(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/converter/docx2html"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionCreate)
 	handler := getHandler(securedEp, decodeDocx2Html, atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method}), atc, method)
 
 	server.Handle(method, path, handler)
@@ -35,7 +36,7 @@ func registerDocx2Html(server *httpTransport.Server, ep goKitEndpoint.Endpoint,
 func registerHtml2Docx(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/converter/html2docx"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionCreate)
 	handler := getHandler(securedEp, decodeHtml2Docx, encodeHtml2DocxResponse, atc, method)
 
 	server.Handle(method, path, handler)
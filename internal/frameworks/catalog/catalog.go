@@ -0,0 +1,36 @@
+// Package catalog reads the controls of a framework from NIST OSCAL catalogs and CSV files.
+package catalog
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+)
+
+// Parse the catalog in the format. The framework overrides the name a catalog may carry.
+func Parse(r io.Reader, format, framework string) (*entities.Catalog, error) {
+	switch format {
+	case entities.CatalogFormatOSCAL:
+		return ParseOSCAL(r, framework)
+	case entities.CatalogFormatCSV:
+		return ParseCSV(r, framework)
+	default:
+		return nil, &appError.ErrValidation{Message: fmt.Sprintf("unknown catalog format %q", format)}
+	}
+}
+
+// FormatOf the catalog file by its extension, empty when unknown.
+func FormatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return entities.CatalogFormatOSCAL
+	case ".csv":
+		return entities.CatalogFormatCSV
+	default:
+		return ""
+	}
+}
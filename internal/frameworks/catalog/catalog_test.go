@@ -0,0 +1,183 @@
+package catalog
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const nistCatalog = `{
+  "catalog": {
+    "uuid": "74c8ba1e-5cd4-4ad1-bbfd-d888e2f6c724",
+    "metadata": {"title": "NIST Special Publication 800-53 Revision 5"},
+    "groups": [{
+      "id": "ac",
+      "class": "family",
+      "title": "Access Control",
+      "controls": [{
+        "id": "ac-1",
+        "class": "SP800-53",
+        "title": "Policy and Procedures",
+        "params": [{"id": "ac-01_odp.01", "label": "personnel or roles"}],
+        "props": [{"name": "label", "value": "AC-1"}, {"name": "group", "value": "low"}],
+        "parts": [
+          {"id": "ac-1_smt", "name": "statement", "parts": [
+            {"id": "ac-1_smt.a", "name": "item", "props": [{"name": "label", "value": "a."}],
+             "prose": "Develop, document, and disseminate to {{ insert: param, ac-01_odp.01 }}:"}
+          ]},
+          {"id": "ac-1_gdn", "name": "guidance", "prose": "Access control policy and procedures address the controls."}
+        ],
+        "controls": [{
+          "id": "ac-1.1",
+          "title": "Policy Review",
+          "props": [{"name": "label", "value": "AC-1(1)"}]
+        }]
+      }, {
+        "id": "ac-13",
+        "title": "Supervision and Review — Access Control",
+        "props": [{"name": "label", "value": "AC-13"}, {"name": "status", "value": "withdrawn"}]
+      }]
+    }]
+  }
+}`
+
+func TestParseOSCAL(t *testing.T) {
+	Convey("Given an OSCAL catalog", t, func() {
+		Convey("Controls and their enhancements are read with the title of their family as domain", func() {
+			c, err := Parse(strings.NewReader(nistCatalog), entities.CatalogFormatOSCAL, "")
+			So(err, ShouldBeNil)
+			So(c.Framework, ShouldEqual, "NIST Special Publication 800-53 Revision 5")
+			So(c.Controls, ShouldHaveLength, 2)
+
+			So(c.Controls[0], ShouldResemble, &entities.CatalogControl{
+				ControlId:     "ac-1",
+				Name:          "AC-1",
+				Topic:         "Policy and Procedures",
+				Domain:        "Access Control",
+				BestPractices: "a. Develop, document, and disseminate to [Assignment: personnel or roles]:",
+				Solution:      "Access control policy and procedures address the controls.",
+				Groups:        []string{"low"},
+			})
+
+			So(c.Controls[1].ControlId, ShouldEqual, "ac-1.1")
+			So(c.Controls[1].Name, ShouldEqual, "AC-1(1)")
+			So(c.Controls[1].Domain, ShouldEqual, "Access Control")
+		})
+
+		Convey("The framework name overrides the title of the catalog", func() {
+			c, err := ParseOSCAL(strings.NewReader(nistCatalog), "NIST 800-53")
+			So(err, ShouldBeNil)
+			So(c.Framework, ShouldEqual, "NIST 800-53")
+		})
+
+		Convey("Other OSCAL documents are rejected", func() {
+			_, err := ParseOSCAL(strings.NewReader(`{"profile": {}}`), "")
+
+			var validationErr *appError.ErrValidation
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+		})
+	})
+}
+
+func TestParseCSV(t *testing.T) {
+	Convey("Given a CSV catalog", t, func() {
+		Convey("Columns are read by their name in the header row", func() {
+			csv := "groups,control_id,name,topic\n\"IG1; IG2\",1.1,1,Inventory and Control of Enterprise Assets\n"
+
+			c, err := Parse(strings.NewReader(csv), entities.CatalogFormatCSV, "CIS")
+			So(err, ShouldBeNil)
+			So(c.Framework, ShouldEqual, "CIS")
+			So(c.Controls, ShouldResemble, []*entities.CatalogControl{{
+				ControlId: "1.1",
+				Name:      "1",
+				Topic:     "Inventory and Control of Enterprise Assets",
+				Groups:    []string{"IG1", "IG2"},
+			}})
+		})
+
+		Convey("Unknown columns and a missing control_id column are rejected", func() {
+			var validationErr *appError.ErrValidation
+
+			_, err := ParseCSV(strings.NewReader("control_id,owner\n1.1,CISO\n"), "CIS")
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+			So(validationErr.Message, ShouldEqual, `unknown csv catalog column "owner"`)
+
+			_, err = ParseCSV(strings.NewReader("name,topic\n1,Inventory\n"), "CIS")
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+			So(validationErr.Message, ShouldEqual, "csv catalog has no control_id column")
+		})
+
+		Convey("The catalogs of the default data have unique control ids", func() {
+			for file, total := range map[string]int{"cis.csv": 153, "mpa.csv": 64} {
+				f, err := os.Open("../../../default_data/frameworks/" + file)
+				So(err, ShouldBeNil)
+
+				c, err := ParseCSV(f, "default")
+				_ = f.Close()
+				So(err, ShouldBeNil)
+				So(c.Controls, ShouldHaveLength, total)
+
+				ids := make(map[string]bool)
+				for _, control := range c.Controls {
+					ids[control.ControlId] = true
+				}
+				So(ids, ShouldHaveLength, total)
+			}
+		})
+	})
+}
+
+func TestParseMappingsCSV(t *testing.T) {
+	Convey("Given a CSV mapping file", t, func() {
+		Convey("Mappings are read by column name", func() {
+			rows, err := ParseMappingsCSV(strings.NewReader("mapped_framework,mapped_control_id,framework,control_id\nMPA,TS-21,CIS,6.4\n"))
+			So(err, ShouldBeNil)
+			So(rows, ShouldResemble, []*entities.ControlMappingRow{{
+				Framework: "CIS", ControlId: "6.4", MappedFramework: "MPA", MappedControlId: "TS-21",
+			}})
+		})
+
+		Convey("All the columns are required", func() {
+			_, err := ParseMappingsCSV(strings.NewReader("framework,control_id,mapped_framework\nCIS,6.4,MPA\n"))
+
+			var validationErr *appError.ErrValidation
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+			So(validationErr.Message, ShouldEqual, "csv mapping file has no mapped_control_id column")
+		})
+
+		Convey("The mappings of the default data map controls of the default catalogs", func() {
+			ids := make(map[string]map[string]bool)
+			for framework, file := range map[string]string{"CIS": "cis.csv", "MPA": "mpa.csv"} {
+				f, err := os.Open("../../../default_data/frameworks/" + file)
+				So(err, ShouldBeNil)
+
+				c, err := ParseCSV(f, framework)
+				_ = f.Close()
+				So(err, ShouldBeNil)
+
+				ids[framework] = make(map[string]bool)
+				for _, control := range c.Controls {
+					ids[framework][control.ControlId] = true
+				}
+			}
+
+			f, err := os.Open("../../../default_data/frameworks/cis_mpa_mappings.csv")
+			So(err, ShouldBeNil)
+
+			rows, err := ParseMappingsCSV(f)
+			_ = f.Close()
+			So(err, ShouldBeNil)
+			So(rows, ShouldNotBeEmpty)
+
+			for _, r := range rows {
+				So(ids[r.Framework][r.ControlId], ShouldBeTrue)
+				So(ids[r.MappedFramework][r.MappedControlId], ShouldBeTrue)
+			}
+		})
+	})
+}
@@ -0,0 +1,115 @@
+package catalog
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"golang.org/x/exp/slices"
+)
+
+// CSV columns. The first row names the columns, in any order. control_id is required, groups
+// are separated by GroupSeparator.
+const (
+	ColumnControlId     = "control_id"
+	ColumnName          = "name"
+	ColumnTopic         = "topic"
+	ColumnDomain        = "domain"
+	ColumnBestPractices = "best_practices"
+	ColumnSolution      = "solution"
+	ColumnGroups        = "groups"
+
+	GroupSeparator = ";"
+)
+
+var columns = []string{ColumnControlId, ColumnName, ColumnTopic, ColumnDomain, ColumnBestPractices, ColumnSolution, ColumnGroups}
+
+// ParseCSV reads the controls of a framework from a CSV file with a header row.
+func ParseCSV(r io.Reader, framework string) (*entities.Catalog, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	index, err := readHeader(reader, "catalog", columns, ColumnControlId)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := &entities.Catalog{Framework: framework}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, &appError.ErrValidation{Message: fmt.Sprintf("invalid csv catalog: %v", err)}
+		}
+
+		value := func(column string) string {
+			if i, ok := index[column]; ok {
+				return strings.TrimSpace(record[i])
+			}
+
+			return ""
+		}
+
+		catalog.Controls = append(catalog.Controls, &entities.CatalogControl{
+			ControlId:     value(ColumnControlId),
+			Name:          value(ColumnName),
+			Topic:         value(ColumnTopic),
+			Domain:        value(ColumnDomain),
+			BestPractices: value(ColumnBestPractices),
+			Solution:      value(ColumnSolution),
+			Groups:        splitGroups(value(ColumnGroups)),
+		})
+	}
+
+	return catalog, nil
+}
+
+// readHeader of a CSV file of the kind, indexing its columns by name. Unknown columns and
+// missing required ones are rejected.
+func readHeader(reader *csv.Reader, kind string, known []string, required ...string) (map[string]int, error) {
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, &appError.ErrValidation{Message: fmt.Sprintf("csv %s is empty", kind)}
+	}
+
+	if err != nil {
+		return nil, &appError.ErrValidation{Message: fmt.Sprintf("invalid csv %s: %v", kind, err)}
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(known, name) {
+			return nil, &appError.ErrValidation{Message: fmt.Sprintf("unknown csv %s column %q", kind, name)}
+		}
+
+		index[name] = i
+	}
+
+	for _, name := range required {
+		if _, ok := index[name]; !ok {
+			return nil, &appError.ErrValidation{Message: fmt.Sprintf("csv %s has no %s column", kind, name)}
+		}
+	}
+
+	return index, nil
+}
+
+func splitGroups(value string) []string {
+	groups := make([]string, 0)
+
+	for _, g := range strings.Split(value, GroupSeparator) {
+		if g = strings.TrimSpace(g); g != "" {
+			groups = append(groups, g)
+		}
+	}
+
+	return groups
+}
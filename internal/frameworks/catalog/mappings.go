@@ -0,0 +1,54 @@
+package catalog
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+)
+
+// Columns of CSV mapping files, all required, in any order. Each row maps a control of a
+// framework to an equivalent control of another framework.
+const (
+	ColumnFramework       = "framework"
+	ColumnMappedFramework = "mapped_framework"
+	ColumnMappedControlId = "mapped_control_id"
+)
+
+var mappingColumns = []string{ColumnFramework, ColumnControlId, ColumnMappedFramework, ColumnMappedControlId}
+
+// ParseMappingsCSV reads control mappings from a CSV file with a header row.
+func ParseMappingsCSV(r io.Reader) ([]*entities.ControlMappingRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	index, err := readHeader(reader, "mapping file", mappingColumns, mappingColumns...)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]*entities.ControlMappingRow, 0)
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, &appError.ErrValidation{Message: fmt.Sprintf("invalid csv mapping file: %v", err)}
+		}
+
+		rows = append(rows, &entities.ControlMappingRow{
+			Framework:       strings.TrimSpace(record[index[ColumnFramework]]),
+			ControlId:       strings.TrimSpace(record[index[ColumnControlId]]),
+			MappedFramework: strings.TrimSpace(record[index[ColumnMappedFramework]]),
+			MappedControlId: strings.TrimSpace(record[index[ColumnMappedControlId]]),
+		})
+	}
+
+	return rows, nil
+}
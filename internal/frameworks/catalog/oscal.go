@@ -0,0 +1,211 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+)
+
+// OSCAL prop names read from controls.
+const (
+	PropLabel  = "label"
+	PropStatus = "status"
+	PropGroup  = "group"
+
+	statusWithdrawn = "withdrawn"
+)
+
+type oscalDocument struct {
+	Catalog *oscalCatalog `json:"catalog"`
+}
+
+type oscalCatalog struct {
+	Metadata struct {
+		Title string `json:"title"`
+	} `json:"metadata"`
+	Groups   []*oscalGroup   `json:"groups"`
+	Controls []*oscalControl `json:"controls"`
+}
+
+type oscalGroup struct {
+	ID       string          `json:"id"`
+	Title    string          `json:"title"`
+	Groups   []*oscalGroup   `json:"groups"`
+	Controls []*oscalControl `json:"controls"`
+}
+
+type oscalControl struct {
+	ID       string          `json:"id"`
+	Title    string          `json:"title"`
+	Params   []*oscalParam   `json:"params"`
+	Props    []*oscalProp    `json:"props"`
+	Parts    []*oscalPart    `json:"parts"`
+	Controls []*oscalControl `json:"controls"`
+}
+
+type oscalParam struct {
+	ID     string `json:"id"`
+	Label  string `json:"label"`
+	Select *struct {
+		Choice []string `json:"choice"`
+	} `json:"select"`
+}
+
+type oscalProp struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type oscalPart struct {
+	Name  string       `json:"name"`
+	Prose string       `json:"prose"`
+	Props []*oscalProp `json:"props"`
+	Parts []*oscalPart `json:"parts"`
+}
+
+// ParseOSCAL reads the controls of an OSCAL catalog in JSON. The framework defaults to the title
+// of the catalog. Controls nested in controls, like NIST control enhancements, are imported as
+// controls of their own and withdrawn controls are left out.
+//
+// A control is identified by its id and named by its label prop, its topic is its title and
+// its domain the title of its top level group. The statement part becomes the best practices
+// and the guidance part the solution, with parameters shown by their label. Props named group
+// become the groups of the control.
+func ParseOSCAL(r io.Reader, framework string) (*entities.Catalog, error) {
+	var doc oscalDocument
+
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, &appError.ErrValidation{Message: fmt.Sprintf("invalid oscal catalog: %v", err)}
+	}
+
+	if doc.Catalog == nil {
+		return nil, &appError.ErrValidation{Message: "oscal document is not a catalog"}
+	}
+
+	if framework == "" {
+		framework = strings.TrimSpace(doc.Catalog.Metadata.Title)
+	}
+
+	catalog := &entities.Catalog{Framework: framework}
+
+	appendOSCALControls(catalog, "", doc.Catalog.Controls)
+
+	for _, group := range doc.Catalog.Groups {
+		appendOSCALGroup(catalog, group.Title, group)
+	}
+
+	return catalog, nil
+}
+
+func appendOSCALGroup(catalog *entities.Catalog, domain string, group *oscalGroup) {
+	appendOSCALControls(catalog, domain, group.Controls)
+
+	for _, g := range group.Groups {
+		appendOSCALGroup(catalog, domain, g)
+	}
+}
+
+func appendOSCALControls(catalog *entities.Catalog, domain string, controls []*oscalControl) {
+	for _, c := range controls {
+		if propValue(c.Props, PropStatus) == statusWithdrawn {
+			continue
+		}
+
+		params := make(map[string]string, len(c.Params))
+		for _, p := range c.Params {
+			params[p.ID] = paramText(p)
+		}
+
+		name := propValue(c.Props, PropLabel)
+		if name == "" {
+			name = c.ID
+		}
+
+		groups := make([]string, 0)
+		for _, p := range c.Props {
+			if p.Name == PropGroup && p.Value != "" {
+				groups = append(groups, p.Value)
+			}
+		}
+
+		catalog.Controls = append(catalog.Controls, &entities.CatalogControl{
+			ControlId:     c.ID,
+			Name:          name,
+			Topic:         strings.TrimSpace(c.Title),
+			Domain:        strings.TrimSpace(domain),
+			BestPractices: partText(c.Parts, "statement", params),
+			Solution:      partText(c.Parts, "guidance", params),
+			Groups:        groups,
+		})
+
+		appendOSCALControls(catalog, domain, c.Controls)
+	}
+}
+
+func propValue(props []*oscalProp, name string) string {
+	for _, p := range props {
+		if p.Name == name {
+			return strings.TrimSpace(p.Value)
+		}
+	}
+
+	return ""
+}
+
+func paramText(p *oscalParam) string {
+	if p.Label != "" {
+		return fmt.Sprintf("[Assignment: %s]", p.Label)
+	}
+
+	if p.Select != nil && len(p.Select.Choice) > 0 {
+		return fmt.Sprintf("[Selection: %s]", strings.Join(p.Select.Choice, "; "))
+	}
+
+	return fmt.Sprintf("[%s]", p.ID)
+}
+
+var insertParam = regexp.MustCompile(`\{\{\s*insert:\s*param,\s*([^\s}]+)\s*\}\}`)
+
+// partText is the prose of the parts with the name and of their sub parts, one line each and
+// prefixed by their label.
+func partText(parts []*oscalPart, name string, params map[string]string) string {
+	var lines []string
+
+	for _, p := range parts {
+		if p.Name == name {
+			lines = appendPartLines(lines, p, params)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func appendPartLines(lines []string, part *oscalPart, params map[string]string) []string {
+	prose := insertParam.ReplaceAllStringFunc(strings.TrimSpace(part.Prose), func(s string) string {
+		id := insertParam.FindStringSubmatch(s)[1]
+		if text, ok := params[id]; ok {
+			return text
+		}
+
+		return fmt.Sprintf("[%s]", id)
+	})
+
+	if label := propValue(part.Props, PropLabel); label != "" && prose != "" {
+		prose = label + " " + prose
+	}
+
+	if prose != "" {
+		lines = append(lines, prose)
+	}
+
+	for _, p := range part.Parts {
+		lines = appendPartLines(lines, p, params)
+	}
+
+	return lines
+}
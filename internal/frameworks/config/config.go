@@ -0,0 +1,48 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultSnapshotCheckInterval = time.Hour
+)
+
+// Config for frameworks.
+type Config struct {
+	Snapshots SnapshotsConfig
+}
+
+// SnapshotsConfig for the daily compliance snapshots of companies. The snapshot of the day is
+// recorded, and refreshed until the day ends, every CheckInterval. A zero value falls back to the
+// default.
+type SnapshotsConfig struct {
+	CheckInterval time.Duration
+}
+
+// Interval between snapshots.
+func (c SnapshotsConfig) Interval() time.Duration {
+	if c.CheckInterval == 0 {
+		return DefaultSnapshotCheckInterval
+	}
+
+	return c.CheckInterval
+}
+
+// Validate config
+func (c *Config) Validate() error {
+	var errs []string
+
+	if c.Snapshots.CheckInterval < 0 {
+		errs = append(errs, "Snapshots check interval shouldn't be negative")
+	}
+
+	if len(errs) > 0 {
+		return errors.Errorf(strings.Join(errs, ","))
+	}
+
+	return nil
+}
@@ -13,6 +13,10 @@ type Endpoints struct {
 	GetFrameworksEndpoint        endpoint.Endpoint
 	GetFrameworkControlsEndpoint endpoint.Endpoint
 	GetFrameworkStatsEndpoint    endpoint.Endpoint
+	GetPolicyCoverageEndpoint    endpoint.Endpoint
+	GetProfileEndpoint           endpoint.Endpoint
+	UpdateProfileEndpoint        endpoint.Endpoint
+	PreviewProfileEndpoint       endpoint.Endpoint
 }
 
 // New returns new endpoints
@@ -21,6 +25,10 @@ func New(svc service.Service) *Endpoints {
 		GetFrameworksEndpoint:        makeGetFrameworksEndpoint(svc),
 		GetFrameworkControlsEndpoint: makeGetFrameworkControlsEndpoint(svc),
 		GetFrameworkStatsEndpoint:    makeGetFrameworkStatsEndpoint(svc),
+		GetPolicyCoverageEndpoint:    makeGetPolicyCoverageEndpoint(svc),
+		GetProfileEndpoint:           makeGetProfileEndpoint(svc),
+		UpdateProfileEndpoint:        makeUpdateProfileEndpoint(svc),
+		PreviewProfileEndpoint:       makePreviewProfileEndpoint(svc),
 	}
 }
 
@@ -47,3 +55,35 @@ func makeGetFrameworkStatsEndpoint(svc service.Service) endpoint.Endpoint {
 		return svc.GetFrameworkStats(ctx, &req.CompanyUuid, &req.UserUuid)
 	}
 }
+
+func makeGetPolicyCoverageEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetFrameworkControlRequest) //nolint:errcheck
+
+		return svc.GetPolicyCoverage(ctx, &req.CompanyUuid, &req.UserUuid, &req.FrameworkUuid)
+	}
+}
+
+func makeGetProfileEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetFrameworkControlRequest) //nolint:errcheck
+
+		return svc.GetProfile(ctx, &req.CompanyUuid, &req.UserUuid, &req.FrameworkUuid)
+	}
+}
+
+func makeUpdateProfileEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.UpdateProfileRequest) //nolint:errcheck
+
+		return svc.UpdateProfile(ctx, req)
+	}
+}
+
+func makePreviewProfileEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.PreviewProfileRequest) //nolint:errcheck
+
+		return svc.PreviewProfile(ctx, req)
+	}
+}
@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Formats of control catalogs.
+const (
+	CatalogFormatOSCAL = "oscal"
+	CatalogFormatCSV   = "csv"
+)
+
+// Catalog of the controls of a framework, as published in a catalog file.
+type Catalog struct {
+	Framework string
+	Controls  []*CatalogControl
+}
+
+// CatalogControl is identified by its ControlId, which stays the same across versions of the
+// catalog and across environments.
+type CatalogControl struct {
+	ControlId     string
+	Name          string
+	Topic         string
+	Domain        string
+	BestPractices string
+	Solution      string
+	Groups        []string
+}
+
+// CatalogImport of the changes to the controls of a framework. The framework is created when
+// NewFramework is set.
+type CatalogImport struct {
+	Framework    *Framework
+	NewFramework bool
+	Added        []*FrameworkControl
+	Changed      []*FrameworkControl
+	Retired      []uuid.UUID
+	Now          time.Time
+}
+
+// CatalogImportReport lists the controls added, changed and retired by an import. A dry run
+// reports the changes without saving them.
+type CatalogImportReport struct {
+	Framework    string                  `json:"framework"`
+	NewFramework bool                    `json:"new_framework"`
+	DryRun       bool                    `json:"dry_run"`
+	Added        []*CatalogControlChange `json:"added"`
+	Changed      []*CatalogControlChange `json:"changed"`
+	Retired      []*CatalogControlChange `json:"retired"`
+	Unchanged    int                     `json:"unchanged"`
+}
+
+// CatalogControlChange of a control. Fields lists the changed fields of a changed control.
+type CatalogControlChange struct {
+	ControlId string   `json:"control_id"`
+	Name      string   `json:"name"`
+	Fields    []string `json:"fields,omitempty"`
+}
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+)
+
+// ControlMapping of equivalent controls of two frameworks. A mapping goes both ways: remediating
+// either control satisfies the other one.
+type ControlMapping struct {
+	ControlMappingUuid   uuid.UUID         `json:"control_mapping_uuid" gorm:"primarykey;column:control_mapping_uuid"`
+	FrameworkControlUuid uuid.UUID         `json:"framework_control_uuid" gorm:"column:framework_control_uuid"`
+	MappedControlUuid    uuid.UUID         `json:"mapped_control_uuid" gorm:"column:mapped_control_uuid"`
+	CreatedAt            nullable.NullTime `json:"created_at,omitempty" gorm:"column:created_at"`
+}
+
+func (m *ControlMapping) TableName() string {
+	return "framework_control_mappings"
+}
+
+// ControlMappingRow of a mapping file, naming the controls by framework and control id.
+type ControlMappingRow struct {
+	Framework       string `json:"framework"`
+	ControlId       string `json:"control_id"`
+	MappedFramework string `json:"mapped_framework"`
+	MappedControlId string `json:"mapped_control_id"`
+}
+
+// ControlMappingImport of the changes to the mappings: Added ones are created and Removed ones
+// deleted.
+type ControlMappingImport struct {
+	Added   []*ControlMapping
+	Removed []uuid.UUID
+}
+
+// ControlMappingImportReport lists the mappings added and removed by an import. A dry run
+// reports the changes without saving them.
+type ControlMappingImportReport struct {
+	DryRun    bool                 `json:"dry_run"`
+	Added     []*ControlMappingRow `json:"added"`
+	Removed   []*ControlMappingRow `json:"removed"`
+	Unchanged int                  `json:"unchanged"`
+}
+
+// SatisfiedVia is a remediated control of another framework of the company mapped to the
+// control FrameworkControlUuid, which is satisfied through it.
+type SatisfiedVia struct {
+	FrameworkControlUuid   uuid.UUID `json:"-" gorm:"column:framework_control_uuid"`
+	FrameworksUuid         uuid.UUID `json:"frameworks_uuid" gorm:"column:frameworks_uuid"`
+	Framework              string    `json:"framework" gorm:"column:framework"`
+	MappedControlUuid      uuid.UUID `json:"framework_control_uuid" gorm:"column:mapped_control_uuid"`
+	ControlId              string    `json:"control_id" gorm:"column:control_id"`
+	Name                   string    `json:"name" gorm:"column:name"`
+	ControlRemediationUuid uuid.UUID `json:"control_remediation_uuid" gorm:"column:control_remediation_uuid"`
+}
+
+// RemediationCoverageStats counts the controls of a framework which apply to the company, the
+// ones with a completed remediation, and the ones Satisfied either by their remediation or by a
+// mapped control.
+type RemediationCoverageStats struct {
+	Name       string `gorm:"column:name"`
+	Total      int    `gorm:"column:total"`
+	Remediated int    `gorm:"column:remediated"`
+	Satisfied  int    `gorm:"column:satisfied"`
+}
+
+// RemediationStatusStats counts the controls of a framework with a remediation status.
+type RemediationStatusStats struct {
+	Name   string `gorm:"column:name"`
+	Status string `gorm:"column:status"`
+	Count  int    `gorm:"column:count"`
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -54,6 +55,8 @@ type CompanyFrameworks struct {
 	UpdatedAt            nullable.NullTime `json:"updated_at,omitempty" gorm:"column:updated_at"`
 	CreatedBy            uuid.UUID         `json:"created_by,omitempty" gorm:"column:created_by"`
 	UpdatedBy            uuid.UUID         `json:"updated_by,omitempty" gorm:"column:updated_by"`
+	// Profile of the framework targeted by the company, nil for the whole framework.
+	Profile *string `json:"profile,omitempty" gorm:"column:profile"`
 }
 
 func (m *CompanyFrameworks) TableName() string {
@@ -63,16 +66,21 @@ func (m *CompanyFrameworks) TableName() string {
 type FrameworkControl struct {
 	FrameworkControlUuid uuid.UUID         `json:"framework_control_uuid" gorm:"column:framework_control_uuid"`
 	FrameworksUuid       uuid.UUID         `json:"-" gorm:"column:frameworks_uuid"`
+	ControlId            string            `json:"control_id" gorm:"column:control_id"`
 	Name                 string            `json:"name,omitempty" gorm:"column:name"`
 	Domain               string            `json:"domain,omitempty" gorm:"column:domain"`
 	Topic                string            `json:"topic,omitempty" gorm:"column:topic"`
 	BestPractices        string            `json:"best_practices,omitempty" gorm:"column:best_practices"`
 	Solution             string            `json:"solution,omitempty" gorm:"column:solution"`
 	Groups               pq.StringArray    `json:"-" gorm:"type:text[]"`
+	RetiredAt            nullable.NullTime `json:"-" gorm:"column:retired_at"`
 	CreatedAt            nullable.NullTime `json:"created_at,omitempty" gorm:"column:created_at"`
 	UpdatedAt            nullable.NullTime `json:"updated_at,omitempty" gorm:"column:updated_at"`
 	CreatedBy            uuid.UUID         `json:"created_by,omitempty" gorm:"column:created_by"`
 	UpdatedBy            uuid.UUID         `json:"updated_by,omitempty" gorm:"column:updated_by"`
+	// SatisfiedVia lists the remediated controls of other frameworks of the company mapped to
+	// the control.
+	SatisfiedVia []*SatisfiedVia `json:"satisfied_via,omitempty" gorm:"-"`
 }
 
 func (c *FrameworkControl) MarshalJSON() ([]byte, error) {
@@ -102,20 +110,51 @@ func (c *FrameworkControl) MarshalJSON() ([]byte, error) {
 	return json.Marshal(o)
 }
 
+// Statuses of control remediations. Completed and not applicable controls are done, not
+// applicable ones are left out of the coverage of the framework.
+const (
+	RemediationStatusNotStarted    = "not_started"
+	RemediationStatusInProgress    = "in_progress"
+	RemediationStatusBlocked       = "blocked"
+	RemediationStatusInReview      = "in_review"
+	RemediationStatusCompleted     = "completed"
+	RemediationStatusNotApplicable = "not_applicable"
+)
+
+// RemediationStatuses in the order of the workflow.
+var RemediationStatuses = []string{
+	RemediationStatusNotStarted,
+	RemediationStatusInProgress,
+	RemediationStatusBlocked,
+	RemediationStatusInReview,
+	RemediationStatusCompleted,
+	RemediationStatusNotApplicable,
+}
+
+// ControlRemediations of the controls of a framework by a company, one per control. The owner,
+// a user of the company or an engineer, remediates the control by the due date.
 type ControlRemediations struct {
-	ControlRemediationsUuid uuid.UUID         `json:"control_remediation_uuid" gorm:"column:control_remediation_uuid"`
+	ControlRemediationsUuid uuid.UUID         `json:"control_remediation_uuid" gorm:"primarykey;column:control_remediation_uuid"`
 	CompanyUuid             uuid.UUID         `json:"company_uuid" gorm:"column:company_uuid"`
 	FrameworksUuid          uuid.UUID         `json:"frameworks_uuid" gorm:"column:frameworks_uuid"`
 	FrameworkControlUuid    uuid.UUID         `json:"framework_control_uuid" gorm:"column:framework_control_uuid"`
 	Severity                string            `json:"severity,omitempty" gorm:"column:severity"`
 	Comment                 string            `json:"comment,omitempty" gorm:"column:comment"`
 	Status                  string            `json:"status,omitempty" gorm:"column:status"`
+	Priority                string            `json:"priority,omitempty" gorm:"column:priority;default:medium"`
+	OwnerUuid               *uuid.UUID        `json:"owner_uuid,omitempty" gorm:"column:owner_uuid"`
+	DueDate                 *time.Time        `json:"due_date,omitempty" gorm:"column:due_date"`
+	CompletedAt             *time.Time        `json:"completed_at,omitempty" gorm:"column:completed_at"`
 	CreatedAt               nullable.NullTime `json:"created_at,omitempty" gorm:"column:created_at"`
 	UpdatedAt               nullable.NullTime `json:"updated_at,omitempty" gorm:"column:updated_at"`
 	CreatedBy               uuid.UUID         `json:"created_by,omitempty" gorm:"column:created_by"`
 	UpdatedBy               uuid.UUID         `json:"updated_by,omitempty" gorm:"column:updated_by"`
 }
 
+func (m *ControlRemediations) TableName() string {
+	return "control_remediations"
+}
+
 // Request Types
 type GetFrameworksRequest struct {
 	CompanyUuid uuid.UUID `json:"company_uuid"`
@@ -137,4 +176,15 @@ type GetFrameworkStatsResponse struct {
 	Name      string `json:"name"`
 	Completed int    `json:"completed"`
 	Total     int    `json:"total"`
+	// Profile targeted by the company, the stats only count its controls and questions.
+	Profile *string `json:"profile"`
+	// PolicyCoverage is the percentage of the controls of the framework covered by an approved policy.
+	PolicyCoverage float64 `json:"policy_coverage"`
+	// RemediationCoverage is the percentage of the controls of the framework with a completed
+	// remediation, EffectiveCoverage also counts the controls mapped to a remediated control of
+	// another framework of the company.
+	RemediationCoverage float64 `json:"remediation_coverage"`
+	EffectiveCoverage   float64 `json:"effective_coverage"`
+	// Remediations counts the controls of the framework by the status of their remediation.
+	Remediations map[string]int `json:"remediations"`
 }
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+)
+
+// Coverage of a control by the policies mapped to it.
+const (
+	CoverageCovered   = "covered"
+	CoverageDraft     = "draft"
+	CoverageUncovered = "uncovered"
+)
+
+// AsOfLatest bounds ApprovedPolicySQL to no date, for the current state of the policies.
+const AsOfLatest = `'infinity'`
+
+// ApprovedPolicySQL is true when a version of the policy p had been approved at asOf, an SQL
+// expression, including versions approved before status transitions were stored. Rows without
+// timestamps count as they are. The approved status is bound to @approved.
+func ApprovedPolicySQL(asOf string) string {
+	return `(exists (
+		select 1 from policy_status_histories psh
+		where psh.policy_uuid = p.policy_uuid and psh.to_status = @approved and coalesce(psh.created_at, ` + asOf + `) <= ` + asOf + `
+	) or (p.status = @approved and coalesce(p.status_updated_at, ` + asOf + `) <= ` + asOf + `))`
+}
+
+// ControlPolicy is a policy of the company mapped to a framework control. Approved is set once
+// a version of the policy has been approved.
+type ControlPolicy struct {
+	FrameworkControlUuid uuid.UUID `json:"-" gorm:"column:framework_control_uuid"`
+	PolicyUuid           uuid.UUID `json:"policy_uuid" gorm:"column:policy_uuid"`
+	Name                 string    `json:"name" gorm:"column:name"`
+	Status               string    `json:"status" gorm:"column:status"`
+	Approved             bool      `json:"approved" gorm:"column:approved"`
+}
+
+// PolicyCoverageStats counts the controls of a framework covered by an approved policy.
+type PolicyCoverageStats struct {
+	Name    string `gorm:"column:name"`
+	Total   int    `gorm:"column:total"`
+	Covered int    `gorm:"column:covered"`
+}
+
+// ControlCoverage is covered when an approved policy is mapped to the control, draft when only
+// policies which were never approved are.
+type ControlCoverage struct {
+	FrameworkControlUuid uuid.UUID        `json:"framework_control_uuid"`
+	Name                 string           `json:"name"`
+	Topic                string           `json:"topic"`
+	Domain               string           `json:"domain"`
+	Coverage             string           `json:"coverage"`
+	Policies             []*ControlPolicy `json:"policies"`
+}
+
+type GetPolicyCoverageResponse struct {
+	FrameworkUuid uuid.UUID `json:"frameworks_uuid"`
+	Name          string    `json:"name"`
+	Total         int       `json:"total"`
+	Covered       int       `json:"covered"`
+	Draft         int       `json:"draft"`
+	Uncovered     int       `json:"uncovered"`
+	// Percentage of the controls covered by an approved policy.
+	Percentage float64            `json:"percentage"`
+	Controls   []*ControlCoverage `json:"controls"`
+}
@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"golang.org/x/exp/slices"
+)
+
+// InProfile is true when a control or question with the groups is in the profile. The empty
+// profile is the whole framework, and controls and questions without groups are in every
+// profile. CIS implementation groups are nested: the controls of IG1 are also in IG2 and IG3.
+func InProfile(groups []string, profile string) bool {
+	return profile == "" || len(groups) == 0 || slices.Contains(groups, profile)
+}
+
+// ControlInProfileSQL is InProfile in SQL for the control fc and the company framework cf.
+const ControlInProfileSQL = `(cf.profile is null or coalesce(cardinality(fc.groups), 0) = 0 or cf.profile = any(fc.groups))`
+
+// QuestionInProfileSQL is InProfile in SQL for the question q and the company framework cf.
+const QuestionInProfileSQL = `(cf.profile is null or coalesce(cardinality(q.groups), 0) = 0 or cf.profile = any(q.groups))`
+
+// ProfileQuestionnaire is a question of a framework, in the groups listed in its category.
+type ProfileQuestionnaire struct {
+	QuestionnairesUuid uuid.UUID      `json:"questionnaires_uuid" gorm:"column:questionnaires_uuid"`
+	Category           string         `json:"category" gorm:"column:category"`
+	Question           string         `json:"question" gorm:"column:question"`
+	Groups             pq.StringArray `json:"-" gorm:"column:groups;type:text[]"`
+}
+
+// FrameworkProfile targeted by a company, nil for the whole framework. Profiles are the groups
+// of the controls of the framework. Controls and Questionnaires count those of the profile.
+type FrameworkProfile struct {
+	FrameworkUuid  uuid.UUID `json:"frameworks_uuid"`
+	Name           string    `json:"name"`
+	Profile        *string   `json:"profile"`
+	Profiles       []string  `json:"profiles"`
+	Controls       int       `json:"controls"`
+	Questionnaires int       `json:"questionnaires"`
+}
+
+// ProfilePreview lists the controls and questions added and removed by switching from the
+// current profile to another one.
+type ProfilePreview struct {
+	FrameworkUuid         uuid.UUID               `json:"frameworks_uuid"`
+	Name                  string                  `json:"name"`
+	CurrentProfile        *string                 `json:"current_profile"`
+	Profile               *string                 `json:"profile"`
+	AddedControls         []*FrameworkControl     `json:"added_controls"`
+	RemovedControls       []*FrameworkControl     `json:"removed_controls"`
+	AddedQuestionnaires   []*ProfileQuestionnaire `json:"added_questionnaires"`
+	RemovedQuestionnaires []*ProfileQuestionnaire `json:"removed_questionnaires"`
+}
+
+// Request Types
+
+// UpdateProfileRequestBody sets the profile, a null or empty profile targets the whole framework.
+type UpdateProfileRequestBody struct {
+	Profile *string `json:"profile"`
+}
+
+type UpdateProfileRequest struct {
+	CompanyUuid   uuid.UUID `json:"company_uuid"`
+	UserUuid      uuid.UUID `json:"user_uuid"`
+	FrameworkUuid uuid.UUID `json:"framework_uuid"`
+	Body          *UpdateProfileRequestBody
+}
+
+// PreviewProfileRequest of the switch to Profile, the whole framework when empty.
+type PreviewProfileRequest struct {
+	CompanyUuid   uuid.UUID `json:"company_uuid"`
+	UserUuid      uuid.UUID `json:"user_uuid"`
+	FrameworkUuid uuid.UUID `json:"framework_uuid"`
+	Profile       string    `json:"profile"`
+}
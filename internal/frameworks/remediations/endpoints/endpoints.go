@@ -0,0 +1,108 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations/service"
+)
+
+type Endpoints struct {
+	GetRemediationsEndpoint   endpoint.Endpoint
+	GetRemediationEndpoint    endpoint.Endpoint
+	UpdateRemediationEndpoint endpoint.Endpoint
+	AddEvidenceEndpoint       endpoint.Endpoint
+	DownloadEvidenceEndpoint  endpoint.Endpoint
+	DeleteEvidenceEndpoint    endpoint.Endpoint
+	GetCommentsEndpoint       endpoint.Endpoint
+	AddCommentEndpoint        endpoint.Endpoint
+	DeleteCommentEndpoint     endpoint.Endpoint
+}
+
+// New returns new endpoints
+func New(svc service.Service) *Endpoints {
+	return &Endpoints{
+		GetRemediationsEndpoint:   makeGetRemediationsEndpoint(svc),
+		GetRemediationEndpoint:    makeGetRemediationEndpoint(svc),
+		UpdateRemediationEndpoint: makeUpdateRemediationEndpoint(svc),
+		AddEvidenceEndpoint:       makeAddEvidenceEndpoint(svc),
+		DownloadEvidenceEndpoint:  makeDownloadEvidenceEndpoint(svc),
+		DeleteEvidenceEndpoint:    makeDeleteEvidenceEndpoint(svc),
+		GetCommentsEndpoint:       makeGetCommentsEndpoint(svc),
+		AddCommentEndpoint:        makeAddCommentEndpoint(svc),
+		DeleteCommentEndpoint:     makeDeleteCommentEndpoint(svc),
+	}
+}
+
+func makeGetRemediationsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.ListRemediationsRequest) //nolint:errcheck
+
+		return svc.GetRemediations(ctx, req)
+	}
+}
+
+func makeGetRemediationEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.RemediationRequest) //nolint:errcheck
+
+		return svc.GetRemediation(ctx, req)
+	}
+}
+
+func makeUpdateRemediationEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.UpdateRemediationRequest) //nolint:errcheck
+
+		return svc.UpdateRemediation(ctx, req)
+	}
+}
+
+func makeAddEvidenceEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.AddEvidenceRequest) //nolint:errcheck
+
+		return svc.AddEvidence(ctx, req)
+	}
+}
+
+func makeDownloadEvidenceEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.EvidenceRequest) //nolint:errcheck
+
+		return svc.DownloadEvidence(ctx, req)
+	}
+}
+
+func makeDeleteEvidenceEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.EvidenceRequest) //nolint:errcheck
+
+		return nil, svc.DeleteEvidence(ctx, req)
+	}
+}
+
+func makeGetCommentsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.RemediationRequest) //nolint:errcheck
+
+		return svc.GetComments(ctx, req)
+	}
+}
+
+func makeAddCommentEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.AddCommentRequest) //nolint:errcheck
+
+		return svc.AddComment(ctx, req)
+	}
+}
+
+func makeDeleteCommentEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.CommentRequest) //nolint:errcheck
+
+		return nil, svc.DeleteComment(ctx, req)
+	}
+}
@@ -0,0 +1,180 @@
+package entities
+
+import (
+	"mime/multipart"
+	"time"
+
+	"github.com/google/uuid"
+	userEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+)
+
+// Priorities of remediations.
+const (
+	PriorityLow      = "low"
+	PriorityMedium   = "medium"
+	PriorityHigh     = "high"
+	PriorityCritical = "critical"
+
+	// DateLayout of due dates.
+	DateLayout = "2006-01-02"
+)
+
+// Priorities from the lowest to the highest.
+var Priorities = []string{PriorityLow, PriorityMedium, PriorityHigh, PriorityCritical}
+
+// RemediationItem is the remediation of a control of a framework by a company, joined with the
+// control and the owner. Controls without a remediation yet are not started.
+type RemediationItem struct {
+	ControlRemediationUuid uuid.UUID  `gorm:"column:control_remediation_uuid"`
+	FrameworksUuid         uuid.UUID  `gorm:"column:frameworks_uuid"`
+	FrameworkControlUuid   uuid.UUID  `gorm:"column:framework_control_uuid"`
+	ControlId              string     `gorm:"column:control_id"`
+	Name                   string     `gorm:"column:name"`
+	Topic                  string     `gorm:"column:topic"`
+	Status                 string     `gorm:"column:status"`
+	Priority               string     `gorm:"column:priority"`
+	OwnerUuid              *uuid.UUID `gorm:"column:owner_uuid"`
+	OwnerFirstName         string     `gorm:"column:owner_first_name"`
+	OwnerLastName          string     `gorm:"column:owner_last_name"`
+	OwnerEmail             string     `gorm:"column:owner_email"`
+	DueDate                *time.Time `gorm:"column:due_date"`
+	CompletedAt            *time.Time `gorm:"column:completed_at"`
+	UpdatedAt              *time.Time `gorm:"column:updated_at"`
+}
+
+// Evidence file of a remediation, stored in S3.
+type Evidence struct {
+	EvidenceUuid           uuid.UUID         `gorm:"primarykey;column:evidence_uuid"`
+	ControlRemediationUuid uuid.UUID         `gorm:"column:control_remediation_uuid"`
+	FileName               string            `gorm:"column:file_name"`
+	ContentType            string            `gorm:"column:content_type"`
+	Size                   int64             `gorm:"column:size"`
+	S3Key                  string            `gorm:"column:s3_key"`
+	CreatedAt              time.Time         `gorm:"column:created_at"`
+	CreatedBy              uuid.UUID         `gorm:"column:created_by"`
+	Creator                userEntities.User `json:"-" gorm:"foreignKey:CreatedBy;references:UserUuid"`
+}
+
+func (m *Evidence) TableName() string {
+	return "control_remediation_evidences"
+}
+
+// Comment of the thread of a remediation.
+type Comment struct {
+	CommentUuid            uuid.UUID         `gorm:"primarykey;column:comment_uuid"`
+	ControlRemediationUuid uuid.UUID         `gorm:"column:control_remediation_uuid"`
+	Body                   string            `gorm:"column:body"`
+	CreatedAt              time.Time         `gorm:"column:created_at"`
+	CreatedBy              uuid.UUID         `gorm:"column:created_by"`
+	Author                 userEntities.User `json:"-" gorm:"foreignKey:CreatedBy;references:UserUuid"`
+}
+
+func (m *Comment) TableName() string {
+	return "control_remediation_comments"
+}
+
+type UserInfo struct {
+	UserUuid  uuid.UUID `json:"user_uuid"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Email     string    `json:"email"`
+}
+
+// RemediationResponse of a control. DueDate is formatted with DateLayout, Overdue is set once
+// the due date passed before the control was done. Evidence and comments are only listed for a
+// single remediation.
+type RemediationResponse struct {
+	ControlRemediationUuid uuid.UUID           `json:"control_remediation_uuid"`
+	FrameworksUuid         uuid.UUID           `json:"frameworks_uuid"`
+	FrameworkControlUuid   uuid.UUID           `json:"framework_control_uuid"`
+	ControlId              string              `json:"control_id"`
+	Name                   string              `json:"name"`
+	Topic                  string              `json:"topic"`
+	Status                 string              `json:"status"`
+	Priority               string              `json:"priority"`
+	Owner                  *UserInfo           `json:"owner"`
+	DueDate                *string             `json:"due_date"`
+	Overdue                bool                `json:"overdue"`
+	CompletedAt            *time.Time          `json:"completed_at"`
+	UpdatedAt              *time.Time          `json:"updated_at"`
+	Evidence               []*EvidenceResponse `json:"evidence,omitempty"`
+	Comments               []*CommentResponse  `json:"comments,omitempty"`
+}
+
+type EvidenceResponse struct {
+	EvidenceUuid uuid.UUID `json:"evidence_uuid"`
+	FileName     string    `json:"file_name"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	UploadedBy   *UserInfo `json:"uploaded_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type CommentResponse struct {
+	CommentUuid uuid.UUID `json:"comment_uuid"`
+	Body        string    `json:"body"`
+	Author      *UserInfo `json:"author"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Request Types
+type ListRemediationsRequest struct {
+	CompanyUuid   uuid.UUID `json:"company_uuid"`
+	UserUuid      uuid.UUID `json:"user_uuid"`
+	FrameworkUuid uuid.UUID `json:"framework_uuid"`
+	// Status and OwnerUuid filter the remediations when set.
+	Status    string     `json:"status"`
+	OwnerUuid *uuid.UUID `json:"owner_uuid"`
+}
+
+type RemediationRequest struct {
+	CompanyUuid   uuid.UUID `json:"company_uuid"`
+	UserUuid      uuid.UUID `json:"user_uuid"`
+	FrameworkUuid uuid.UUID `json:"framework_uuid"`
+	ControlUuid   uuid.UUID `json:"control_uuid"`
+}
+
+// UpdateRemediationRequestBody changes the fields which are set. An empty owner uuid removes the
+// owner, an empty due date the due date.
+type UpdateRemediationRequestBody struct {
+	Status    *string `json:"status"`
+	Priority  *string `json:"priority"`
+	OwnerUuid *string `json:"owner_uuid"`
+	DueDate   *string `json:"due_date"`
+}
+
+type UpdateRemediationRequest struct {
+	RemediationRequest
+	Body *UpdateRemediationRequestBody
+}
+
+type AddEvidenceRequest struct {
+	RemediationRequest
+	Files []*multipart.FileHeader
+}
+
+type EvidenceRequest struct {
+	RemediationRequest
+	EvidenceUuid uuid.UUID `json:"evidence_uuid"`
+}
+
+// DownloadEvidenceResponse is the content of an evidence file.
+type DownloadEvidenceResponse struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+type AddCommentRequestBody struct {
+	Body string `json:"body"`
+}
+
+type AddCommentRequest struct {
+	RemediationRequest
+	Body *AddCommentRequestBody
+}
+
+type CommentRequest struct {
+	RemediationRequest
+	CommentUuid uuid.UUID `json:"comment_uuid"`
+}
@@ -0,0 +1,45 @@
+// Package remediations tracks the remediation of the controls of the frameworks of a company.
+package remediations
+
+import (
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	s3client "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/s3"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations/endpoints"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations/service"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations/transport/http"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ModuleParams for remediations.
+type ModuleParams struct {
+	fx.In
+
+	DB           *gorm.DB
+	HTTPServer   *httpTransport.Server
+	APPTransport svcTransport.Client
+	AuthClient   auth.Client
+	S3Client     s3client.Client
+	Logger       *zap.SugaredLogger
+}
+
+// NewModule for remediations.
+// nolint:gocritic
+func NewModule(p ModuleParams) error {
+	repo := repository.New(p.DB)
+	svc := service.New(repo, p.S3Client, p.Logger)
+	eps := endpoints.New(svc)
+
+	http.RegisterTransport(p.HTTPServer, eps, p.AuthClient, p.APPTransport)
+
+	return nil
+}
+
+var (
+	// ModuleHttpAPI for uber fx.
+	ModuleHttpAPI = fx.Options(fx.Invoke(NewModule))
+)
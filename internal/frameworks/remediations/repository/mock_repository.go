@@ -0,0 +1,211 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations/entities"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CheckOwner mocks base method.
+func (m *MockRepository) CheckOwner(ctx context.Context, companyUuid, ownerUuid uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckOwner", ctx, companyUuid, ownerUuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckOwner indicates an expected call of CheckOwner.
+func (mr *MockRepositoryMockRecorder) CheckOwner(ctx, companyUuid, ownerUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckOwner", reflect.TypeOf((*MockRepository)(nil).CheckOwner), ctx, companyUuid, ownerUuid)
+}
+
+// CreateComment mocks base method.
+func (m *MockRepository) CreateComment(ctx context.Context, comment *entities.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", ctx, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockRepositoryMockRecorder) CreateComment(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockRepository)(nil).CreateComment), ctx, comment)
+}
+
+// CreateEvidence mocks base method.
+func (m *MockRepository) CreateEvidence(ctx context.Context, evidence []*entities.Evidence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEvidence", ctx, evidence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateEvidence indicates an expected call of CreateEvidence.
+func (mr *MockRepositoryMockRecorder) CreateEvidence(ctx, evidence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEvidence", reflect.TypeOf((*MockRepository)(nil).CreateEvidence), ctx, evidence)
+}
+
+// DeleteComment mocks base method.
+func (m *MockRepository) DeleteComment(ctx context.Context, commentUuid uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, commentUuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockRepositoryMockRecorder) DeleteComment(ctx, commentUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockRepository)(nil).DeleteComment), ctx, commentUuid)
+}
+
+// DeleteEvidence mocks base method.
+func (m *MockRepository) DeleteEvidence(ctx context.Context, evidenceUuid uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEvidence", ctx, evidenceUuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEvidence indicates an expected call of DeleteEvidence.
+func (mr *MockRepositoryMockRecorder) DeleteEvidence(ctx, evidenceUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvidence", reflect.TypeOf((*MockRepository)(nil).DeleteEvidence), ctx, evidenceUuid)
+}
+
+// EnsureRemediation mocks base method.
+func (m *MockRepository) EnsureRemediation(ctx context.Context, companyUuid, frameworkUuid, controlUuid, userUuid uuid.UUID) (*entities.RemediationItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureRemediation", ctx, companyUuid, frameworkUuid, controlUuid, userUuid)
+	ret0, _ := ret[0].(*entities.RemediationItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsureRemediation indicates an expected call of EnsureRemediation.
+func (mr *MockRepositoryMockRecorder) EnsureRemediation(ctx, companyUuid, frameworkUuid, controlUuid, userUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureRemediation", reflect.TypeOf((*MockRepository)(nil).EnsureRemediation), ctx, companyUuid, frameworkUuid, controlUuid, userUuid)
+}
+
+// GetComment mocks base method.
+func (m *MockRepository) GetComment(ctx context.Context, remediationUuid, commentUuid uuid.UUID) (*entities.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComment", ctx, remediationUuid, commentUuid)
+	ret0, _ := ret[0].(*entities.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComment indicates an expected call of GetComment.
+func (mr *MockRepositoryMockRecorder) GetComment(ctx, remediationUuid, commentUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComment", reflect.TypeOf((*MockRepository)(nil).GetComment), ctx, remediationUuid, commentUuid)
+}
+
+// GetComments mocks base method.
+func (m *MockRepository) GetComments(ctx context.Context, remediationUuid uuid.UUID) ([]*entities.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", ctx, remediationUuid)
+	ret0, _ := ret[0].([]*entities.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockRepositoryMockRecorder) GetComments(ctx, remediationUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockRepository)(nil).GetComments), ctx, remediationUuid)
+}
+
+// GetEvidence mocks base method.
+func (m *MockRepository) GetEvidence(ctx context.Context, remediationUuid uuid.UUID) ([]*entities.Evidence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvidence", ctx, remediationUuid)
+	ret0, _ := ret[0].([]*entities.Evidence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvidence indicates an expected call of GetEvidence.
+func (mr *MockRepositoryMockRecorder) GetEvidence(ctx, remediationUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvidence", reflect.TypeOf((*MockRepository)(nil).GetEvidence), ctx, remediationUuid)
+}
+
+// GetEvidenceFile mocks base method.
+func (m *MockRepository) GetEvidenceFile(ctx context.Context, remediationUuid, evidenceUuid uuid.UUID) (*entities.Evidence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEvidenceFile", ctx, remediationUuid, evidenceUuid)
+	ret0, _ := ret[0].(*entities.Evidence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvidenceFile indicates an expected call of GetEvidenceFile.
+func (mr *MockRepositoryMockRecorder) GetEvidenceFile(ctx, remediationUuid, evidenceUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvidenceFile", reflect.TypeOf((*MockRepository)(nil).GetEvidenceFile), ctx, remediationUuid, evidenceUuid)
+}
+
+// GetRemediations mocks base method.
+func (m *MockRepository) GetRemediations(ctx context.Context, companyUuid, frameworkUuid uuid.UUID, controlUuid *uuid.UUID, status string, ownerUuid *uuid.UUID) ([]*entities.RemediationItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemediations", ctx, companyUuid, frameworkUuid, controlUuid, status, ownerUuid)
+	ret0, _ := ret[0].([]*entities.RemediationItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRemediations indicates an expected call of GetRemediations.
+func (mr *MockRepositoryMockRecorder) GetRemediations(ctx, companyUuid, frameworkUuid, controlUuid, status, ownerUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemediations", reflect.TypeOf((*MockRepository)(nil).GetRemediations), ctx, companyUuid, frameworkUuid, controlUuid, status, ownerUuid)
+}
+
+// UpdateRemediation mocks base method.
+func (m *MockRepository) UpdateRemediation(ctx context.Context, remediationUuid uuid.UUID, values map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRemediation", ctx, remediationUuid, values)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRemediation indicates an expected call of UpdateRemediation.
+func (mr *MockRepositoryMockRecorder) UpdateRemediation(ctx, remediationUuid, values interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRemediation", reflect.TypeOf((*MockRepository)(nil).UpdateRemediation), ctx, remediationUuid, values)
+}
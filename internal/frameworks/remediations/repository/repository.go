@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations/entities"
+	"gorm.io/gorm"
+)
+
+// Repository for the remediations of framework controls.
+type Repository interface {
+	// GetRemediations of the active controls of a framework of the company, all of them or the
+	// one of controlUuid, filtered by status and owner when set.
+	GetRemediations(ctx context.Context, companyUuid, frameworkUuid uuid.UUID, controlUuid *uuid.UUID, status string, ownerUuid *uuid.UUID) ([]*entities.RemediationItem, error)
+	// EnsureRemediation of an active control of a framework of the company, created not started
+	// when the control has none yet.
+	EnsureRemediation(ctx context.Context, companyUuid, frameworkUuid, controlUuid, userUuid uuid.UUID) (*entities.RemediationItem, error)
+	// CheckOwner is an active user of the company or an engineer.
+	CheckOwner(ctx context.Context, companyUuid, ownerUuid uuid.UUID) error
+	UpdateRemediation(ctx context.Context, remediationUuid uuid.UUID, values map[string]interface{}) error
+
+	CreateEvidence(ctx context.Context, evidence []*entities.Evidence) error
+	GetEvidence(ctx context.Context, remediationUuid uuid.UUID) ([]*entities.Evidence, error)
+	GetEvidenceFile(ctx context.Context, remediationUuid, evidenceUuid uuid.UUID) (*entities.Evidence, error)
+	DeleteEvidence(ctx context.Context, evidenceUuid uuid.UUID) error
+
+	CreateComment(ctx context.Context, comment *entities.Comment) error
+	GetComments(ctx context.Context, remediationUuid uuid.UUID) ([]*entities.Comment, error)
+	GetComment(ctx context.Context, remediationUuid, commentUuid uuid.UUID) (*entities.Comment, error)
+	DeleteComment(ctx context.Context, commentUuid uuid.UUID) error
+}
+
+// New repository for control remediations.
+func New(db *gorm.DB) Repository {
+	repo := &sqlRepository{gormDB: db}
+
+	return repo
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	frameworkEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations/entities"
+	userConstants "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/constants"
+	userEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"gorm.io/gorm"
+)
+
+type sqlRepository struct {
+	gormDB *gorm.DB
+}
+
+func (s *sqlRepository) GetRemediations(ctx context.Context, companyUuid, frameworkUuid uuid.UUID, controlUuid *uuid.UUID, status string, ownerUuid *uuid.UUID) ([]*entities.RemediationItem, error) {
+	items := make([]*entities.RemediationItem, 0)
+
+	// controls added to the framework after the company subscribed to it have no remediation yet
+	query := `
+		select cr.control_remediation_uuid, fc.frameworks_uuid, fc.framework_control_uuid, fc.control_id,
+			coalesce(fc.name, '') as name,
+			coalesce(fc.topic, '') as topic,
+			coalesce(cr.status, @not_started) as status,
+			coalesce(cr.priority, @medium) as priority,
+			cr.owner_uuid,
+			coalesce(u.first_name, '') as owner_first_name,
+			coalesce(u.last_name, '') as owner_last_name,
+			coalesce(u.email, '') as owner_email,
+			cr.due_date, cr.completed_at, cr.updated_at
+		from framework_controls fc
+			join company_frameworks cf on cf.frameworks_uuid = fc.frameworks_uuid and cf.company_uuid = @company_uuid
+			left join control_remediations cr on cr.company_uuid = cf.company_uuid and cr.framework_control_uuid = fc.framework_control_uuid
+			left join users u on u.user_uuid = cr.owner_uuid
+		where fc.frameworks_uuid = @framework_uuid
+			and fc.retired_at is null
+			and ` + frameworkEntities.ControlInProfileSQL
+
+	params := map[string]interface{}{
+		"company_uuid":   companyUuid,
+		"framework_uuid": frameworkUuid,
+		"not_started":    frameworkEntities.RemediationStatusNotStarted,
+		"medium":         entities.PriorityMedium,
+	}
+
+	if controlUuid != nil {
+		query += " and fc.framework_control_uuid = @control_uuid"
+		params["control_uuid"] = controlUuid
+	}
+
+	if status != "" {
+		query += " and coalesce(cr.status, @not_started) = @status"
+		params["status"] = status
+	}
+
+	if ownerUuid != nil {
+		query += " and cr.owner_uuid = @owner_uuid"
+		params["owner_uuid"] = ownerUuid
+	}
+
+	query += " order by fc.control_id"
+
+	if err := s.gormDB.WithContext(ctx).Raw(query, params).Scan(&items).Error; err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (s *sqlRepository) EnsureRemediation(ctx context.Context, companyUuid, frameworkUuid, controlUuid, userUuid uuid.UUID) (*entities.RemediationItem, error) {
+	query := `
+		insert into control_remediations (control_remediation_uuid, company_uuid, frameworks_uuid, framework_control_uuid, status, priority, created_at, created_by)
+		select @control_remediation_uuid, cf.company_uuid, fc.frameworks_uuid, fc.framework_control_uuid, @not_started, @medium, now(), @user_uuid
+		from framework_controls fc
+			join company_frameworks cf on cf.frameworks_uuid = fc.frameworks_uuid and cf.company_uuid = @company_uuid
+		where fc.framework_control_uuid = @control_uuid
+			and fc.frameworks_uuid = @framework_uuid
+			and fc.retired_at is null
+			and ` + frameworkEntities.ControlInProfileSQL + `
+		on conflict do nothing`
+
+	err := s.gormDB.WithContext(ctx).Exec(query, map[string]interface{}{
+		"control_remediation_uuid": uuid.New(),
+		"company_uuid":             companyUuid,
+		"framework_uuid":           frameworkUuid,
+		"control_uuid":             controlUuid,
+		"user_uuid":                userUuid,
+		"not_started":              frameworkEntities.RemediationStatusNotStarted,
+		"medium":                   entities.PriorityMedium,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.GetRemediations(ctx, companyUuid, frameworkUuid, &controlUuid, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, &appError.ErrNotFound{Message: "framework control not found"}
+	}
+
+	return items[0], nil
+}
+
+func (s *sqlRepository) CheckOwner(ctx context.Context, companyUuid, ownerUuid uuid.UUID) error {
+	var count int64
+
+	err := s.gormDB.WithContext(ctx).Table("public.users u").
+		Where("u.user_uuid = ?", ownerUuid).
+		Where("u.user_group = ? OR EXISTS (SELECT 1 FROM public.company_users cu WHERE cu.user_uuid = u.user_uuid AND cu.company_uuid = ? AND "+userEntities.ActiveCompanyUserSQL+")",
+			userConstants.UserGroupEngineer, companyUuid).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return &appError.ErrValidation{Message: "owner should be an active user of the company or an engineer"}
+	}
+
+	return nil
+}
+
+func (s *sqlRepository) UpdateRemediation(ctx context.Context, remediationUuid uuid.UUID, values map[string]interface{}) error {
+	return s.gormDB.WithContext(ctx).Model(&frameworkEntities.ControlRemediations{}).
+		Where("control_remediation_uuid = ?", remediationUuid).
+		Updates(values).Error
+}
+
+func (s *sqlRepository) CreateEvidence(ctx context.Context, evidence []*entities.Evidence) error {
+	return s.gormDB.WithContext(ctx).Omit("Creator").Create(evidence).Error
+}
+
+func (s *sqlRepository) GetEvidence(ctx context.Context, remediationUuid uuid.UUID) ([]*entities.Evidence, error) {
+	evidence := make([]*entities.Evidence, 0)
+
+	err := s.gormDB.WithContext(ctx).Model(&entities.Evidence{}).
+		Preload("Creator").
+		Where("control_remediation_uuid = ?", remediationUuid).
+		Order("created_at").
+		Find(&evidence).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return evidence, nil
+}
+
+func (s *sqlRepository) GetEvidenceFile(ctx context.Context, remediationUuid, evidenceUuid uuid.UUID) (*entities.Evidence, error) {
+	var evidence entities.Evidence
+
+	result := s.gormDB.WithContext(ctx).Model(&entities.Evidence{}).
+		Limit(1).
+		Find(&evidence, "evidence_uuid = ? AND control_remediation_uuid = ?", evidenceUuid, remediationUuid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &appError.ErrNotFound{Message: "remediation evidence not found"}
+	}
+
+	return &evidence, nil
+}
+
+func (s *sqlRepository) DeleteEvidence(ctx context.Context, evidenceUuid uuid.UUID) error {
+	return s.gormDB.WithContext(ctx).Delete(&entities.Evidence{}, "evidence_uuid = ?", evidenceUuid).Error
+}
+
+func (s *sqlRepository) CreateComment(ctx context.Context, comment *entities.Comment) error {
+	return s.gormDB.WithContext(ctx).Omit("Author").Create(comment).Error
+}
+
+func (s *sqlRepository) GetComments(ctx context.Context, remediationUuid uuid.UUID) ([]*entities.Comment, error) {
+	comments := make([]*entities.Comment, 0)
+
+	err := s.gormDB.WithContext(ctx).Model(&entities.Comment{}).
+		Preload("Author").
+		Where("control_remediation_uuid = ?", remediationUuid).
+		Order("created_at").
+		Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (s *sqlRepository) GetComment(ctx context.Context, remediationUuid, commentUuid uuid.UUID) (*entities.Comment, error) {
+	var comment entities.Comment
+
+	result := s.gormDB.WithContext(ctx).Model(&entities.Comment{}).
+		Limit(1).
+		Find(&comment, "comment_uuid = ? AND control_remediation_uuid = ?", commentUuid, remediationUuid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &appError.ErrNotFound{Message: "remediation comment not found"}
+	}
+
+	return &comment, nil
+}
+
+func (s *sqlRepository) DeleteComment(ctx context.Context, commentUuid uuid.UUID) error {
+	return s.gormDB.WithContext(ctx).Delete(&entities.Comment{}, "comment_uuid = ?", commentUuid).Error
+}
@@ -0,0 +1,448 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	s3client "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/s3"
+	s3Entities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/s3/entities"
+	frameworkEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations/repository"
+	userEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+)
+
+type Service interface {
+	GetRemediations(ctx context.Context, req *entities.ListRemediationsRequest) ([]*entities.RemediationResponse, error)
+	// GetRemediation of a control with its evidence and comments.
+	GetRemediation(ctx context.Context, req *entities.RemediationRequest) (*entities.RemediationResponse, error)
+	// UpdateRemediation changes the status, priority, owner and due date of the remediation of a
+	// control. Completing it records when.
+	UpdateRemediation(ctx context.Context, req *entities.UpdateRemediationRequest) (*entities.RemediationResponse, error)
+
+	AddEvidence(ctx context.Context, req *entities.AddEvidenceRequest) ([]*entities.EvidenceResponse, error)
+	DownloadEvidence(ctx context.Context, req *entities.EvidenceRequest) (*entities.DownloadEvidenceResponse, error)
+	DeleteEvidence(ctx context.Context, req *entities.EvidenceRequest) error
+
+	GetComments(ctx context.Context, req *entities.RemediationRequest) ([]*entities.CommentResponse, error)
+	AddComment(ctx context.Context, req *entities.AddCommentRequest) (*entities.CommentResponse, error)
+	// DeleteComment of the user, comments of other users are kept.
+	DeleteComment(ctx context.Context, req *entities.CommentRequest) error
+}
+
+type service struct {
+	repo     repository.Repository
+	s3client s3client.Client
+	logger   *zap.SugaredLogger
+}
+
+func (s *service) GetRemediations(ctx context.Context, req *entities.ListRemediationsRequest) ([]*entities.RemediationResponse, error) {
+	if req.Status != "" && !slices.Contains(frameworkEntities.RemediationStatuses, req.Status) {
+		return nil, statusError()
+	}
+
+	items, err := s.repo.GetRemediations(ctx, req.CompanyUuid, req.FrameworkUuid, nil, req.Status, req.OwnerUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	today := today()
+	res := make([]*entities.RemediationResponse, 0, len(items))
+
+	for _, item := range items {
+		res = append(res, remediationResponse(item, today))
+	}
+
+	return res, nil
+}
+
+func (s *service) GetRemediation(ctx context.Context, req *entities.RemediationRequest) (*entities.RemediationResponse, error) {
+	item, err := s.getRemediation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := remediationResponse(item, today())
+
+	if item.ControlRemediationUuid == uuid.Nil {
+		return res, nil
+	}
+
+	evidence, err := s.repo.GetEvidence(ctx, item.ControlRemediationUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range evidence {
+		res.Evidence = append(res.Evidence, evidenceResponse(e))
+	}
+
+	comments, err := s.repo.GetComments(ctx, item.ControlRemediationUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range comments {
+		res.Comments = append(res.Comments, commentResponse(c))
+	}
+
+	return res, nil
+}
+
+func (s *service) UpdateRemediation(ctx context.Context, req *entities.UpdateRemediationRequest) (*entities.RemediationResponse, error) {
+	body := req.Body
+	values := map[string]interface{}{}
+
+	if body.Status != nil {
+		if !slices.Contains(frameworkEntities.RemediationStatuses, *body.Status) {
+			return nil, statusError()
+		}
+
+		values["status"] = *body.Status
+	}
+
+	if body.Priority != nil {
+		if !slices.Contains(entities.Priorities, *body.Priority) {
+			return nil, &appError.ErrValidation{Message: "priority should be one of " + strings.Join(entities.Priorities, ", ")}
+		}
+
+		values["priority"] = *body.Priority
+	}
+
+	if body.DueDate != nil {
+		if *body.DueDate == "" {
+			values["due_date"] = nil
+		} else {
+			dueDate, err := time.Parse(entities.DateLayout, *body.DueDate)
+			if err != nil {
+				return nil, &appError.ErrValidation{Message: "due_date should be a date like " + entities.DateLayout}
+			}
+
+			values["due_date"] = dueDate
+		}
+	}
+
+	if body.OwnerUuid != nil {
+		if *body.OwnerUuid == "" {
+			values["owner_uuid"] = nil
+		} else {
+			ownerUuid, err := uuid.Parse(*body.OwnerUuid)
+			if err != nil {
+				return nil, &appError.ErrValidation{Message: "owner_uuid should be a uuid"}
+			}
+
+			if err = s.repo.CheckOwner(ctx, req.CompanyUuid, ownerUuid); err != nil {
+				return nil, err
+			}
+
+			values["owner_uuid"] = ownerUuid
+		}
+	}
+
+	if len(values) == 0 {
+		return nil, &appError.ErrValidation{Message: "nothing to update"}
+	}
+
+	item, err := s.repo.EnsureRemediation(ctx, req.CompanyUuid, req.FrameworkUuid, req.ControlUuid, req.UserUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if status, ok := values["status"]; ok && status != item.Status {
+		if status == frameworkEntities.RemediationStatusCompleted {
+			values["completed_at"] = now
+		} else {
+			values["completed_at"] = nil
+		}
+	}
+
+	values["updated_at"] = now
+	values["updated_by"] = req.UserUuid
+
+	if err = s.repo.UpdateRemediation(ctx, item.ControlRemediationUuid, values); err != nil {
+		return nil, err
+	}
+
+	return s.GetRemediation(ctx, &req.RemediationRequest)
+}
+
+func (s *service) AddEvidence(ctx context.Context, req *entities.AddEvidenceRequest) ([]*entities.EvidenceResponse, error) {
+	if len(req.Files) == 0 {
+		return nil, &appError.ErrValidation{Message: "files should not be empty"}
+	}
+
+	item, err := s.repo.EnsureRemediation(ctx, req.CompanyUuid, req.FrameworkUuid, req.ControlUuid, req.UserUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	evidence := make([]*entities.Evidence, 0, len(req.Files))
+	objects := make([]s3Entities.BatchUploadObject, 0, len(req.Files))
+
+	for _, f := range req.Files {
+		e := &entities.Evidence{
+			EvidenceUuid:           uuid.New(),
+			ControlRemediationUuid: item.ControlRemediationUuid,
+			FileName:               f.Filename,
+			ContentType:            f.Header.Get("Content-Type"),
+			Size:                   f.Size,
+			CreatedAt:              now,
+			CreatedBy:              req.UserUuid,
+		}
+		e.S3Key = s.s3key(req.CompanyUuid.String(), item.ControlRemediationUuid.String(), e.EvidenceUuid.String(), f.Filename)
+
+		objects = append(objects, s3Entities.BatchUploadObject{
+			FileHeader: f,
+			Key:        e.S3Key,
+			FileName:   f.Filename,
+		})
+		evidence = append(evidence, e)
+	}
+
+	if err = s.s3client.UploadFilesFromFileHeaders(ctx, objects); err != nil {
+		return nil, err
+	}
+
+	if err = s.repo.CreateEvidence(ctx, evidence); err != nil {
+		return nil, err
+	}
+
+	res := make([]*entities.EvidenceResponse, 0, len(evidence))
+	for _, e := range evidence {
+		res = append(res, evidenceResponse(e))
+	}
+
+	return res, nil
+}
+
+func (s *service) DownloadEvidence(ctx context.Context, req *entities.EvidenceRequest) (*entities.DownloadEvidenceResponse, error) {
+	evidence, err := s.getEvidence(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := s.s3client.DownloadFile(ctx, evidence.S3Key)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := evidence.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &entities.DownloadEvidenceResponse{
+		FileName:    evidence.FileName,
+		ContentType: contentType,
+		Content:     content,
+	}, nil
+}
+
+func (s *service) DeleteEvidence(ctx context.Context, req *entities.EvidenceRequest) error {
+	evidence, err := s.getEvidence(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if err = s.s3client.DeleteFile(ctx, evidence.S3Key); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteEvidence(ctx, evidence.EvidenceUuid)
+}
+
+func (s *service) GetComments(ctx context.Context, req *entities.RemediationRequest) ([]*entities.CommentResponse, error) {
+	item, err := s.getRemediation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*entities.CommentResponse, 0)
+
+	if item.ControlRemediationUuid == uuid.Nil {
+		return res, nil
+	}
+
+	comments, err := s.repo.GetComments(ctx, item.ControlRemediationUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range comments {
+		res = append(res, commentResponse(c))
+	}
+
+	return res, nil
+}
+
+func (s *service) AddComment(ctx context.Context, req *entities.AddCommentRequest) (*entities.CommentResponse, error) {
+	body := strings.TrimSpace(req.Body.Body)
+	if body == "" {
+		return nil, &appError.ErrValidation{Message: "body should not be empty"}
+	}
+
+	item, err := s.repo.EnsureRemediation(ctx, req.CompanyUuid, req.FrameworkUuid, req.ControlUuid, req.UserUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &entities.Comment{
+		CommentUuid:            uuid.New(),
+		ControlRemediationUuid: item.ControlRemediationUuid,
+		Body:                   body,
+		CreatedAt:              time.Now(),
+		CreatedBy:              req.UserUuid,
+	}
+
+	if err = s.repo.CreateComment(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	return commentResponse(comment), nil
+}
+
+func (s *service) DeleteComment(ctx context.Context, req *entities.CommentRequest) error {
+	item, err := s.getRemediation(ctx, &req.RemediationRequest)
+	if err != nil {
+		return err
+	}
+
+	if item.ControlRemediationUuid == uuid.Nil {
+		return &appError.ErrNotFound{Message: "remediation comment not found"}
+	}
+
+	comment, err := s.repo.GetComment(ctx, item.ControlRemediationUuid, req.CommentUuid)
+	if err != nil {
+		return err
+	}
+
+	if comment.CreatedBy != req.UserUuid {
+		return &appError.ErrValidation{Message: "only the author can delete a comment"}
+	}
+
+	return s.repo.DeleteComment(ctx, comment.CommentUuid)
+}
+
+// getRemediation of the control, without a uuid when it was never changed.
+func (s *service) getRemediation(ctx context.Context, req *entities.RemediationRequest) (*entities.RemediationItem, error) {
+	items, err := s.repo.GetRemediations(ctx, req.CompanyUuid, req.FrameworkUuid, &req.ControlUuid, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, &appError.ErrNotFound{Message: "framework control not found"}
+	}
+
+	return items[0], nil
+}
+
+func (s *service) getEvidence(ctx context.Context, req *entities.EvidenceRequest) (*entities.Evidence, error) {
+	item, err := s.getRemediation(ctx, &req.RemediationRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.ControlRemediationUuid == uuid.Nil {
+		return nil, &appError.ErrNotFound{Message: "remediation evidence not found"}
+	}
+
+	return s.repo.GetEvidenceFile(ctx, item.ControlRemediationUuid, req.EvidenceUuid)
+}
+
+// S3 Key
+//
+//	{company_uuid}/control_remediation_evidences/{control_remediation_uuid}/{evidence_uuid}/file.png
+func (s *service) s3key(companyUuid, remediationUuid, evidenceUuid, filename string) string {
+	return fmt.Sprintf("%s/control_remediation_evidences/%s/%s/%s", companyUuid, remediationUuid, evidenceUuid, filename)
+}
+
+func statusError() error {
+	return &appError.ErrValidation{Message: "status should be one of " + strings.Join(frameworkEntities.RemediationStatuses, ", ")}
+}
+
+// today as a date, due dates are days.
+func today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+func remediationResponse(item *entities.RemediationItem, today time.Time) *entities.RemediationResponse {
+	res := &entities.RemediationResponse{
+		ControlRemediationUuid: item.ControlRemediationUuid,
+		FrameworksUuid:         item.FrameworksUuid,
+		FrameworkControlUuid:   item.FrameworkControlUuid,
+		ControlId:              item.ControlId,
+		Name:                   item.Name,
+		Topic:                  item.Topic,
+		Status:                 item.Status,
+		Priority:               item.Priority,
+		CompletedAt:            item.CompletedAt,
+		UpdatedAt:              item.UpdatedAt,
+	}
+
+	if item.OwnerUuid != nil {
+		res.Owner = &entities.UserInfo{
+			UserUuid:  *item.OwnerUuid,
+			FirstName: item.OwnerFirstName,
+			LastName:  item.OwnerLastName,
+			Email:     item.OwnerEmail,
+		}
+	}
+
+	if item.DueDate != nil {
+		dueDate := item.DueDate.Format(entities.DateLayout)
+		res.DueDate = &dueDate
+
+		done := item.Status == frameworkEntities.RemediationStatusCompleted || item.Status == frameworkEntities.RemediationStatusNotApplicable
+		res.Overdue = !done && item.DueDate.Before(today)
+	}
+
+	return res
+}
+
+func evidenceResponse(e *entities.Evidence) *entities.EvidenceResponse {
+	return &entities.EvidenceResponse{
+		EvidenceUuid: e.EvidenceUuid,
+		FileName:     e.FileName,
+		ContentType:  e.ContentType,
+		Size:         e.Size,
+		UploadedBy:   userInfo(e.CreatedBy, &e.Creator),
+		CreatedAt:    e.CreatedAt,
+	}
+}
+
+func commentResponse(c *entities.Comment) *entities.CommentResponse {
+	return &entities.CommentResponse{
+		CommentUuid: c.CommentUuid,
+		Body:        c.Body,
+		Author:      userInfo(c.CreatedBy, &c.Author),
+		CreatedAt:   c.CreatedAt,
+	}
+}
+
+func userInfo(userUuid uuid.UUID, user *userEntities.User) *entities.UserInfo {
+	return &entities.UserInfo{
+		UserUuid:  userUuid,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Email:     user.Email,
+	}
+}
+
+// New remediations service.
+func New(repo repository.Repository, s3client s3client.Client, logger *zap.SugaredLogger) Service {
+	return &service{
+		repo:     repo,
+		s3client: s3client,
+		logger:   logger,
+	}
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"mime/multipart"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	s3client "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/s3"
+	s3Entities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/s3/entities"
+	frameworkEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations/repository"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+)
+
+func TestUpdateRemediation(t *testing.T) {
+	Convey("Given the remediation of a control", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, nil, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		req := entities.RemediationRequest{CompanyUuid: uuid.New(), UserUuid: uuid.New(), FrameworkUuid: uuid.New(), ControlUuid: uuid.New()}
+		item := &entities.RemediationItem{
+			ControlRemediationUuid: uuid.New(), FrameworksUuid: req.FrameworkUuid, FrameworkControlUuid: req.ControlUuid,
+			ControlId: "1.1", Status: frameworkEntities.RemediationStatusInReview, Priority: entities.PriorityMedium,
+		}
+
+		update := func(body *entities.UpdateRemediationRequestBody) (*entities.RemediationResponse, error) {
+			return svc.UpdateRemediation(ctx, &entities.UpdateRemediationRequest{RemediationRequest: req, Body: body})
+		}
+
+		Convey("Completing it records when, the owner is checked and the due date parsed", func() {
+			ownerUuid := uuid.New()
+			status, priority, owner, dueDate := frameworkEntities.RemediationStatusCompleted, entities.PriorityHigh, ownerUuid.String(), "2023-05-01"
+
+			repo.EXPECT().CheckOwner(ctx, req.CompanyUuid, ownerUuid).Return(nil)
+			repo.EXPECT().EnsureRemediation(ctx, req.CompanyUuid, req.FrameworkUuid, req.ControlUuid, req.UserUuid).Return(item, nil)
+			repo.EXPECT().UpdateRemediation(ctx, item.ControlRemediationUuid, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, values map[string]interface{}) error {
+				So(values["status"], ShouldEqual, frameworkEntities.RemediationStatusCompleted)
+				So(values["priority"], ShouldEqual, entities.PriorityHigh)
+				So(values["owner_uuid"], ShouldEqual, ownerUuid)
+				So(values["due_date"], ShouldEqual, time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC))
+				So(values["completed_at"], ShouldNotBeNil)
+				So(values["updated_by"], ShouldEqual, req.UserUuid)
+
+				return nil
+			})
+
+			completed := *item
+			completed.Status = frameworkEntities.RemediationStatusCompleted
+			completed.DueDate = &time.Time{}
+			repo.EXPECT().GetRemediations(ctx, req.CompanyUuid, req.FrameworkUuid, &req.ControlUuid, "", nil).Return([]*entities.RemediationItem{&completed}, nil)
+			repo.EXPECT().GetEvidence(ctx, item.ControlRemediationUuid).Return(nil, nil)
+			repo.EXPECT().GetComments(ctx, item.ControlRemediationUuid).Return(nil, nil)
+
+			res, err := update(&entities.UpdateRemediationRequestBody{Status: &status, Priority: &priority, OwnerUuid: &owner, DueDate: &dueDate})
+			So(err, ShouldBeNil)
+			So(res.Status, ShouldEqual, frameworkEntities.RemediationStatusCompleted)
+			So(res.Overdue, ShouldBeFalse)
+		})
+
+		Convey("Reopening it clears when it was completed and an empty owner removes the owner", func() {
+			status, owner := frameworkEntities.RemediationStatusInProgress, ""
+			item.Status = frameworkEntities.RemediationStatusCompleted
+
+			repo.EXPECT().EnsureRemediation(ctx, req.CompanyUuid, req.FrameworkUuid, req.ControlUuid, req.UserUuid).Return(item, nil)
+			repo.EXPECT().UpdateRemediation(ctx, item.ControlRemediationUuid, gomock.Any()).DoAndReturn(func(_ context.Context, _ uuid.UUID, values map[string]interface{}) error {
+				So(values, ShouldContainKey, "completed_at")
+				So(values["completed_at"], ShouldBeNil)
+				So(values, ShouldContainKey, "owner_uuid")
+				So(values["owner_uuid"], ShouldBeNil)
+
+				return nil
+			})
+			repo.EXPECT().GetRemediations(ctx, req.CompanyUuid, req.FrameworkUuid, &req.ControlUuid, "", nil).Return([]*entities.RemediationItem{item}, nil)
+			repo.EXPECT().GetEvidence(ctx, item.ControlRemediationUuid).Return(nil, nil)
+			repo.EXPECT().GetComments(ctx, item.ControlRemediationUuid).Return(nil, nil)
+
+			_, err := update(&entities.UpdateRemediationRequestBody{Status: &status, OwnerUuid: &owner})
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Unknown statuses, priorities and dates are rejected", func() {
+			var validationErr *appError.ErrValidation
+
+			status, priority, dueDate := "pending", "urgent", "05/01/2023"
+
+			_, err := update(&entities.UpdateRemediationRequestBody{Status: &status})
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+			So(validationErr.Message, ShouldEqual, "status should be one of not_started, in_progress, blocked, in_review, completed, not_applicable")
+
+			_, err = update(&entities.UpdateRemediationRequestBody{Priority: &priority})
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+
+			_, err = update(&entities.UpdateRemediationRequestBody{DueDate: &dueDate})
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+
+			_, err = update(&entities.UpdateRemediationRequestBody{})
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+		})
+	})
+}
+
+func TestGetRemediations(t *testing.T) {
+	Convey("Given remediations with due dates", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, nil, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		req := &entities.ListRemediationsRequest{CompanyUuid: uuid.New(), UserUuid: uuid.New(), FrameworkUuid: uuid.New()}
+		past := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		ownerUuid := uuid.New()
+
+		Convey("Remediations past their due date are overdue until done", func() {
+			repo.EXPECT().GetRemediations(ctx, req.CompanyUuid, req.FrameworkUuid, nil, "", nil).Return([]*entities.RemediationItem{
+				{ControlId: "1.1", Status: frameworkEntities.RemediationStatusBlocked, DueDate: &past, OwnerUuid: &ownerUuid, OwnerEmail: "owner@customer"},
+				{ControlId: "1.2", Status: frameworkEntities.RemediationStatusNotApplicable, DueDate: &past},
+				{ControlId: "1.3", Status: frameworkEntities.RemediationStatusNotStarted},
+			}, nil)
+
+			res, err := svc.GetRemediations(ctx, req)
+			So(err, ShouldBeNil)
+			So(res, ShouldHaveLength, 3)
+			So(res[0].Overdue, ShouldBeTrue)
+			So(*res[0].DueDate, ShouldEqual, "2020-01-01")
+			So(res[0].Owner, ShouldResemble, &entities.UserInfo{UserUuid: ownerUuid, Email: "owner@customer"})
+			So(res[1].Overdue, ShouldBeFalse)
+			So(res[2].DueDate, ShouldBeNil)
+			So(res[2].Owner, ShouldBeNil)
+		})
+
+		Convey("Unknown statuses are rejected", func() {
+			req.Status = "done"
+
+			_, err := svc.GetRemediations(ctx, req)
+
+			var validationErr *appError.ErrValidation
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+		})
+	})
+}
+
+func TestEvidenceAndComments(t *testing.T) {
+	Convey("Given the remediation of a control", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		s3 := s3client.NewMockClient(ctrl)
+		svc := New(repo, s3, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		req := entities.RemediationRequest{CompanyUuid: uuid.New(), UserUuid: uuid.New(), FrameworkUuid: uuid.New(), ControlUuid: uuid.New()}
+		item := &entities.RemediationItem{ControlRemediationUuid: uuid.New(), Status: frameworkEntities.RemediationStatusInProgress}
+
+		Convey("Evidence files are uploaded by remediation and saved", func() {
+			file := &multipart.FileHeader{Filename: "scan.pdf", Size: 42, Header: textproto.MIMEHeader{"Content-Type": {"application/pdf"}}}
+
+			repo.EXPECT().EnsureRemediation(ctx, req.CompanyUuid, req.FrameworkUuid, req.ControlUuid, req.UserUuid).Return(item, nil)
+			s3.EXPECT().UploadFilesFromFileHeaders(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, objects []s3Entities.BatchUploadObject) error {
+				So(objects, ShouldHaveLength, 1)
+				So(objects[0].Key, ShouldStartWith, req.CompanyUuid.String()+"/control_remediation_evidences/"+item.ControlRemediationUuid.String()+"/")
+				So(objects[0].Key, ShouldEndWith, "/scan.pdf")
+
+				return nil
+			})
+			repo.EXPECT().CreateEvidence(ctx, gomock.Any()).Return(nil)
+
+			res, err := svc.AddEvidence(ctx, &entities.AddEvidenceRequest{RemediationRequest: req, Files: []*multipart.FileHeader{file}})
+			So(err, ShouldBeNil)
+			So(res, ShouldHaveLength, 1)
+			So(res[0].ContentType, ShouldEqual, "application/pdf")
+			So(res[0].UploadedBy.UserUuid, ShouldEqual, req.UserUuid)
+		})
+
+		Convey("Only the author deletes a comment", func() {
+			comment := &entities.Comment{CommentUuid: uuid.New(), ControlRemediationUuid: item.ControlRemediationUuid, CreatedBy: uuid.New()}
+
+			repo.EXPECT().GetRemediations(ctx, req.CompanyUuid, req.FrameworkUuid, &req.ControlUuid, "", nil).Return([]*entities.RemediationItem{item}, nil)
+			repo.EXPECT().GetComment(ctx, item.ControlRemediationUuid, comment.CommentUuid).Return(comment, nil)
+
+			err := svc.DeleteComment(ctx, &entities.CommentRequest{RemediationRequest: req, CommentUuid: comment.CommentUuid})
+
+			var validationErr *appError.ErrValidation
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+			So(validationErr.Message, ShouldEqual, "only the author can delete a comment")
+		})
+
+		Convey("Empty comments are rejected", func() {
+			_, err := svc.AddComment(ctx, &entities.AddCommentRequest{RemediationRequest: req, Body: &entities.AddCommentRequestBody{Body: "  "}})
+
+			var validationErr *appError.ErrValidation
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,138 @@
+// Package http for control remediations.
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations/entities"
+	httpError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors/http"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"github.com/pkg/errors"
+)
+
+func decodeRemediationPath(r *http.Request, names ...string) (*entities.RemediationRequest, []uuid.UUID, error) {
+	ids, err := httpTransport.DecodePathIDs(r, append([]string{"company_id", "user_id", "framework_id", "control_id"}, names...)...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &entities.RemediationRequest{
+		CompanyUuid:   ids[0],
+		UserUuid:      ids[1],
+		FrameworkUuid: ids[2],
+		ControlUuid:   ids[3],
+	}, ids[4:], nil
+}
+
+func decodeGetRemediationsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	ids, err := httpTransport.DecodePathIDs(r, "company_id", "user_id", "framework_id")
+	if err != nil {
+		return nil, err
+	}
+
+	req := &entities.ListRemediationsRequest{
+		CompanyUuid:   ids[0],
+		UserUuid:      ids[1],
+		FrameworkUuid: ids[2],
+		Status:        r.URL.Query().Get("status"),
+	}
+
+	if v := r.URL.Query().Get("owner_uuid"); v != "" {
+		ownerUuid, err := uuid.Parse(v)
+		if err != nil {
+			return nil, httpError.NewErrBadOrInvalidPathParameter("owner_uuid")
+		}
+
+		req.OwnerUuid = &ownerUuid
+	}
+
+	return req, nil
+}
+
+func decodeRemediationRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req, _, err := decodeRemediationPath(r)
+
+	return req, err
+}
+
+func decodeUpdateRemediationRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req, _, err := decodeRemediationPath(r)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Body.Close()
+
+	body := &entities.UpdateRemediationRequestBody{}
+	if err = json.NewDecoder(r.Body).Decode(body); err != nil {
+		return nil, errors.WithMessage(httpError.ErrBadRequestBody, err.Error())
+	}
+
+	return &entities.UpdateRemediationRequest{
+		RemediationRequest: *req,
+		Body:               body,
+	}, nil
+}
+
+func decodeAddEvidenceRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req, _, err := decodeRemediationPath(r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.ParseMultipartForm(10 * 1024 * 1024)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.AddEvidenceRequest{
+		RemediationRequest: *req,
+		Files:              r.MultipartForm.File["files"],
+	}, nil
+}
+
+func decodeEvidenceRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req, ids, err := decodeRemediationPath(r, "evidence_id")
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.EvidenceRequest{
+		RemediationRequest: *req,
+		EvidenceUuid:       ids[0],
+	}, nil
+}
+
+func decodeAddCommentRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req, _, err := decodeRemediationPath(r)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Body.Close()
+
+	body := &entities.AddCommentRequestBody{}
+	if err = json.NewDecoder(r.Body).Decode(body); err != nil {
+		return nil, errors.WithMessage(httpError.ErrBadRequestBody, err.Error())
+	}
+
+	return &entities.AddCommentRequest{
+		RemediationRequest: *req,
+		Body:               body,
+	}, nil
+}
+
+func decodeCommentRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req, ids, err := decodeRemediationPath(r, "comment_id")
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.CommentRequest{
+		RemediationRequest: *req,
+		CommentUuid:        ids[0],
+	}, nil
+}
@@ -0,0 +1,156 @@
+// Package http for control remediations.
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	goKitEndpoint "github.com/go-kit/kit/endpoint"
+	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations/endpoints"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/remediations/entities"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+)
+
+// RegisterTransport for http.
+func RegisterTransport(
+	server *httpTransport.Server,
+	ep *endpoints.Endpoints,
+	authClient auth.Client,
+	svcTransportClient svcTransport.Client,
+) {
+	registerGetRemediations(server, ep.GetRemediationsEndpoint, authClient, svcTransportClient)
+	registerGetRemediation(server, ep.GetRemediationEndpoint, authClient, svcTransportClient)
+	registerUpdateRemediation(server, ep.UpdateRemediationEndpoint, authClient, svcTransportClient)
+	registerAddEvidence(server, ep.AddEvidenceEndpoint, authClient, svcTransportClient)
+	registerDownloadEvidence(server, ep.DownloadEvidenceEndpoint, authClient, svcTransportClient)
+	registerDeleteEvidence(server, ep.DeleteEvidenceEndpoint, authClient, svcTransportClient)
+	registerGetComments(server, ep.GetCommentsEndpoint, authClient, svcTransportClient)
+	registerAddComment(server, ep.AddCommentEndpoint, authClient, svcTransportClient)
+	registerDeleteComment(server, ep.DeleteCommentEndpoint, authClient, svcTransportClient)
+}
+
+func registerGetRemediations(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/frameworks/{framework_id}/remediations"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetRemediationsRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerGetRemediation(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/frameworks/{framework_id}/controls/{control_id}/remediation"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeRemediationRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerUpdateRemediation(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/frameworks/{framework_id}/controls/{control_id}/remediation"
+	method := "PUT"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", permissions.ActionUpdate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeUpdateRemediationRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerAddEvidence(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/frameworks/{framework_id}/controls/{control_id}/remediation/evidence"
+	method := "POST"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", permissions.ActionCreate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeAddEvidenceRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerDownloadEvidence(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/frameworks/{framework_id}/controls/{control_id}/remediation/evidence/{evidence_id}"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encodeDownloadEvidenceResponse, []string{method})
+	handler := getHandler(securedEp, decodeEvidenceRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerDeleteEvidence(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/frameworks/{framework_id}/controls/{control_id}/remediation/evidence/{evidence_id}"
+	method := "DELETE"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", permissions.ActionDelete)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeEvidenceRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerGetComments(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/frameworks/{framework_id}/controls/{control_id}/remediation/comments"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeRemediationRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerAddComment(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/frameworks/{framework_id}/controls/{control_id}/remediation/comments"
+	method := "POST"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", permissions.ActionCreate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeAddCommentRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerDeleteComment(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/frameworks/{framework_id}/controls/{control_id}/remediation/comments/{comment_id}"
+	method := "DELETE"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", permissions.ActionDelete)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeCommentRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func encodeDownloadEvidenceResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	file := response.(*entities.DownloadEvidenceResponse) //nolint:errcheck
+
+	w.Header().Set("Content-Disposition", "attachment; filename="+strconv.Quote(file.FileName))
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(file.Content)))
+	_, err := w.Write(file.Content)
+
+	return err
+}
+
+func getHandler(ep goKitEndpoint.Endpoint, dec goKitHTTPTransport.DecodeRequestFunc, enc goKitHTTPTransport.EncodeResponseFunc, atc svcTransport.Client, method string) *goKitHTTPTransport.Server {
+	return goKitHTTPTransport.NewServer(
+		ep,
+		dec,
+		enc,
+		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
+		goKitHTTPTransport.ServerErrorHandler(atc.LogErrorHandler()),
+	)
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/db"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// remediatedMappings joins the mappings m, both ways, of the controls to the controls mc of the
+// other frameworks of the company with a remediation mcr completed, or evidenced unless not
+// applicable.
+const remediatedMappings = `(
+		select framework_control_uuid, mapped_control_uuid from framework_control_mappings
+		union all
+		select mapped_control_uuid, framework_control_uuid from framework_control_mappings
+	) m
+	join framework_controls mc on mc.framework_control_uuid = m.mapped_control_uuid and mc.retired_at is null
+	join company_frameworks mcf on mcf.frameworks_uuid = mc.frameworks_uuid and mcf.company_uuid = @company_uuid
+	join control_remediations mcr on mcr.framework_control_uuid = mc.framework_control_uuid
+		and mcr.company_uuid = @company_uuid
+		and (mcr.status = @completed or (mcr.status <> @not_applicable and exists (
+			select 1 from control_remediation_evidences e where e.control_remediation_uuid = mcr.control_remediation_uuid)))`
+
+// remediationStatus of the control fc by the company, not started without a remediation.
+const remediationStatus = `coalesce((
+	select cr.status from control_remediations cr
+	where cr.framework_control_uuid = fc.framework_control_uuid
+		and cr.company_uuid = @company_uuid), @not_started)`
+
+func (s *sqlRepository) GetControlMappings(ctx context.Context, frameworkUuids []uuid.UUID) ([]*entities.ControlMapping, error) {
+	mappings := make([]*entities.ControlMapping, 0)
+
+	err := s.gormDB.WithContext(ctx).Model(&entities.ControlMapping{}).
+		Joins("join framework_controls fc on fc.framework_control_uuid = framework_control_mappings.framework_control_uuid").
+		Joins("join framework_controls mc on mc.framework_control_uuid = framework_control_mappings.mapped_control_uuid").
+		Where("fc.frameworks_uuid IN ? AND mc.frameworks_uuid IN ?", frameworkUuids, frameworkUuids).
+		Find(&mappings).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return mappings, nil
+}
+
+// ImportControlMappings saves the changes to the mappings in one transaction.
+func (s *sqlRepository) ImportControlMappings(ctx context.Context, mappings *entities.ControlMappingImport) error {
+	return s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(mappings.Removed) > 0 {
+			err := tx.Where("control_mapping_uuid IN ?", mappings.Removed).
+				Delete(&entities.ControlMapping{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(mappings.Added) > 0 {
+			if err := tx.Create(&mappings.Added).Error; err != nil {
+				if db.IsAlreadyExistError(err) {
+					return errors.WithMessage(err, "control mapping already exists")
+				}
+
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *sqlRepository) GetSatisfiedVia(ctx context.Context, companyUuid, frameworkUuid *uuid.UUID) ([]*entities.SatisfiedVia, error) {
+	satisfiedVia := make([]*entities.SatisfiedVia, 0)
+
+	query := `
+		select * from (
+			select distinct on (m.framework_control_uuid, mc.framework_control_uuid)
+				m.framework_control_uuid, mc.frameworks_uuid, f.name as framework,
+				mc.framework_control_uuid as mapped_control_uuid, mc.control_id, mc.name, mcr.control_remediation_uuid
+			from ` + remediatedMappings + `
+				join framework_controls fc on fc.framework_control_uuid = m.framework_control_uuid
+				join frameworks f on f.frameworks_uuid = mc.frameworks_uuid
+			where fc.frameworks_uuid = @frameworks_uuid
+				and fc.retired_at is null
+			order by m.framework_control_uuid, mc.framework_control_uuid, mcr.created_at desc
+		) sv
+		order by sv.framework, sv.control_id`
+
+	err := s.gormDB.WithContext(ctx).Raw(query, map[string]interface{}{
+		"company_uuid":    companyUuid,
+		"frameworks_uuid": frameworkUuid,
+		"completed":       entities.RemediationStatusCompleted,
+		"not_applicable":  entities.RemediationStatusNotApplicable,
+	}).Scan(&satisfiedVia).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return satisfiedVia, nil
+}
+
+func (s *sqlRepository) GetRemediationCoverageStats(ctx context.Context, companyUuid *uuid.UUID) ([]*entities.RemediationCoverageStats, error) {
+	var stats []*entities.RemediationCoverageStats
+
+	query := `
+		with satisfied_via as (
+			select distinct m.framework_control_uuid from ` + remediatedMappings + `
+		), controls as (
+			select f.name, fc.framework_control_uuid, ` + remediationStatus + ` as status
+			from company_frameworks cf
+				join frameworks f on f.frameworks_uuid = cf.frameworks_uuid
+				join framework_controls fc on fc.frameworks_uuid = f.frameworks_uuid and fc.retired_at is null
+			where cf.company_uuid = @company_uuid
+				and ` + entities.ControlInProfileSQL + `
+		)
+		select c.name,
+			count(*) filter (where c.status <> @not_applicable) as total,
+			count(*) filter (where c.status = @completed) as remediated,
+			count(*) filter (where c.status = @completed
+				or (c.status <> @not_applicable and sv.framework_control_uuid is not null)) as satisfied
+		from controls c
+			left join satisfied_via sv on sv.framework_control_uuid = c.framework_control_uuid
+		group by c.name`
+
+	err := s.gormDB.WithContext(ctx).Raw(query, map[string]interface{}{
+		"company_uuid":   companyUuid,
+		"not_started":    entities.RemediationStatusNotStarted,
+		"completed":      entities.RemediationStatusCompleted,
+		"not_applicable": entities.RemediationStatusNotApplicable,
+	}).Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (s *sqlRepository) GetRemediationStatusStats(ctx context.Context, companyUuid *uuid.UUID) ([]*entities.RemediationStatusStats, error) {
+	var stats []*entities.RemediationStatusStats
+
+	query := `
+		select f.name, ` + remediationStatus + ` as status, count(*) as count
+		from company_frameworks cf
+			join frameworks f on f.frameworks_uuid = cf.frameworks_uuid
+			join framework_controls fc on fc.frameworks_uuid = f.frameworks_uuid and fc.retired_at is null
+		where cf.company_uuid = @company_uuid
+			and ` + entities.ControlInProfileSQL + `
+		group by 1, 2`
+
+	err := s.gormDB.WithContext(ctx).Raw(query, map[string]interface{}{
+		"company_uuid": companyUuid,
+		"not_started":  entities.RemediationStatusNotStarted,
+	}).Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -51,6 +51,20 @@ func (mr *MockRepositoryMockRecorder) CreateCompanyFrameworksLink(ctx, companyUU
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompanyFrameworksLink", reflect.TypeOf((*MockRepository)(nil).CreateCompanyFrameworksLink), ctx, companyUUID, userUUID, frameworkNames)
 }
 
+// CreateCompanySubscription mocks base method.
+func (m *MockRepository) CreateCompanySubscription(ctx context.Context, cs *entities.CompanySubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCompanySubscription", ctx, cs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCompanySubscription indicates an expected call of CreateCompanySubscription.
+func (mr *MockRepositoryMockRecorder) CreateCompanySubscription(ctx, cs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompanySubscription", reflect.TypeOf((*MockRepository)(nil).CreateCompanySubscription), ctx, cs)
+}
+
 // CreateFrameworkControlRemediations mocks base method.
 func (m *MockRepository) CreateFrameworkControlRemediations(ctx context.Context, companyUUID, userUUID *uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -94,6 +108,82 @@ func (mr *MockRepositoryMockRecorder) GetCompanyByUUID(ctx, companyUuid interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyByUUID", reflect.TypeOf((*MockRepository)(nil).GetCompanyByUUID), ctx, companyUuid)
 }
 
+// GetCompanyFramework mocks base method.
+func (m *MockRepository) GetCompanyFramework(ctx context.Context, companyUuid, frameworkUuid *uuid.UUID) (*entities0.CompanyFrameworks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyFramework", ctx, companyUuid, frameworkUuid)
+	ret0, _ := ret[0].(*entities0.CompanyFrameworks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyFramework indicates an expected call of GetCompanyFramework.
+func (mr *MockRepositoryMockRecorder) GetCompanyFramework(ctx, companyUuid, frameworkUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyFramework", reflect.TypeOf((*MockRepository)(nil).GetCompanyFramework), ctx, companyUuid, frameworkUuid)
+}
+
+// GetControlMappings mocks base method.
+func (m *MockRepository) GetControlMappings(ctx context.Context, frameworkUuids []uuid.UUID) ([]*entities0.ControlMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetControlMappings", ctx, frameworkUuids)
+	ret0, _ := ret[0].([]*entities0.ControlMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetControlMappings indicates an expected call of GetControlMappings.
+func (mr *MockRepositoryMockRecorder) GetControlMappings(ctx, frameworkUuids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetControlMappings", reflect.TypeOf((*MockRepository)(nil).GetControlMappings), ctx, frameworkUuids)
+}
+
+// GetControlPolicies mocks base method.
+func (m *MockRepository) GetControlPolicies(ctx context.Context, companyUuid, frameworkUuid *uuid.UUID) ([]*entities0.ControlPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetControlPolicies", ctx, companyUuid, frameworkUuid)
+	ret0, _ := ret[0].([]*entities0.ControlPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetControlPolicies indicates an expected call of GetControlPolicies.
+func (mr *MockRepositoryMockRecorder) GetControlPolicies(ctx, companyUuid, frameworkUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetControlPolicies", reflect.TypeOf((*MockRepository)(nil).GetControlPolicies), ctx, companyUuid, frameworkUuid)
+}
+
+// GetFramework mocks base method.
+func (m *MockRepository) GetFramework(ctx context.Context, frameworkUuid *uuid.UUID) (*entities0.Framework, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFramework", ctx, frameworkUuid)
+	ret0, _ := ret[0].(*entities0.Framework)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFramework indicates an expected call of GetFramework.
+func (mr *MockRepositoryMockRecorder) GetFramework(ctx, frameworkUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFramework", reflect.TypeOf((*MockRepository)(nil).GetFramework), ctx, frameworkUuid)
+}
+
+// GetFrameworkCatalog mocks base method.
+func (m *MockRepository) GetFrameworkCatalog(ctx context.Context, name string) (*entities0.Framework, []*entities0.FrameworkControl, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFrameworkCatalog", ctx, name)
+	ret0, _ := ret[0].(*entities0.Framework)
+	ret1, _ := ret[1].([]*entities0.FrameworkControl)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFrameworkCatalog indicates an expected call of GetFrameworkCatalog.
+func (mr *MockRepositoryMockRecorder) GetFrameworkCatalog(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFrameworkCatalog", reflect.TypeOf((*MockRepository)(nil).GetFrameworkCatalog), ctx, name)
+}
+
 // GetFrameworkControls mocks base method.
 func (m *MockRepository) GetFrameworkControls(ctx context.Context, companyUuid, userUuid, frameworkUuid *uuid.UUID) ([]*entities0.FrameworkControl, error) {
 	m.ctrl.T.Helper()
@@ -138,3 +228,135 @@ func (mr *MockRepositoryMockRecorder) GetFrameworks(ctx, companyUuid, userUuid i
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFrameworks", reflect.TypeOf((*MockRepository)(nil).GetFrameworks), ctx, companyUuid, userUuid)
 }
+
+// GetPolicyCoverageStats mocks base method.
+func (m *MockRepository) GetPolicyCoverageStats(ctx context.Context, companyUuid *uuid.UUID) ([]*entities0.PolicyCoverageStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicyCoverageStats", ctx, companyUuid)
+	ret0, _ := ret[0].([]*entities0.PolicyCoverageStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicyCoverageStats indicates an expected call of GetPolicyCoverageStats.
+func (mr *MockRepositoryMockRecorder) GetPolicyCoverageStats(ctx, companyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyCoverageStats", reflect.TypeOf((*MockRepository)(nil).GetPolicyCoverageStats), ctx, companyUuid)
+}
+
+// GetProfileControls mocks base method.
+func (m *MockRepository) GetProfileControls(ctx context.Context, frameworkUuid *uuid.UUID) ([]*entities0.FrameworkControl, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfileControls", ctx, frameworkUuid)
+	ret0, _ := ret[0].([]*entities0.FrameworkControl)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfileControls indicates an expected call of GetProfileControls.
+func (mr *MockRepositoryMockRecorder) GetProfileControls(ctx, frameworkUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileControls", reflect.TypeOf((*MockRepository)(nil).GetProfileControls), ctx, frameworkUuid)
+}
+
+// GetProfileQuestionnaires mocks base method.
+func (m *MockRepository) GetProfileQuestionnaires(ctx context.Context, frameworkUuid *uuid.UUID) ([]*entities0.ProfileQuestionnaire, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfileQuestionnaires", ctx, frameworkUuid)
+	ret0, _ := ret[0].([]*entities0.ProfileQuestionnaire)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfileQuestionnaires indicates an expected call of GetProfileQuestionnaires.
+func (mr *MockRepositoryMockRecorder) GetProfileQuestionnaires(ctx, frameworkUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileQuestionnaires", reflect.TypeOf((*MockRepository)(nil).GetProfileQuestionnaires), ctx, frameworkUuid)
+}
+
+// GetRemediationCoverageStats mocks base method.
+func (m *MockRepository) GetRemediationCoverageStats(ctx context.Context, companyUuid *uuid.UUID) ([]*entities0.RemediationCoverageStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemediationCoverageStats", ctx, companyUuid)
+	ret0, _ := ret[0].([]*entities0.RemediationCoverageStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRemediationCoverageStats indicates an expected call of GetRemediationCoverageStats.
+func (mr *MockRepositoryMockRecorder) GetRemediationCoverageStats(ctx, companyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemediationCoverageStats", reflect.TypeOf((*MockRepository)(nil).GetRemediationCoverageStats), ctx, companyUuid)
+}
+
+// GetRemediationStatusStats mocks base method.
+func (m *MockRepository) GetRemediationStatusStats(ctx context.Context, companyUuid *uuid.UUID) ([]*entities0.RemediationStatusStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemediationStatusStats", ctx, companyUuid)
+	ret0, _ := ret[0].([]*entities0.RemediationStatusStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRemediationStatusStats indicates an expected call of GetRemediationStatusStats.
+func (mr *MockRepositoryMockRecorder) GetRemediationStatusStats(ctx, companyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemediationStatusStats", reflect.TypeOf((*MockRepository)(nil).GetRemediationStatusStats), ctx, companyUuid)
+}
+
+// GetSatisfiedVia mocks base method.
+func (m *MockRepository) GetSatisfiedVia(ctx context.Context, companyUuid, frameworkUuid *uuid.UUID) ([]*entities0.SatisfiedVia, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSatisfiedVia", ctx, companyUuid, frameworkUuid)
+	ret0, _ := ret[0].([]*entities0.SatisfiedVia)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSatisfiedVia indicates an expected call of GetSatisfiedVia.
+func (mr *MockRepositoryMockRecorder) GetSatisfiedVia(ctx, companyUuid, frameworkUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSatisfiedVia", reflect.TypeOf((*MockRepository)(nil).GetSatisfiedVia), ctx, companyUuid, frameworkUuid)
+}
+
+// ImportCatalog mocks base method.
+func (m *MockRepository) ImportCatalog(ctx context.Context, catalog *entities0.CatalogImport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCatalog", ctx, catalog)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportCatalog indicates an expected call of ImportCatalog.
+func (mr *MockRepositoryMockRecorder) ImportCatalog(ctx, catalog interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCatalog", reflect.TypeOf((*MockRepository)(nil).ImportCatalog), ctx, catalog)
+}
+
+// ImportControlMappings mocks base method.
+func (m *MockRepository) ImportControlMappings(ctx context.Context, mappings *entities0.ControlMappingImport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportControlMappings", ctx, mappings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportControlMappings indicates an expected call of ImportControlMappings.
+func (mr *MockRepositoryMockRecorder) ImportControlMappings(ctx, mappings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportControlMappings", reflect.TypeOf((*MockRepository)(nil).ImportControlMappings), ctx, mappings)
+}
+
+// UpdateCompanyFrameworkProfile mocks base method.
+func (m *MockRepository) UpdateCompanyFrameworkProfile(ctx context.Context, companyFrameworkUuid, userUuid uuid.UUID, profile *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCompanyFrameworkProfile", ctx, companyFrameworkUuid, userUuid, profile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCompanyFrameworkProfile indicates an expected call of UpdateCompanyFrameworkProfile.
+func (mr *MockRepositoryMockRecorder) UpdateCompanyFrameworkProfile(ctx, companyFrameworkUuid, userUuid, profile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCompanyFrameworkProfile", reflect.TypeOf((*MockRepository)(nil).UpdateCompanyFrameworkProfile), ctx, companyFrameworkUuid, userUuid, profile)
+}
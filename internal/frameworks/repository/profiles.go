@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+)
+
+func (s *sqlRepository) GetCompanyFramework(ctx context.Context, companyUuid, frameworkUuid *uuid.UUID) (*entities.CompanyFrameworks, error) {
+	var companyFramework entities.CompanyFrameworks
+
+	result := s.gormDB.WithContext(ctx).Model(&entities.CompanyFrameworks{}).
+		Limit(1).
+		Find(&companyFramework, "company_uuid = ? AND frameworks_uuid = ?", companyUuid, frameworkUuid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &appError.ErrNotFound{Message: "framework not found"}
+	}
+
+	return &companyFramework, nil
+}
+
+func (s *sqlRepository) UpdateCompanyFrameworkProfile(ctx context.Context, companyFrameworkUuid, userUuid uuid.UUID, profile *string) error {
+	return s.gormDB.WithContext(ctx).Model(&entities.CompanyFrameworks{}).
+		Where("company_frameworks_uuid = ?", companyFrameworkUuid).
+		Updates(map[string]interface{}{
+			"profile":    profile,
+			"updated_at": time.Now(),
+			"updated_by": userUuid,
+		}).Error
+}
+
+func (s *sqlRepository) GetProfileControls(ctx context.Context, frameworkUuid *uuid.UUID) ([]*entities.FrameworkControl, error) {
+	controls := make([]*entities.FrameworkControl, 0)
+
+	err := s.gormDB.WithContext(ctx).Model(&entities.FrameworkControl{}).
+		Order("control_id").
+		Find(&controls, "frameworks_uuid = ? AND retired_at IS NULL", frameworkUuid).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return controls, nil
+}
+
+func (s *sqlRepository) GetProfileQuestionnaires(ctx context.Context, frameworkUuid *uuid.UUID) ([]*entities.ProfileQuestionnaire, error) {
+	questionnaires := make([]*entities.ProfileQuestionnaire, 0)
+
+	err := s.gormDB.WithContext(ctx).Table("frameworks_questionnaires fq").
+		Select("q.questionnaires_uuid, q.category, q.question, q.groups").
+		Joins("join questionnaires q on q.questionnaires_uuid = fq.questionnaires_uuid").
+		Where("fq.frameworks_uuid = ?", frameworkUuid).
+		Order("q.category, q.question").
+		Find(&questionnaires).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return questionnaires, nil
+}
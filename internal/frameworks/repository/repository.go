@@ -19,6 +19,34 @@ type Repository interface {
 	GetCompanyByUUID(ctx context.Context, companyUuid *uuid.UUID) (*companyEntities.Company, error)
 	DeleteCompanyFrameworksLink(ctx context.Context, companyUUID, userUUID *uuid.UUID, frameworkNames []string) error
 	CreateCompanySubscription(ctx context.Context, cs *companyEntities.CompanySubscription) error
+	GetFramework(ctx context.Context, frameworkUuid *uuid.UUID) (*entities.Framework, error)
+	// GetControlPolicies lists the policies of the company, inactive ones aside, mapped to the
+	// controls of the framework.
+	GetControlPolicies(ctx context.Context, companyUuid, frameworkUuid *uuid.UUID) ([]*entities.ControlPolicy, error)
+	// GetPolicyCoverageStats counts the controls of the frameworks of the company covered by an
+	// approved policy.
+	GetPolicyCoverageStats(ctx context.Context, companyUuid *uuid.UUID) ([]*entities.PolicyCoverageStats, error)
+	GetFrameworkCatalog(ctx context.Context, name string) (*entities.Framework, []*entities.FrameworkControl, error)
+	ImportCatalog(ctx context.Context, catalog *entities.CatalogImport) error
+	// GetControlMappings lists the mappings between the controls of the frameworks.
+	GetControlMappings(ctx context.Context, frameworkUuids []uuid.UUID) ([]*entities.ControlMapping, error)
+	ImportControlMappings(ctx context.Context, mappings *entities.ControlMappingImport) error
+	// GetSatisfiedVia lists the remediated controls of the other frameworks of the company mapped
+	// to the controls of the framework.
+	GetSatisfiedVia(ctx context.Context, companyUuid, frameworkUuid *uuid.UUID) ([]*entities.SatisfiedVia, error)
+	// GetRemediationCoverageStats counts the remediated controls of the frameworks of the company,
+	// with and without the credit of mapped controls. Not applicable controls are left out.
+	GetRemediationCoverageStats(ctx context.Context, companyUuid *uuid.UUID) ([]*entities.RemediationCoverageStats, error)
+	// GetRemediationStatusStats counts the controls of the frameworks of the company by the status
+	// of their remediation.
+	GetRemediationStatusStats(ctx context.Context, companyUuid *uuid.UUID) ([]*entities.RemediationStatusStats, error)
+	// GetCompanyFramework links the company to the framework, with the profile it targets.
+	GetCompanyFramework(ctx context.Context, companyUuid, frameworkUuid *uuid.UUID) (*entities.CompanyFrameworks, error)
+	UpdateCompanyFrameworkProfile(ctx context.Context, companyFrameworkUuid, userUuid uuid.UUID, profile *string) error
+	// GetProfileControls lists the controls of the framework whatever the profile, by control id.
+	GetProfileControls(ctx context.Context, frameworkUuid *uuid.UUID) ([]*entities.FrameworkControl, error)
+	// GetProfileQuestionnaires lists the questions of the framework whatever the profile.
+	GetProfileQuestionnaires(ctx context.Context, frameworkUuid *uuid.UUID) ([]*entities.ProfileQuestionnaire, error)
 }
 
 // New repository for websites.
@@ -12,10 +12,13 @@ import (
 	"github.com/lib/pq"
 	companyEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company/entities"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	policiesEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/db"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type sqlRepository struct {
@@ -94,10 +97,12 @@ func (s *sqlRepository) GetFrameworks(ctx context.Context, companyUuid, userUuid
 
 func (s *sqlRepository) GetFrameworkControls(ctx context.Context, companyUuid, userUuid, frameworkUuid *uuid.UUID) ([]*entities.FrameworkControl, error) {
 	var framework_controls []*entities.FrameworkControl
-	err := s.gormDB.WithContext(ctx).Model(&entities.FrameworkControl{}).
-		Order("created_at desc").
+	err := s.gormDB.WithContext(ctx).Table("framework_controls fc").
+		Order("fc.created_at desc").
 		// Joins("join public.control_remediations ON framework_controls.framework_control_uuid = control_remediations.framework_control_uuid AND framework_controls.frameworks_uuid = control_remediations.frameworks_uuid").
-		Where("frameworks_uuid = ?", frameworkUuid).
+		Where("fc.frameworks_uuid = ? AND fc.retired_at IS NULL", frameworkUuid).
+		// controls out of the profile targeted by the company are left out
+		Where("NOT EXISTS (SELECT 1 FROM company_frameworks cf WHERE cf.frameworks_uuid = fc.frameworks_uuid AND cf.company_uuid = ? AND NOT "+entities.ControlInProfileSQL+")", companyUuid).
 		Find(&framework_controls).Error
 
 	if err != nil {
@@ -109,20 +114,21 @@ func (s *sqlRepository) GetFrameworkControls(ctx context.Context, companyUuid, u
 
 func (s *sqlRepository) GetFrameworkStats(ctx context.Context, companyUuid, userUuid *uuid.UUID) ([]*entities.GetFrameworkStatsResponse, error) {
 	type qResult struct {
-		Name      string `json:"name" gorm:"column:name"`
-		Total     int    `json:"total" gorm:"column:total"`
-		Completed int    `json:"completed" gorm:"column:completed"`
+		Name      string  `json:"name" gorm:"column:name"`
+		Profile   *string `json:"profile" gorm:"column:profile"`
+		Total     int     `json:"total" gorm:"column:total"`
+		Completed int     `json:"completed" gorm:"column:completed"`
 	}
 
 	var rs []*qResult
 	err := s.gormDB.WithContext(ctx).Debug().Table("frameworks_questionnaires fq").
-		Select("f.name,count(q.questionnaires_uuid) as total,count(qa.questionnaire_answers_uuid) as completed").
+		Select("f.name,cf.profile,count(q.questionnaires_uuid) as total,count(qa.questionnaire_answers_uuid) as completed").
 		Joins("left join frameworks f on f.frameworks_uuid = fq.frameworks_uuid").
 		Joins("left join questionnaires q on q.questionnaires_uuid = fq.questionnaires_uuid").
 		Joins("left join company_frameworks cf on cf.frameworks_uuid = fq.frameworks_uuid").
 		Joins("left join questionnaire_answers qa on qa.questionnaires_uuid = q.questionnaires_uuid and qa.company_uuid = ?", companyUuid).
-		Where("cf.company_uuid = ?", companyUuid).
-		Group("f.name").
+		Where("cf.company_uuid = ? AND "+entities.QuestionInProfileSQL, companyUuid).
+		Group("f.name, cf.profile").
 		Order("f.name").
 		Find(&rs).Error
 	if err != nil {
@@ -133,6 +139,7 @@ func (s *sqlRepository) GetFrameworkStats(ctx context.Context, companyUuid, user
 	for _, r := range rs {
 		controlStats = append(controlStats, &entities.GetFrameworkStatsResponse{
 			Name:      r.Name,
+			Profile:   r.Profile,
 			Completed: r.Completed,
 			Total:     r.Total,
 		})
@@ -141,7 +148,7 @@ func (s *sqlRepository) GetFrameworkStats(ctx context.Context, companyUuid, user
 }
 
 func (s *sqlRepository) CreateFrameworkControlRemediations(ctx context.Context, companyUUID, userUUID *uuid.UUID) error {
-	// Load all MPA & CIS controls added at time of ENV creation
+	// Load all MPA & CIS controls added at time of ENV creation, in the profiles of the company
 	controls, err := s.getFrameworkControls(ctx, companyUUID)
 	if err != nil {
 		return err
@@ -149,14 +156,14 @@ func (s *sqlRepository) CreateFrameworkControlRemediations(ctx context.Context,
 
 	controlRemediations := make([]entities.ControlRemediations, 0)
 
-	// By default all control remediations are in "pending" state
+	// By default all control remediations are not started
 	for _, c := range controls {
 		remediation := entities.ControlRemediations{
 			ControlRemediationsUuid: uuid.New(),
 			CompanyUuid:             *companyUUID,
 			FrameworksUuid:          c.FrameworksUuid,
 			FrameworkControlUuid:    c.FrameworkControlUuid,
-			Status:                  "pending",
+			Status:                  entities.RemediationStatusNotStarted,
 			CreatedAt:               nullable.NewNullTime(time.Now()),
 			CreatedBy:               *userUUID,
 		}
@@ -164,7 +171,14 @@ func (s *sqlRepository) CreateFrameworkControlRemediations(ctx context.Context,
 		controlRemediations = append(controlRemediations, remediation)
 	}
 
-	result := s.gormDB.WithContext(ctx).Create(&controlRemediations)
+	if len(controlRemediations) == 0 {
+		return nil
+	}
+
+	// controls remediated already keep their remediation
+	result := s.gormDB.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&controlRemediations)
 	if result.Error != nil {
 		err := result.Error
 		if db.IsForeignKeyViolationError(err) {
@@ -238,10 +252,10 @@ func (r *sqlRepository) CreateCompanySubscription(ctx context.Context, cs *compa
 func (s *sqlRepository) getFrameworkControls(ctx context.Context, companyUUID *uuid.UUID) ([]*entities.FrameworkControl, error) {
 	var frameworkControls []*entities.FrameworkControl
 
-	subQuery := s.gormDB.Select("frameworks_uuid").Where("company_uuid = ?", companyUUID).Table("company_frameworks")
-
-	err := s.gormDB.WithContext(ctx).Model(&entities.FrameworkControl{}).
-		Where("frameworks_uuid in (?)", subQuery).
+	err := s.gormDB.WithContext(ctx).Table("framework_controls fc").
+		Select("fc.*").
+		Joins("JOIN company_frameworks cf ON cf.frameworks_uuid = fc.frameworks_uuid AND cf.company_uuid = ?", companyUUID).
+		Where("fc.retired_at IS NULL AND " + entities.ControlInProfileSQL).
 		Find(&frameworkControls).Error
 	if err != nil {
 		return nil, err
@@ -263,3 +277,163 @@ func (s *sqlRepository) getFrameworksByNames(ctx context.Context, frameworkNames
 
 	return frameworks, nil
 }
+
+func (s *sqlRepository) GetFramework(ctx context.Context, frameworkUuid *uuid.UUID) (*entities.Framework, error) {
+	var framework entities.Framework
+
+	result := s.gormDB.WithContext(ctx).Model(&entities.Framework{}).
+		Limit(1).
+		Find(&framework, "frameworks_uuid = ?", frameworkUuid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &appError.ErrNotFound{Message: "framework not found"}
+	}
+
+	return &framework, nil
+}
+
+func (s *sqlRepository) GetControlPolicies(ctx context.Context, companyUuid, frameworkUuid *uuid.UUID) ([]*entities.ControlPolicy, error) {
+	policies := make([]*entities.ControlPolicy, 0)
+
+	query := `
+		select pfc.framework_control_uuid, p.policy_uuid, p.name, p.status, ` + entities.ApprovedPolicySQL(entities.AsOfLatest) + ` as approved
+		from policy_framework_controls pfc
+			join framework_controls fc on fc.framework_control_uuid = pfc.framework_control_uuid
+			join policies p on p.policy_uuid = pfc.policy_uuid
+		where fc.frameworks_uuid = @frameworks_uuid
+			and p.company_uuid = @company_uuid
+			and p.status <> @inactive
+		order by p.name, p.policy_uuid`
+
+	err := s.gormDB.WithContext(ctx).Raw(query, map[string]interface{}{
+		"frameworks_uuid": frameworkUuid,
+		"company_uuid":    companyUuid,
+		"approved":        policiesEntities.PolicyStatusApproved,
+		"inactive":        policiesEntities.PolicyStatusInactive,
+	}).Scan(&policies).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func (s *sqlRepository) GetPolicyCoverageStats(ctx context.Context, companyUuid *uuid.UUID) ([]*entities.PolicyCoverageStats, error) {
+	var stats []*entities.PolicyCoverageStats
+
+	query := `
+		select f.name, count(fc.framework_control_uuid) as total,
+			count(fc.framework_control_uuid) filter (where exists (
+				select 1
+				from policy_framework_controls pfc
+					join policies p on p.policy_uuid = pfc.policy_uuid
+				where pfc.framework_control_uuid = fc.framework_control_uuid
+					and p.company_uuid = @company_uuid
+					and p.status <> @inactive
+					and ` + entities.ApprovedPolicySQL(entities.AsOfLatest) + `
+			)) as covered
+		from company_frameworks cf
+			join frameworks f on f.frameworks_uuid = cf.frameworks_uuid
+			join framework_controls fc on fc.frameworks_uuid = f.frameworks_uuid and fc.retired_at is null
+		where cf.company_uuid = @company_uuid
+			and ` + entities.ControlInProfileSQL + `
+		group by f.name`
+
+	err := s.gormDB.WithContext(ctx).Raw(query, map[string]interface{}{
+		"company_uuid": companyUuid,
+		"approved":     policiesEntities.PolicyStatusApproved,
+		"inactive":     policiesEntities.PolicyStatusInactive,
+	}).Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetFrameworkCatalog returns the framework with the name and all of its controls, retired ones
+// included. The framework is nil when there is none with the name.
+func (s *sqlRepository) GetFrameworkCatalog(ctx context.Context, name string) (*entities.Framework, []*entities.FrameworkControl, error) {
+	var framework entities.Framework
+
+	result := s.gormDB.WithContext(ctx).Model(&entities.Framework{}).
+		Limit(1).
+		Find(&framework, "name = ?", name)
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, nil, nil
+	}
+
+	controls := make([]*entities.FrameworkControl, 0)
+
+	err := s.gormDB.WithContext(ctx).Model(&entities.FrameworkControl{}).
+		Order("control_id").
+		Find(&controls, "frameworks_uuid = ?", framework.FrameworkUuid).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &framework, controls, nil
+}
+
+// ImportCatalog saves the changes to the controls of the framework in one transaction. Retired
+// controls are kept for the remediations and mappings referring to them.
+func (s *sqlRepository) ImportCatalog(ctx context.Context, catalog *entities.CatalogImport) error {
+	return s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if catalog.NewFramework {
+			err := tx.Omit("created_by", "updated_by").Create(catalog.Framework).Error
+			if err != nil {
+				if db.IsAlreadyExistError(err) {
+					return errors.WithMessage(err, "framework already exists")
+				}
+
+				return err
+			}
+		}
+
+		if len(catalog.Added) > 0 {
+			if err := tx.Omit("created_by", "updated_by").Create(&catalog.Added).Error; err != nil {
+				if db.IsAlreadyExistError(err) {
+					return errors.WithMessage(err, "framework control already exists")
+				}
+
+				return err
+			}
+		}
+
+		for _, c := range catalog.Changed {
+			err := tx.Model(&entities.FrameworkControl{}).
+				Where("framework_control_uuid = ?", c.FrameworkControlUuid).
+				Updates(map[string]interface{}{
+					"name":           c.Name,
+					"topic":          c.Topic,
+					"domain":         c.Domain,
+					"best_practices": c.BestPractices,
+					"solution":       c.Solution,
+					"groups":         c.Groups,
+					"retired_at":     nil,
+					"updated_at":     catalog.Now,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(catalog.Retired) > 0 {
+			err := tx.Model(&entities.FrameworkControl{}).
+				Where("framework_control_uuid IN ?", catalog.Retired).
+				Updates(map[string]interface{}{"retired_at": catalog.Now, "updated_at": catalog.Now}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+	"golang.org/x/exp/slices"
+)
+
+// ImportCatalog upserts the framework of the catalog and its controls by control id. Controls
+// of the framework missing from the catalog are retired, retired controls back in the catalog
+// are restored. A dry run only reports the changes.
+func (s *service) ImportCatalog(ctx context.Context, catalog *entities.Catalog, dryRun bool) (*entities.CatalogImportReport, error) {
+	if err := validateCatalog(catalog); err != nil {
+		return nil, err
+	}
+
+	framework, controls, err := s.repo.GetFrameworkCatalog(ctx, catalog.Framework)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	changes := &entities.CatalogImport{Framework: framework, Now: now}
+
+	if framework == nil {
+		changes.NewFramework = true
+		changes.Framework = &entities.Framework{
+			FrameworkUuid: uuid.New(),
+			Name:          catalog.Framework,
+			CreatedAt:     nullable.NewNullTime(now),
+			UpdatedAt:     nullable.NewNullTime(now),
+		}
+	}
+
+	report := &entities.CatalogImportReport{
+		Framework:    catalog.Framework,
+		NewFramework: changes.NewFramework,
+		DryRun:       dryRun,
+		Added:        make([]*entities.CatalogControlChange, 0),
+		Changed:      make([]*entities.CatalogControlChange, 0),
+		Retired:      make([]*entities.CatalogControlChange, 0),
+	}
+
+	existing := make(map[string]*entities.FrameworkControl, len(controls))
+	for _, c := range controls {
+		existing[c.ControlId] = c
+	}
+
+	imported := make(map[string]bool, len(catalog.Controls))
+
+	for _, cc := range catalog.Controls {
+		imported[cc.ControlId] = true
+
+		c, ok := existing[cc.ControlId]
+		if !ok {
+			changes.Added = append(changes.Added, &entities.FrameworkControl{
+				FrameworkControlUuid: uuid.New(),
+				FrameworksUuid:       changes.Framework.FrameworkUuid,
+				ControlId:            cc.ControlId,
+				Name:                 cc.Name,
+				Topic:                cc.Topic,
+				Domain:               cc.Domain,
+				BestPractices:        cc.BestPractices,
+				Solution:             cc.Solution,
+				Groups:               pq.StringArray(cc.Groups),
+				CreatedAt:            nullable.NewNullTime(now),
+				UpdatedAt:            nullable.NewNullTime(now),
+			})
+			report.Added = append(report.Added, &entities.CatalogControlChange{ControlId: cc.ControlId, Name: cc.Name})
+
+			continue
+		}
+
+		fields := changedFields(c, cc)
+		if len(fields) == 0 {
+			report.Unchanged++
+
+			continue
+		}
+
+		changes.Changed = append(changes.Changed, &entities.FrameworkControl{
+			FrameworkControlUuid: c.FrameworkControlUuid,
+			FrameworksUuid:       c.FrameworksUuid,
+			ControlId:            cc.ControlId,
+			Name:                 cc.Name,
+			Topic:                cc.Topic,
+			Domain:               cc.Domain,
+			BestPractices:        cc.BestPractices,
+			Solution:             cc.Solution,
+			Groups:               pq.StringArray(cc.Groups),
+		})
+		report.Changed = append(report.Changed, &entities.CatalogControlChange{ControlId: cc.ControlId, Name: cc.Name, Fields: fields})
+	}
+
+	for _, c := range controls {
+		if imported[c.ControlId] || c.RetiredAt.Valid {
+			continue
+		}
+
+		changes.Retired = append(changes.Retired, c.FrameworkControlUuid)
+		report.Retired = append(report.Retired, &entities.CatalogControlChange{ControlId: c.ControlId, Name: c.Name})
+	}
+
+	if dryRun {
+		return report, nil
+	}
+
+	if err = s.repo.ImportCatalog(ctx, changes); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// validateCatalog requires a framework name and controls with unique ids.
+func validateCatalog(catalog *entities.Catalog) error {
+	catalog.Framework = strings.TrimSpace(catalog.Framework)
+	if catalog.Framework == "" {
+		return &appError.ErrValidation{Message: "framework name is required"}
+	}
+
+	if len(catalog.Controls) == 0 {
+		return &appError.ErrValidation{Message: "catalog has no controls"}
+	}
+
+	ids := make(map[string]bool, len(catalog.Controls))
+
+	for i, c := range catalog.Controls {
+		if c.ControlId == "" {
+			return &appError.ErrValidation{Message: fmt.Sprintf("control %d has no control_id", i+1)}
+		}
+
+		if ids[c.ControlId] {
+			return &appError.ErrValidation{Message: fmt.Sprintf("duplicate control_id %q", c.ControlId)}
+		}
+
+		ids[c.ControlId] = true
+	}
+
+	return nil
+}
+
+// changedFields of the control in the catalog. A retired control is restored.
+func changedFields(c *entities.FrameworkControl, cc *entities.CatalogControl) []string {
+	var fields []string
+
+	for _, f := range []struct {
+		name     string
+		from, to string
+	}{
+		{"name", c.Name, cc.Name},
+		{"topic", c.Topic, cc.Topic},
+		{"domain", c.Domain, cc.Domain},
+		{"best_practices", c.BestPractices, cc.BestPractices},
+		{"solution", c.Solution, cc.Solution},
+	} {
+		if f.from != f.to {
+			fields = append(fields, f.name)
+		}
+	}
+
+	if !slices.Equal([]string(c.Groups), cc.Groups) && (len(c.Groups) > 0 || len(cc.Groups) > 0) {
+		fields = append(fields, "groups")
+	}
+
+	if c.RetiredAt.Valid {
+		fields = append(fields, "retired")
+	}
+
+	return fields
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/repository"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+)
+
+func TestImportCatalog(t *testing.T) {
+	Convey("Given a framework with controls", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, nil, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		framework := &entities.Framework{FrameworkUuid: uuid.New(), Name: "CIS"}
+		inventory := &entities.FrameworkControl{
+			FrameworkControlUuid: uuid.New(), FrameworksUuid: framework.FrameworkUuid, ControlId: "1.1", Name: "1",
+			BestPractices: "Establish and Maintain Detailed Enterprise Asset Inventory", Groups: pq.StringArray{"IG1", "IG2", "IG3"},
+		}
+		unauthorized := &entities.FrameworkControl{
+			FrameworkControlUuid: uuid.New(), FrameworksUuid: framework.FrameworkUuid, ControlId: "1.2", Name: "1",
+			BestPractices: "Address Unauthorized Assets", Groups: pq.StringArray{"IG1", "IG2", "IG3"},
+		}
+		discovery := &entities.FrameworkControl{
+			FrameworkControlUuid: uuid.New(), FrameworksUuid: framework.FrameworkUuid, ControlId: "1.3", Name: "1",
+			BestPractices: "Utilize an Active Discovery Tool", Groups: pq.StringArray{"IG2", "IG3"},
+			RetiredAt: nullable.NewNullTime(time.Now()),
+		}
+
+		catalog := &entities.Catalog{
+			Framework: "CIS",
+			Controls: []*entities.CatalogControl{
+				{ControlId: "1.1", Name: "1", BestPractices: "Establish and Maintain Detailed Enterprise Asset Inventory", Groups: []string{"IG1", "IG2", "IG3"}},
+				{ControlId: "1.3", Name: "1", BestPractices: "Utilize an Active Discovery Tool", Groups: []string{"IG2", "IG3"}},
+				{ControlId: "1.4", Name: "1", BestPractices: "Use DHCP Logging to Update Enterprise Asset Inventory", Groups: []string{"IG2", "IG3"}},
+			},
+		}
+
+		repo.EXPECT().GetFrameworkCatalog(ctx, "CIS").
+			Return(framework, []*entities.FrameworkControl{inventory, unauthorized, discovery}, nil)
+
+		Convey("A dry run reports the added, changed and retired controls without saving them", func() {
+			report, err := svc.ImportCatalog(ctx, catalog, true)
+			So(err, ShouldBeNil)
+			So(report.DryRun, ShouldBeTrue)
+			So(report.NewFramework, ShouldBeFalse)
+			So(report.Added, ShouldResemble, []*entities.CatalogControlChange{{ControlId: "1.4", Name: "1"}})
+			So(report.Changed, ShouldResemble, []*entities.CatalogControlChange{{ControlId: "1.3", Name: "1", Fields: []string{"retired"}}})
+			So(report.Retired, ShouldResemble, []*entities.CatalogControlChange{{ControlId: "1.2", Name: "1"}})
+			So(report.Unchanged, ShouldEqual, 1)
+		})
+
+		Convey("An import saves the changes by control id", func() {
+			catalog.Controls[0].Groups = []string{"IG1"}
+
+			repo.EXPECT().ImportCatalog(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, changes *entities.CatalogImport) error {
+				So(changes.NewFramework, ShouldBeFalse)
+				So(changes.Added, ShouldHaveLength, 1)
+				So(changes.Added[0].FrameworksUuid, ShouldEqual, framework.FrameworkUuid)
+				So(changes.Changed, ShouldHaveLength, 2)
+				So(changes.Changed[0].FrameworkControlUuid, ShouldEqual, inventory.FrameworkControlUuid)
+				So([]string(changes.Changed[0].Groups), ShouldResemble, []string{"IG1"})
+				So(changes.Retired, ShouldResemble, []uuid.UUID{unauthorized.FrameworkControlUuid})
+
+				return nil
+			})
+
+			report, err := svc.ImportCatalog(ctx, catalog, false)
+			So(err, ShouldBeNil)
+			So(report.Changed[0].Fields, ShouldResemble, []string{"groups"})
+		})
+	})
+
+	Convey("Given a catalog with duplicate control ids", t, func() {
+		svc := New(nil, nil, zap.NewNop().Sugar())
+
+		_, err := svc.ImportCatalog(context.Background(), &entities.Catalog{
+			Framework: "CIS",
+			Controls:  []*entities.CatalogControl{{ControlId: "1.1"}, {ControlId: "1.1"}},
+		}, true)
+
+		var validationErr *appError.ErrValidation
+		So(errors.As(err, &validationErr), ShouldBeTrue)
+		So(validationErr.Message, ShouldEqual, `duplicate control_id "1.1"`)
+	})
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+)
+
+// ImportControlMappings replaces the mappings between the controls of each pair of frameworks
+// of the rows. Mappings go both ways, a pair of controls mapped twice is mapped once. A dry run
+// only reports the changes.
+func (s *service) ImportControlMappings(ctx context.Context, rows []*entities.ControlMappingRow, dryRun bool) (*entities.ControlMappingImportReport, error) {
+	if len(rows) == 0 {
+		return nil, &appError.ErrValidation{Message: "mapping file has no mappings"}
+	}
+
+	controls := make(map[string]map[string]*entities.FrameworkControl)
+	frameworks := make(map[uuid.UUID]string)
+	byUuid := make(map[uuid.UUID]*entities.FrameworkControl)
+
+	control := func(framework, controlId string) (*entities.FrameworkControl, error) {
+		if _, ok := controls[framework]; !ok {
+			f, fcs, err := s.repo.GetFrameworkCatalog(ctx, framework)
+			if err != nil {
+				return nil, err
+			}
+
+			if f == nil {
+				return nil, &appError.ErrValidation{Message: fmt.Sprintf("unknown framework %q", framework)}
+			}
+
+			frameworks[f.FrameworkUuid] = f.Name
+			controls[framework] = make(map[string]*entities.FrameworkControl, len(fcs))
+
+			for _, c := range fcs {
+				controls[framework][c.ControlId] = c
+				byUuid[c.FrameworkControlUuid] = c
+			}
+		}
+
+		c, ok := controls[framework][controlId]
+		if !ok {
+			return nil, &appError.ErrValidation{Message: fmt.Sprintf("unknown control %q of %s", controlId, framework)}
+		}
+
+		return c, nil
+	}
+
+	now := time.Now()
+	changes := &entities.ControlMappingImport{}
+	report := &entities.ControlMappingImportReport{
+		DryRun:  dryRun,
+		Added:   make([]*entities.ControlMappingRow, 0),
+		Removed: make([]*entities.ControlMappingRow, 0),
+	}
+
+	wanted := make(map[[2]uuid.UUID]*entities.ControlMapping)
+	pairs := make(map[[2]uuid.UUID]bool)
+
+	for i, r := range rows {
+		if r.Framework == "" || r.ControlId == "" || r.MappedFramework == "" || r.MappedControlId == "" {
+			return nil, &appError.ErrValidation{Message: fmt.Sprintf("mapping %d is incomplete", i+1)}
+		}
+
+		if r.Framework == r.MappedFramework {
+			return nil, &appError.ErrValidation{Message: fmt.Sprintf("mapping %d maps controls of the same framework", i+1)}
+		}
+
+		c, err := control(r.Framework, r.ControlId)
+		if err != nil {
+			return nil, err
+		}
+
+		mc, err := control(r.MappedFramework, r.MappedControlId)
+		if err != nil {
+			return nil, err
+		}
+
+		pairs[unorderedPair(c.FrameworksUuid, mc.FrameworksUuid)] = true
+
+		key := unorderedPair(c.FrameworkControlUuid, mc.FrameworkControlUuid)
+		if _, ok := wanted[key]; ok {
+			continue
+		}
+
+		wanted[key] = &entities.ControlMapping{
+			ControlMappingUuid:   uuid.New(),
+			FrameworkControlUuid: c.FrameworkControlUuid,
+			MappedControlUuid:    mc.FrameworkControlUuid,
+			CreatedAt:            nullable.NewNullTime(now),
+		}
+		changes.Added = append(changes.Added, wanted[key])
+	}
+
+	frameworkUuids := make([]uuid.UUID, 0, len(frameworks))
+	for u := range frameworks {
+		frameworkUuids = append(frameworkUuids, u)
+	}
+
+	existing, err := s.repo.GetControlMappings(ctx, frameworkUuids)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make(map[[2]uuid.UUID]bool)
+
+	for _, m := range existing {
+		c, mc := byUuid[m.FrameworkControlUuid], byUuid[m.MappedControlUuid]
+		if c == nil || mc == nil || !pairs[unorderedPair(c.FrameworksUuid, mc.FrameworksUuid)] {
+			continue
+		}
+
+		key := unorderedPair(m.FrameworkControlUuid, m.MappedControlUuid)
+		if _, ok := wanted[key]; ok {
+			kept[key] = true
+			report.Unchanged++
+
+			continue
+		}
+
+		changes.Removed = append(changes.Removed, m.ControlMappingUuid)
+		report.Removed = append(report.Removed, mappingRow(frameworks, c, mc))
+	}
+
+	added := changes.Added[:0]
+
+	for _, m := range changes.Added {
+		if kept[unorderedPair(m.FrameworkControlUuid, m.MappedControlUuid)] {
+			continue
+		}
+
+		added = append(added, m)
+		report.Added = append(report.Added, mappingRow(frameworks, byUuid[m.FrameworkControlUuid], byUuid[m.MappedControlUuid]))
+	}
+
+	changes.Added = added
+
+	if dryRun {
+		return report, nil
+	}
+
+	if err = s.repo.ImportControlMappings(ctx, changes); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func mappingRow(frameworks map[uuid.UUID]string, c, mc *entities.FrameworkControl) *entities.ControlMappingRow {
+	return &entities.ControlMappingRow{
+		Framework:       frameworks[c.FrameworksUuid],
+		ControlId:       c.ControlId,
+		MappedFramework: frameworks[mc.FrameworksUuid],
+		MappedControlId: mc.ControlId,
+	}
+}
+
+// unorderedPair of uuids, the same whatever their order.
+func unorderedPair(a, b uuid.UUID) [2]uuid.UUID {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		return [2]uuid.UUID{b, a}
+	}
+
+	return [2]uuid.UUID{a, b}
+}
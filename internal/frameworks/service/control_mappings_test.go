@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/repository"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+)
+
+func TestImportControlMappings(t *testing.T) {
+	Convey("Given the controls of two frameworks", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, nil, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		cis := &entities.Framework{FrameworkUuid: uuid.New(), Name: "CIS"}
+		mpa := &entities.Framework{FrameworkUuid: uuid.New(), Name: "MPA"}
+
+		control := func(f *entities.Framework, controlId string) *entities.FrameworkControl {
+			return &entities.FrameworkControl{FrameworkControlUuid: uuid.New(), FrameworksUuid: f.FrameworkUuid, ControlId: controlId}
+		}
+
+		mfaRemote, mfaAdmin, training := control(cis, "6.4"), control(cis, "6.5"), control(cis, "14.1")
+		remoteAccess, awareness := control(mpa, "TS-21"), control(mpa, "OR-5")
+
+		repo.EXPECT().GetFrameworkCatalog(ctx, "CIS").Return(cis, []*entities.FrameworkControl{mfaRemote, mfaAdmin, training}, nil).AnyTimes()
+		repo.EXPECT().GetFrameworkCatalog(ctx, "MPA").Return(mpa, []*entities.FrameworkControl{remoteAccess, awareness}, nil).AnyTimes()
+
+		kept := &entities.ControlMapping{ControlMappingUuid: uuid.New(), FrameworkControlUuid: remoteAccess.FrameworkControlUuid, MappedControlUuid: mfaRemote.FrameworkControlUuid}
+		stale := &entities.ControlMapping{ControlMappingUuid: uuid.New(), FrameworkControlUuid: mfaAdmin.FrameworkControlUuid, MappedControlUuid: remoteAccess.FrameworkControlUuid}
+
+		rows := []*entities.ControlMappingRow{
+			{Framework: "CIS", ControlId: "6.4", MappedFramework: "MPA", MappedControlId: "TS-21"},
+			{Framework: "CIS", ControlId: "14.1", MappedFramework: "MPA", MappedControlId: "OR-5"},
+			{Framework: "MPA", ControlId: "OR-5", MappedFramework: "CIS", MappedControlId: "14.1"},
+		}
+
+		Convey("Mappings are matched both ways and the ones missing from the file are removed", func() {
+			repo.EXPECT().GetControlMappings(ctx, gomock.Any()).Return([]*entities.ControlMapping{kept, stale}, nil)
+			repo.EXPECT().ImportControlMappings(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, changes *entities.ControlMappingImport) error {
+				So(changes.Added, ShouldHaveLength, 1)
+				So(changes.Added[0].FrameworkControlUuid, ShouldEqual, training.FrameworkControlUuid)
+				So(changes.Added[0].MappedControlUuid, ShouldEqual, awareness.FrameworkControlUuid)
+				So(changes.Removed, ShouldResemble, []uuid.UUID{stale.ControlMappingUuid})
+
+				return nil
+			})
+
+			report, err := svc.ImportControlMappings(ctx, rows, false)
+			So(err, ShouldBeNil)
+			So(report.Unchanged, ShouldEqual, 1)
+			So(report.Added, ShouldResemble, []*entities.ControlMappingRow{rows[1]})
+			So(report.Removed, ShouldResemble, []*entities.ControlMappingRow{
+				{Framework: "CIS", ControlId: "6.5", MappedFramework: "MPA", MappedControlId: "TS-21"},
+			})
+		})
+
+		Convey("Unknown controls and mappings within a framework are rejected", func() {
+			var validationErr *appError.ErrValidation
+
+			_, err := svc.ImportControlMappings(ctx, []*entities.ControlMappingRow{
+				{Framework: "CIS", ControlId: "6.6", MappedFramework: "MPA", MappedControlId: "TS-21"},
+			}, true)
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+			So(validationErr.Message, ShouldEqual, `unknown control "6.6" of CIS`)
+
+			_, err = svc.ImportControlMappings(ctx, []*entities.ControlMappingRow{
+				{Framework: "CIS", ControlId: "6.4", MappedFramework: "CIS", MappedControlId: "6.5"},
+			}, true)
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+			So(validationErr.Message, ShouldEqual, "mapping 1 maps controls of the same framework")
+		})
+	})
+}
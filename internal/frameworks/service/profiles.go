@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"golang.org/x/exp/slices"
+)
+
+func (s *service) GetProfile(ctx context.Context, companyUuid, userUuid, frameworkUuid *uuid.UUID) (*entities.FrameworkProfile, error) {
+	framework, err := s.repo.GetFramework(ctx, frameworkUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	companyFramework, err := s.repo.GetCompanyFramework(ctx, companyUuid, frameworkUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	controls, err := s.repo.GetProfileControls(ctx, frameworkUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	questionnaires, err := s.repo.GetProfileQuestionnaires(ctx, frameworkUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := profileOf(companyFramework.Profile)
+	res := &entities.FrameworkProfile{
+		FrameworkUuid: framework.FrameworkUuid,
+		Name:          framework.Name,
+		Profile:       companyFramework.Profile,
+		Profiles:      profiles(controls),
+	}
+
+	for _, c := range controls {
+		if entities.InProfile(c.Groups, profile) {
+			res.Controls++
+		}
+	}
+
+	for _, q := range questionnaires {
+		if entities.InProfile(q.Groups, profile) {
+			res.Questionnaires++
+		}
+	}
+
+	return res, nil
+}
+
+// UpdateProfile targeted by the company. The remediations of the controls added to the profile
+// are seeded, those of the controls removed from it are kept for a later switch back.
+func (s *service) UpdateProfile(ctx context.Context, req *entities.UpdateProfileRequest) (*entities.FrameworkProfile, error) {
+	companyFramework, err := s.repo.GetCompanyFramework(ctx, &req.CompanyUuid, &req.FrameworkUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	controls, err := s.repo.GetProfileControls(ctx, &req.FrameworkUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	var profile *string
+	if req.Body.Profile != nil && *req.Body.Profile != "" {
+		if err := validateProfile(*req.Body.Profile, controls); err != nil {
+			return nil, err
+		}
+
+		profile = req.Body.Profile
+	}
+
+	err = s.repo.UpdateCompanyFrameworkProfile(ctx, companyFramework.CompanyFrameworkUuid, req.UserUuid, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.CreateFrameworkControlRemediations(ctx, &req.CompanyUuid, &req.UserUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetProfile(ctx, &req.CompanyUuid, &req.UserUuid, &req.FrameworkUuid)
+}
+
+// PreviewProfile lists what switching to the profile would add to the controls and questions of
+// the company, like upgrading from IG1 to IG2, and what it would remove.
+func (s *service) PreviewProfile(ctx context.Context, req *entities.PreviewProfileRequest) (*entities.ProfilePreview, error) {
+	framework, err := s.repo.GetFramework(ctx, &req.FrameworkUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	companyFramework, err := s.repo.GetCompanyFramework(ctx, &req.CompanyUuid, &req.FrameworkUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	controls, err := s.repo.GetProfileControls(ctx, &req.FrameworkUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	var profile *string
+	if req.Profile != "" {
+		if err := validateProfile(req.Profile, controls); err != nil {
+			return nil, err
+		}
+
+		profile = &req.Profile
+	}
+
+	questionnaires, err := s.repo.GetProfileQuestionnaires(ctx, &req.FrameworkUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	current := profileOf(companyFramework.Profile)
+	res := &entities.ProfilePreview{
+		FrameworkUuid:         framework.FrameworkUuid,
+		Name:                  framework.Name,
+		CurrentProfile:        companyFramework.Profile,
+		Profile:               profile,
+		AddedControls:         make([]*entities.FrameworkControl, 0),
+		RemovedControls:       make([]*entities.FrameworkControl, 0),
+		AddedQuestionnaires:   make([]*entities.ProfileQuestionnaire, 0),
+		RemovedQuestionnaires: make([]*entities.ProfileQuestionnaire, 0),
+	}
+
+	for _, c := range controls {
+		was, is := entities.InProfile(c.Groups, current), entities.InProfile(c.Groups, req.Profile)
+
+		switch {
+		case is && !was:
+			res.AddedControls = append(res.AddedControls, c)
+		case was && !is:
+			res.RemovedControls = append(res.RemovedControls, c)
+		}
+	}
+
+	for _, q := range questionnaires {
+		was, is := entities.InProfile(q.Groups, current), entities.InProfile(q.Groups, req.Profile)
+
+		switch {
+		case is && !was:
+			res.AddedQuestionnaires = append(res.AddedQuestionnaires, q)
+		case was && !is:
+			res.RemovedQuestionnaires = append(res.RemovedQuestionnaires, q)
+		}
+	}
+
+	return res, nil
+}
+
+// profiles of a framework, the groups of its controls in order.
+func profiles(controls []*entities.FrameworkControl) []string {
+	groups := make([]string, 0)
+
+	for _, c := range controls {
+		for _, g := range c.Groups {
+			if !slices.Contains(groups, g) {
+				groups = append(groups, g)
+			}
+		}
+	}
+
+	sort.Strings(groups)
+
+	return groups
+}
+
+func validateProfile(profile string, controls []*entities.FrameworkControl) error {
+	available := profiles(controls)
+
+	if len(available) == 0 {
+		return &appError.ErrValidation{Message: "framework has no profiles"}
+	}
+
+	if !slices.Contains(available, profile) {
+		return &appError.ErrValidation{Message: "profile should be one of " + strings.Join(available, ", ")}
+	}
+
+	return nil
+}
+
+// profileOf a company framework, empty for the whole framework.
+func profileOf(profile *string) string {
+	if profile == nil {
+		return ""
+	}
+
+	return *profile
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/repository"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+)
+
+func TestProfiles(t *testing.T) {
+	Convey("Given a company targeting IG1 of CIS", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, nil, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		companyUuid, userUuid := uuid.New(), uuid.New()
+		cis := &entities.Framework{FrameworkUuid: uuid.New(), Name: "CIS"}
+		ig1 := "IG1"
+		companyFramework := &entities.CompanyFrameworks{CompanyFrameworkUuid: uuid.New(), CompanyUuid: companyUuid, FrameworksUuid: cis.FrameworkUuid, Profile: &ig1}
+
+		control := func(controlId string, groups ...string) *entities.FrameworkControl {
+			return &entities.FrameworkControl{FrameworkControlUuid: uuid.New(), FrameworksUuid: cis.FrameworkUuid, ControlId: controlId, Groups: groups}
+		}
+
+		inventory, logs, pentest := control("1.1", "IG1", "IG2", "IG3"), control("8.5", "IG2", "IG3"), control("18.2", "IG3")
+		question := func(category string, groups ...string) *entities.ProfileQuestionnaire {
+			return &entities.ProfileQuestionnaire{QuestionnairesUuid: uuid.New(), Category: category, Groups: groups}
+		}
+
+		identify, detect, general := question("Identify - [IG1,IG2,IG3]", "IG1", "IG2", "IG3"), question("Detect - [IG2,IG3]", "IG2", "IG3"), question("General")
+
+		repo.EXPECT().GetFramework(ctx, &cis.FrameworkUuid).Return(cis, nil).AnyTimes()
+		repo.EXPECT().GetCompanyFramework(ctx, &companyUuid, &cis.FrameworkUuid).Return(companyFramework, nil).AnyTimes()
+		repo.EXPECT().GetProfileControls(ctx, &cis.FrameworkUuid).Return([]*entities.FrameworkControl{inventory, logs, pentest}, nil).AnyTimes()
+		repo.EXPECT().GetProfileQuestionnaires(ctx, &cis.FrameworkUuid).Return([]*entities.ProfileQuestionnaire{identify, detect, general}, nil).AnyTimes()
+
+		Convey("Only the controls and questions of IG1 are counted", func() {
+			res, err := svc.GetProfile(ctx, &companyUuid, &userUuid, &cis.FrameworkUuid)
+			So(err, ShouldBeNil)
+			So(*res.Profile, ShouldEqual, "IG1")
+			So(res.Profiles, ShouldResemble, []string{"IG1", "IG2", "IG3"})
+			So(res.Controls, ShouldEqual, 1)
+			So(res.Questionnaires, ShouldEqual, 2)
+		})
+
+		Convey("Upgrading to IG2 adds the controls and questions of IG2 only", func() {
+			res, err := svc.PreviewProfile(ctx, &entities.PreviewProfileRequest{CompanyUuid: companyUuid, UserUuid: userUuid, FrameworkUuid: cis.FrameworkUuid, Profile: "IG2"})
+			So(err, ShouldBeNil)
+			So(*res.CurrentProfile, ShouldEqual, "IG1")
+			So(res.AddedControls, ShouldResemble, []*entities.FrameworkControl{logs})
+			So(res.RemovedControls, ShouldBeEmpty)
+			So(res.AddedQuestionnaires, ShouldResemble, []*entities.ProfileQuestionnaire{detect})
+			So(res.RemovedQuestionnaires, ShouldBeEmpty)
+		})
+
+		Convey("Targeting the whole framework seeds the remediations of the added controls", func() {
+			repo.EXPECT().UpdateCompanyFrameworkProfile(ctx, companyFramework.CompanyFrameworkUuid, userUuid, nil).Return(nil)
+			repo.EXPECT().CreateFrameworkControlRemediations(ctx, &companyUuid, &userUuid).Return(nil)
+
+			empty := ""
+			_, err := svc.UpdateProfile(ctx, &entities.UpdateProfileRequest{
+				CompanyUuid: companyUuid, UserUuid: userUuid, FrameworkUuid: cis.FrameworkUuid,
+				Body: &entities.UpdateProfileRequestBody{Profile: &empty},
+			})
+			So(err, ShouldBeNil)
+		})
+
+		Convey("Profiles which are not groups of the controls are rejected", func() {
+			ig4 := "IG4"
+			_, err := svc.UpdateProfile(ctx, &entities.UpdateProfileRequest{
+				CompanyUuid: companyUuid, UserUuid: userUuid, FrameworkUuid: cis.FrameworkUuid,
+				Body: &entities.UpdateProfileRequestBody{Profile: &ig4},
+			})
+
+			var validationErr *appError.ErrValidation
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+			So(validationErr.Message, ShouldEqual, "profile should be one of IG1, IG2, IG3")
+		})
+	})
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"math"
 	"strings"
 
 	companyEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company/entities"
@@ -21,6 +22,17 @@ type Service interface {
 	GetFrameworkStats(ctx context.Context, companyUuid, userUuid *uuid.UUID) ([]*entities.GetFrameworkStatsResponse, error)
 	CreateCompanyFrameworksLink(ctx context.Context, companyUUID, userUUID *uuid.UUID, frameworkNames []string) error
 	DeleteCompanyFrameworksLink(ctx context.Context, companyUUID, userUUID *uuid.UUID, frameworkNames []string) error
+	// GetPolicyCoverage of the controls of the framework by the policies of the company.
+	GetPolicyCoverage(ctx context.Context, companyUuid, userUuid, frameworkUuid *uuid.UUID) (*entities.GetPolicyCoverageResponse, error)
+	// ImportCatalog of the controls of a framework, or report the changes of a dry run.
+	ImportCatalog(ctx context.Context, catalog *entities.Catalog, dryRun bool) (*entities.CatalogImportReport, error)
+	// ImportControlMappings between the controls of frameworks, or report the changes of a dry run.
+	ImportControlMappings(ctx context.Context, rows []*entities.ControlMappingRow, dryRun bool) (*entities.ControlMappingImportReport, error)
+	// GetProfile of the framework targeted by the company. Controls, questions, remediations and
+	// stats of the company are filtered to the profile.
+	GetProfile(ctx context.Context, companyUuid, userUuid, frameworkUuid *uuid.UUID) (*entities.FrameworkProfile, error)
+	UpdateProfile(ctx context.Context, req *entities.UpdateProfileRequest) (*entities.FrameworkProfile, error)
+	PreviewProfile(ctx context.Context, req *entities.PreviewProfileRequest) (*entities.ProfilePreview, error)
 }
 
 func New(repo repository.Repository, sfClient salesforce.Client, logger *zap.SugaredLogger) Service {
@@ -53,7 +65,26 @@ func (s *service) GetFrameworks(ctx context.Context, companyUuid, userUuid *uuid
 }
 
 func (s *service) GetFrameworkControls(ctx context.Context, companyUuid, userUuid, frameworkUuid *uuid.UUID) ([]*entities.FrameworkControl, error) {
-	return s.repo.GetFrameworkControls(ctx, companyUuid, userUuid, frameworkUuid)
+	controls, err := s.repo.GetFrameworkControls(ctx, companyUuid, userUuid, frameworkUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	satisfiedVia, err := s.repo.GetSatisfiedVia(ctx, companyUuid, frameworkUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	satisfiedViaByControl := make(map[uuid.UUID][]*entities.SatisfiedVia)
+	for _, sv := range satisfiedVia {
+		satisfiedViaByControl[sv.FrameworkControlUuid] = append(satisfiedViaByControl[sv.FrameworkControlUuid], sv)
+	}
+
+	for _, c := range controls {
+		c.SatisfiedVia = satisfiedViaByControl[c.FrameworkControlUuid]
+	}
+
+	return controls, nil
 }
 
 func (s *service) GetFrameworkStats(ctx context.Context, companyUuid, userUuid *uuid.UUID) ([]*entities.GetFrameworkStatsResponse, error) {
@@ -71,7 +102,140 @@ func (s *service) GetFrameworkStats(ctx context.Context, companyUuid, userUuid *
 		}
 	}
 
-	return s.repo.GetFrameworkStats(ctx, companyUuid, userUuid)
+	stats, err := s.repo.GetFrameworkStats(ctx, companyUuid, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	coverage, err := s.repo.GetPolicyCoverageStats(ctx, companyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	coverageByName := make(map[string]*entities.PolicyCoverageStats, len(coverage))
+	for _, c := range coverage {
+		coverageByName[c.Name] = c
+	}
+
+	remediation, err := s.repo.GetRemediationCoverageStats(ctx, companyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	remediationByName := make(map[string]*entities.RemediationCoverageStats, len(remediation))
+	for _, r := range remediation {
+		remediationByName[r.Name] = r
+	}
+
+	statuses, err := s.repo.GetRemediationStatusStats(ctx, companyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	statusesByName := make(map[string]map[string]int)
+	for _, st := range statuses {
+		if statusesByName[st.Name] == nil {
+			statusesByName[st.Name] = make(map[string]int, len(entities.RemediationStatuses))
+		}
+
+		statusesByName[st.Name][st.Status] = st.Count
+	}
+
+	for _, st := range stats {
+		st.Remediations = make(map[string]int, len(entities.RemediationStatuses))
+		for _, status := range entities.RemediationStatuses {
+			st.Remediations[status] = statusesByName[st.Name][status]
+		}
+
+		if c, ok := coverageByName[st.Name]; ok {
+			st.PolicyCoverage = percentage(c.Covered, c.Total)
+		}
+
+		if r, ok := remediationByName[st.Name]; ok {
+			st.RemediationCoverage = percentage(r.Remediated, r.Total)
+			st.EffectiveCoverage = percentage(r.Satisfied, r.Total)
+		}
+	}
+
+	return stats, nil
+}
+
+func (s *service) GetPolicyCoverage(ctx context.Context, companyUuid, userUuid, frameworkUuid *uuid.UUID) (*entities.GetPolicyCoverageResponse, error) {
+	framework, err := s.repo.GetFramework(ctx, frameworkUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	controls, err := s.repo.GetFrameworkControls(ctx, companyUuid, userUuid, frameworkUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := s.repo.GetControlPolicies(ctx, companyUuid, frameworkUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	policiesByControl := make(map[uuid.UUID][]*entities.ControlPolicy)
+	for _, p := range policies {
+		policiesByControl[p.FrameworkControlUuid] = append(policiesByControl[p.FrameworkControlUuid], p)
+	}
+
+	res := &entities.GetPolicyCoverageResponse{
+		FrameworkUuid: framework.FrameworkUuid,
+		Name:          framework.Name,
+		Total:         len(controls),
+		Controls:      make([]*entities.ControlCoverage, 0, len(controls)),
+	}
+
+	for _, c := range controls {
+		cc := &entities.ControlCoverage{
+			FrameworkControlUuid: c.FrameworkControlUuid,
+			Name:                 c.Name,
+			Topic:                c.Topic,
+			Domain:               c.Domain,
+			Coverage:             entities.CoverageUncovered,
+			Policies:             policiesByControl[c.FrameworkControlUuid],
+		}
+
+		if cc.Policies == nil {
+			cc.Policies = make([]*entities.ControlPolicy, 0)
+		}
+
+		for _, p := range cc.Policies {
+			if p.Approved {
+				cc.Coverage = entities.CoverageCovered
+
+				break
+			}
+
+			cc.Coverage = entities.CoverageDraft
+		}
+
+		switch cc.Coverage {
+		case entities.CoverageCovered:
+			res.Covered++
+		case entities.CoverageDraft:
+			res.Draft++
+		default:
+			res.Uncovered++
+		}
+
+		res.Controls = append(res.Controls, cc)
+	}
+
+	res.Percentage = percentage(res.Covered, res.Total)
+
+	return res, nil
+}
+
+// percentage of part in total, rounded to one decimal.
+func percentage(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return math.Round(float64(part)*1000/float64(total)) / 10
 }
 
 func (s *service) CreateFrameworkControlRemediations(ctx context.Context, companyUUID, userUUID *uuid.UUID) error {
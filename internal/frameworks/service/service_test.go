@@ -86,6 +86,27 @@ func Test_service_GetFrameworkControls(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 
 	svc := New(mockRepo, salesforceClient, logger)
+
+	expected := []*entities.FrameworkControl{
+		{
+			FrameworkControlUuid: uuid.New(),
+			FrameworksUuid:       frameworkUUID,
+			Name:                 gofakeit.Word(),
+			Topic:                gofakeit.Word(),
+			Domain:               gofakeit.Word(),
+		},
+	}
+
+	satisfiedVia := &entities.SatisfiedVia{
+		FrameworkControlUuid: expected[0].FrameworkControlUuid,
+		Framework:            "MPA",
+		MappedControlUuid:    uuid.New(),
+		ControlId:            "TS-21",
+	}
+
+	mockRepo.EXPECT().GetFrameworkControls(ctx, &companyUUID, &userUUID, &frameworkUUID).Return(expected, nil)
+	mockRepo.EXPECT().GetSatisfiedVia(ctx, &companyUUID, &frameworkUUID).Return([]*entities.SatisfiedVia{satisfiedVia}, nil)
+
 	Convey("Given company_id, user_id and framework_id", t, func() {
 		reqBody := &entities.GetFrameworkControlRequest{
 			CompanyUuid:   companyUUID,
@@ -97,13 +118,112 @@ func Test_service_GetFrameworkControls(t *testing.T) {
 			Convey("The value should be equal to the expected value", func() {
 				So(err, ShouldBeNil)
 				So(actual, ShouldNotBeNil)
-				Convey("Control Topic & Domain should not be empty", func() {
+				Convey("Control Topic & Domain should not be empty, mapped controls satisfy them", func() {
 					for _, control := range actual {
 						So(control.Topic, ShouldNotBeEmpty)
 						So(control.Domain, ShouldNotBeEmpty)
 					}
+					So(actual[0].SatisfiedVia, ShouldResemble, []*entities.SatisfiedVia{satisfiedVia})
 				})
 			})
 		})
 	})
 }
+
+func Test_service_GetPolicyCoverage(t *testing.T) {
+	Convey("Given the controls of a framework mapped to policies", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, nil, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		companyUUID, userUUID, frameworkUUID := uuid.New(), uuid.New(), uuid.New()
+		controls := []*entities.FrameworkControl{
+			{FrameworkControlUuid: uuid.New(), Name: "1.1"},
+			{FrameworkControlUuid: uuid.New(), Name: "1.2"},
+			{FrameworkControlUuid: uuid.New(), Name: "1.3"},
+		}
+
+		repo.EXPECT().GetFramework(ctx, &frameworkUUID).Return(&entities.Framework{FrameworkUuid: frameworkUUID, Name: "CIS"}, nil)
+		repo.EXPECT().GetFrameworkControls(ctx, &companyUUID, &userUUID, &frameworkUUID).Return(controls, nil)
+		repo.EXPECT().GetControlPolicies(ctx, &companyUUID, &frameworkUUID).Return([]*entities.ControlPolicy{
+			{FrameworkControlUuid: controls[0].FrameworkControlUuid, Name: "Access Control", Status: "Draft"},
+			{FrameworkControlUuid: controls[0].FrameworkControlUuid, Name: "Information Security", Status: "Approved", Approved: true},
+			{FrameworkControlUuid: controls[1].FrameworkControlUuid, Name: "Backup", Status: "Submitted"},
+		}, nil)
+
+		Convey("Controls are covered by approved policies, drafts only count as draft", func() {
+			res, err := svc.GetPolicyCoverage(ctx, &companyUUID, &userUUID, &frameworkUUID)
+			So(err, ShouldBeNil)
+			So(res.Name, ShouldEqual, "CIS")
+			So(res.Total, ShouldEqual, 3)
+			So(res.Covered, ShouldEqual, 1)
+			So(res.Draft, ShouldEqual, 1)
+			So(res.Uncovered, ShouldEqual, 1)
+			So(res.Percentage, ShouldEqual, 33.3)
+			So(res.Controls[0].Coverage, ShouldEqual, entities.CoverageCovered)
+			So(res.Controls[0].Policies, ShouldHaveLength, 2)
+			So(res.Controls[1].Coverage, ShouldEqual, entities.CoverageDraft)
+			So(res.Controls[2].Coverage, ShouldEqual, entities.CoverageUncovered)
+			So(res.Controls[2].Policies, ShouldBeEmpty)
+		})
+	})
+}
+
+func Test_service_GetFrameworkStats(t *testing.T) {
+	Convey("Given the frameworks of a company", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, nil, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		companyUUID, userUUID := uuid.New(), uuid.New()
+
+		repo.EXPECT().GetFrameworks(ctx, &companyUUID, &userUUID).Return([]*entities.Framework{{Name: "CIS"}}, nil)
+		repo.EXPECT().GetFrameworkStats(ctx, &companyUUID, &userUUID).Return([]*entities.GetFrameworkStatsResponse{
+			{Name: "CIS", Completed: 10, Total: 20},
+			{Name: "MPA", Completed: 0, Total: 5},
+		}, nil)
+		repo.EXPECT().GetPolicyCoverageStats(ctx, &companyUUID).Return([]*entities.PolicyCoverageStats{
+			{Name: "CIS", Total: 8, Covered: 3},
+		}, nil)
+		repo.EXPECT().GetRemediationCoverageStats(ctx, &companyUUID).Return([]*entities.RemediationCoverageStats{
+			{Name: "CIS", Total: 8, Remediated: 2, Satisfied: 4},
+			{Name: "MPA", Total: 5, Remediated: 1, Satisfied: 1},
+		}, nil)
+		repo.EXPECT().GetRemediationStatusStats(ctx, &companyUUID).Return([]*entities.RemediationStatusStats{
+			{Name: "CIS", Status: entities.RemediationStatusCompleted, Count: 2},
+			{Name: "CIS", Status: entities.RemediationStatusBlocked, Count: 1},
+		}, nil)
+
+		Convey("The stats include the policy coverage of each framework", func() {
+			stats, err := svc.GetFrameworkStats(ctx, &companyUUID, &userUUID)
+			So(err, ShouldBeNil)
+			So(stats, ShouldHaveLength, 2)
+			So(stats[0].PolicyCoverage, ShouldEqual, 37.5)
+			So(stats[1].PolicyCoverage, ShouldEqual, 0)
+		})
+
+		Convey("The effective coverage includes the credit of mapped controls", func() {
+			stats, err := svc.GetFrameworkStats(ctx, &companyUUID, &userUUID)
+			So(err, ShouldBeNil)
+			So(stats[0].RemediationCoverage, ShouldEqual, 25)
+			So(stats[0].EffectiveCoverage, ShouldEqual, 50)
+			So(stats[1].RemediationCoverage, ShouldEqual, 20)
+			So(stats[1].EffectiveCoverage, ShouldEqual, 20)
+		})
+
+		Convey("The controls are counted by remediation status", func() {
+			stats, err := svc.GetFrameworkStats(ctx, &companyUUID, &userUUID)
+			So(err, ShouldBeNil)
+			So(stats[0].Remediations, ShouldHaveLength, len(entities.RemediationStatuses))
+			So(stats[0].Remediations[entities.RemediationStatusCompleted], ShouldEqual, 2)
+			So(stats[0].Remediations[entities.RemediationStatusBlocked], ShouldEqual, 1)
+			So(stats[1].Remediations[entities.RemediationStatusInProgress], ShouldEqual, 0)
+		})
+	})
+}
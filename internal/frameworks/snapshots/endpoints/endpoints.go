@@ -0,0 +1,28 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/service"
+)
+
+type Endpoints struct {
+	GetTrendEndpoint endpoint.Endpoint
+}
+
+// New returns new endpoints
+func New(svc service.Service) *Endpoints {
+	return &Endpoints{
+		GetTrendEndpoint: makeGetTrendEndpoint(svc),
+	}
+}
+
+func makeGetTrendEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetTrendRequest) //nolint:errcheck
+
+		return svc.GetTrend(ctx, req)
+	}
+}
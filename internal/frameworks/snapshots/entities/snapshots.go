@@ -0,0 +1,110 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Granularities of trends, the last snapshot of each period stands for the period.
+const (
+	GranularityDay     = "day"
+	GranularityWeek    = "week"
+	GranularityMonth   = "month"
+	GranularityQuarter = "quarter"
+
+	// DateLayout of snapshot dates.
+	DateLayout = "2006-01-02"
+)
+
+var Granularities = []string{GranularityDay, GranularityWeek, GranularityMonth, GranularityQuarter}
+
+// StatusCounts counts controls by the status of their remediation.
+type StatusCounts map[string]int
+
+// Value simply returns the JSON-encoded representation of the counts.
+func (a StatusCounts) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
+// Scan decodes a JSON-encoded value into the counts.
+func (a *StatusCounts) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, a)
+}
+
+// Snapshot of the compliance of a company with a framework at the end of a day. Backfilled
+// snapshots were rebuilt afterwards from the timestamps of the data.
+type Snapshot struct {
+	SnapshotUuid          uuid.UUID    `gorm:"primarykey;column:snapshot_uuid"`
+	CompanyUuid           uuid.UUID    `gorm:"column:company_uuid"`
+	FrameworksUuid        uuid.UUID    `gorm:"column:frameworks_uuid"`
+	SnapshotDate          time.Time    `gorm:"column:snapshot_date"`
+	ControlsTotal         int          `gorm:"column:controls_total"`
+	ControlsByStatus      StatusCounts `gorm:"column:controls_by_status"`
+	PolicyCovered         int          `gorm:"column:policy_covered"`
+	QuestionnaireTotal    int          `gorm:"column:questionnaire_total"`
+	QuestionnaireAnswered int          `gorm:"column:questionnaire_answered"`
+	Backfilled            bool         `gorm:"column:backfilled"`
+	CreatedAt             time.Time    `gorm:"column:created_at"`
+	UpdatedAt             time.Time    `gorm:"column:updated_at"`
+}
+
+func (m *Snapshot) TableName() string {
+	return "compliance_snapshots"
+}
+
+// BackfillReport of the snapshots built for each day, days already recorded are kept.
+type BackfillReport struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Days    int    `json:"days"`
+	Created int64  `json:"created"`
+}
+
+// TrendPoint is the last snapshot of a period. Coverages are percentages: the remediation
+// coverage of the applicable controls, the policy coverage of all of them.
+type TrendPoint struct {
+	Period                  string       `json:"period"`
+	SnapshotDate            string       `json:"snapshot_date"`
+	ControlsTotal           int          `json:"controls_total"`
+	ControlsByStatus        StatusCounts `json:"controls_by_status"`
+	RemediationCoverage     float64      `json:"remediation_coverage"`
+	PolicyCoverage          float64      `json:"policy_coverage"`
+	QuestionnaireTotal      int          `json:"questionnaire_total"`
+	QuestionnaireAnswered   int          `json:"questionnaire_answered"`
+	QuestionnaireCompletion float64      `json:"questionnaire_completion"`
+	Backfilled              bool         `json:"backfilled"`
+}
+
+type TrendResponse struct {
+	FrameworksUuid uuid.UUID     `json:"frameworks_uuid"`
+	From           string        `json:"from"`
+	To             string        `json:"to"`
+	Granularity    string        `json:"granularity"`
+	Points         []*TrendPoint `json:"points"`
+}
+
+// Request Types
+
+// GetTrendRequest of the snapshots from From to To, both dates formatted with DateLayout. The
+// trend covers the last 90 days by week by default.
+type GetTrendRequest struct {
+	CompanyUuid   uuid.UUID `json:"company_uuid"`
+	UserUuid      uuid.UUID `json:"user_uuid"`
+	FrameworkUuid uuid.UUID `json:"framework_uuid"`
+	From          string    `json:"from"`
+	To            string    `json:"to"`
+	Granularity   string    `json:"granularity"`
+}
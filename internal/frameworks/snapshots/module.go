@@ -0,0 +1,49 @@
+// Package snapshots records the daily compliance of companies with their frameworks for trends.
+package snapshots
+
+import (
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	frameworksCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/endpoints"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/service"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/transport/http"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/periodic"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ModuleParams for snapshots.
+type ModuleParams struct {
+	fx.In
+
+	DB           *gorm.DB
+	HTTPServer   *httpTransport.Server
+	APPTransport svcTransport.Client
+	AuthClient   auth.Client
+	Config       frameworksCfg.Config
+	Logger       *zap.SugaredLogger
+	Lifecycle    fx.Lifecycle
+}
+
+// NewModule for snapshots.
+// nolint:gocritic
+func NewModule(p ModuleParams) error {
+	repo := repository.New(p.DB)
+	svc := service.New(repo, p.Logger)
+	eps := endpoints.New(svc)
+
+	http.RegisterTransport(p.HTTPServer, eps, p.AuthClient, p.APPTransport)
+
+	periodic.Start(p.Lifecycle, "compliance snapshots", p.Config.Snapshots.Interval(), p.Logger, svc.RecordSnapshots)
+
+	return nil
+}
+
+var (
+	// ModuleHttpAPI for uber fx.
+	ModuleHttpAPI = fx.Options(fx.Invoke(NewModule))
+)
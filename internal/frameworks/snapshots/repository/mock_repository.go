@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/entities"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetSnapshots mocks base method.
+func (m *MockRepository) GetSnapshots(ctx context.Context, companyUuid, frameworkUuid uuid.UUID, from, to time.Time) ([]*entities.Snapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSnapshots", ctx, companyUuid, frameworkUuid, from, to)
+	ret0, _ := ret[0].([]*entities.Snapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSnapshots indicates an expected call of GetSnapshots.
+func (mr *MockRepositoryMockRecorder) GetSnapshots(ctx, companyUuid, frameworkUuid, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSnapshots", reflect.TypeOf((*MockRepository)(nil).GetSnapshots), ctx, companyUuid, frameworkUuid, from, to)
+}
+
+// RecordSnapshots mocks base method.
+func (m *MockRepository) RecordSnapshots(ctx context.Context, day, asOf time.Time, backfill bool) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSnapshots", ctx, day, asOf, backfill)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordSnapshots indicates an expected call of RecordSnapshots.
+func (mr *MockRepositoryMockRecorder) RecordSnapshots(ctx, day, asOf, backfill interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSnapshots", reflect.TypeOf((*MockRepository)(nil).RecordSnapshots), ctx, day, asOf, backfill)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/entities"
+	"gorm.io/gorm"
+)
+
+// Repository for compliance snapshots.
+type Repository interface {
+	// RecordSnapshots of every framework of every company for the day, from the state of the data
+	// at asOf, and returns how many were saved. Snapshots of the day are refreshed, unless
+	// backfilled ones are recorded: those never replace a snapshot.
+	RecordSnapshots(ctx context.Context, day, asOf time.Time, backfill bool) (int64, error)
+	// GetSnapshots of a framework of the company from one day to another, oldest first.
+	GetSnapshots(ctx context.Context, companyUuid, frameworkUuid uuid.UUID, from, to time.Time) ([]*entities.Snapshot, error)
+}
+
+// New repository for compliance snapshots.
+func New(db *gorm.DB) Repository {
+	repo := &sqlRepository{gormDB: db}
+
+	return repo
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	frameworkEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/entities"
+	policiesEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	"gorm.io/gorm"
+)
+
+type sqlRepository struct {
+	gormDB *gorm.DB
+}
+
+// recordSnapshots builds the snapshots from the state of the data at @as_of. Only the current
+// status of a remediation is stored: a remediation changed since then counts as not started,
+// unless it was already completed. Rows without timestamps count as they are. Controls and
+// questions are those of the profile currently targeted by the company.
+var recordSnapshots = `
+	with controls as (
+		select cf.company_uuid, fc.frameworks_uuid, fc.framework_control_uuid,
+			case
+				when cr.status = @completed and cr.completed_at <= @as_of then @completed
+				when coalesce(cr.updated_at, cr.created_at, @as_of) <= @as_of then coalesce(cr.status, @not_started)
+				else @not_started
+			end as status,
+			exists (
+				select 1
+				from policy_framework_controls pfc
+					join policies p on p.policy_uuid = pfc.policy_uuid
+				where pfc.framework_control_uuid = fc.framework_control_uuid
+					and p.company_uuid = cf.company_uuid
+					and p.status <> @inactive
+					and coalesce(pfc.created_at, @as_of) <= @as_of
+					and ` + frameworkEntities.ApprovedPolicySQL("@as_of") + `
+			) as covered
+		from company_frameworks cf
+			join framework_controls fc on fc.frameworks_uuid = cf.frameworks_uuid
+			left join control_remediations cr on cr.company_uuid = cf.company_uuid and cr.framework_control_uuid = fc.framework_control_uuid
+		where coalesce(cf.created_at, @as_of) <= @as_of
+			and coalesce(fc.created_at, @as_of) <= @as_of
+			and (fc.retired_at is null or fc.retired_at > @as_of)
+			and ` + frameworkEntities.ControlInProfileSQL + `
+	), by_status as (
+		select company_uuid, frameworks_uuid, status, count(*) as n, count(*) filter (where covered) as covered
+		from controls
+		group by 1, 2, 3
+	), control_stats as (
+		select company_uuid, frameworks_uuid, sum(n)::int as controls_total,
+			jsonb_object_agg(status, n) as controls_by_status, sum(covered)::int as policy_covered
+		from by_status
+		group by 1, 2
+	), questionnaire_stats as (
+		select cf.company_uuid, cf.frameworks_uuid, count(fq.questionnaires_uuid)::int as questionnaire_total,
+			count(qa.questionnaire_answers_uuid)::int as questionnaire_answered
+		from company_frameworks cf
+			join frameworks_questionnaires fq on fq.frameworks_uuid = cf.frameworks_uuid
+			join questionnaires q on q.questionnaires_uuid = fq.questionnaires_uuid
+			left join questionnaire_answers qa on qa.questionnaires_uuid = fq.questionnaires_uuid
+				and qa.company_uuid = cf.company_uuid
+				and coalesce(qa.created_at, @as_of) <= @as_of
+		where coalesce(cf.created_at, @as_of) <= @as_of
+			and ` + frameworkEntities.QuestionInProfileSQL + `
+		group by 1, 2
+	)
+	insert into compliance_snapshots (snapshot_uuid, company_uuid, frameworks_uuid, snapshot_date, controls_total,
+		controls_by_status, policy_covered, questionnaire_total, questionnaire_answered, backfilled, created_at, updated_at)
+	select gen_random_uuid(), cs.company_uuid, cs.frameworks_uuid, cast(@day as date), cs.controls_total,
+		cs.controls_by_status, cs.policy_covered, coalesce(qs.questionnaire_total, 0), coalesce(qs.questionnaire_answered, 0),
+		cast(@backfilled as boolean), now(), now()
+	from control_stats cs
+		left join questionnaire_stats qs on qs.company_uuid = cs.company_uuid and qs.frameworks_uuid = cs.frameworks_uuid
+	on conflict (company_uuid, frameworks_uuid, snapshot_date) do `
+
+func (s *sqlRepository) RecordSnapshots(ctx context.Context, day, asOf time.Time, backfill bool) (int64, error) {
+	query := recordSnapshots + `update set controls_total = excluded.controls_total,
+		controls_by_status = excluded.controls_by_status,
+		policy_covered = excluded.policy_covered,
+		questionnaire_total = excluded.questionnaire_total,
+		questionnaire_answered = excluded.questionnaire_answered,
+		backfilled = false,
+		updated_at = excluded.updated_at`
+
+	if backfill {
+		query = recordSnapshots + `nothing`
+	}
+
+	result := s.gormDB.WithContext(ctx).Exec(query, map[string]interface{}{
+		"day":         day.Format(entities.DateLayout),
+		"as_of":       asOf,
+		"backfilled":  backfill,
+		"completed":   frameworkEntities.RemediationStatusCompleted,
+		"not_started": frameworkEntities.RemediationStatusNotStarted,
+		"approved":    policiesEntities.PolicyStatusApproved,
+		"inactive":    policiesEntities.PolicyStatusInactive,
+	})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (s *sqlRepository) GetSnapshots(ctx context.Context, companyUuid, frameworkUuid uuid.UUID, from, to time.Time) ([]*entities.Snapshot, error) {
+	snapshots := make([]*entities.Snapshot, 0)
+
+	err := s.gormDB.WithContext(ctx).Model(&entities.Snapshot{}).
+		Where("company_uuid = ? AND frameworks_uuid = ?", companyUuid, frameworkUuid).
+		Where("snapshot_date BETWEEN ? AND ?", from.Format(entities.DateLayout), to.Format(entities.DateLayout)).
+		Order("snapshot_date").
+		Find(&snapshots).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	frameworkEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/repository"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// defaultTrendDays covered by a trend without a start date.
+	defaultTrendDays = 90
+	// maxBackfillDays in one backfill, one query runs per day.
+	maxBackfillDays = 3 * 366
+)
+
+type Service interface {
+	// RecordSnapshots of the day of now, for every framework of every company. The snapshot of
+	// the day is refreshed until the day ends.
+	RecordSnapshots(ctx context.Context, now time.Time) error
+	// Backfill the snapshots of the days from one date to another, before today, from the
+	// timestamps of the data. Days which have a snapshot are kept.
+	Backfill(ctx context.Context, from, to time.Time) (*entities.BackfillReport, error)
+	// GetTrend of a framework of the company, one point per period with snapshots.
+	GetTrend(ctx context.Context, req *entities.GetTrendRequest) (*entities.TrendResponse, error)
+}
+
+type service struct {
+	repo   repository.Repository
+	logger *zap.SugaredLogger
+}
+
+func (s *service) RecordSnapshots(ctx context.Context, now time.Time) error {
+	now = now.UTC()
+
+	count, err := s.repo.RecordSnapshots(ctx, day(now), now, false)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Debugf("recorded %d compliance snapshots", count)
+
+	return nil
+}
+
+func (s *service) Backfill(ctx context.Context, from, to time.Time) (*entities.BackfillReport, error) {
+	from, to = day(from), day(to)
+
+	if to.Before(from) {
+		return nil, &appError.ErrValidation{Message: "from should not be after to"}
+	}
+
+	if !to.Before(day(time.Now().UTC())) {
+		return nil, &appError.ErrValidation{Message: "to should be before today, today is recorded by the api"}
+	}
+
+	days := int(to.Sub(from).Hours()/24) + 1
+	if days > maxBackfillDays {
+		return nil, &appError.ErrValidation{Message: "backfill at most 3 years at once"}
+	}
+
+	report := &entities.BackfillReport{
+		From: from.Format(entities.DateLayout),
+		To:   to.Format(entities.DateLayout),
+		Days: days,
+	}
+
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		// the state at the end of the day
+		count, err := s.repo.RecordSnapshots(ctx, d, d.AddDate(0, 0, 1).Add(-time.Microsecond), true)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Created += count
+	}
+
+	return report, nil
+}
+
+func (s *service) GetTrend(ctx context.Context, req *entities.GetTrendRequest) (*entities.TrendResponse, error) {
+	granularity := req.Granularity
+	if granularity == "" {
+		granularity = entities.GranularityWeek
+	}
+
+	if !slices.Contains(entities.Granularities, granularity) {
+		return nil, &appError.ErrValidation{Message: "granularity should be one of " + strings.Join(entities.Granularities, ", ")}
+	}
+
+	to := day(time.Now().UTC())
+	if req.To != "" {
+		t, err := time.Parse(entities.DateLayout, req.To)
+		if err != nil {
+			return nil, &appError.ErrValidation{Message: "to should be a date like " + entities.DateLayout}
+		}
+
+		to = t
+	}
+
+	from := to.AddDate(0, 0, -defaultTrendDays)
+	if req.From != "" {
+		f, err := time.Parse(entities.DateLayout, req.From)
+		if err != nil {
+			return nil, &appError.ErrValidation{Message: "from should be a date like " + entities.DateLayout}
+		}
+
+		from = f
+	}
+
+	if to.Before(from) {
+		return nil, &appError.ErrValidation{Message: "from should not be after to"}
+	}
+
+	snapshots, err := s.repo.GetSnapshots(ctx, req.CompanyUuid, req.FrameworkUuid, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &entities.TrendResponse{
+		FrameworksUuid: req.FrameworkUuid,
+		From:           from.Format(entities.DateLayout),
+		To:             to.Format(entities.DateLayout),
+		Granularity:    granularity,
+		Points:         make([]*entities.TrendPoint, 0),
+	}
+
+	// snapshots are sorted by date, the last one of a period replaces the earlier ones
+	for _, snapshot := range snapshots {
+		point := trendPoint(snapshot, periodStart(snapshot.SnapshotDate, granularity))
+
+		if n := len(res.Points); n > 0 && res.Points[n-1].Period == point.Period {
+			res.Points[n-1] = point
+		} else {
+			res.Points = append(res.Points, point)
+		}
+	}
+
+	return res, nil
+}
+
+// day of t, at midnight.
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// periodStart is the first day of the period of the day: the day itself, the Monday of its
+// week, or the first day of its month or quarter.
+func periodStart(d time.Time, granularity string) time.Time {
+	d = day(d)
+
+	switch granularity {
+	case entities.GranularityWeek:
+		return d.AddDate(0, 0, -(int(d.Weekday())+6)%7)
+	case entities.GranularityMonth:
+		return time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+	case entities.GranularityQuarter:
+		return time.Date(d.Year(), d.Month()-(d.Month()-1)%3, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return d
+	}
+}
+
+func trendPoint(snapshot *entities.Snapshot, period time.Time) *entities.TrendPoint {
+	counts := make(entities.StatusCounts, len(frameworkEntities.RemediationStatuses))
+	for _, status := range frameworkEntities.RemediationStatuses {
+		counts[status] = snapshot.ControlsByStatus[status]
+	}
+
+	applicable := snapshot.ControlsTotal - counts[frameworkEntities.RemediationStatusNotApplicable]
+
+	return &entities.TrendPoint{
+		Period:                  period.Format(entities.DateLayout),
+		SnapshotDate:            snapshot.SnapshotDate.Format(entities.DateLayout),
+		ControlsTotal:           snapshot.ControlsTotal,
+		ControlsByStatus:        counts,
+		RemediationCoverage:     percentage(counts[frameworkEntities.RemediationStatusCompleted], applicable),
+		PolicyCoverage:          percentage(snapshot.PolicyCovered, snapshot.ControlsTotal),
+		QuestionnaireTotal:      snapshot.QuestionnaireTotal,
+		QuestionnaireAnswered:   snapshot.QuestionnaireAnswered,
+		QuestionnaireCompletion: percentage(snapshot.QuestionnaireAnswered, snapshot.QuestionnaireTotal),
+		Backfilled:              snapshot.Backfilled,
+	}
+}
+
+func percentage(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return math.Round(float64(part)*1000/float64(total)) / 10
+}
+
+// New compliance snapshots service.
+func New(repo repository.Repository, logger *zap.SugaredLogger) Service {
+	return &service{
+		repo:   repo,
+		logger: logger,
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	frameworkEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/repository"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGetTrend(t *testing.T) {
+	Convey("Given the daily snapshots of a framework", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		req := &entities.GetTrendRequest{CompanyUuid: uuid.New(), UserUuid: uuid.New(), FrameworkUuid: uuid.New(), From: "2023-01-01", To: "2023-06-30"}
+
+		snapshot := func(d time.Time, completed int) *entities.Snapshot {
+			return &entities.Snapshot{
+				SnapshotDate:  d,
+				ControlsTotal: 20,
+				ControlsByStatus: entities.StatusCounts{
+					frameworkEntities.RemediationStatusCompleted:     completed,
+					frameworkEntities.RemediationStatusNotApplicable: 4,
+					frameworkEntities.RemediationStatusNotStarted:    16 - completed,
+				},
+				PolicyCovered:         5,
+				QuestionnaireTotal:    10,
+				QuestionnaireAnswered: 7,
+			}
+		}
+
+		Convey("The last snapshot of each quarter stands for the quarter", func() {
+			repo.EXPECT().GetSnapshots(ctx, req.CompanyUuid, req.FrameworkUuid, date(2023, 1, 1), date(2023, 6, 30)).
+				Return([]*entities.Snapshot{
+					snapshot(date(2023, 2, 1), 2),
+					snapshot(date(2023, 3, 31), 4),
+					snapshot(date(2023, 4, 1), 8),
+				}, nil)
+
+			req.Granularity = entities.GranularityQuarter
+
+			res, err := svc.GetTrend(ctx, req)
+			So(err, ShouldBeNil)
+			So(res.Points, ShouldHaveLength, 2)
+
+			So(res.Points[0].Period, ShouldEqual, "2023-01-01")
+			So(res.Points[0].SnapshotDate, ShouldEqual, "2023-03-31")
+			So(res.Points[0].RemediationCoverage, ShouldEqual, 25)
+			So(res.Points[0].PolicyCoverage, ShouldEqual, 25)
+			So(res.Points[0].QuestionnaireCompletion, ShouldEqual, 70)
+			So(res.Points[0].ControlsByStatus, ShouldHaveLength, len(frameworkEntities.RemediationStatuses))
+
+			So(res.Points[1].Period, ShouldEqual, "2023-04-01")
+			So(res.Points[1].RemediationCoverage, ShouldEqual, 50)
+		})
+
+		Convey("Weeks start on Monday", func() {
+			So(periodStart(date(2023, 4, 9), entities.GranularityWeek), ShouldEqual, date(2023, 4, 3))
+			So(periodStart(date(2023, 4, 10), entities.GranularityWeek), ShouldEqual, date(2023, 4, 10))
+			So(periodStart(date(2023, 12, 31), entities.GranularityMonth), ShouldEqual, date(2023, 12, 1))
+			So(periodStart(date(2023, 12, 31), entities.GranularityQuarter), ShouldEqual, date(2023, 10, 1))
+		})
+
+		Convey("Unknown granularities and reversed ranges are rejected", func() {
+			var validationErr *appError.ErrValidation
+
+			req.Granularity = "year"
+			_, err := svc.GetTrend(ctx, req)
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+
+			req.Granularity, req.From = entities.GranularityDay, "2023-07-01"
+			_, err = svc.GetTrend(ctx, req)
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+			So(validationErr.Message, ShouldEqual, "from should not be after to")
+		})
+	})
+}
+
+func TestBackfill(t *testing.T) {
+	Convey("Given days without snapshots", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+
+		Convey("Each day is backfilled from the data at its end", func() {
+			for _, d := range []time.Time{date(2023, 1, 30), date(2023, 1, 31), date(2023, 2, 1)} {
+				repo.EXPECT().RecordSnapshots(ctx, d, d.Add(24*time.Hour-time.Microsecond), true).Return(int64(2), nil)
+			}
+
+			report, err := svc.Backfill(ctx, date(2023, 1, 30), date(2023, 2, 1).Add(5*time.Hour))
+			So(err, ShouldBeNil)
+			So(report, ShouldResemble, &entities.BackfillReport{From: "2023-01-30", To: "2023-02-01", Days: 3, Created: 6})
+		})
+
+		Convey("Today is left to the api", func() {
+			_, err := svc.Backfill(ctx, date(2023, 1, 1), time.Now())
+
+			var validationErr *appError.ErrValidation
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+		})
+	})
+}
+
+func TestRecordSnapshots(t *testing.T) {
+	Convey("The snapshot of the day is recorded from the current data", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		now := time.Date(2023, 4, 8, 15, 30, 0, 0, time.UTC)
+
+		repo.EXPECT().RecordSnapshots(ctx, date(2023, 4, 8), now, false).Return(int64(3), nil)
+
+		So(svc.RecordSnapshots(ctx, now), ShouldBeNil)
+	})
+}
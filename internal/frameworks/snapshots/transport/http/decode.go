@@ -0,0 +1,28 @@
+// Package http for compliance snapshots.
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/snapshots/entities"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+)
+
+func decodeGetTrendRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	ids, err := httpTransport.DecodePathIDs(r, "company_id", "user_id", "framework_id")
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.URL.Query()
+
+	return &entities.GetTrendRequest{
+		CompanyUuid:   ids[0],
+		UserUuid:      ids[1],
+		FrameworkUuid: ids[2],
+		From:          query.Get("from"),
+		To:            query.Get("to"),
+		Granularity:   query.Get("granularity"),
+	}, nil
+}
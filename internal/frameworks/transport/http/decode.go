@@ -2,12 +2,14 @@ package http
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/entities"
 	httpError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors/http"
+	"github.com/pkg/errors"
 )
 
 type RequestBodyType interface {
@@ -77,3 +79,42 @@ func decodeGetFrameworkStatsRequest(_ context.Context, r *http.Request) (interfa
 	}
 	return req, nil
 }
+
+func decodeUpdateProfileRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	path, err := decodeGetFrameworkControlRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Body.Close()
+
+	body := &entities.UpdateProfileRequestBody{}
+	if err = json.NewDecoder(r.Body).Decode(body); err != nil {
+		return nil, errors.WithMessage(httpError.ErrBadRequestBody, err.Error())
+	}
+
+	req := path.(*entities.GetFrameworkControlRequest) //nolint:errcheck
+
+	return &entities.UpdateProfileRequest{
+		CompanyUuid:   req.CompanyUuid,
+		UserUuid:      req.UserUuid,
+		FrameworkUuid: req.FrameworkUuid,
+		Body:          body,
+	}, nil
+}
+
+func decodePreviewProfileRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	path, err := decodeGetFrameworkControlRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := path.(*entities.GetFrameworkControlRequest) //nolint:errcheck
+
+	return &entities.PreviewProfileRequest{
+		CompanyUuid:   req.CompanyUuid,
+		UserUuid:      req.UserUuid,
+		FrameworkUuid: req.FrameworkUuid,
+		Profile:       r.URL.Query().Get("profile"),
+	}, nil
+}
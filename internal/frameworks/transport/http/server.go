@@ -4,6 +4,7 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/frameworks/endpoints"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
@@ -20,12 +21,16 @@ func RegisterTransport(
 	registerGetFrameworks(server, ep.GetFrameworksEndpoint, authClient, svcTransportClient)
 	registerGetFrameworkControls(server, ep.GetFrameworkControlsEndpoint, authClient, svcTransportClient)
 	registerGetFrameworkStats(server, ep.GetFrameworkStatsEndpoint, authClient, svcTransportClient)
+	registerGetPolicyCoverage(server, ep.GetPolicyCoverageEndpoint, authClient, svcTransportClient)
+	registerGetProfile(server, ep.GetProfileEndpoint, authClient, svcTransportClient)
+	registerUpdateProfile(server, ep.UpdateProfileEndpoint, authClient, svcTransportClient)
+	registerPreviewProfile(server, ep.PreviewProfileEndpoint, authClient, svcTransportClient)
 }
 
 func registerGetFrameworks(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/frameworks"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetFrameworksRequest, encoder, atc, method)
 
@@ -36,7 +41,7 @@ func registerGetFrameworks(server *httpTransport.Server, ep goKitEndpoint.Endpoi
 func registerGetFrameworkControls(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/frameworks/{framework_id}/controls"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetFrameworkControlRequest, encoder, atc, method)
 
@@ -47,7 +52,7 @@ func registerGetFrameworkControls(server *httpTransport.Server, ep goKitEndpoint
 func registerGetFrameworkStats(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/frameworks/stats"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetFrameworkStatsRequest, encoder, atc, method)
 
@@ -55,6 +60,50 @@ func registerGetFrameworkStats(server *httpTransport.Server, ep goKitEndpoint.En
 	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
 }
 
+func registerGetPolicyCoverage(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/frameworks/{framework_id}/policy-coverage"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetFrameworkControlRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerGetProfile(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/frameworks/{framework_id}/profile"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetFrameworkControlRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerUpdateProfile(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/frameworks/{framework_id}/profile"
+	method := "PUT"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", permissions.ActionUpdate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeUpdateProfileRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerPreviewProfile(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/frameworks/{framework_id}/profile/preview"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodePreviewProfileRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
 func getHandler(ep goKitEndpoint.Endpoint, dec goKitHTTPTransport.DecodeRequestFunc, enc goKitHTTPTransport.EncodeResponseFunc, atc svcTransport.Client, method string) *goKitHTTPTransport.Server {
 	return goKitHTTPTransport.NewServer(
 		ep,
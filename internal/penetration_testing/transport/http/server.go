@@ -4,6 +4,7 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/penetration_testing/endpoints"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
@@ -24,7 +25,7 @@ func RegisterTransport(
 func registerGetPenetrationTests(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/penetration/topremediation"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "penetration-test", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "penetration-test", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetVulnerabilitiesRequest, encoder, atc, method)
 
@@ -35,7 +36,7 @@ func registerGetPenetrationTests(server *httpTransport.Server, ep goKitEndpoint.
 func registerGetPenetrationTestStats(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/penetration/stats"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "penetration-test", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "penetration-test", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetVulnerabilityStatsRequest, encoder, atc, method)
 
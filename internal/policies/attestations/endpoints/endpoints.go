@@ -0,0 +1,78 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/attestations/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/attestations/service"
+)
+
+type Endpoints struct {
+	PublishPolicyEndpoint            endpoint.Endpoint
+	GetPolicyAttestationsEndpoint    endpoint.Endpoint
+	RemindPolicyAttestationsEndpoint endpoint.Endpoint
+	GetAttestationStatsEndpoint      endpoint.Endpoint
+	ListUserAttestationsEndpoint     endpoint.Endpoint
+	AcknowledgeAttestationEndpoint   endpoint.Endpoint
+}
+
+// New returns new endpoints
+func New(svc service.Service) *Endpoints {
+	return &Endpoints{
+		PublishPolicyEndpoint:            makePublishPolicyEndpoint(svc),
+		GetPolicyAttestationsEndpoint:    makeGetPolicyAttestationsEndpoint(svc),
+		RemindPolicyAttestationsEndpoint: makeRemindPolicyAttestationsEndpoint(svc),
+		GetAttestationStatsEndpoint:      makeGetAttestationStatsEndpoint(svc),
+		ListUserAttestationsEndpoint:     makeListUserAttestationsEndpoint(svc),
+		AcknowledgeAttestationEndpoint:   makeAcknowledgeAttestationEndpoint(svc),
+	}
+}
+
+func makePublishPolicyEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.PublishPolicyRequest) //nolint:errcheck
+
+		return svc.PublishPolicy(ctx, req)
+	}
+}
+
+func makeGetPolicyAttestationsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetPolicyAttestationsRequest) //nolint:errcheck
+
+		return svc.GetPolicyAttestations(ctx, req)
+	}
+}
+
+func makeRemindPolicyAttestationsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.RemindPolicyAttestationsRequest) //nolint:errcheck
+
+		return svc.RemindPolicyAttestations(ctx, req)
+	}
+}
+
+func makeGetAttestationStatsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetAttestationStatsRequest) //nolint:errcheck
+
+		return svc.GetAttestationStats(ctx, req)
+	}
+}
+
+func makeListUserAttestationsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.ListUserAttestationsRequest) //nolint:errcheck
+
+		return svc.ListUserAttestations(ctx, req)
+	}
+}
+
+func makeAcknowledgeAttestationEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.AcknowledgeAttestationRequest) //nolint:errcheck
+
+		return svc.AcknowledgeAttestation(ctx, req)
+	}
+}
@@ -0,0 +1,208 @@
+package entities
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+)
+
+const (
+	AudienceAll      = "all"
+	AudienceSelected = "selected"
+
+	StatusPending      = "pending"
+	StatusAcknowledged = "acknowledged"
+	// StatusSuperseded marks the attestations left pending when a new version was published.
+	StatusSuperseded = "superseded"
+)
+
+// Publication of an approved version of a policy to the users of its company. A policy has a
+// single current publication, publishing another version supersedes it.
+type Publication struct {
+	PublicationUuid   uuid.UUID         `json:"publication_uuid" gorm:"column:publication_uuid"`
+	PolicyUuid        uuid.UUID         `json:"policy_uuid" gorm:"column:policy_uuid"`
+	PolicyHistoryUuid uuid.UUID         `json:"policy_history_uuid" gorm:"column:policy_history_uuid"`
+	Version           int               `json:"version" gorm:"->;column:version"`
+	CompanyUuid       uuid.UUID         `json:"company_uuid" gorm:"column:company_uuid"`
+	Audience          string            `json:"audience" gorm:"column:audience"`
+	PublishedAt       time.Time         `json:"published_at" gorm:"column:published_at"`
+	PublishedBy       uuid.UUID         `json:"published_by" gorm:"column:published_by"`
+	SupersededAt      nullable.NullTime `json:"superseded_at" gorm:"column:superseded_at"`
+}
+
+func (m *Publication) TableName() string {
+	return "policy_publications"
+}
+
+// Attestation of a user that they read a published version of a policy.
+type Attestation struct {
+	AttestationUuid uuid.UUID         `json:"attestation_uuid" gorm:"column:attestation_uuid"`
+	PublicationUuid uuid.UUID         `json:"publication_uuid" gorm:"column:publication_uuid"`
+	UserUuid        uuid.UUID         `json:"user_uuid" gorm:"column:user_uuid"`
+	Status          string            `json:"status" gorm:"column:status"`
+	AcknowledgedAt  nullable.NullTime `json:"acknowledged_at" gorm:"column:acknowledged_at"`
+	IPAddress       string            `json:"ip_address" gorm:"column:ip_address"`
+	RemindedAt      nullable.NullTime `json:"reminded_at" gorm:"column:reminded_at"`
+	ReminderCount   int               `json:"reminder_count" gorm:"column:reminder_count"`
+	CreatedAt       time.Time         `json:"created_at" gorm:"column:created_at"`
+}
+
+func (m *Attestation) TableName() string {
+	return "policy_attestations"
+}
+
+// AttestationUser is the user an attestation is asked of.
+type AttestationUser struct {
+	UserUuid  uuid.UUID `json:"user_uuid" gorm:"column:user_uuid"`
+	FirstName string    `json:"first_name" gorm:"column:first_name"`
+	LastName  string    `json:"last_name" gorm:"column:last_name"`
+	Email     string    `json:"email" gorm:"column:email"`
+}
+
+// AttestationView is an attestation with the policy version and the user it is about.
+type AttestationView struct {
+	AttestationUuid uuid.UUID       `json:"attestation_uuid" gorm:"column:attestation_uuid"`
+	PolicyUuid      uuid.UUID       `json:"policy_uuid" gorm:"column:policy_uuid"`
+	PolicyName      string          `json:"policy_name" gorm:"column:policy_name"`
+	Version         int             `json:"version" gorm:"column:version"`
+	PublishedAt     time.Time       `json:"published_at" gorm:"column:published_at"`
+	Status          string          `json:"status" gorm:"column:status"`
+	AcknowledgedAt  *time.Time      `json:"acknowledged_at" gorm:"column:acknowledged_at"`
+	IPAddress       string          `json:"ip_address,omitempty" gorm:"column:ip_address"`
+	RemindedAt      *time.Time      `json:"reminded_at" gorm:"column:reminded_at"`
+	User            AttestationUser `json:"user" gorm:"embedded"`
+}
+
+// AttestationFilter narrows the attestations of a company. Zero values match everything.
+type AttestationFilter struct {
+	CompanyUuid     uuid.UUID
+	PublicationUuid uuid.UUID
+	UserUuid        uuid.UUID
+	Status          string
+	// CurrentOnly leaves out the attestations of superseded publications.
+	CurrentOnly bool
+}
+
+// Completion of the attestations asked of a set of users, superseded ones aside.
+type Completion struct {
+	Total        int     `json:"total" gorm:"column:total"`
+	Acknowledged int     `json:"acknowledged" gorm:"column:acknowledged"`
+	Pending      int     `json:"pending" gorm:"column:pending"`
+	Percent      float64 `json:"percent" gorm:"-"`
+}
+
+// NewCompletion of acknowledged attestations out of total, with the percent rounded to two
+// decimals.
+func NewCompletion(total, acknowledged int) Completion {
+	c := Completion{Total: total, Acknowledged: acknowledged, Pending: total - acknowledged}
+	if total > 0 {
+		c.Percent = math.Round(float64(acknowledged)*10000/float64(total)) / 100
+	}
+
+	return c
+}
+
+// PolicyCompletion of the current publication of a policy.
+type PolicyCompletion struct {
+	PolicyUuid  uuid.UUID `json:"policy_uuid" gorm:"column:policy_uuid"`
+	Name        string    `json:"name" gorm:"column:name"`
+	Version     int       `json:"version" gorm:"column:version"`
+	PublishedAt time.Time `json:"published_at" gorm:"column:published_at"`
+	Completion  `gorm:"embedded"`
+}
+
+// UserCompletion of the attestations of the current publications asked of a user.
+type UserCompletion struct {
+	User       AttestationUser `json:"user" gorm:"embedded"`
+	Completion `gorm:"embedded"`
+}
+
+// Reminder to send to a user whose attestation is pending.
+type Reminder struct {
+	AttestationUuid uuid.UUID `gorm:"column:attestation_uuid"`
+	PolicyUuid      uuid.UUID `gorm:"column:policy_uuid"`
+	PolicyName      string    `gorm:"column:policy_name"`
+	Version         int       `gorm:"column:version"`
+	PublishedAt     time.Time `gorm:"column:published_at"`
+	Email           string    `gorm:"column:email"`
+	FirstName       string    `gorm:"column:first_name"`
+}
+
+// ReminderFilter selects the pending attestations to remind. Zero uuids match everything.
+type ReminderFilter struct {
+	CompanyUuid uuid.UUID
+	PolicyUuid  uuid.UUID
+	// AskedBefore leaves out the attestations asked of since.
+	AskedBefore time.Time
+	// RemindedBefore leaves out the attestations reminded of since.
+	RemindedBefore time.Time
+}
+
+// PublishPolicyRequestBody publishes a version of a policy, the latest approved one by
+// default, to all the active users of the company or to the selected ones.
+type PublishPolicyRequestBody struct {
+	Version   int         `json:"version"`
+	Audience  string      `json:"audience"`
+	UserUuids []uuid.UUID `json:"user_uuids"`
+}
+
+type PublishPolicyRequest struct {
+	CompanyUuid uuid.UUID
+	UserUuid    uuid.UUID
+	PolicyUuid  uuid.UUID
+	Body        *PublishPolicyRequestBody
+}
+
+type GetPolicyAttestationsRequest struct {
+	CompanyUuid uuid.UUID
+	UserUuid    uuid.UUID
+	PolicyUuid  uuid.UUID
+}
+
+// GetPolicyAttestationsResponse is the current publication of a policy with its attestations.
+type GetPolicyAttestationsResponse struct {
+	PublicationUuid uuid.UUID          `json:"publication_uuid"`
+	PolicyUuid      uuid.UUID          `json:"policy_uuid"`
+	Version         int                `json:"version"`
+	Audience        string             `json:"audience"`
+	PublishedAt     time.Time          `json:"published_at"`
+	Completion      Completion         `json:"completion"`
+	Attestations    []*AttestationView `json:"attestations"`
+}
+
+type RemindPolicyAttestationsRequest struct {
+	CompanyUuid uuid.UUID
+	UserUuid    uuid.UUID
+	PolicyUuid  uuid.UUID
+}
+
+type RemindPolicyAttestationsResponse struct {
+	Reminded int `json:"reminded"`
+}
+
+type ListUserAttestationsRequest struct {
+	CompanyUuid uuid.UUID
+	UserUuid    uuid.UUID
+	Status      string
+}
+
+type AcknowledgeAttestationRequest struct {
+	CompanyUuid     uuid.UUID
+	UserUuid        uuid.UUID
+	AttestationUuid uuid.UUID
+}
+
+type GetAttestationStatsRequest struct {
+	CompanyUuid uuid.UUID
+	UserUuid    uuid.UUID
+}
+
+// GetAttestationStatsResponse is the completion of the current publications of a company,
+// overall, per policy and per user.
+type GetAttestationStatsResponse struct {
+	Completion Completion          `json:"completion"`
+	Policies   []*PolicyCompletion `json:"policies"`
+	Users      []*UserCompletion   `json:"users"`
+}
@@ -0,0 +1,56 @@
+// Package attestations publishes approved policy versions to the users of a company and tracks
+// their acknowledgements.
+package attestations
+
+import (
+	audit "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/attestations/endpoints"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/attestations/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/attestations/service"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/attestations/transport/http"
+	policiesCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/ses"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/cfg"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/periodic"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ModuleParams for attestations.
+type ModuleParams struct {
+	fx.In
+
+	DB            *gorm.DB
+	HTTPServer    *httpTransport.Server
+	APPTransport  svcTransport.Client
+	AuthClient    auth.Client
+	AuditRecorder audit.Recorder
+	EmailClient   ses.Client
+	Config        policiesCfg.Config
+	CommonConfig  cfg.Config
+	Logger        *zap.SugaredLogger
+	Lifecycle     fx.Lifecycle
+}
+
+// NewModule for attestations.
+// nolint:gocritic
+func NewModule(p ModuleParams) error {
+	repo := repository.New(p.DB, p.AuditRecorder)
+	svc := service.New(repo, p.EmailClient, p.Config, p.CommonConfig, p.Logger)
+	eps := endpoints.New(svc)
+
+	http.RegisterTransport(p.HTTPServer, eps, p.AuthClient, p.APPTransport)
+
+	periodic.Start(p.Lifecycle, "policy attestation reminders", p.Config.Attestations.Interval(), p.Logger, svc.SendReminders)
+
+	return nil
+}
+
+var (
+	// ModuleHttpAPI for uber fx.
+	ModuleHttpAPI = fx.Options(fx.Invoke(NewModule))
+)
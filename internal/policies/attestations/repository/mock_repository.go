@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/attestations/entities"
+	entities0 "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Acknowledge mocks base method.
+func (m *MockRepository) Acknowledge(ctx context.Context, companyUuid, userUuid, attestationUuid uuid.UUID, at time.Time, ipAddress string) (*entities.Attestation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acknowledge", ctx, companyUuid, userUuid, attestationUuid, at, ipAddress)
+	ret0, _ := ret[0].(*entities.Attestation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Acknowledge indicates an expected call of Acknowledge.
+func (mr *MockRepositoryMockRecorder) Acknowledge(ctx, companyUuid, userUuid, attestationUuid, at, ipAddress interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acknowledge", reflect.TypeOf((*MockRepository)(nil).Acknowledge), ctx, companyUuid, userUuid, attestationUuid, at, ipAddress)
+}
+
+// ClaimReminders mocks base method.
+func (m *MockRepository) ClaimReminders(ctx context.Context, filter *entities.ReminderFilter, now time.Time) ([]*entities.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimReminders", ctx, filter, now)
+	ret0, _ := ret[0].([]*entities.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimReminders indicates an expected call of ClaimReminders.
+func (mr *MockRepositoryMockRecorder) ClaimReminders(ctx, filter, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimReminders", reflect.TypeOf((*MockRepository)(nil).ClaimReminders), ctx, filter, now)
+}
+
+// GetApprovedVersion mocks base method.
+func (m *MockRepository) GetApprovedVersion(ctx context.Context, companyUuid, policyUuid uuid.UUID, version int) (*entities0.PolicyHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApprovedVersion", ctx, companyUuid, policyUuid, version)
+	ret0, _ := ret[0].(*entities0.PolicyHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovedVersion indicates an expected call of GetApprovedVersion.
+func (mr *MockRepositoryMockRecorder) GetApprovedVersion(ctx, companyUuid, policyUuid, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovedVersion", reflect.TypeOf((*MockRepository)(nil).GetApprovedVersion), ctx, companyUuid, policyUuid, version)
+}
+
+// GetCurrentPublication mocks base method.
+func (m *MockRepository) GetCurrentPublication(ctx context.Context, companyUuid, policyUuid uuid.UUID) (*entities.Publication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentPublication", ctx, companyUuid, policyUuid)
+	ret0, _ := ret[0].(*entities.Publication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentPublication indicates an expected call of GetCurrentPublication.
+func (mr *MockRepositoryMockRecorder) GetCurrentPublication(ctx, companyUuid, policyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentPublication", reflect.TypeOf((*MockRepository)(nil).GetCurrentPublication), ctx, companyUuid, policyUuid)
+}
+
+// GetPolicyCompletions mocks base method.
+func (m *MockRepository) GetPolicyCompletions(ctx context.Context, companyUuid uuid.UUID) ([]*entities.PolicyCompletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicyCompletions", ctx, companyUuid)
+	ret0, _ := ret[0].([]*entities.PolicyCompletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicyCompletions indicates an expected call of GetPolicyCompletions.
+func (mr *MockRepositoryMockRecorder) GetPolicyCompletions(ctx, companyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyCompletions", reflect.TypeOf((*MockRepository)(nil).GetPolicyCompletions), ctx, companyUuid)
+}
+
+// GetUserCompletions mocks base method.
+func (m *MockRepository) GetUserCompletions(ctx context.Context, companyUuid uuid.UUID) ([]*entities.UserCompletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCompletions", ctx, companyUuid)
+	ret0, _ := ret[0].([]*entities.UserCompletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCompletions indicates an expected call of GetUserCompletions.
+func (mr *MockRepositoryMockRecorder) GetUserCompletions(ctx, companyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCompletions", reflect.TypeOf((*MockRepository)(nil).GetUserCompletions), ctx, companyUuid)
+}
+
+// ListAttestations mocks base method.
+func (m *MockRepository) ListAttestations(ctx context.Context, filter *entities.AttestationFilter) ([]*entities.AttestationView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttestations", ctx, filter)
+	ret0, _ := ret[0].([]*entities.AttestationView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttestations indicates an expected call of ListAttestations.
+func (mr *MockRepositoryMockRecorder) ListAttestations(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttestations", reflect.TypeOf((*MockRepository)(nil).ListAttestations), ctx, filter)
+}
+
+// Publish mocks base method.
+func (m *MockRepository) Publish(ctx context.Context, publication *entities.Publication, userUuids []uuid.UUID) (*entities.Publication, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, publication, userUuids)
+	ret0, _ := ret[0].(*entities.Publication)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockRepositoryMockRecorder) Publish(ctx, publication, userUuids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockRepository)(nil).Publish), ctx, publication, userUuids)
+}
+
+// ReleaseReminder mocks base method.
+func (m *MockRepository) ReleaseReminder(ctx context.Context, reminder *entities.Reminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseReminder", ctx, reminder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseReminder indicates an expected call of ReleaseReminder.
+func (mr *MockRepositoryMockRecorder) ReleaseReminder(ctx, reminder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReminder", reflect.TypeOf((*MockRepository)(nil).ReleaseReminder), ctx, reminder)
+}
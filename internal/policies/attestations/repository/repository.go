@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	audit "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/attestations/entities"
+	policiesEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	"gorm.io/gorm"
+)
+
+// Repository for policy publications and the attestations of their readers.
+type Repository interface {
+	// GetApprovedVersion of the policy, the latest approved one when version is 0.
+	GetApprovedVersion(ctx context.Context, companyUuid, policyUuid uuid.UUID, version int) (*policiesEntities.PolicyHistory, error)
+	// Publish the publication to the users, or to all the active users of the company when
+	// userUuids is nil. Publishing the current version again only adds the missing users,
+	// another version supersedes the current publication and its pending attestations.
+	Publish(ctx context.Context, publication *entities.Publication, userUuids []uuid.UUID) (*entities.Publication, error)
+	GetCurrentPublication(ctx context.Context, companyUuid, policyUuid uuid.UUID) (*entities.Publication, error)
+	ListAttestations(ctx context.Context, filter *entities.AttestationFilter) ([]*entities.AttestationView, error)
+	// Acknowledge the pending attestation of the user.
+	Acknowledge(ctx context.Context, companyUuid, userUuid, attestationUuid uuid.UUID, at time.Time, ipAddress string) (*entities.Attestation, error)
+	GetPolicyCompletions(ctx context.Context, companyUuid uuid.UUID) ([]*entities.PolicyCompletion, error)
+	GetUserCompletions(ctx context.Context, companyUuid uuid.UUID) ([]*entities.UserCompletion, error)
+	// ClaimReminders marks the pending attestations matching the filter as reminded at now and
+	// returns them. Claimed rows are skipped by other instances.
+	ClaimReminders(ctx context.Context, filter *entities.ReminderFilter, now time.Time) ([]*entities.Reminder, error)
+	// ReleaseReminder that could not be sent, so it is claimed again.
+	ReleaseReminder(ctx context.Context, reminder *entities.Reminder) error
+}
+
+// New repository for attestations.
+func New(db *gorm.DB, auditRecorder audit.Recorder) Repository {
+	repo := &sqlRepository{gormDB: db, audit: auditRecorder}
+
+	return repo
+}
@@ -0,0 +1,425 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	auditEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/entities"
+	audit "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/attestations/entities"
+	policiesEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	policiesRepository "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/repository"
+	userEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/db"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sqlRepository struct {
+	gormDB *gorm.DB
+	audit  audit.Recorder
+}
+
+func (s *sqlRepository) GetApprovedVersion(ctx context.Context, companyUuid, policyUuid uuid.UUID, version int) (*policiesEntities.PolicyHistory, error) {
+	var policy policiesEntities.Policy
+
+	result := s.gormDB.WithContext(ctx).Model(&policiesEntities.Policy{}).
+		Limit(1).
+		Find(&policy, "policy_uuid = ? AND company_uuid = ?", policyUuid, companyUuid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &appError.ErrNotFound{Message: "policy not found"}
+	}
+
+	if policy.Status == policiesEntities.PolicyStatusInactive {
+		return nil, &appError.ErrValidation{Message: "inactive policies can't be published"}
+	}
+
+	// versions approved before transitions were stored only show in the status of the policy
+	query := s.gormDB.WithContext(ctx).Model(&policiesEntities.PolicyHistory{}).
+		Select("policy_history_uuid", "policy_uuid", "version", "created_at").
+		Where("policy_uuid = ?", policyUuid).
+		Where(
+			`(EXISTS (SELECT 1 FROM policy_status_histories psh WHERE psh.policy_history_uuid = policy_histories.policy_history_uuid AND psh.to_status = ?)
+			OR (? AND version = (SELECT max(version) FROM policy_histories WHERE policy_uuid = ?)))`,
+			policiesEntities.PolicyStatusApproved, policy.Status == policiesEntities.PolicyStatusApproved, policyUuid,
+		)
+
+	if version > 0 {
+		query = query.Where("version = ?", version)
+	}
+
+	var versions []*policiesEntities.PolicyHistory
+
+	if err := query.Order("version desc").Limit(1).Find(&versions).Error; err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		if version > 0 {
+			return nil, &appError.ErrValidation{Message: fmt.Sprintf("version %d of the policy is not approved", version)}
+		}
+
+		return nil, &appError.ErrValidation{Message: "policy has no approved version"}
+	}
+
+	return versions[0], nil
+}
+
+func (s *sqlRepository) Publish(ctx context.Context, publication *entities.Publication, userUuids []uuid.UUID) (*entities.Publication, error) {
+	var before *entities.Publication
+	published := publication
+
+	err := s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if userUuids != nil {
+			err := policiesRepository.CheckCompanyUsers(tx, &publication.CompanyUuid, userUuids, "users should be active users of the company")
+			if err != nil {
+				return err
+			}
+		}
+
+		var current []*entities.Publication
+
+		err := tx.Model(&entities.Publication{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Limit(1).
+			Find(&current, "policy_uuid = ? AND superseded_at IS NULL", publication.PolicyUuid).Error
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case len(current) > 0 && current[0].PolicyHistoryUuid == publication.PolicyHistoryUuid:
+			// the same version again reaches more users
+			before = current[0]
+			published = &entities.Publication{}
+			*published = *before
+			published.Version = publication.Version
+
+			if publication.Audience == entities.AudienceAll && before.Audience != entities.AudienceAll {
+				published.Audience = entities.AudienceAll
+
+				err = tx.Model(&entities.Publication{}).
+					Where("publication_uuid = ?", before.PublicationUuid).
+					Update("audience", entities.AudienceAll).Error
+				if err != nil {
+					return err
+				}
+			}
+		case len(current) > 0:
+			err = supersede(tx, current[0], publication.PublishedAt)
+			if err != nil {
+				return err
+			}
+
+			fallthrough
+		default:
+			if err = tx.Create(publication).Error; err != nil {
+				if db.IsAlreadyExistError(err) {
+					return &appError.ErrValidation{Message: "policy is being published already"}
+				}
+
+				return err
+			}
+		}
+
+		return createAttestations(tx, published, userUuids)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	action := auditEntities.ActionCreate
+	if before != nil {
+		action = auditEntities.ActionUpdate
+	}
+
+	s.audit.Record(ctx, &auditEntities.Change{
+		EntityType:  "policy_publication",
+		EntityID:    published.PublicationUuid.String(),
+		CompanyUuid: published.CompanyUuid,
+		Action:      action,
+		Before:      before,
+		After:       published,
+	})
+
+	return published, nil
+}
+
+// supersede the publication, so its pending attestations are no longer asked.
+func supersede(tx *gorm.DB, publication *entities.Publication, at time.Time) error {
+	err := tx.Model(&entities.Publication{}).
+		Where("publication_uuid = ?", publication.PublicationUuid).
+		Update("superseded_at", nullable.NewNullTime(at)).Error
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&entities.Attestation{}).
+		Where("publication_uuid = ? AND status = ?", publication.PublicationUuid, entities.StatusPending).
+		Update("status", entities.StatusSuperseded).Error
+}
+
+// createAttestations asks the active users of the company, or the given ones, to attest the
+// publication. Users asked already are left as they are.
+func createAttestations(tx *gorm.DB, publication *entities.Publication, userUuids []uuid.UUID) error {
+	query := `
+		insert into policy_attestations (attestation_uuid, publication_uuid, user_uuid, status, created_at)
+		select gen_random_uuid(), @publication_uuid, cu.user_uuid, @pending, @now
+		from company_users cu
+		where cu.company_uuid = @company_uuid and ` + userEntities.ActiveCompanyUserSQL
+
+	params := map[string]interface{}{
+		"publication_uuid": publication.PublicationUuid,
+		"pending":          entities.StatusPending,
+		"now":              time.Now(),
+		"company_uuid":     publication.CompanyUuid,
+	}
+
+	if userUuids != nil {
+		query += " and cu.user_uuid in @user_uuids"
+		params["user_uuids"] = userUuids
+	}
+
+	query += " on conflict (publication_uuid, user_uuid) do nothing"
+
+	return tx.Exec(query, params).Error
+}
+
+func (s *sqlRepository) GetCurrentPublication(ctx context.Context, companyUuid, policyUuid uuid.UUID) (*entities.Publication, error) {
+	var publication entities.Publication
+
+	result := s.gormDB.WithContext(ctx).Model(&entities.Publication{}).
+		Select("policy_publications.*, ph.version").
+		Joins("JOIN policy_histories ph ON ph.policy_history_uuid = policy_publications.policy_history_uuid").
+		Where("policy_publications.superseded_at IS NULL").
+		Limit(1).
+		Find(&publication, "policy_publications.policy_uuid = ? AND policy_publications.company_uuid = ?", policyUuid, companyUuid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &appError.ErrNotFound{Message: "policy is not published"}
+	}
+
+	return &publication, nil
+}
+
+// ListAttestations matching the filter, latest publications first.
+func (s *sqlRepository) ListAttestations(ctx context.Context, filter *entities.AttestationFilter) ([]*entities.AttestationView, error) {
+	views := make([]*entities.AttestationView, 0)
+
+	query := s.gormDB.WithContext(ctx).Table("policy_attestations a").
+		Select(`a.attestation_uuid, pp.policy_uuid, p.name as policy_name, ph.version, pp.published_at, a.status,
+			a.acknowledged_at, coalesce(a.ip_address, '') as ip_address, a.reminded_at, u.user_uuid,
+			coalesce(u.first_name, '') as first_name, coalesce(u.last_name, '') as last_name, u.email`).
+		Joins("JOIN policy_publications pp ON pp.publication_uuid = a.publication_uuid").
+		Joins("JOIN policies p ON p.policy_uuid = pp.policy_uuid").
+		Joins("JOIN policy_histories ph ON ph.policy_history_uuid = pp.policy_history_uuid").
+		Joins("JOIN users u ON u.user_uuid = a.user_uuid").
+		Where("pp.company_uuid = ?", filter.CompanyUuid)
+
+	if filter.PublicationUuid != uuid.Nil {
+		query = query.Where("a.publication_uuid = ?", filter.PublicationUuid)
+	}
+
+	if filter.UserUuid != uuid.Nil {
+		query = query.Where("a.user_uuid = ?", filter.UserUuid)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("a.status = ?", filter.Status)
+	}
+
+	if filter.CurrentOnly {
+		query = query.Where("pp.superseded_at IS NULL")
+	}
+
+	err := query.Order("pp.published_at desc, u.first_name, u.last_name").Scan(&views).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+func (s *sqlRepository) Acknowledge(ctx context.Context, companyUuid, userUuid, attestationUuid uuid.UUID, at time.Time, ipAddress string) (*entities.Attestation, error) {
+	var before, after entities.Attestation
+
+	err := s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.Attestation{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("publication_uuid IN (SELECT publication_uuid FROM policy_publications WHERE company_uuid = ?)", companyUuid).
+			Limit(1).
+			Find(&before, "attestation_uuid = ? AND user_uuid = ?", attestationUuid, userUuid)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return &appError.ErrNotFound{Message: "attestation not found"}
+		}
+
+		switch before.Status {
+		case entities.StatusAcknowledged:
+			return &appError.ErrValidation{Message: "policy acknowledged already"}
+		case entities.StatusSuperseded:
+			return &appError.ErrValidation{Message: "a newer version of the policy was published"}
+		}
+
+		after = before
+		after.Status = entities.StatusAcknowledged
+		after.AcknowledgedAt = nullable.NewNullTime(at)
+		after.IPAddress = ipAddress
+
+		return tx.Model(&entities.Attestation{}).
+			Where("attestation_uuid = ?", attestationUuid).
+			Updates(map[string]interface{}{
+				"status":          after.Status,
+				"acknowledged_at": after.AcknowledgedAt,
+				"ip_address":      ipAddress,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, &auditEntities.Change{
+		EntityType:  "policy_attestation",
+		EntityID:    attestationUuid.String(),
+		CompanyUuid: companyUuid,
+		Action:      auditEntities.ActionUpdate,
+		Before:      &before,
+		After:       &after,
+	})
+
+	return &after, nil
+}
+
+// GetPolicyCompletions counts the attestations of the current publications of the company,
+// per policy.
+func (s *sqlRepository) GetPolicyCompletions(ctx context.Context, companyUuid uuid.UUID) ([]*entities.PolicyCompletion, error) {
+	completions := make([]*entities.PolicyCompletion, 0)
+
+	query := `
+		select pp.policy_uuid, p.name, ph.version, pp.published_at,
+			count(a.attestation_uuid) filter (where a.status <> @superseded) as total,
+			count(a.attestation_uuid) filter (where a.status = @acknowledged) as acknowledged,
+			count(a.attestation_uuid) filter (where a.status = @pending) as pending
+		from policy_publications pp
+			join policies p on p.policy_uuid = pp.policy_uuid
+			join policy_histories ph on ph.policy_history_uuid = pp.policy_history_uuid
+			left join policy_attestations a on a.publication_uuid = pp.publication_uuid
+		where pp.company_uuid = @company_uuid and pp.superseded_at is null
+		group by pp.policy_uuid, p.name, ph.version, pp.published_at
+		order by p.name`
+
+	err := s.gormDB.WithContext(ctx).Raw(query, completionParams(companyUuid)).Scan(&completions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return completions, nil
+}
+
+// GetUserCompletions counts the attestations of the current publications of the company, per
+// user, the least complete first.
+func (s *sqlRepository) GetUserCompletions(ctx context.Context, companyUuid uuid.UUID) ([]*entities.UserCompletion, error) {
+	completions := make([]*entities.UserCompletion, 0)
+
+	query := `
+		select u.user_uuid, coalesce(u.first_name, '') as first_name, coalesce(u.last_name, '') as last_name, u.email,
+			count(*) as total,
+			count(*) filter (where a.status = @acknowledged) as acknowledged,
+			count(*) filter (where a.status = @pending) as pending
+		from policy_attestations a
+			join policy_publications pp on pp.publication_uuid = a.publication_uuid
+			join users u on u.user_uuid = a.user_uuid
+		where pp.company_uuid = @company_uuid and pp.superseded_at is null and a.status <> @superseded
+		group by u.user_uuid, u.first_name, u.last_name, u.email
+		order by count(*) filter (where a.status = @acknowledged)::float / count(*), u.first_name, u.last_name`
+
+	err := s.gormDB.WithContext(ctx).Raw(query, completionParams(companyUuid)).Scan(&completions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return completions, nil
+}
+
+func completionParams(companyUuid uuid.UUID) map[string]interface{} {
+	return map[string]interface{}{
+		"company_uuid": companyUuid,
+		"pending":      entities.StatusPending,
+		"acknowledged": entities.StatusAcknowledged,
+		"superseded":   entities.StatusSuperseded,
+	}
+}
+
+func (s *sqlRepository) ClaimReminders(ctx context.Context, filter *entities.ReminderFilter, now time.Time) ([]*entities.Reminder, error) {
+	reminders := make([]*entities.Reminder, 0)
+
+	due := `
+		select a.attestation_uuid
+		from policy_attestations a
+			join policy_publications pp on pp.publication_uuid = a.publication_uuid
+		where a.status = @pending
+			and pp.superseded_at is null
+			and a.created_at <= @asked_before
+			and (a.reminded_at is null or a.reminded_at <= @reminded_before)`
+
+	params := map[string]interface{}{
+		"pending":         entities.StatusPending,
+		"asked_before":    filter.AskedBefore,
+		"reminded_before": filter.RemindedBefore,
+		"now":             now,
+	}
+
+	if filter.CompanyUuid != uuid.Nil {
+		due += " and pp.company_uuid = @company_uuid"
+		params["company_uuid"] = filter.CompanyUuid
+	}
+
+	if filter.PolicyUuid != uuid.Nil {
+		due += " and pp.policy_uuid = @policy_uuid"
+		params["policy_uuid"] = filter.PolicyUuid
+	}
+
+	query := `
+		with due as (` + due + `
+			for update of a skip locked
+		)
+		update policy_attestations a set reminded_at = @now, reminder_count = a.reminder_count + 1
+		from due, policy_publications pp, policies p, policy_histories ph, users u
+		where a.attestation_uuid = due.attestation_uuid
+			and pp.publication_uuid = a.publication_uuid
+			and p.policy_uuid = pp.policy_uuid
+			and ph.policy_history_uuid = pp.policy_history_uuid
+			and u.user_uuid = a.user_uuid
+		returning a.attestation_uuid, pp.policy_uuid, p.name as policy_name, ph.version, pp.published_at, u.email,
+			coalesce(u.first_name, '') as first_name`
+
+	err := s.gormDB.WithContext(ctx).Raw(query, params).Scan(&reminders).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+func (s *sqlRepository) ReleaseReminder(ctx context.Context, reminder *entities.Reminder) error {
+	return s.gormDB.WithContext(ctx).Model(&entities.Attestation{}).
+		Where("attestation_uuid = ?", reminder.AttestationUuid).
+		Updates(map[string]interface{}{
+			"reminded_at":    nil,
+			"reminder_count": gorm.Expr("greatest(reminder_count - 1, 0)"),
+		}).Error
+}
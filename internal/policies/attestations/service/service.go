@@ -0,0 +1,293 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/attestations/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/attestations/repository"
+	policiesCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/ses"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/cfg"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/meta"
+	"go.uber.org/zap"
+)
+
+// MinReminderGap between two reminders an admin sends of the same attestation.
+const MinReminderGap = time.Hour
+
+var reminderTemplate = template.Must(template.New("policy-attestation").Parse(`<html><head> <style>p{line-height: 22px;}.acknowledge{width: 240px; height: 32px; background: #436AF3; border-radius: 4.16px; text-decoration: none; margin-top: 40px; margin-bottom: 40px; font-size: 16px; padding-top: 6.5px; text-align: center; display: block;}</style></head><body> <table width="600" cellpadding="0" cellspacing="0" align="center"> <tr> <td> <img alt="REDESIGN Logo" title="REDESIGN Logo" style="display:block" height="37px" src="https://redesigntrustportal-static-files.s3.us-west-2.amazonaws.com/rdt_logo_small.png"> <p>Hi {{.firstName}},</p><p>Version {{.version}} of the policy <strong>{{.name}}</strong> was published on {{.date}} and is waiting for your acknowledgement.</p><p>Please read the policy and confirm that you have read it.</p><p><a class="acknowledge" style="color: #FFFFFF" href="{{.policyLink}}">Read policy</a></p><p>Thank you,</p><p>REDESIGN Trust Portal</p></td></tr></table></body></html>`))
+
+type Service interface {
+	PublishPolicy(ctx context.Context, req *entities.PublishPolicyRequest) (*entities.GetPolicyAttestationsResponse, error)
+	GetPolicyAttestations(ctx context.Context, req *entities.GetPolicyAttestationsRequest) (*entities.GetPolicyAttestationsResponse, error)
+	RemindPolicyAttestations(ctx context.Context, req *entities.RemindPolicyAttestationsRequest) (*entities.RemindPolicyAttestationsResponse, error)
+	ListUserAttestations(ctx context.Context, req *entities.ListUserAttestationsRequest) ([]*entities.AttestationView, error)
+	AcknowledgeAttestation(ctx context.Context, req *entities.AcknowledgeAttestationRequest) (*entities.Attestation, error)
+	GetAttestationStats(ctx context.Context, req *entities.GetAttestationStatsRequest) (*entities.GetAttestationStatsResponse, error)
+	// SendReminders emails the users whose attestations are pending for the reminder interval.
+	SendReminders(ctx context.Context, now time.Time) error
+}
+
+type service struct {
+	repo         repository.Repository
+	emailClient  ses.Client
+	config       policiesCfg.AttestationsConfig
+	commonConfig cfg.Config
+	logger       *zap.SugaredLogger
+}
+
+func (s *service) PublishPolicy(ctx context.Context, req *entities.PublishPolicyRequest) (*entities.GetPolicyAttestationsResponse, error) {
+	audience, userUuids, err := publicationAudience(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := s.repo.GetApprovedVersion(ctx, req.CompanyUuid, req.PolicyUuid, req.Body.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	publication, err := s.repo.Publish(ctx, &entities.Publication{
+		PublicationUuid:   uuid.New(),
+		PolicyUuid:        req.PolicyUuid,
+		PolicyHistoryUuid: version.PolicyHistoryUuid,
+		Version:           version.Version,
+		CompanyUuid:       req.CompanyUuid,
+		Audience:          audience,
+		PublishedAt:       time.Now(),
+		PublishedBy:       req.UserUuid,
+	}, userUuids)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.policyAttestations(ctx, publication)
+}
+
+// publicationAudience of the request. All the active users of the company are asked unless
+// users are selected, nil users stand for all of them.
+func publicationAudience(body *entities.PublishPolicyRequestBody) (string, []uuid.UUID, error) {
+	audience := body.Audience
+	if audience == "" {
+		audience = entities.AudienceAll
+		if len(body.UserUuids) > 0 {
+			audience = entities.AudienceSelected
+		}
+	}
+
+	switch audience {
+	case entities.AudienceAll:
+		if len(body.UserUuids) > 0 {
+			return "", nil, &appError.ErrValidation{Message: "user_uuids can't be set when publishing to all users"}
+		}
+
+		return audience, nil, nil
+	case entities.AudienceSelected:
+		seen := make(map[uuid.UUID]bool, len(body.UserUuids))
+		userUuids := make([]uuid.UUID, 0, len(body.UserUuids))
+
+		for _, userUuid := range body.UserUuids {
+			if userUuid == uuid.Nil || seen[userUuid] {
+				continue
+			}
+
+			seen[userUuid] = true
+			userUuids = append(userUuids, userUuid)
+		}
+
+		if len(userUuids) == 0 {
+			return "", nil, &appError.ErrValidation{Message: "user_uuids are required when publishing to selected users"}
+		}
+
+		return audience, userUuids, nil
+	default:
+		return "", nil, &appError.ErrValidation{Message: "audience should be either all or selected"}
+	}
+}
+
+func (s *service) GetPolicyAttestations(ctx context.Context, req *entities.GetPolicyAttestationsRequest) (*entities.GetPolicyAttestationsResponse, error) {
+	publication, err := s.repo.GetCurrentPublication(ctx, req.CompanyUuid, req.PolicyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.policyAttestations(ctx, publication)
+}
+
+func (s *service) policyAttestations(ctx context.Context, publication *entities.Publication) (*entities.GetPolicyAttestationsResponse, error) {
+	attestations, err := s.repo.ListAttestations(ctx, &entities.AttestationFilter{
+		CompanyUuid:     publication.CompanyUuid,
+		PublicationUuid: publication.PublicationUuid,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.GetPolicyAttestationsResponse{
+		PublicationUuid: publication.PublicationUuid,
+		PolicyUuid:      publication.PolicyUuid,
+		Version:         publication.Version,
+		Audience:        publication.Audience,
+		PublishedAt:     publication.PublishedAt,
+		Completion:      completionOf(attestations),
+		Attestations:    attestations,
+	}, nil
+}
+
+// completionOf the attestations of the current publication, none of which is superseded.
+func completionOf(attestations []*entities.AttestationView) entities.Completion {
+	var acknowledged int
+
+	for _, a := range attestations {
+		if a.Status == entities.StatusAcknowledged {
+			acknowledged++
+		}
+	}
+
+	return entities.NewCompletion(len(attestations), acknowledged)
+}
+
+func (s *service) RemindPolicyAttestations(ctx context.Context, req *entities.RemindPolicyAttestationsRequest) (*entities.RemindPolicyAttestationsResponse, error) {
+	if _, err := s.repo.GetCurrentPublication(ctx, req.CompanyUuid, req.PolicyUuid); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	reminded, err := s.remind(ctx, &entities.ReminderFilter{
+		CompanyUuid:    req.CompanyUuid,
+		PolicyUuid:     req.PolicyUuid,
+		AskedBefore:    now,
+		RemindedBefore: now.Add(-MinReminderGap),
+	}, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.RemindPolicyAttestationsResponse{Reminded: reminded}, nil
+}
+
+func (s *service) SendReminders(ctx context.Context, now time.Time) error {
+	_, err := s.remind(ctx, &entities.ReminderFilter{
+		AskedBefore:    now.Add(-s.config.Reminder()),
+		RemindedBefore: now.Add(-s.config.Reminder()),
+	}, now)
+
+	return err
+}
+
+// remind the users of the pending attestations matching the filter and return how many were
+// reminded. A reminder that fails to send is released, so it is sent again later.
+func (s *service) remind(ctx context.Context, filter *entities.ReminderFilter, now time.Time) (int, error) {
+	reminders, err := s.repo.ClaimReminders(ctx, filter, now)
+	if err != nil {
+		return 0, err
+	}
+
+	var sent int
+
+	for _, reminder := range reminders {
+		if err = s.sendReminder(ctx, reminder); err == nil {
+			sent++
+
+			continue
+		}
+
+		s.logger.Errorf("failed to send attestation reminder %s: %v", reminder.AttestationUuid, err)
+
+		if err = s.repo.ReleaseReminder(ctx, reminder); err != nil {
+			s.logger.Errorf("failed to release attestation reminder %s: %v", reminder.AttestationUuid, err)
+		}
+	}
+
+	return sent, nil
+}
+
+func (s *service) sendReminder(ctx context.Context, reminder *entities.Reminder) error {
+	var body bytes.Buffer
+
+	err := reminderTemplate.Execute(&body, map[string]interface{}{
+		"firstName":  reminder.FirstName,
+		"name":       reminder.PolicyName,
+		"version":    reminder.Version,
+		"date":       reminder.PublishedAt.Format("2006-01-02"),
+		"policyLink": fmt.Sprintf("https://%s/%s/%s", s.commonConfig.FrontendDomain, "policies-procedures", reminder.PolicyUuid),
+	})
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("Please acknowledge the policy: %s", reminder.PolicyName)
+
+	return s.emailClient.SendEmail(ctx, subject, body.String(), reminder.Email)
+}
+
+func (s *service) ListUserAttestations(ctx context.Context, req *entities.ListUserAttestationsRequest) ([]*entities.AttestationView, error) {
+	switch req.Status {
+	case "", entities.StatusPending, entities.StatusAcknowledged, entities.StatusSuperseded:
+	default:
+		return nil, &appError.ErrValidation{Message: "status should be pending, acknowledged or superseded"}
+	}
+
+	return s.repo.ListAttestations(ctx, &entities.AttestationFilter{
+		CompanyUuid: req.CompanyUuid,
+		UserUuid:    req.UserUuid,
+		Status:      req.Status,
+	})
+}
+
+func (s *service) AcknowledgeAttestation(ctx context.Context, req *entities.AcknowledgeAttestationRequest) (*entities.Attestation, error) {
+	return s.repo.Acknowledge(ctx, req.CompanyUuid, req.UserUuid, req.AttestationUuid, time.Now(), meta.ClientIP(ctx))
+}
+
+func (s *service) GetAttestationStats(ctx context.Context, req *entities.GetAttestationStatsRequest) (*entities.GetAttestationStatsResponse, error) {
+	policies, err := s.repo.GetPolicyCompletions(ctx, req.CompanyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.repo.GetUserCompletions(ctx, req.CompanyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	var total, acknowledged int
+
+	for _, p := range policies {
+		p.Completion = entities.NewCompletion(p.Total, p.Acknowledged)
+		total += p.Total
+		acknowledged += p.Acknowledged
+	}
+
+	for _, u := range users {
+		u.Completion = entities.NewCompletion(u.Total, u.Acknowledged)
+	}
+
+	return &entities.GetAttestationStatsResponse{
+		Completion: entities.NewCompletion(total, acknowledged),
+		Policies:   policies,
+		Users:      users,
+	}, nil
+}
+
+func New(
+	repo repository.Repository,
+	emailClient ses.Client,
+	config policiesCfg.Config,
+	commonConfig cfg.Config,
+	logger *zap.SugaredLogger,
+) Service {
+	return &service{
+		repo:         repo,
+		emailClient:  emailClient,
+		config:       config.Attestations,
+		commonConfig: commonConfig,
+		logger:       logger,
+	}
+}
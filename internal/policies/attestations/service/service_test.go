@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/attestations/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/attestations/repository"
+	policiesCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/config"
+	policiesEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/ses"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/cfg"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/meta"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+)
+
+func TestPublishPolicy(t *testing.T) {
+	Convey("Given an approved policy", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, ses.NewMockClient(ctrl), policiesCfg.Config{}, cfg.Config{}, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		companyUuid, adminUuid, policyUuid := uuid.New(), uuid.New(), uuid.New()
+		version := &policiesEntities.PolicyHistory{PolicyHistoryUuid: uuid.New(), PolicyUuid: policyUuid, Version: 3}
+
+		publish := func(body *entities.PublishPolicyRequestBody) (*entities.GetPolicyAttestationsResponse, error) {
+			return svc.PublishPolicy(ctx, &entities.PublishPolicyRequest{
+				CompanyUuid: companyUuid,
+				UserUuid:    adminUuid,
+				PolicyUuid:  policyUuid,
+				Body:        body,
+			})
+		}
+
+		Convey("Its latest approved version is published to all users by default", func() {
+			repo.EXPECT().GetApprovedVersion(ctx, companyUuid, policyUuid, 0).Return(version, nil)
+			repo.EXPECT().Publish(ctx, gomock.Any(), nil).DoAndReturn(func(_ context.Context, p *entities.Publication, _ []uuid.UUID) (*entities.Publication, error) {
+				So(p.PolicyHistoryUuid, ShouldEqual, version.PolicyHistoryUuid)
+				So(p.Version, ShouldEqual, 3)
+				So(p.Audience, ShouldEqual, entities.AudienceAll)
+				So(p.PublishedBy, ShouldEqual, adminUuid)
+
+				return p, nil
+			})
+			repo.EXPECT().ListAttestations(ctx, gomock.Any()).Return([]*entities.AttestationView{
+				{Status: entities.StatusAcknowledged},
+				{Status: entities.StatusPending},
+				{Status: entities.StatusPending},
+			}, nil)
+
+			res, err := publish(&entities.PublishPolicyRequestBody{})
+			So(err, ShouldBeNil)
+			So(res.Version, ShouldEqual, 3)
+			So(res.Completion, ShouldResemble, entities.Completion{Total: 3, Acknowledged: 1, Pending: 2, Percent: 33.33})
+		})
+
+		Convey("Selected users are deduplicated", func() {
+			alice := uuid.New()
+
+			repo.EXPECT().GetApprovedVersion(ctx, companyUuid, policyUuid, 3).Return(version, nil)
+			repo.EXPECT().Publish(ctx, gomock.Any(), []uuid.UUID{alice}).DoAndReturn(func(_ context.Context, p *entities.Publication, _ []uuid.UUID) (*entities.Publication, error) {
+				So(p.Audience, ShouldEqual, entities.AudienceSelected)
+
+				return p, nil
+			})
+			repo.EXPECT().ListAttestations(ctx, gomock.Any()).Return(nil, nil)
+
+			_, err := publish(&entities.PublishPolicyRequestBody{Version: 3, UserUuids: []uuid.UUID{alice, alice, uuid.Nil}})
+			So(err, ShouldBeNil)
+		})
+
+		Convey("The audience must match the selected users", func() {
+			var validationErr *appError.ErrValidation
+
+			_, err := publish(&entities.PublishPolicyRequestBody{Audience: entities.AudienceSelected})
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+
+			_, err = publish(&entities.PublishPolicyRequestBody{Audience: entities.AudienceAll, UserUuids: []uuid.UUID{uuid.New()}})
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+
+			_, err = publish(&entities.PublishPolicyRequestBody{Audience: "everyone"})
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+		})
+	})
+}
+
+func TestAcknowledgeAttestation(t *testing.T) {
+	Convey("The acknowledgement of a user keeps the ip address of the request", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, ses.NewMockClient(ctrl), policiesCfg.Config{}, cfg.Config{}, zap.NewNop().Sugar())
+
+		ctx := meta.WithClientIP(context.Background(), "10.0.0.1")
+		req := &entities.AcknowledgeAttestationRequest{CompanyUuid: uuid.New(), UserUuid: uuid.New(), AttestationUuid: uuid.New()}
+
+		repo.EXPECT().Acknowledge(ctx, req.CompanyUuid, req.UserUuid, req.AttestationUuid, gomock.Any(), "10.0.0.1").
+			Return(&entities.Attestation{Status: entities.StatusAcknowledged}, nil)
+
+		attestation, err := svc.AcknowledgeAttestation(ctx, req)
+		So(err, ShouldBeNil)
+		So(attestation.Status, ShouldEqual, entities.StatusAcknowledged)
+	})
+}
+
+func TestSendReminders(t *testing.T) {
+	Convey("Given attestations pending for the reminder interval", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		emailClient := ses.NewMockClient(ctrl)
+		config := policiesCfg.Config{Attestations: policiesCfg.AttestationsConfig{ReminderDays: 3}}
+		svc := New(repo, emailClient, config, cfg.Config{FrontendDomain: "portal.example.com"}, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		now := time.Date(2023, 3, 30, 9, 0, 0, 0, time.UTC)
+
+		alice := &entities.Reminder{AttestationUuid: uuid.New(), PolicyName: "Acceptable Use", Version: 2, Email: "alice@customer"}
+		bob := &entities.Reminder{AttestationUuid: uuid.New(), PolicyName: "Acceptable Use", Version: 2, Email: "bob@customer"}
+
+		repo.EXPECT().ClaimReminders(ctx, &entities.ReminderFilter{
+			AskedBefore:    now.AddDate(0, 0, -3),
+			RemindedBefore: now.AddDate(0, 0, -3),
+		}, now).Return([]*entities.Reminder{alice, bob}, nil)
+
+		Convey("Users are emailed and reminders that fail to send are released", func() {
+			emailClient.EXPECT().SendEmail(ctx, "Please acknowledge the policy: Acceptable Use", gomock.Any(), "alice@customer").Return(nil)
+			emailClient.EXPECT().SendEmail(ctx, gomock.Any(), gomock.Any(), "bob@customer").Return(errors.New("throttled"))
+			repo.EXPECT().ReleaseReminder(ctx, bob).Return(nil)
+
+			So(svc.SendReminders(ctx, now), ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,102 @@
+// Package http for attestations.
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/attestations/entities"
+	httpError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors/http"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"github.com/pkg/errors"
+)
+
+func decodePublishPolicyRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	ids, err := httpTransport.DecodePathIDs(r, "company_id", "user_id", "policy_id")
+	if err != nil {
+		return nil, err
+	}
+
+	body := &entities.PublishPolicyRequestBody{}
+
+	// the body is optional, the latest approved version is published to all users
+	if r.ContentLength != 0 {
+		defer r.Body.Close()
+
+		if err = json.NewDecoder(r.Body).Decode(body); err != nil {
+			return nil, errors.WithMessage(httpError.ErrBadRequestBody, err.Error())
+		}
+	}
+
+	return &entities.PublishPolicyRequest{
+		CompanyUuid: ids[0],
+		UserUuid:    ids[1],
+		PolicyUuid:  ids[2],
+		Body:        body,
+	}, nil
+}
+
+func decodeGetPolicyAttestationsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	ids, err := httpTransport.DecodePathIDs(r, "company_id", "user_id", "policy_id")
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.GetPolicyAttestationsRequest{
+		CompanyUuid: ids[0],
+		UserUuid:    ids[1],
+		PolicyUuid:  ids[2],
+	}, nil
+}
+
+func decodeRemindPolicyAttestationsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	ids, err := httpTransport.DecodePathIDs(r, "company_id", "user_id", "policy_id")
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.RemindPolicyAttestationsRequest{
+		CompanyUuid: ids[0],
+		UserUuid:    ids[1],
+		PolicyUuid:  ids[2],
+	}, nil
+}
+
+func decodeGetAttestationStatsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	ids, err := httpTransport.DecodePathIDs(r, "company_id", "user_id")
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.GetAttestationStatsRequest{
+		CompanyUuid: ids[0],
+		UserUuid:    ids[1],
+	}, nil
+}
+
+func decodeListUserAttestationsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	ids, err := httpTransport.DecodePathIDs(r, "company_id", "user_id")
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.ListUserAttestationsRequest{
+		CompanyUuid: ids[0],
+		UserUuid:    ids[1],
+		Status:      r.URL.Query().Get("status"),
+	}, nil
+}
+
+func decodeAcknowledgeAttestationRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	ids, err := httpTransport.DecodePathIDs(r, "company_id", "user_id", "attestation_id")
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.AcknowledgeAttestationRequest{
+		CompanyUuid:     ids[0],
+		UserUuid:        ids[1],
+		AttestationUuid: ids[2],
+	}, nil
+}
@@ -0,0 +1,106 @@
+// Package http for attestations.
+package http
+
+import (
+	goKitEndpoint "github.com/go-kit/kit/endpoint"
+	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/attestations/endpoints"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+)
+
+// RegisterTransport for http.
+func RegisterTransport(
+	server *httpTransport.Server,
+	ep *endpoints.Endpoints,
+	authClient auth.Client,
+	svcTransportClient svcTransport.Client,
+) {
+	registerPublishPolicy(server, ep.PublishPolicyEndpoint, authClient, svcTransportClient)
+	registerGetPolicyAttestations(server, ep.GetPolicyAttestationsEndpoint, authClient, svcTransportClient)
+	registerRemindPolicyAttestations(server, ep.RemindPolicyAttestationsEndpoint, authClient, svcTransportClient)
+	registerGetAttestationStats(server, ep.GetAttestationStatsEndpoint, authClient, svcTransportClient)
+	registerListUserAttestations(server, ep.ListUserAttestationsEndpoint, authClient, svcTransportClient)
+	registerAcknowledgeAttestation(server, ep.AcknowledgeAttestationEndpoint, authClient, svcTransportClient)
+}
+
+func registerPublishPolicy(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/attestations/publish"
+	method := "POST"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policy-attestations", permissions.ActionCreate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodePublishPolicyRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerGetPolicyAttestations(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/attestations"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policy-attestations", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetPolicyAttestationsRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerRemindPolicyAttestations(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/attestations/reminders"
+	method := "POST"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policy-attestations", permissions.ActionUpdate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeRemindPolicyAttestationsRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerGetAttestationStats(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policies/attestations/stats"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policy-attestations", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetAttestationStatsRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+// Every user who may read policies attests their own, so the endpoints of the user only need
+// the read permission.
+func registerListUserAttestations(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/attestations"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeListUserAttestationsRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerAcknowledgeAttestation(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/attestations/{attestation_id}/acknowledge"
+	method := "POST"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeAcknowledgeAttestationRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func getHandler(ep goKitEndpoint.Endpoint, dec goKitHTTPTransport.DecodeRequestFunc, enc goKitHTTPTransport.EncodeResponseFunc, atc svcTransport.Client, method string) *goKitHTTPTransport.Server {
+	return goKitHTTPTransport.NewServer(
+		ep,
+		dec,
+		enc,
+		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
+		goKitHTTPTransport.ServerErrorHandler(atc.LogErrorHandler()),
+	)
+}
@@ -0,0 +1,209 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultDueSoonDays         = 30
+	DefaultOverdueReminderDays = 7
+	DefaultCheckInterval       = time.Hour
+	DefaultReminderDays        = 7
+	DefaultSyncMaxPolicies     = 5
+	DefaultExportCheckInterval = 15 * time.Second
+	DefaultExportTimeout       = 30 * time.Minute
+	DefaultEditLockTimeout     = 2 * time.Minute
+)
+
+// Config for policies.
+type Config struct {
+	Reviews      ReviewsConfig
+	Attestations AttestationsConfig
+	Exports      ExportsConfig
+	Templates    TemplatesConfig
+	EditLocks    EditLocksConfig
+}
+
+// ReviewsConfig for periodic policy reviews. A policy is due soon DueSoonDays ahead of its next
+// review date, when its owner is reminded, and the owner is reminded again every
+// OverdueReminderDays once the date passed. Reminders are checked every CheckInterval, zero
+// values fall back to the defaults.
+type ReviewsConfig struct {
+	DueSoonDays         int
+	OverdueReminderDays int
+	CheckInterval       time.Duration
+}
+
+// DueSoon is the due soon window.
+func (c ReviewsConfig) DueSoon() time.Duration {
+	if c.DueSoonDays == 0 {
+		return DefaultDueSoonDays * 24 * time.Hour
+	}
+
+	return time.Duration(c.DueSoonDays) * 24 * time.Hour
+}
+
+// OverdueReminder is the interval between reminders of an overdue review.
+func (c ReviewsConfig) OverdueReminder() time.Duration {
+	if c.OverdueReminderDays == 0 {
+		return DefaultOverdueReminderDays * 24 * time.Hour
+	}
+
+	return time.Duration(c.OverdueReminderDays) * 24 * time.Hour
+}
+
+// Interval between reminder checks.
+func (c ReviewsConfig) Interval() time.Duration {
+	if c.CheckInterval == 0 {
+		return DefaultCheckInterval
+	}
+
+	return c.CheckInterval
+}
+
+// AttestationsConfig for policy attestations. Users who did not acknowledge a published policy
+// are reminded every ReminderDays, checked every CheckInterval. Zero values fall back to the
+// defaults.
+type AttestationsConfig struct {
+	ReminderDays  int
+	CheckInterval time.Duration
+}
+
+// Reminder is the interval between reminders of a pending attestation.
+func (c AttestationsConfig) Reminder() time.Duration {
+	if c.ReminderDays == 0 {
+		return DefaultReminderDays * 24 * time.Hour
+	}
+
+	return time.Duration(c.ReminderDays) * 24 * time.Hour
+}
+
+// Interval between reminder checks.
+func (c AttestationsConfig) Interval() time.Duration {
+	if c.CheckInterval == 0 {
+		return DefaultCheckInterval
+	}
+
+	return c.CheckInterval
+}
+
+// ExportsConfig for exports of approved policies. Exports of up to SyncMaxPolicies policies are
+// built right away, larger ones are picked up by a worker every CheckInterval. An export still
+// running after Timeout is picked up again. Zero values fall back to the defaults.
+type ExportsConfig struct {
+	SyncMaxPolicies int
+	CheckInterval   time.Duration
+	Timeout         time.Duration
+}
+
+// SyncMax is the number of policies up to which an export is built right away.
+func (c ExportsConfig) SyncMax() int {
+	if c.SyncMaxPolicies == 0 {
+		return DefaultSyncMaxPolicies
+	}
+
+	return c.SyncMaxPolicies
+}
+
+// Interval between checks for pending exports.
+func (c ExportsConfig) Interval() time.Duration {
+	if c.CheckInterval == 0 {
+		return DefaultExportCheckInterval
+	}
+
+	return c.CheckInterval
+}
+
+// Stale is how long an export may run before it is picked up again.
+func (c ExportsConfig) Stale() time.Duration {
+	if c.Timeout == 0 {
+		return DefaultExportTimeout
+	}
+
+	return c.Timeout
+}
+
+// TemplatesConfig for policy templates. The owners of the policies created from a template are
+// notified of its new versions, checked every CheckInterval. Zero values fall back to the
+// defaults.
+type TemplatesConfig struct {
+	CheckInterval time.Duration
+}
+
+// Interval between checks for template updates to notify.
+func (c TemplatesConfig) Interval() time.Duration {
+	if c.CheckInterval == 0 {
+		return DefaultCheckInterval
+	}
+
+	return c.CheckInterval
+}
+
+// EditLocksConfig for soft locks of policy documents. A lock expires Timeout after it was last
+// refreshed by the editor. A zero value falls back to the default.
+type EditLocksConfig struct {
+	Timeout time.Duration
+}
+
+// Expiry of a lock which is not refreshed.
+func (c EditLocksConfig) Expiry() time.Duration {
+	if c.Timeout == 0 {
+		return DefaultEditLockTimeout
+	}
+
+	return c.Timeout
+}
+
+// Validate config
+func (c *Config) Validate() error {
+	var errs []string
+
+	if c.Reviews.DueSoonDays < 0 {
+		errs = append(errs, "Reviews due soon days shouldn't be negative")
+	}
+
+	if c.Reviews.OverdueReminderDays < 0 {
+		errs = append(errs, "Reviews overdue reminder days shouldn't be negative")
+	}
+
+	if c.Reviews.CheckInterval < 0 {
+		errs = append(errs, "Reviews check interval shouldn't be negative")
+	}
+
+	if c.Attestations.ReminderDays < 0 {
+		errs = append(errs, "Attestations reminder days shouldn't be negative")
+	}
+
+	if c.Attestations.CheckInterval < 0 {
+		errs = append(errs, "Attestations check interval shouldn't be negative")
+	}
+
+	if c.Exports.SyncMaxPolicies < 0 {
+		errs = append(errs, "Exports sync max policies shouldn't be negative")
+	}
+
+	if c.Exports.CheckInterval < 0 {
+		errs = append(errs, "Exports check interval shouldn't be negative")
+	}
+
+	if c.Exports.Timeout < 0 {
+		errs = append(errs, "Exports timeout shouldn't be negative")
+	}
+
+	if c.Templates.CheckInterval < 0 {
+		errs = append(errs, "Templates check interval shouldn't be negative")
+	}
+
+	if c.EditLocks.Timeout < 0 {
+		errs = append(errs, "Edit locks timeout shouldn't be negative")
+	}
+
+	if len(errs) > 0 {
+		return errors.Errorf(strings.Join(errs, ","))
+	}
+
+	return nil
+}
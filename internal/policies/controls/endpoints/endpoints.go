@@ -0,0 +1,68 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/controls/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/controls/service"
+)
+
+type Endpoints struct {
+	GetPolicyControlsEndpoint       endpoint.Endpoint
+	SetPolicyControlsEndpoint       endpoint.Endpoint
+	AcceptSuggestedControlsEndpoint endpoint.Endpoint
+	GetTemplateControlsEndpoint     endpoint.Endpoint
+	SetTemplateControlsEndpoint     endpoint.Endpoint
+}
+
+// New returns new endpoints
+func New(svc service.Service) *Endpoints {
+	return &Endpoints{
+		GetPolicyControlsEndpoint:       makeGetPolicyControlsEndpoint(svc),
+		SetPolicyControlsEndpoint:       makeSetPolicyControlsEndpoint(svc),
+		AcceptSuggestedControlsEndpoint: makeAcceptSuggestedControlsEndpoint(svc),
+		GetTemplateControlsEndpoint:     makeGetTemplateControlsEndpoint(svc),
+		SetTemplateControlsEndpoint:     makeSetTemplateControlsEndpoint(svc),
+	}
+}
+
+func makeGetPolicyControlsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetPolicyControlsRequest) //nolint:errcheck
+
+		return svc.GetPolicyControls(ctx, req)
+	}
+}
+
+func makeSetPolicyControlsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.SetPolicyControlsRequest) //nolint:errcheck
+
+		return svc.SetPolicyControls(ctx, req)
+	}
+}
+
+func makeAcceptSuggestedControlsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetPolicyControlsRequest) //nolint:errcheck
+
+		return svc.AcceptSuggestedControls(ctx, req)
+	}
+}
+
+func makeGetTemplateControlsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetTemplateControlsRequest) //nolint:errcheck
+
+		return svc.GetTemplateControls(ctx, req)
+	}
+}
+
+func makeSetTemplateControlsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.SetTemplateControlsRequest) //nolint:errcheck
+
+		return svc.SetTemplateControls(ctx, req)
+	}
+}
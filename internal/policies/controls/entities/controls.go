@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PolicyControl maps a policy to a framework control it satisfies.
+type PolicyControl struct {
+	PolicyUuid           uuid.UUID `gorm:"column:policy_uuid"`
+	FrameworkControlUuid uuid.UUID `gorm:"column:framework_control_uuid"`
+	CreatedAt            time.Time `gorm:"column:created_at"`
+	CreatedBy            uuid.UUID `gorm:"column:created_by"`
+}
+
+func (m *PolicyControl) TableName() string {
+	return "policy_framework_controls"
+}
+
+// TemplateControl maps a policy template to a framework control that the policies created
+// from it are suggested to satisfy.
+type TemplateControl struct {
+	PolicyTemplateUuid   uuid.UUID `gorm:"column:policy_template_uuid"`
+	FrameworkControlUuid uuid.UUID `gorm:"column:framework_control_uuid"`
+	CreatedAt            time.Time `gorm:"column:created_at"`
+	CreatedBy            uuid.UUID `gorm:"column:created_by"`
+}
+
+func (m *TemplateControl) TableName() string {
+	return "policy_template_framework_controls"
+}
+
+// Control is a framework control mapped to a policy or a template.
+type Control struct {
+	FrameworkControlUuid uuid.UUID `json:"framework_control_uuid" gorm:"column:framework_control_uuid"`
+	FrameworksUuid       uuid.UUID `json:"frameworks_uuid" gorm:"column:frameworks_uuid"`
+	FrameworkName        string    `json:"framework_name" gorm:"column:framework_name"`
+	Name                 string    `json:"name" gorm:"column:name"`
+	Topic                string    `json:"topic" gorm:"column:topic"`
+	Domain               string    `json:"domain" gorm:"column:domain"`
+}
+
+// PolicyControls are the controls mapped to a policy, and the controls of its template which
+// are not mapped yet.
+type PolicyControls struct {
+	PolicyUuid uuid.UUID  `json:"policy_uuid"`
+	Controls   []*Control `json:"controls"`
+	Suggested  []*Control `json:"suggested"`
+}
+
+type TemplateControls struct {
+	PolicyTemplateUuid uuid.UUID  `json:"policy_template_uuid"`
+	Controls           []*Control `json:"controls"`
+}
+
+type SetControlsRequestBody struct {
+	FrameworkControlUuids []uuid.UUID `json:"framework_control_uuids"`
+}
+
+type GetPolicyControlsRequest struct {
+	CompanyUuid uuid.UUID
+	UserUuid    uuid.UUID
+	PolicyUuid  uuid.UUID
+}
+
+// SetPolicyControlsRequest replaces the controls mapped to the policy.
+type SetPolicyControlsRequest struct {
+	CompanyUuid uuid.UUID
+	UserUuid    uuid.UUID
+	PolicyUuid  uuid.UUID
+	Body        *SetControlsRequestBody
+}
+
+type GetTemplateControlsRequest struct {
+	CompanyUuid        uuid.UUID
+	UserUuid           uuid.UUID
+	PolicyTemplateUuid uuid.UUID
+}
+
+// SetTemplateControlsRequest replaces the controls suggested for the policies of the template.
+type SetTemplateControlsRequest struct {
+	CompanyUuid        uuid.UUID
+	UserUuid           uuid.UUID
+	PolicyTemplateUuid uuid.UUID
+	Body               *SetControlsRequestBody
+}
@@ -0,0 +1,44 @@
+// Package controls maps policies, and the templates they are created from, to the framework
+// controls they satisfy.
+package controls
+
+import (
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/controls/endpoints"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/controls/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/controls/service"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/controls/transport/http"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ModuleParams for policy controls.
+type ModuleParams struct {
+	fx.In
+
+	DB           *gorm.DB
+	HTTPServer   *httpTransport.Server
+	APPTransport svcTransport.Client
+	AuthClient   auth.Client
+	Logger       *zap.SugaredLogger
+}
+
+// NewModule for policy controls.
+// nolint:gocritic
+func NewModule(p ModuleParams) error {
+	repo := repository.New(p.DB)
+	svc := service.New(repo, p.Logger)
+	eps := endpoints.New(svc)
+
+	http.RegisterTransport(p.HTTPServer, eps, p.AuthClient, p.APPTransport)
+
+	return nil
+}
+
+var (
+	// ModuleHttpAPI for uber fx.
+	ModuleHttpAPI = fx.Options(fx.Invoke(NewModule))
+)
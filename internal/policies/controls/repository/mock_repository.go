@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/controls/entities"
+	entities0 "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddPolicyControls mocks base method.
+func (m *MockRepository) AddPolicyControls(ctx context.Context, policyUuid, userUuid uuid.UUID, controlUuids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPolicyControls", ctx, policyUuid, userUuid, controlUuids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPolicyControls indicates an expected call of AddPolicyControls.
+func (mr *MockRepositoryMockRecorder) AddPolicyControls(ctx, policyUuid, userUuid, controlUuids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPolicyControls", reflect.TypeOf((*MockRepository)(nil).AddPolicyControls), ctx, policyUuid, userUuid, controlUuids)
+}
+
+// GetPolicy mocks base method.
+func (m *MockRepository) GetPolicy(ctx context.Context, companyUuid, policyUuid uuid.UUID) (*entities0.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicy", ctx, companyUuid, policyUuid)
+	ret0, _ := ret[0].(*entities0.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicy indicates an expected call of GetPolicy.
+func (mr *MockRepositoryMockRecorder) GetPolicy(ctx, companyUuid, policyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicy", reflect.TypeOf((*MockRepository)(nil).GetPolicy), ctx, companyUuid, policyUuid)
+}
+
+// GetPolicyControls mocks base method.
+func (m *MockRepository) GetPolicyControls(ctx context.Context, policyUuid uuid.UUID) ([]*entities.Control, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicyControls", ctx, policyUuid)
+	ret0, _ := ret[0].([]*entities.Control)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicyControls indicates an expected call of GetPolicyControls.
+func (mr *MockRepositoryMockRecorder) GetPolicyControls(ctx, policyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyControls", reflect.TypeOf((*MockRepository)(nil).GetPolicyControls), ctx, policyUuid)
+}
+
+// GetTemplateControls mocks base method.
+func (m *MockRepository) GetTemplateControls(ctx context.Context, policyTemplateUuid uuid.UUID) ([]*entities.Control, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateControls", ctx, policyTemplateUuid)
+	ret0, _ := ret[0].([]*entities.Control)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateControls indicates an expected call of GetTemplateControls.
+func (mr *MockRepositoryMockRecorder) GetTemplateControls(ctx, policyTemplateUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateControls", reflect.TypeOf((*MockRepository)(nil).GetTemplateControls), ctx, policyTemplateUuid)
+}
+
+// SetPolicyControls mocks base method.
+func (m *MockRepository) SetPolicyControls(ctx context.Context, policyUuid, userUuid uuid.UUID, controlUuids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPolicyControls", ctx, policyUuid, userUuid, controlUuids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPolicyControls indicates an expected call of SetPolicyControls.
+func (mr *MockRepositoryMockRecorder) SetPolicyControls(ctx, policyUuid, userUuid, controlUuids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPolicyControls", reflect.TypeOf((*MockRepository)(nil).SetPolicyControls), ctx, policyUuid, userUuid, controlUuids)
+}
+
+// SetTemplateControls mocks base method.
+func (m *MockRepository) SetTemplateControls(ctx context.Context, policyTemplateUuid, userUuid uuid.UUID, controlUuids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTemplateControls", ctx, policyTemplateUuid, userUuid, controlUuids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTemplateControls indicates an expected call of SetTemplateControls.
+func (mr *MockRepositoryMockRecorder) SetTemplateControls(ctx, policyTemplateUuid, userUuid, controlUuids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTemplateControls", reflect.TypeOf((*MockRepository)(nil).SetTemplateControls), ctx, policyTemplateUuid, userUuid, controlUuids)
+}
+
+// TemplateExists mocks base method.
+func (m *MockRepository) TemplateExists(ctx context.Context, policyTemplateUuid uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TemplateExists", ctx, policyTemplateUuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TemplateExists indicates an expected call of TemplateExists.
+func (mr *MockRepositoryMockRecorder) TemplateExists(ctx, policyTemplateUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TemplateExists", reflect.TypeOf((*MockRepository)(nil).TemplateExists), ctx, policyTemplateUuid)
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/controls/entities"
+	policiesEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	"gorm.io/gorm"
+)
+
+// Repository for the framework controls mapped to policies and policy templates.
+type Repository interface {
+	GetPolicy(ctx context.Context, companyUuid, policyUuid uuid.UUID) (*policiesEntities.Policy, error)
+	// TemplateExists fails with not found when there is no such policy template.
+	TemplateExists(ctx context.Context, policyTemplateUuid uuid.UUID) error
+	GetPolicyControls(ctx context.Context, policyUuid uuid.UUID) ([]*entities.Control, error)
+	GetTemplateControls(ctx context.Context, policyTemplateUuid uuid.UUID) ([]*entities.Control, error)
+	// SetPolicyControls replaces the controls mapped to the policy.
+	SetPolicyControls(ctx context.Context, policyUuid, userUuid uuid.UUID, controlUuids []uuid.UUID) error
+	// AddPolicyControls maps the controls to the policy, keeping the controls mapped already.
+	AddPolicyControls(ctx context.Context, policyUuid, userUuid uuid.UUID, controlUuids []uuid.UUID) error
+	// SetTemplateControls replaces the controls mapped to the template.
+	SetTemplateControls(ctx context.Context, policyTemplateUuid, userUuid uuid.UUID, controlUuids []uuid.UUID) error
+}
+
+// New repository for policy controls.
+func New(db *gorm.DB) Repository {
+	repo := &sqlRepository{gormDB: db}
+
+	return repo
+}
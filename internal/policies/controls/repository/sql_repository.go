@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/controls/entities"
+	policiesEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/db"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sqlRepository struct {
+	gormDB *gorm.DB
+}
+
+func (s *sqlRepository) GetPolicy(ctx context.Context, companyUuid, policyUuid uuid.UUID) (*policiesEntities.Policy, error) {
+	var policy policiesEntities.Policy
+
+	result := s.gormDB.WithContext(ctx).Model(&policiesEntities.Policy{}).
+		Select("policy_uuid", "company_uuid", "policy_template_uuid", "name", "status").
+		Limit(1).
+		Find(&policy, "policy_uuid = ? AND company_uuid = ?", policyUuid, companyUuid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &appError.ErrNotFound{Message: "policy not found"}
+	}
+
+	return &policy, nil
+}
+
+func (s *sqlRepository) TemplateExists(ctx context.Context, policyTemplateUuid uuid.UUID) error {
+	var count int64
+
+	err := s.gormDB.WithContext(ctx).Model(&policiesEntities.PolicyTemplates{}).
+		Where("policy_template_uuid = ?", policyTemplateUuid).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return &appError.ErrNotFound{Message: "policy template not found"}
+	}
+
+	return nil
+}
+
+func (s *sqlRepository) GetPolicyControls(ctx context.Context, policyUuid uuid.UUID) ([]*entities.Control, error) {
+	return s.getControls(ctx, &entities.PolicyControl{}, "m.policy_uuid = ?", policyUuid)
+}
+
+func (s *sqlRepository) GetTemplateControls(ctx context.Context, policyTemplateUuid uuid.UUID) ([]*entities.Control, error) {
+	return s.getControls(ctx, &entities.TemplateControl{}, "m.policy_template_uuid = ?", policyTemplateUuid)
+}
+
+// getControls of the mapping table, ordered by framework and control name.
+func (s *sqlRepository) getControls(ctx context.Context, mapping interface{ TableName() string }, where string, id uuid.UUID) ([]*entities.Control, error) {
+	controls := make([]*entities.Control, 0)
+
+	err := s.gormDB.WithContext(ctx).Table(mapping.TableName()+" m").
+		Select("fc.framework_control_uuid, fc.frameworks_uuid, f.name as framework_name, "+
+			"coalesce(fc.name, '') as name, coalesce(fc.topic, '') as topic, coalesce(fc.domain, '') as domain").
+		Joins("join framework_controls fc on fc.framework_control_uuid = m.framework_control_uuid").
+		Joins("join frameworks f on f.frameworks_uuid = fc.frameworks_uuid").
+		Where(where, id).
+		Order("f.name, fc.name, fc.framework_control_uuid").
+		Scan(&controls).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return controls, nil
+}
+
+func (s *sqlRepository) SetPolicyControls(ctx context.Context, policyUuid, userUuid uuid.UUID, controlUuids []uuid.UUID) error {
+	return s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entities.PolicyControl{}, "policy_uuid = ?", policyUuid).Error; err != nil {
+			return err
+		}
+
+		return createPolicyControls(tx, policyUuid, userUuid, controlUuids)
+	})
+}
+
+func (s *sqlRepository) AddPolicyControls(ctx context.Context, policyUuid, userUuid uuid.UUID, controlUuids []uuid.UUID) error {
+	return createPolicyControls(s.gormDB.WithContext(ctx), policyUuid, userUuid, controlUuids)
+}
+
+func createPolicyControls(tx *gorm.DB, policyUuid, userUuid uuid.UUID, controlUuids []uuid.UUID) error {
+	if len(controlUuids) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	mappings := make([]*entities.PolicyControl, 0, len(controlUuids))
+
+	for _, controlUuid := range controlUuids {
+		mappings = append(mappings, &entities.PolicyControl{
+			PolicyUuid:           policyUuid,
+			FrameworkControlUuid: controlUuid,
+			CreatedAt:            now,
+			CreatedBy:            userUuid,
+		})
+	}
+
+	return mappingError(tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&mappings).Error)
+}
+
+func (s *sqlRepository) SetTemplateControls(ctx context.Context, policyTemplateUuid, userUuid uuid.UUID, controlUuids []uuid.UUID) error {
+	return s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&entities.TemplateControl{}, "policy_template_uuid = ?", policyTemplateUuid).Error; err != nil {
+			return err
+		}
+
+		if len(controlUuids) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		mappings := make([]*entities.TemplateControl, 0, len(controlUuids))
+
+		for _, controlUuid := range controlUuids {
+			mappings = append(mappings, &entities.TemplateControl{
+				PolicyTemplateUuid:   policyTemplateUuid,
+				FrameworkControlUuid: controlUuid,
+				CreatedAt:            now,
+				CreatedBy:            userUuid,
+			})
+		}
+
+		return mappingError(tx.Create(&mappings).Error)
+	})
+}
+
+// mappingError reports the mapping of controls which do not exist as a validation error.
+func mappingError(err error) error {
+	if err != nil && db.IsForeignKeyViolationError(err) {
+		return &appError.ErrValidation{Message: "unknown framework controls"}
+	}
+
+	return err
+}
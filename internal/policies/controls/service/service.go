@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/controls/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/controls/repository"
+	"go.uber.org/zap"
+)
+
+type Service interface {
+	// GetPolicyControls mapped to the policy, with the controls of its template suggested.
+	GetPolicyControls(ctx context.Context, req *entities.GetPolicyControlsRequest) (*entities.PolicyControls, error)
+	SetPolicyControls(ctx context.Context, req *entities.SetPolicyControlsRequest) (*entities.PolicyControls, error)
+	// AcceptSuggestedControls maps the controls of the template of the policy to the policy.
+	AcceptSuggestedControls(ctx context.Context, req *entities.GetPolicyControlsRequest) (*entities.PolicyControls, error)
+	GetTemplateControls(ctx context.Context, req *entities.GetTemplateControlsRequest) (*entities.TemplateControls, error)
+	SetTemplateControls(ctx context.Context, req *entities.SetTemplateControlsRequest) (*entities.TemplateControls, error)
+}
+
+type service struct {
+	repo   repository.Repository
+	logger *zap.SugaredLogger
+}
+
+func (s *service) GetPolicyControls(ctx context.Context, req *entities.GetPolicyControlsRequest) (*entities.PolicyControls, error) {
+	policy, err := s.repo.GetPolicy(ctx, req.CompanyUuid, req.PolicyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	controls, err := s.repo.GetPolicyControls(ctx, policy.PolicyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &entities.PolicyControls{
+		PolicyUuid: policy.PolicyUuid,
+		Controls:   controls,
+		Suggested:  make([]*entities.Control, 0),
+	}
+
+	if !policy.PolicyTemplateUuid.Valid {
+		return res, nil
+	}
+
+	suggested, err := s.repo.GetTemplateControls(ctx, policy.PolicyTemplateUuid.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped := make(map[uuid.UUID]bool, len(controls))
+	for _, c := range controls {
+		mapped[c.FrameworkControlUuid] = true
+	}
+
+	for _, c := range suggested {
+		if !mapped[c.FrameworkControlUuid] {
+			res.Suggested = append(res.Suggested, c)
+		}
+	}
+
+	return res, nil
+}
+
+func (s *service) SetPolicyControls(ctx context.Context, req *entities.SetPolicyControlsRequest) (*entities.PolicyControls, error) {
+	policy, err := s.repo.GetPolicy(ctx, req.CompanyUuid, req.PolicyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repo.SetPolicyControls(ctx, policy.PolicyUuid, req.UserUuid, uniqueControlUuids(req.Body.FrameworkControlUuids))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPolicyControls(ctx, &entities.GetPolicyControlsRequest{
+		CompanyUuid: req.CompanyUuid,
+		UserUuid:    req.UserUuid,
+		PolicyUuid:  req.PolicyUuid,
+	})
+}
+
+func (s *service) AcceptSuggestedControls(ctx context.Context, req *entities.GetPolicyControlsRequest) (*entities.PolicyControls, error) {
+	controls, err := s.GetPolicyControls(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(controls.Suggested) == 0 {
+		return controls, nil
+	}
+
+	controlUuids := make([]uuid.UUID, 0, len(controls.Suggested))
+	for _, c := range controls.Suggested {
+		controlUuids = append(controlUuids, c.FrameworkControlUuid)
+	}
+
+	if err = s.repo.AddPolicyControls(ctx, req.PolicyUuid, req.UserUuid, controlUuids); err != nil {
+		return nil, err
+	}
+
+	return s.GetPolicyControls(ctx, req)
+}
+
+func (s *service) GetTemplateControls(ctx context.Context, req *entities.GetTemplateControlsRequest) (*entities.TemplateControls, error) {
+	if err := s.repo.TemplateExists(ctx, req.PolicyTemplateUuid); err != nil {
+		return nil, err
+	}
+
+	controls, err := s.repo.GetTemplateControls(ctx, req.PolicyTemplateUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.TemplateControls{PolicyTemplateUuid: req.PolicyTemplateUuid, Controls: controls}, nil
+}
+
+func (s *service) SetTemplateControls(ctx context.Context, req *entities.SetTemplateControlsRequest) (*entities.TemplateControls, error) {
+	if err := s.repo.TemplateExists(ctx, req.PolicyTemplateUuid); err != nil {
+		return nil, err
+	}
+
+	err := s.repo.SetTemplateControls(ctx, req.PolicyTemplateUuid, req.UserUuid, uniqueControlUuids(req.Body.FrameworkControlUuids))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetTemplateControls(ctx, &entities.GetTemplateControlsRequest{
+		CompanyUuid:        req.CompanyUuid,
+		UserUuid:           req.UserUuid,
+		PolicyTemplateUuid: req.PolicyTemplateUuid,
+	})
+}
+
+func uniqueControlUuids(controlUuids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(controlUuids))
+	unique := make([]uuid.UUID, 0, len(controlUuids))
+
+	for _, controlUuid := range controlUuids {
+		if !seen[controlUuid] {
+			seen[controlUuid] = true
+			unique = append(unique, controlUuid)
+		}
+	}
+
+	return unique
+}
+
+func New(repo repository.Repository, logger *zap.SugaredLogger) Service {
+	return &service{
+		repo:   repo,
+		logger: logger,
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/controls/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/controls/repository"
+	policiesEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+)
+
+func TestPolicyControls(t *testing.T) {
+	Convey("Given a policy created from a template mapped to controls", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		companyUuid, userUuid := uuid.New(), uuid.New()
+		policy := &policiesEntities.Policy{
+			PolicyUuid:         uuid.New(),
+			CompanyUuid:        companyUuid,
+			PolicyTemplateUuid: *nullable.NewNullUUID(uuid.New()),
+		}
+		mapped := &entities.Control{FrameworkControlUuid: uuid.New(), Name: "3.1"}
+		suggested := &entities.Control{FrameworkControlUuid: uuid.New(), Name: "3.2"}
+		req := &entities.GetPolicyControlsRequest{CompanyUuid: companyUuid, UserUuid: userUuid, PolicyUuid: policy.PolicyUuid}
+
+		repo.EXPECT().GetPolicy(ctx, companyUuid, policy.PolicyUuid).Return(policy, nil).AnyTimes()
+
+		Convey("The controls of the template which are not mapped yet are suggested", func() {
+			repo.EXPECT().GetPolicyControls(ctx, policy.PolicyUuid).Return([]*entities.Control{mapped}, nil)
+			repo.EXPECT().GetTemplateControls(ctx, policy.PolicyTemplateUuid.UUID).Return([]*entities.Control{mapped, suggested}, nil)
+
+			res, err := svc.GetPolicyControls(ctx, req)
+			So(err, ShouldBeNil)
+			So(res.Controls, ShouldResemble, []*entities.Control{mapped})
+			So(res.Suggested, ShouldResemble, []*entities.Control{suggested})
+		})
+
+		Convey("Accepting the suggestions maps them to the policy", func() {
+			gomock.InOrder(
+				repo.EXPECT().GetPolicyControls(ctx, policy.PolicyUuid).Return([]*entities.Control{mapped}, nil),
+				repo.EXPECT().AddPolicyControls(ctx, policy.PolicyUuid, userUuid, []uuid.UUID{suggested.FrameworkControlUuid}).Return(nil),
+				repo.EXPECT().GetPolicyControls(ctx, policy.PolicyUuid).Return([]*entities.Control{mapped, suggested}, nil),
+			)
+			repo.EXPECT().GetTemplateControls(ctx, policy.PolicyTemplateUuid.UUID).Return([]*entities.Control{mapped, suggested}, nil).Times(2)
+
+			res, err := svc.AcceptSuggestedControls(ctx, req)
+			So(err, ShouldBeNil)
+			So(res.Controls, ShouldHaveLength, 2)
+			So(res.Suggested, ShouldBeEmpty)
+		})
+
+		Convey("Setting the controls replaces them, duplicates aside", func() {
+			repo.EXPECT().SetPolicyControls(ctx, policy.PolicyUuid, userUuid, []uuid.UUID{suggested.FrameworkControlUuid}).Return(nil)
+			repo.EXPECT().GetPolicyControls(ctx, policy.PolicyUuid).Return([]*entities.Control{suggested}, nil)
+			repo.EXPECT().GetTemplateControls(ctx, policy.PolicyTemplateUuid.UUID).Return([]*entities.Control{mapped, suggested}, nil)
+
+			res, err := svc.SetPolicyControls(ctx, &entities.SetPolicyControlsRequest{
+				CompanyUuid: companyUuid,
+				UserUuid:    userUuid,
+				PolicyUuid:  policy.PolicyUuid,
+				Body: &entities.SetControlsRequestBody{
+					FrameworkControlUuids: []uuid.UUID{suggested.FrameworkControlUuid, suggested.FrameworkControlUuid},
+				},
+			})
+			So(err, ShouldBeNil)
+			So(res.Controls, ShouldResemble, []*entities.Control{suggested})
+			So(res.Suggested, ShouldResemble, []*entities.Control{mapped})
+		})
+	})
+}
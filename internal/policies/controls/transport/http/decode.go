@@ -0,0 +1,88 @@
+// Package http for policy controls.
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/controls/entities"
+	httpError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors/http"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"github.com/pkg/errors"
+)
+
+func decodeSetControlsRequestBody(r *http.Request) (*entities.SetControlsRequestBody, error) {
+	defer r.Body.Close()
+
+	body := &entities.SetControlsRequestBody{}
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		return nil, errors.WithMessage(httpError.ErrBadRequestBody, err.Error())
+	}
+
+	return body, nil
+}
+
+func decodeGetPolicyControlsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	ids, err := httpTransport.DecodePathIDs(r, "company_id", "user_id", "policy_id")
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.GetPolicyControlsRequest{
+		CompanyUuid: ids[0],
+		UserUuid:    ids[1],
+		PolicyUuid:  ids[2],
+	}, nil
+}
+
+func decodeSetPolicyControlsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	ids, err := httpTransport.DecodePathIDs(r, "company_id", "user_id", "policy_id")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := decodeSetControlsRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.SetPolicyControlsRequest{
+		CompanyUuid: ids[0],
+		UserUuid:    ids[1],
+		PolicyUuid:  ids[2],
+		Body:        body,
+	}, nil
+}
+
+func decodeGetTemplateControlsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	ids, err := httpTransport.DecodePathIDs(r, "company_id", "user_id", "template_id")
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.GetTemplateControlsRequest{
+		CompanyUuid:        ids[0],
+		UserUuid:           ids[1],
+		PolicyTemplateUuid: ids[2],
+	}, nil
+}
+
+func decodeSetTemplateControlsRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	ids, err := httpTransport.DecodePathIDs(r, "company_id", "user_id", "template_id")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := decodeSetControlsRequestBody(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.SetTemplateControlsRequest{
+		CompanyUuid:        ids[0],
+		UserUuid:           ids[1],
+		PolicyTemplateUuid: ids[2],
+		Body:               body,
+	}, nil
+}
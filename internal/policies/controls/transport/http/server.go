@@ -0,0 +1,92 @@
+package http
+
+import (
+	goKitEndpoint "github.com/go-kit/kit/endpoint"
+	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/controls/endpoints"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+)
+
+// RegisterTransport for http.
+func RegisterTransport(
+	server *httpTransport.Server,
+	ep *endpoints.Endpoints,
+	authClient auth.Client,
+	svcTransportClient svcTransport.Client,
+) {
+	registerGetPolicyControls(server, ep.GetPolicyControlsEndpoint, authClient, svcTransportClient)
+	registerSetPolicyControls(server, ep.SetPolicyControlsEndpoint, authClient, svcTransportClient)
+	registerAcceptSuggestedControls(server, ep.AcceptSuggestedControlsEndpoint, authClient, svcTransportClient)
+	registerGetTemplateControls(server, ep.GetTemplateControlsEndpoint, authClient, svcTransportClient)
+	registerSetTemplateControls(server, ep.SetTemplateControlsEndpoint, authClient, svcTransportClient)
+}
+
+// Everyone who may read a policy sees the controls it satisfies, only engineering users map them.
+func registerGetPolicyControls(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/controls"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetPolicyControlsRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerSetPolicyControls(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/controls"
+	method := "PUT"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policy-control-mappings", permissions.ActionUpdate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeSetPolicyControlsRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerAcceptSuggestedControls(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/controls/suggested/accept"
+	method := "POST"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policy-control-mappings", permissions.ActionUpdate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetPolicyControlsRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerGetTemplateControls(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/policies/templates/{template_id}/controls"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policy-control-mappings", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetTemplateControlsRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerSetTemplateControls(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/policies/templates/{template_id}/controls"
+	method := "PUT"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policy-control-mappings", permissions.ActionUpdate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeSetTemplateControlsRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func getHandler(ep goKitEndpoint.Endpoint, dec goKitHTTPTransport.DecodeRequestFunc, enc goKitHTTPTransport.EncodeResponseFunc, atc svcTransport.Client, method string) *goKitHTTPTransport.Server {
+	return goKitHTTPTransport.NewServer(
+		ep,
+		dec,
+		enc,
+		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
+		goKitHTTPTransport.ServerErrorHandler(atc.LogErrorHandler()),
+	)
+}
@@ -20,6 +20,25 @@ type Endpoints struct {
 	GetPoliciesStatsEndpoint           endpoint.Endpoint
 	GetTemplatesEndpoint               endpoint.Endpoint
 	CreateDocumentFromTemplateEndpoint endpoint.Endpoint
+	PreviewTemplateEndpoint            endpoint.Endpoint
+	SetPolicyReviewersEndpoint         endpoint.Endpoint
+	GetPolicyReviewersEndpoint         endpoint.Endpoint
+	GetPolicyStatusHistoryEndpoint     endpoint.Endpoint
+	GetPolicyDiffEndpoint              endpoint.Endpoint
+	GetPolicyReviewScheduleEndpoint    endpoint.Endpoint
+	UpdatePolicyReviewScheduleEndpoint endpoint.Endpoint
+	ListTemplateLibraryEndpoint        endpoint.Endpoint
+	GetTemplateEndpoint                endpoint.Endpoint
+	CreateTemplateEndpoint             endpoint.Endpoint
+	UpdateTemplateEndpoint             endpoint.Endpoint
+	DeleteTemplateEndpoint             endpoint.Endpoint
+	PublishTemplateEndpoint            endpoint.Endpoint
+	GetTemplateVersionsEndpoint        endpoint.Endpoint
+	GetTemplateUpdateEndpoint          endpoint.Endpoint
+	DismissTemplateUpdateEndpoint      endpoint.Endpoint
+	AcquireEditLockEndpoint            endpoint.Endpoint
+	GetEditLockEndpoint                endpoint.Endpoint
+	ReleaseEditLockEndpoint            endpoint.Endpoint
 }
 
 // New returns new endpoints
@@ -36,6 +55,25 @@ func New(svc service.Service) *Endpoints {
 		GetPoliciesStatsEndpoint:           makeGetPoliciesStatsEndpoint(svc),
 		GetTemplatesEndpoint:               makeGetTemplatesEndpoint(svc),
 		CreateDocumentFromTemplateEndpoint: makeCreateDocumentFromTemplateEndpoint(svc),
+		PreviewTemplateEndpoint:            makePreviewTemplateEndpoint(svc),
+		SetPolicyReviewersEndpoint:         makeSetPolicyReviewersEndpoint(svc),
+		GetPolicyReviewersEndpoint:         makeGetPolicyReviewersEndpoint(svc),
+		GetPolicyStatusHistoryEndpoint:     makeGetPolicyStatusHistoryEndpoint(svc),
+		GetPolicyDiffEndpoint:              makeGetPolicyDiffEndpoint(svc),
+		GetPolicyReviewScheduleEndpoint:    makeGetPolicyReviewScheduleEndpoint(svc),
+		UpdatePolicyReviewScheduleEndpoint: makeUpdatePolicyReviewScheduleEndpoint(svc),
+		ListTemplateLibraryEndpoint:        makeListTemplateLibraryEndpoint(svc),
+		GetTemplateEndpoint:                makeGetTemplateEndpoint(svc),
+		CreateTemplateEndpoint:             makeCreateTemplateEndpoint(svc),
+		UpdateTemplateEndpoint:             makeUpdateTemplateEndpoint(svc),
+		DeleteTemplateEndpoint:             makeDeleteTemplateEndpoint(svc),
+		PublishTemplateEndpoint:            makePublishTemplateEndpoint(svc),
+		GetTemplateVersionsEndpoint:        makeGetTemplateVersionsEndpoint(svc),
+		GetTemplateUpdateEndpoint:          makeGetTemplateUpdateEndpoint(svc),
+		DismissTemplateUpdateEndpoint:      makeDismissTemplateUpdateEndpoint(svc),
+		AcquireEditLockEndpoint:            makeAcquireEditLockEndpoint(svc),
+		GetEditLockEndpoint:                makeGetEditLockEndpoint(svc),
+		ReleaseEditLockEndpoint:            makeReleaseEditLockEndpoint(svc),
 	}
 }
 
@@ -126,3 +164,157 @@ func makeCreateDocumentFromTemplateEndpoint(svc service.Service) endpoint.Endpoi
 		return svc.CreateDocumentFromTemplate(ctx, req)
 	}
 }
+
+func makePreviewTemplateEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.PreviewTemplateRequest) //nolint:errcheck
+
+		return svc.PreviewTemplate(ctx, req)
+	}
+}
+
+func makeSetPolicyReviewersEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.SetPolicyReviewersRequest) //nolint:errcheck
+
+		return svc.SetPolicyReviewers(ctx, req)
+	}
+}
+
+func makeGetPolicyReviewersEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetPolicyReviewersRequest) //nolint:errcheck
+
+		return svc.GetPolicyReviewers(ctx, req)
+	}
+}
+
+func makeGetPolicyStatusHistoryEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetPolicyStatusHistoryRequest) //nolint:errcheck
+
+		return svc.GetPolicyStatusHistory(ctx, req)
+	}
+}
+
+func makeGetPolicyDiffEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetPolicyDiffRequest) //nolint:errcheck
+
+		return svc.GetPolicyDiff(ctx, req)
+	}
+}
+
+func makeGetPolicyReviewScheduleEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetPolicyReviewScheduleRequest) //nolint:errcheck
+
+		return svc.GetPolicyReviewSchedule(ctx, req)
+	}
+}
+
+func makeUpdatePolicyReviewScheduleEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.UpdatePolicyReviewScheduleRequest) //nolint:errcheck
+
+		return svc.UpdatePolicyReviewSchedule(ctx, req)
+	}
+}
+
+func makeListTemplateLibraryEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetTemplateLibraryRequest) //nolint:errcheck
+
+		return svc.ListTemplateLibrary(ctx, req)
+	}
+}
+
+func makeGetTemplateEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetTemplateRequest) //nolint:errcheck
+
+		return svc.GetTemplate(ctx, req)
+	}
+}
+
+func makeCreateTemplateEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.CreateTemplateRequest) //nolint:errcheck
+
+		return svc.CreateTemplate(ctx, req)
+	}
+}
+
+func makeUpdateTemplateEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.UpdateTemplateRequest) //nolint:errcheck
+
+		return svc.UpdateTemplate(ctx, req)
+	}
+}
+
+func makeDeleteTemplateEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetTemplateRequest) //nolint:errcheck
+
+		err := svc.DeleteTemplate(ctx, req)
+		return "", err
+	}
+}
+
+func makePublishTemplateEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.PublishTemplateRequest) //nolint:errcheck
+
+		return svc.PublishTemplate(ctx, req)
+	}
+}
+
+func makeGetTemplateVersionsEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetTemplateRequest) //nolint:errcheck
+
+		return svc.GetTemplateVersions(ctx, req)
+	}
+}
+
+func makeGetTemplateUpdateEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetTemplateUpdateRequest) //nolint:errcheck
+
+		return svc.GetTemplateUpdate(ctx, req)
+	}
+}
+
+func makeDismissTemplateUpdateEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetTemplateUpdateRequest) //nolint:errcheck
+
+		return svc.DismissTemplateUpdate(ctx, req)
+	}
+}
+
+func makeAcquireEditLockEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.PolicyEditLockRequest) //nolint:errcheck
+
+		return svc.AcquireEditLock(ctx, req)
+	}
+}
+
+func makeGetEditLockEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.PolicyEditLockRequest) //nolint:errcheck
+
+		return svc.GetEditLock(ctx, req)
+	}
+}
+
+func makeReleaseEditLockEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.PolicyEditLockRequest) //nolint:errcheck
+
+		err := svc.ReleaseEditLock(ctx, req)
+		return "", err
+	}
+}
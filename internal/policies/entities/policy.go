@@ -14,25 +14,45 @@ type Policy struct {
 	PolicyUuid         uuid.UUID         `json:"policy_uuid" gorm:"column:policy_uuid"`
 	CompanyUuid        uuid.UUID         `json:"company_uuid" gorm:"column:company_uuid"`
 	PolicyTemplateUuid nullable.NullUUID `json:"policy_template_uuid" gorm:"column:policy_template_uuid"`
-	Name               string            `json:"name" gorm:"column:name"`
-	Status             string            `json:"status" gorm:"column:status"`
-	CreatedAt          nullable.NullTime `json:"created_at" gorm:"column:created_at"`
-	UpdatedAt          nullable.NullTime `json:"updated_at" gorm:"column:updated_at"`
-	CreatedBy          uuid.UUID         `json:"created_by" gorm:"column:created_by"`
-	UpdatedBy          nullable.NullUUID `json:"updated_by" gorm:"column:updated_by"`
-	StatusUpdatedAt    time.Time         `json:"-" gorm:"column:status_updated_at"`
-	StatusUpdatedBy    uuid.UUID         `json:"-" gorm:"column:status_updated_by"`
-	StatusUpdated      entities.User     `json:"-" gorm:"foreignKey:StatusUpdatedBy;references:UserUuid"`
+	// PolicyTemplateVersion the policy was created from, or last brought up to date with.
+	PolicyTemplateVersion *int              `json:"policy_template_version" gorm:"column:policy_template_version"`
+	Name                  string            `json:"name" gorm:"column:name"`
+	Status                string            `json:"status" gorm:"column:status"`
+	ApprovalRule          string            `json:"approval_rule" gorm:"column:approval_rule;default:all"`
+	CreatedAt             nullable.NullTime `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt             nullable.NullTime `json:"updated_at" gorm:"column:updated_at"`
+	CreatedBy             uuid.UUID         `json:"created_by" gorm:"column:created_by"`
+	UpdatedBy             nullable.NullUUID `json:"updated_by" gorm:"column:updated_by"`
+	StatusUpdatedAt       time.Time         `json:"-" gorm:"column:status_updated_at"`
+	StatusUpdatedBy       uuid.UUID         `json:"-" gorm:"column:status_updated_by"`
+	StatusUpdated         entities.User     `json:"-" gorm:"foreignKey:StatusUpdatedBy;references:UserUuid"`
+	// ReviewIntervalMonths between periodic reviews, which start once the policy is approved
+	ReviewIntervalMonths int               `json:"review_interval_months" gorm:"column:review_interval_months;default:12"`
+	ReviewOwnerUuid      nullable.NullUUID `json:"review_owner_uuid" gorm:"column:review_owner_uuid"`
+	ReviewOwner          entities.User     `json:"-" gorm:"foreignKey:ReviewOwnerUuid;references:UserUuid"`
+	LastReviewedAt       nullable.NullTime `json:"last_reviewed_at" gorm:"column:last_reviewed_at"`
+	NextReviewAt         nullable.NullTime `json:"next_review_at" gorm:"column:next_review_at"`
 }
 
 func (m *Policy) TableName() string {
 	return "policies"
 }
 
+// GetAllPoliciesRequest lists the policies of a company. NeedsReview lists the ones whose
+// review is due soon or overdue.
+// Search modes of the keyword listing policies. Name matches the name, status, version and owner
+// of the policies, content searches the text of their latest document.
+const (
+	SearchModeName    = "name"
+	SearchModeContent = "content"
+)
+
 type GetAllPoliciesRequest struct {
 	CompanyUuid uuid.UUID `json:"company_uuid"`
 	UserUuid    uuid.UUID `json:"user_uuid"`
 	Keyword     string
+	SearchMode  string
+	NeedsReview bool
 }
 
 type GetAllPoliciesResponse struct {
@@ -45,6 +65,10 @@ type GetAllPoliciesResponse struct {
 	StatusUpdatedBy *UserInfo         `json:"status_updated_by"`
 	Owner           *UserInfo         `json:"owner"`
 	CreatedAt       time.Time         `json:"-"`
+	// Rank and Snippet of the policies found by content, the matches are marked with mark elements.
+	Rank    *float64 `json:"rank,omitempty"`
+	Snippet *string  `json:"snippet,omitempty"`
+	*PolicyReviewSchedule
 }
 
 func (t *GetAllPoliciesResponse) MarshalJSON() ([]byte, error) {
@@ -141,9 +165,13 @@ type GetPolicyDocumentResponse struct {
 	Owner           *UserInfo `json:"owner"`
 	CreatedAt       time.Time `json:"created_at"`
 }
+
+// SaveDocumentRequestBody with the version the document was edited from, 0 for a policy without
+// versions. A save based on an older version than the latest one is rejected.
 type SaveDocumentRequestBody struct {
-	Name     string `json:"name"`
-	Document string `json:"document"`
+	Name        string `json:"name"`
+	Document    string `json:"document"`
+	BaseVersion *int   `json:"base_version"`
 }
 
 type SaveDocumentRequest struct {
@@ -194,9 +222,11 @@ type GetPoliciesStatsRequest struct {
 }
 
 type GetPoliciesStatsResponse struct {
-	Total     int `json:"total"`
-	Draft     int `json:"draft"`
-	Submitted int `json:"submitted"`
-	Approved  int `json:"approved"`
-	Rejected  int `json:"rejected"`
+	Total         int `json:"total"`
+	Draft         int `json:"draft"`
+	Submitted     int `json:"submitted"`
+	Approved      int `json:"approved"`
+	Rejected      int `json:"rejected"`
+	ReviewDueSoon int `json:"review_due_soon"`
+	ReviewOverdue int `json:"review_overdue"`
 }
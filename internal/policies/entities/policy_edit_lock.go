@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+)
+
+// PolicyEditLock tells others a user is editing the document of a policy. The lock is soft: it
+// does not prevent saves, and it expires unless refreshed by the editor.
+type PolicyEditLock struct {
+	PolicyUuid uuid.UUID     `json:"policy_uuid" gorm:"column:policy_uuid"`
+	UserUuid   uuid.UUID     `json:"user_uuid" gorm:"column:user_uuid"`
+	User       entities.User `json:"-" gorm:"foreignKey:UserUuid;references:UserUuid"`
+	AcquiredAt time.Time     `json:"acquired_at" gorm:"column:acquired_at"`
+	ExpiresAt  time.Time     `json:"expires_at" gorm:"column:expires_at"`
+}
+
+func (m *PolicyEditLock) TableName() string {
+	return "policy_edit_locks"
+}
+
+type PolicyEditLockRequest struct {
+	CompanyUuid uuid.UUID `json:"company_uuid"`
+	UserUuid    uuid.UUID `json:"user_uuid"`
+	PolicyUuid  uuid.UUID `json:"policy_uuid"`
+}
+
+// PolicyEditLockResponse tells whether someone is editing the policy, who and for how long.
+// Mine is set when the editor is the user asking.
+type PolicyEditLockResponse struct {
+	PolicyUuid     uuid.UUID  `json:"policy_uuid"`
+	Locked         bool       `json:"locked"`
+	Mine           bool       `json:"mine"`
+	Editor         *UserInfo  `json:"editor,omitempty"`
+	AcquiredAt     *time.Time `json:"acquired_at,omitempty"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	EditingSeconds int64      `json:"editing_seconds"`
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/htmldiff"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
 )
 
@@ -20,6 +21,8 @@ type PolicyHistory struct {
 	CreatedBy         uuid.UUID         `json:"created_by" gorm:"column:created_by"`
 	Created           entities.User     `json:"-" gorm:"foreignKey:CreatedBy;references:UserUuid"`
 	UpdatedBy         nullable.NullUUID `json:"updated_by" gorm:"column:updated_by"`
+	// Changes compared to the previous version, nil for the first version.
+	Changes *htmldiff.Summary `json:"changes" gorm:"column:changes;serializer:json"`
 }
 
 func (m *PolicyHistory) TableName() string {
@@ -32,9 +35,40 @@ type GetPolicyHistoriesByPolicyRequest struct {
 	PolicyUUID  uuid.UUID `json:"policy_uuid"`
 }
 
+// GetPolicyHistoriesByPolicyResponse is a version of a policy. Changes compares it to the
+// previous version, nil for the first version.
 type GetPolicyHistoriesByPolicyResponse struct {
-	PolicyHistoryUUID uuid.UUID `json:"policy_history_uuid"`
+	PolicyHistoryUUID uuid.UUID         `json:"policy_history_uuid"`
+	Version           int               `json:"version"`
+	CreatedAt         time.Time         `json:"created_at"`
+	Owner             *UserInfo         `json:"owner"`
+	Changes           *htmldiff.Summary `json:"changes"`
+}
+
+// GetPolicyDiffRequest compares the From version of a policy to the To version. To defaults
+// to the latest version and From to the version before To.
+type GetPolicyDiffRequest struct {
+	CompanyUuid uuid.UUID `json:"company_uuid"`
+	UserUuid    uuid.UUID `json:"user_uuid"`
+	PolicyUuid  uuid.UUID `json:"policy_uuid"`
+	From        int       `json:"from"`
+	To          int       `json:"to"`
+}
+
+type PolicyVersion struct {
+	PolicyHistoryUuid uuid.UUID `json:"policy_history_uuid"`
 	Version           int       `json:"version"`
 	CreatedAt         time.Time `json:"created_at"`
 	Owner             *UserInfo `json:"owner"`
 }
+
+// GetPolicyDiffResponse holds the changed blocks of the document and a rendered view of the To
+// version with insertions and deletions marked. From is nil when To is the first version.
+type GetPolicyDiffResponse struct {
+	PolicyUuid uuid.UUID          `json:"policy_uuid"`
+	From       *PolicyVersion     `json:"from"`
+	To         *PolicyVersion     `json:"to"`
+	Summary    htmldiff.Summary   `json:"summary"`
+	Changes    []*htmldiff.Change `json:"changes"`
+	HTML       string             `json:"html"`
+}
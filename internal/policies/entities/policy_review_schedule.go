@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReviewStatusDueSoon = "due_soon"
+	ReviewStatusOverdue = "overdue"
+
+	// DateLayout of review dates.
+	DateLayout = "2006-01-02"
+)
+
+// PolicyReviewSchedule of the periodic review of a policy. NextReviewDate is nil until the
+// policy is approved. ReviewStatus is due_soon or overdue, empty otherwise.
+type PolicyReviewSchedule struct {
+	ReviewIntervalMonths int        `json:"review_interval_months"`
+	LastReviewedAt       *time.Time `json:"last_reviewed_at"`
+	NextReviewDate       *string    `json:"next_review_date"`
+	ReviewOwner          *UserInfo  `json:"review_owner"`
+	ReviewStatus         string     `json:"review_status"`
+}
+
+type GetPolicyReviewScheduleRequest struct {
+	CompanyUuid uuid.UUID `json:"company_uuid"`
+	UserUuid    uuid.UUID `json:"user_uuid"`
+	PolicyUuid  uuid.UUID `json:"policy_uuid"`
+}
+
+// UpdatePolicyReviewScheduleRequestBody sets the review schedule of a policy. Without
+// NextReviewDate the next review is due an interval after the last one.
+type UpdatePolicyReviewScheduleRequestBody struct {
+	ReviewIntervalMonths int       `json:"review_interval_months"`
+	NextReviewDate       string    `json:"next_review_date"`
+	OwnerUuid            uuid.UUID `json:"owner_uuid"`
+}
+
+type UpdatePolicyReviewScheduleRequest struct {
+	CompanyUuid uuid.UUID `json:"company_uuid"`
+	UserUuid    uuid.UUID `json:"user_uuid"`
+	PolicyUuid  uuid.UUID `json:"policy_uuid"`
+	Body        *UpdatePolicyReviewScheduleRequestBody
+}
+
+type GetPolicyReviewScheduleResponse struct {
+	PolicyUuid uuid.UUID `json:"policy_uuid"`
+	*PolicyReviewSchedule
+}
+
+// PolicyReviewReminder to send to the review owner of a policy.
+type PolicyReviewReminder struct {
+	PolicyUuid     uuid.UUID `gorm:"column:policy_uuid"`
+	CompanyUuid    uuid.UUID `gorm:"column:company_uuid"`
+	Name           string    `gorm:"column:name"`
+	NextReviewAt   time.Time `gorm:"column:next_review_at"`
+	Overdue        bool      `gorm:"column:overdue"`
+	OwnerEmail     string    `gorm:"column:email"`
+	OwnerFirstName string    `gorm:"column:first_name"`
+}
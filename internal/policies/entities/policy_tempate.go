@@ -1,48 +1,36 @@
 package entities
 
 import (
-	"database/sql/driver"
-	"encoding/json"
-	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/htmldiff"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
-	"github.com/pkg/errors"
 )
 
-type IndustryType []string
-
-// Value simply returns the JSON-encoded representation of the struct.
-func (a IndustryType) Value() (driver.Value, error) {
-	return json.Marshal(a)
-}
-
-// Scan makes the IndustryType implement the sql.Scanner interface. This method
-// simply decodes a value into the string slice.
-func (a *IndustryType) Scan(value interface{}) error {
-	b, ok := value.([]byte)
-	if !ok {
-		return errors.New("IndustryType type assertion to []byte failed")
-	}
-
-	return json.Unmarshal(b, &a)
-}
-
-// String simply returns the joined slice elements separated by comma.
-func (a IndustryType) String() string {
-	return strings.Join(a, ",")
-}
+const (
+	TemplateStatusDraft     = "draft"
+	TemplateStatusPublished = "published"
+)
 
+// PolicyTemplates is the working copy of a template. Its status is draft until the copy is
+// published as a new version; companies only see the latest published version.
 type PolicyTemplates struct {
-	PolicyTemplateUuid uuid.UUID         `json:"policy_template_uuid" gorm:"column:policy_template_uuid"`
-	Name               string            `json:"name" gorm:"column:name"`
-	Description        string            `json:"description" gorm:"column:description"`
-	Document           string            `json:"document" gorm:"column:document"`
-	IndustryType       IndustryType      `json:"industry_type" gorm:"column:industry_type"`
-	CreatedAt          nullable.NullTime `json:"created_at" gorm:"column:created_at"`
-	UpdatedAt          nullable.NullTime `json:"updated_at" gorm:"column:updated_at"`
-	CreatedBy          uuid.UUID         `json:"created_by" gorm:"column:created_by"`
-	UpdatedBy          uuid.UUID         `json:"updated_by" gorm:"column:updated_by"`
+	PolicyTemplateUuid uuid.UUID `json:"policy_template_uuid" gorm:"column:policy_template_uuid"`
+	Name               string    `json:"name" gorm:"column:name"`
+	Description        string    `json:"description" gorm:"column:description"`
+	Document           string    `json:"document,omitempty" gorm:"column:document"`
+	// IndustryType and CompanyTypes the template targets, all of them when empty.
+	IndustryType pq.StringArray `json:"industry_type" gorm:"column:industry_type;type:industry_type[]"`
+	CompanyTypes pq.StringArray `json:"company_types" gorm:"column:company_types;type:company_type[]"`
+	Status       string         `json:"status" gorm:"column:status"`
+	// Version is the latest published version, 0 until the template is published.
+	Version   int               `json:"version" gorm:"column:version"`
+	CreatedAt nullable.NullTime `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt nullable.NullTime `json:"updated_at" gorm:"column:updated_at"`
+	CreatedBy uuid.UUID         `json:"created_by" gorm:"column:created_by"`
+	UpdatedBy uuid.UUID         `json:"updated_by" gorm:"column:updated_by"`
 }
 
 func (m *PolicyTemplates) TableName() string {
@@ -66,3 +54,129 @@ type CreateDocumentFromTemplateRequest struct {
 	UserUuid           uuid.UUID `json:"user_uuid"`
 	PolicyTemplateUuid uuid.UUID `json:"policy_template_uuid"`
 }
+
+type PreviewTemplateRequest struct {
+	CompanyUuid        uuid.UUID `json:"company_uuid"`
+	UserUuid           uuid.UUID `json:"user_uuid"`
+	PolicyTemplateUuid uuid.UUID `json:"policy_template_uuid"`
+}
+
+// PreviewTemplateResponse is a template with its merge fields filled for the company.
+type PreviewTemplateResponse struct {
+	PolicyTemplateUuid uuid.UUID `json:"policy_template_uuid"`
+	Name               string    `json:"name"`
+	Document           string    `json:"document"`
+}
+
+// PolicyTemplateVersion is a published version of a template, never changed once published.
+type PolicyTemplateVersion struct {
+	PolicyTemplateUuid uuid.UUID `json:"policy_template_uuid" gorm:"column:policy_template_uuid"`
+	Version            int       `json:"version" gorm:"column:version"`
+	Name               string    `json:"name" gorm:"column:name"`
+	Description        string    `json:"description" gorm:"column:description"`
+	Document           string    `json:"document,omitempty" gorm:"column:document"`
+	Comment            string    `json:"comment" gorm:"column:comment"`
+	CreatedAt          time.Time `json:"created_at" gorm:"column:created_at"`
+	CreatedBy          uuid.UUID `json:"created_by" gorm:"column:created_by"`
+}
+
+func (m *PolicyTemplateVersion) TableName() string {
+	return "policy_template_versions"
+}
+
+// PolicyTemplateUpdate is a new version of the template a policy was created from, pending
+// until the company dismisses it.
+type PolicyTemplateUpdate struct {
+	PolicyTemplateUpdateUuid uuid.UUID         `json:"policy_template_update_uuid" gorm:"column:policy_template_update_uuid"`
+	PolicyUuid               uuid.UUID         `json:"policy_uuid" gorm:"column:policy_uuid"`
+	PolicyTemplateUuid       uuid.UUID         `json:"policy_template_uuid" gorm:"column:policy_template_uuid"`
+	FromVersion              *int              `json:"from_version" gorm:"column:from_version"`
+	ToVersion                int               `json:"to_version" gorm:"column:to_version"`
+	CreatedAt                time.Time         `json:"created_at" gorm:"column:created_at"`
+	NotifiedAt               nullable.NullTime `json:"notified_at" gorm:"column:notified_at"`
+	DismissedAt              nullable.NullTime `json:"dismissed_at" gorm:"column:dismissed_at"`
+	DismissedBy              nullable.NullUUID `json:"dismissed_by" gorm:"column:dismissed_by"`
+}
+
+func (m *PolicyTemplateUpdate) TableName() string {
+	return "policy_template_updates"
+}
+
+// PolicyTemplateUpdateNotification tells the owner of a policy about a new version of its
+// template.
+type PolicyTemplateUpdateNotification struct {
+	PolicyTemplateUpdateUuid uuid.UUID `gorm:"column:policy_template_update_uuid"`
+	PolicyUuid               uuid.UUID `gorm:"column:policy_uuid"`
+	PolicyName               string    `gorm:"column:policy_name"`
+	TemplateName             string    `gorm:"column:template_name"`
+	PolicyTemplateUuid       uuid.UUID `gorm:"column:policy_template_uuid"`
+	FromVersion              *int      `gorm:"column:from_version"`
+	ToVersion                int       `gorm:"column:to_version"`
+	OwnerEmail               string    `gorm:"column:email"`
+	OwnerFirstName           string    `gorm:"column:first_name"`
+}
+
+type GetTemplateLibraryRequest struct {
+	CompanyUuid uuid.UUID
+	UserUuid    uuid.UUID
+}
+
+// TemplateRequestBody creates or updates the working copy of a template.
+type TemplateRequestBody struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	Document     string   `json:"document"`
+	IndustryType []string `json:"industry_type"`
+	CompanyTypes []string `json:"company_types"`
+}
+
+type CreateTemplateRequest struct {
+	CompanyUuid uuid.UUID
+	UserUuid    uuid.UUID
+	Body        *TemplateRequestBody
+}
+
+type GetTemplateRequest struct {
+	CompanyUuid        uuid.UUID
+	UserUuid           uuid.UUID
+	PolicyTemplateUuid uuid.UUID
+}
+
+type UpdateTemplateRequest struct {
+	CompanyUuid        uuid.UUID
+	UserUuid           uuid.UUID
+	PolicyTemplateUuid uuid.UUID
+	Body               *TemplateRequestBody
+}
+
+type PublishTemplateRequestBody struct {
+	Comment string `json:"comment"`
+}
+
+type PublishTemplateRequest struct {
+	CompanyUuid        uuid.UUID
+	UserUuid           uuid.UUID
+	PolicyTemplateUuid uuid.UUID
+	Body               *PublishTemplateRequestBody
+}
+
+// PublishTemplateResponse is the published version and the number of policies created from the
+// template whose owners are notified of it.
+type PublishTemplateResponse struct {
+	*PolicyTemplateVersion
+	UpdatedPolicies int `json:"updated_policies"`
+}
+
+type GetTemplateUpdateRequest struct {
+	CompanyUuid uuid.UUID
+	UserUuid    uuid.UUID
+	PolicyUuid  uuid.UUID
+}
+
+// GetTemplateUpdateResponse is the pending update of a policy, with the diff of the current
+// document of the policy against the new version of its template.
+type GetTemplateUpdateResponse struct {
+	*PolicyTemplateUpdate
+	TemplateName string         `json:"template_name"`
+	Diff         *htmldiff.Diff `json:"diff"`
+}
@@ -0,0 +1,135 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+)
+
+const (
+	PolicyStatusDraft     = "Draft"
+	PolicyStatusSubmitted = "Submitted"
+	PolicyStatusApproved  = "Approved"
+	PolicyStatusRejected  = "Rejected"
+	PolicyStatusInactive  = "Inactive"
+
+	// ApprovalRuleAll approves a policy once every reviewer signed off.
+	ApprovalRuleAll = "all"
+	// ApprovalRuleAny approves a policy on the first sign-off.
+	ApprovalRuleAny = "any"
+)
+
+// PolicyReviewer is a company user assigned to review a policy.
+type PolicyReviewer struct {
+	PolicyUuid uuid.UUID     `json:"policy_uuid" gorm:"column:policy_uuid"`
+	UserUuid   uuid.UUID     `json:"user_uuid" gorm:"column:user_uuid"`
+	User       entities.User `json:"-" gorm:"foreignKey:UserUuid;references:UserUuid"`
+	CreatedAt  time.Time     `json:"created_at" gorm:"column:created_at"`
+	CreatedBy  uuid.UUID     `json:"created_by" gorm:"column:created_by"`
+}
+
+func (m *PolicyReviewer) TableName() string {
+	return "policy_reviewers"
+}
+
+// PolicyReview is the decision of a reviewer on a submission of a policy version.
+type PolicyReview struct {
+	PolicyStatusHistoryUuid uuid.UUID `json:"policy_status_history_uuid" gorm:"column:policy_status_history_uuid"`
+	PolicyHistoryUuid       uuid.UUID `json:"policy_history_uuid" gorm:"column:policy_history_uuid"`
+	ReviewerUuid            uuid.UUID `json:"reviewer_uuid" gorm:"column:reviewer_uuid"`
+	Decision                string    `json:"decision" gorm:"column:decision"`
+	Comment                 string    `json:"comment" gorm:"column:comment"`
+	CreatedAt               time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (m *PolicyReview) TableName() string {
+	return "policy_reviews"
+}
+
+// PolicyStatusHistory is a status transition of a policy, applied to the version it links to.
+type PolicyStatusHistory struct {
+	PolicyStatusHistoryUuid uuid.UUID         `json:"policy_status_history_uuid" gorm:"column:policy_status_history_uuid"`
+	PolicyUuid              uuid.UUID         `json:"policy_uuid" gorm:"column:policy_uuid"`
+	PolicyHistoryUuid       nullable.NullUUID `json:"policy_history_uuid" gorm:"column:policy_history_uuid"`
+	PolicyHistory           *PolicyHistory    `json:"-" gorm:"foreignKey:PolicyHistoryUuid;references:PolicyHistoryUuid"`
+	FromStatus              string            `json:"from_status" gorm:"column:from_status"`
+	ToStatus                string            `json:"to_status" gorm:"column:to_status"`
+	Comment                 string            `json:"comment" gorm:"column:comment"`
+	CreatedAt               time.Time         `json:"created_at" gorm:"column:created_at"`
+	CreatedBy               uuid.UUID         `json:"created_by" gorm:"column:created_by"`
+	Created                 entities.User     `json:"-" gorm:"foreignKey:CreatedBy;references:UserUuid"`
+}
+
+func (m *PolicyStatusHistory) TableName() string {
+	return "policy_status_histories"
+}
+
+// PolicyWorkflow is the review state of a policy: its latest version, nil before a document
+// is saved, the assigned reviewers, the last submission for review, nil before the policy is
+// submitted, and the decisions of the reviewers on that submission.
+type PolicyWorkflow struct {
+	Policy        *Policy
+	LatestVersion *PolicyHistory
+	Reviewers     []*PolicyReviewer
+	Submission    *PolicyStatusHistory
+	Reviews       []*PolicyReview
+}
+
+// PolicyWorkflowChange to apply to a policy. Review is the decision of a reviewer and
+// Transition the status change, either of them may be nil.
+type PolicyWorkflowChange struct {
+	Review     *PolicyReview
+	Transition *PolicyStatusHistory
+}
+
+type SetPolicyReviewersRequestBody struct {
+	Reviewers    []uuid.UUID `json:"reviewers"`
+	ApprovalRule string      `json:"approval_rule"`
+}
+
+type SetPolicyReviewersRequest struct {
+	CompanyUuid uuid.UUID `json:"company_uuid"`
+	UserUuid    uuid.UUID `json:"user_uuid"`
+	PolicyUuid  uuid.UUID `json:"policy_uuid"`
+	Body        *SetPolicyReviewersRequestBody
+}
+
+type GetPolicyReviewersRequest struct {
+	CompanyUuid uuid.UUID `json:"company_uuid"`
+	UserUuid    uuid.UUID `json:"user_uuid"`
+	PolicyUuid  uuid.UUID `json:"policy_uuid"`
+}
+
+// PolicyReviewerStatus is the decision of a reviewer on the latest version, empty while pending.
+type PolicyReviewerStatus struct {
+	Reviewer  *UserInfo  `json:"reviewer"`
+	Decision  string     `json:"decision"`
+	Comment   string     `json:"comment"`
+	DecidedAt *time.Time `json:"decided_at"`
+}
+
+type GetPolicyReviewersResponse struct {
+	PolicyUuid   uuid.UUID               `json:"policy_uuid"`
+	Status       string                  `json:"status"`
+	ApprovalRule string                  `json:"approval_rule"`
+	Version      int                     `json:"version"`
+	Reviewers    []*PolicyReviewerStatus `json:"reviewers"`
+}
+
+type GetPolicyStatusHistoryRequest struct {
+	CompanyUuid uuid.UUID `json:"company_uuid"`
+	UserUuid    uuid.UUID `json:"user_uuid"`
+	PolicyUuid  uuid.UUID `json:"policy_uuid"`
+}
+
+type GetPolicyStatusHistoryResponse struct {
+	FromStatus        string     `json:"from_status"`
+	ToStatus          string     `json:"to_status"`
+	Comment           string     `json:"comment"`
+	PolicyHistoryUuid *uuid.UUID `json:"policy_history_uuid"`
+	Version           int        `json:"version"`
+	CreatedAt         time.Time  `json:"created_at"`
+	CreatedBy         *UserInfo  `json:"created_by"`
+}
@@ -0,0 +1,48 @@
+package endpoints
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports/service"
+)
+
+type Endpoints struct {
+	CreateExportEndpoint   endpoint.Endpoint
+	GetExportEndpoint      endpoint.Endpoint
+	DownloadExportEndpoint endpoint.Endpoint
+}
+
+// New returns new endpoints
+func New(svc service.Service) *Endpoints {
+	return &Endpoints{
+		CreateExportEndpoint:   makeCreateExportEndpoint(svc),
+		GetExportEndpoint:      makeGetExportEndpoint(svc),
+		DownloadExportEndpoint: makeDownloadExportEndpoint(svc),
+	}
+}
+
+func makeCreateExportEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.CreateExportRequest) //nolint:errcheck
+
+		return svc.CreateExport(ctx, req)
+	}
+}
+
+func makeGetExportEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetExportRequest) //nolint:errcheck
+
+		return svc.GetExport(ctx, req)
+	}
+}
+
+func makeDownloadExportEndpoint(svc service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(*entities.GetExportRequest) //nolint:errcheck
+
+		return svc.DownloadExport(ctx, req)
+	}
+}
@@ -0,0 +1,129 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+	"github.com/pkg/errors"
+)
+
+const (
+	FormatDocx = "docx"
+	FormatPdf  = "pdf"
+
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+)
+
+type PolicyUuids []uuid.UUID
+
+// Value simply returns the JSON-encoded representation of the slice, nil for all policies.
+func (a PolicyUuids) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(a)
+}
+
+// Scan makes the PolicyUuids implement the sql.Scanner interface. This method
+// simply decodes a value into the uuid slice.
+func (a *PolicyUuids) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("PolicyUuids type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &a)
+}
+
+// Export of the approved policies of a company as a ZIP of documents with a manifest.
+type Export struct {
+	ExportUuid  uuid.UUID `json:"export_uuid" gorm:"column:export_uuid"`
+	CompanyUuid uuid.UUID `json:"company_uuid" gorm:"column:company_uuid"`
+	RequestedBy uuid.UUID `json:"requested_by" gorm:"column:requested_by"`
+	Format      string    `json:"format" gorm:"column:format"`
+	// PolicyUuids chosen for the export, nil for all the approved policies of the company.
+	PolicyUuids PolicyUuids       `json:"policy_uuids" gorm:"column:policy_uuids"`
+	Status      string            `json:"status" gorm:"column:status"`
+	PolicyCount int               `json:"policy_count" gorm:"column:policy_count"`
+	FileKey     string            `json:"-" gorm:"column:file_key"`
+	FileName    string            `json:"file_name" gorm:"column:file_name"`
+	Error       string            `json:"error,omitempty" gorm:"column:error"`
+	CreatedAt   time.Time         `json:"created_at" gorm:"column:created_at"`
+	StartedAt   nullable.NullTime `json:"started_at" gorm:"column:started_at"`
+	CompletedAt nullable.NullTime `json:"completed_at" gorm:"column:completed_at"`
+}
+
+func (m *Export) TableName() string {
+	return "policy_exports"
+}
+
+// ApprovedPolicy is the latest approved version of a policy with its approval.
+type ApprovedPolicy struct {
+	PolicyUuid        uuid.UUID `gorm:"column:policy_uuid"`
+	Name              string    `gorm:"column:name"`
+	PolicyHistoryUuid uuid.UUID `gorm:"column:policy_history_uuid"`
+	Version           int       `gorm:"column:version"`
+	Document          string    `gorm:"column:document"`
+	ApprovedAt        time.Time `gorm:"column:approved_at"`
+	ApproverFirstName string    `gorm:"column:approver_first_name"`
+	ApproverLastName  string    `gorm:"column:approver_last_name"`
+	ApproverEmail     string    `gorm:"column:approver_email"`
+}
+
+// Manifest of an export, written to manifest.json at the root of the ZIP.
+type Manifest struct {
+	ExportUuid  uuid.UUID         `json:"export_uuid"`
+	CompanyUuid uuid.UUID         `json:"company_uuid"`
+	CompanyName string            `json:"company_name"`
+	Format      string            `json:"format"`
+	GeneratedAt time.Time         `json:"generated_at"`
+	Policies    []*ManifestPolicy `json:"policies"`
+}
+
+type ManifestPolicy struct {
+	PolicyUuid uuid.UUID `json:"policy_uuid"`
+	Name       string    `json:"name"`
+	Version    int       `json:"version"`
+	ApprovedBy string    `json:"approved_by"`
+	ApprovedAt time.Time `json:"approved_at"`
+	File       string    `json:"file"`
+}
+
+// CreateExportRequestBody exports the chosen policies, or all the approved ones, as DOCX or PDF
+// documents, PDF by default.
+type CreateExportRequestBody struct {
+	Format      string      `json:"format"`
+	PolicyUuids []uuid.UUID `json:"policy_uuids"`
+}
+
+type CreateExportRequest struct {
+	CompanyUuid uuid.UUID
+	UserUuid    uuid.UUID
+	Body        *CreateExportRequestBody
+}
+
+type GetExportRequest struct {
+	CompanyUuid uuid.UUID
+	UserUuid    uuid.UUID
+	ExportUuid  uuid.UUID
+}
+
+// DownloadExportResponse is the ZIP of a completed export.
+type DownloadExportResponse struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
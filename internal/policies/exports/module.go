@@ -0,0 +1,53 @@
+// Package exports bundles the approved policies of a company as documents for its auditors.
+package exports
+
+import (
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	s3client "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/s3"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company"
+	policiesCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports/endpoints"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports/service"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports/transport/http"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/periodic"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ModuleParams for exports.
+type ModuleParams struct {
+	fx.In
+
+	DB            *gorm.DB
+	HTTPServer    *httpTransport.Server
+	APPTransport  svcTransport.Client
+	AuthClient    auth.Client
+	CompanyClient company.Client
+	S3Client      s3client.Client
+	Config        policiesCfg.Config
+	Logger        *zap.SugaredLogger
+	Lifecycle     fx.Lifecycle
+}
+
+// NewModule for exports.
+// nolint:gocritic
+func NewModule(p ModuleParams) error {
+	repo := repository.New(p.DB)
+	svc := service.New(repo, p.CompanyClient, p.S3Client, p.Config, p.Logger)
+	eps := endpoints.New(svc)
+
+	http.RegisterTransport(p.HTTPServer, eps, p.AuthClient, p.APPTransport)
+
+	periodic.Start(p.Lifecycle, "policy exports", p.Config.Exports.Interval(), p.Logger, svc.RunExports)
+
+	return nil
+}
+
+var (
+	// ModuleHttpAPI for uber fx.
+	ModuleHttpAPI = fx.Options(fx.Invoke(NewModule))
+)
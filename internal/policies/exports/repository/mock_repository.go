@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports/entities"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimExport mocks base method.
+func (m *MockRepository) ClaimExport(ctx context.Context, now, staleBefore time.Time) (*entities.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimExport", ctx, now, staleBefore)
+	ret0, _ := ret[0].(*entities.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimExport indicates an expected call of ClaimExport.
+func (mr *MockRepositoryMockRecorder) ClaimExport(ctx, now, staleBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimExport", reflect.TypeOf((*MockRepository)(nil).ClaimExport), ctx, now, staleBefore)
+}
+
+// CreateExport mocks base method.
+func (m *MockRepository) CreateExport(ctx context.Context, export *entities.Export) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExport", ctx, export)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateExport indicates an expected call of CreateExport.
+func (mr *MockRepositoryMockRecorder) CreateExport(ctx, export interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExport", reflect.TypeOf((*MockRepository)(nil).CreateExport), ctx, export)
+}
+
+// FinishExport mocks base method.
+func (m *MockRepository) FinishExport(ctx context.Context, export *entities.Export) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishExport", ctx, export)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishExport indicates an expected call of FinishExport.
+func (mr *MockRepositoryMockRecorder) FinishExport(ctx, export interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishExport", reflect.TypeOf((*MockRepository)(nil).FinishExport), ctx, export)
+}
+
+// GetApprovedPolicies mocks base method.
+func (m *MockRepository) GetApprovedPolicies(ctx context.Context, companyUuid uuid.UUID, policyUuids []uuid.UUID) ([]*entities.ApprovedPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApprovedPolicies", ctx, companyUuid, policyUuids)
+	ret0, _ := ret[0].([]*entities.ApprovedPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApprovedPolicies indicates an expected call of GetApprovedPolicies.
+func (mr *MockRepositoryMockRecorder) GetApprovedPolicies(ctx, companyUuid, policyUuids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovedPolicies", reflect.TypeOf((*MockRepository)(nil).GetApprovedPolicies), ctx, companyUuid, policyUuids)
+}
+
+// GetExport mocks base method.
+func (m *MockRepository) GetExport(ctx context.Context, companyUuid, exportUuid uuid.UUID) (*entities.Export, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", ctx, companyUuid, exportUuid)
+	ret0, _ := ret[0].(*entities.Export)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockRepositoryMockRecorder) GetExport(ctx, companyUuid, exportUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockRepository)(nil).GetExport), ctx, companyUuid, exportUuid)
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports/entities"
+	"gorm.io/gorm"
+)
+
+// Repository for exports of approved policies.
+type Repository interface {
+	// GetApprovedPolicies of the company with the latest approved version of each, the chosen
+	// ones or all of them when policyUuids is nil. Inactive policies are left out.
+	GetApprovedPolicies(ctx context.Context, companyUuid uuid.UUID, policyUuids []uuid.UUID) ([]*entities.ApprovedPolicy, error)
+	CreateExport(ctx context.Context, export *entities.Export) error
+	GetExport(ctx context.Context, companyUuid, exportUuid uuid.UUID) (*entities.Export, error)
+	// ClaimExport marks the oldest pending export, or one left running since staleBefore, as
+	// running at now and returns it. It returns nil when there is none, claimed exports are
+	// skipped by other instances.
+	ClaimExport(ctx context.Context, now, staleBefore time.Time) (*entities.Export, error)
+	// FinishExport saves the status, file and error of the export.
+	FinishExport(ctx context.Context, export *entities.Export) error
+}
+
+// New repository for policy exports.
+func New(db *gorm.DB) Repository {
+	repo := &sqlRepository{gormDB: db}
+
+	return repo
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	policiesEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"gorm.io/gorm"
+)
+
+type sqlRepository struct {
+	gormDB *gorm.DB
+}
+
+func (s *sqlRepository) GetApprovedPolicies(ctx context.Context, companyUuid uuid.UUID, policyUuids []uuid.UUID) ([]*entities.ApprovedPolicy, error) {
+	policies := make([]*entities.ApprovedPolicy, 0)
+
+	// versions approved before transitions were stored only show in the status of the policy,
+	// approved by whoever last changed it
+	query := `
+		select distinct on (p.policy_uuid) p.policy_uuid, p.name, ph.policy_history_uuid, ph.version, ph.document,
+			coalesce(approval.created_at, p.status_updated_at, ph.created_at) as approved_at,
+			coalesce(u.first_name, '') as approver_first_name,
+			coalesce(u.last_name, '') as approver_last_name,
+			coalesce(u.email, '') as approver_email
+		from policies p
+			join policy_histories ph on ph.policy_uuid = p.policy_uuid
+			left join lateral (
+				select psh.created_at, psh.created_by
+				from policy_status_histories psh
+				where psh.policy_history_uuid = ph.policy_history_uuid and psh.to_status = @approved
+				order by psh.created_at desc
+				limit 1
+			) approval on true
+			left join users u on u.user_uuid = coalesce(approval.created_by, p.status_updated_by)
+		where p.company_uuid = @company_uuid
+			and p.status <> @inactive
+			and (approval.created_at is not null
+				or (p.status = @approved and ph.version = (select max(version) from policy_histories where policy_uuid = p.policy_uuid)))`
+
+	params := map[string]interface{}{
+		"company_uuid": companyUuid,
+		"approved":     policiesEntities.PolicyStatusApproved,
+		"inactive":     policiesEntities.PolicyStatusInactive,
+	}
+
+	if policyUuids != nil {
+		query += " and p.policy_uuid in @policy_uuids"
+		params["policy_uuids"] = policyUuids
+	}
+
+	query = `select * from (` + query + `
+		order by p.policy_uuid, ph.version desc
+	) approved order by name, policy_uuid`
+
+	if err := s.gormDB.WithContext(ctx).Raw(query, params).Scan(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+func (s *sqlRepository) CreateExport(ctx context.Context, export *entities.Export) error {
+	return s.gormDB.WithContext(ctx).Create(export).Error
+}
+
+func (s *sqlRepository) GetExport(ctx context.Context, companyUuid, exportUuid uuid.UUID) (*entities.Export, error) {
+	var export entities.Export
+
+	result := s.gormDB.WithContext(ctx).Model(&entities.Export{}).
+		Limit(1).
+		Find(&export, "export_uuid = ? AND company_uuid = ?", exportUuid, companyUuid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &appError.ErrNotFound{Message: "policy export not found"}
+	}
+
+	return &export, nil
+}
+
+func (s *sqlRepository) ClaimExport(ctx context.Context, now, staleBefore time.Time) (*entities.Export, error) {
+	exports := make([]*entities.Export, 0)
+
+	query := `
+		with due as (
+			select export_uuid
+			from policy_exports
+			where status = @pending or (status = @running and started_at <= @stale_before)
+			order by created_at
+			limit 1
+			for update skip locked
+		)
+		update policy_exports e set status = @running, started_at = @now
+		from due
+		where e.export_uuid = due.export_uuid
+		returning e.*`
+
+	err := s.gormDB.WithContext(ctx).Raw(query, map[string]interface{}{
+		"pending":      entities.StatusPending,
+		"running":      entities.StatusRunning,
+		"stale_before": staleBefore,
+		"now":          now,
+	}).Scan(&exports).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(exports) == 0 {
+		return nil, nil
+	}
+
+	return exports[0], nil
+}
+
+func (s *sqlRepository) FinishExport(ctx context.Context, export *entities.Export) error {
+	return s.gormDB.WithContext(ctx).Model(&entities.Export{}).
+		Where("export_uuid = ?", export.ExportUuid).
+		Updates(map[string]interface{}{
+			"status":       export.Status,
+			"policy_count": export.PolicyCount,
+			"file_key":     export.FileKey,
+			"file_name":    export.FileName,
+			"error":        export.Error,
+			"completed_at": export.CompletedAt,
+		}).Error
+}
@@ -0,0 +1,394 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	s3client "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/s3"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company"
+	companyEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company/entities"
+	policiesCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/converter"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+	"go.uber.org/zap"
+)
+
+const (
+	manifestFileName = "manifest.json"
+	zipContentType   = "application/zip"
+)
+
+var (
+	// coverTemplate is the first page of every exported document. Its rule is replaced by a page break.
+	coverTemplate = template.Must(template.New("policy-export-cover").Parse(`<h1>{{.name}}</h1><p><strong>{{.company}}</strong></p><p>Version {{.version}}</p><p>Approved by {{.approver}} on {{.date}}</p><hr/>`))
+
+	// pageBreaks of the formats, raw blocks of the pandoc document tree.
+	pageBreaks = map[string]json.RawMessage{
+		entities.FormatPdf:  json.RawMessage(`{"t":"RawBlock","c":["latex","\\newpage"]}`),
+		entities.FormatDocx: json.RawMessage(`{"t":"RawBlock","c":["openxml","<w:p><w:r><w:br w:type=\"page\"/></w:r></w:p>"]}`),
+	}
+
+	// unsafeFileName matches the characters left out of the names of exported files.
+	unsafeFileName = regexp.MustCompile(`[^\w\- ]+`)
+)
+
+type Service interface {
+	// CreateExport of the approved policies. Exports of a few policies are built right away,
+	// larger ones are left pending for RunExports.
+	CreateExport(ctx context.Context, req *entities.CreateExportRequest) (*entities.Export, error)
+	GetExport(ctx context.Context, req *entities.GetExportRequest) (*entities.Export, error)
+	DownloadExport(ctx context.Context, req *entities.GetExportRequest) (*entities.DownloadExportResponse, error)
+	// RunExports builds the pending exports one after the other.
+	RunExports(ctx context.Context, now time.Time) error
+}
+
+type service struct {
+	repo          repository.Repository
+	companyClient company.Client
+	s3client      s3client.Client
+	config        policiesCfg.ExportsConfig
+	logger        *zap.SugaredLogger
+	// convert documents, converter.Convert outside of tests
+	convert func(data []byte, from, to string) ([]byte, error)
+}
+
+func (s *service) CreateExport(ctx context.Context, req *entities.CreateExportRequest) (*entities.Export, error) {
+	format := req.Body.Format
+	if format == "" {
+		format = entities.FormatPdf
+	}
+
+	if format != entities.FormatPdf && format != entities.FormatDocx {
+		return nil, &appError.ErrValidation{Message: "format should be either pdf or docx"}
+	}
+
+	policyUuids := uniquePolicyUuids(req.Body.PolicyUuids)
+
+	policies, err := s.repo.GetApprovedPolicies(ctx, req.CompanyUuid, policyUuids)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(policies) == 0 {
+		return nil, &appError.ErrValidation{Message: "there are no approved policies to export"}
+	}
+
+	if policyUuids != nil && len(policies) != len(policyUuids) {
+		return nil, &appError.ErrValidation{Message: fmt.Sprintf("%d of the chosen policies are not approved", len(policyUuids)-len(policies))}
+	}
+
+	export := &entities.Export{
+		ExportUuid:  uuid.New(),
+		CompanyUuid: req.CompanyUuid,
+		RequestedBy: req.UserUuid,
+		Format:      format,
+		PolicyUuids: policyUuids,
+		Status:      entities.StatusPending,
+		PolicyCount: len(policies),
+		CreatedAt:   time.Now(),
+	}
+
+	if len(policies) <= s.config.SyncMax() {
+		export.Status = entities.StatusRunning
+		export.StartedAt = nullable.NewNullTime(export.CreatedAt)
+	}
+
+	if err = s.repo.CreateExport(ctx, export); err != nil {
+		return nil, err
+	}
+
+	if export.Status == entities.StatusRunning {
+		if err = s.finish(ctx, export, policies); err != nil {
+			return nil, err
+		}
+	}
+
+	return export, nil
+}
+
+// uniquePolicyUuids chosen for an export, nil for all the policies.
+func uniquePolicyUuids(policyUuids []uuid.UUID) entities.PolicyUuids {
+	if len(policyUuids) == 0 {
+		return nil
+	}
+
+	seen := make(map[uuid.UUID]bool, len(policyUuids))
+	unique := make(entities.PolicyUuids, 0, len(policyUuids))
+
+	for _, policyUuid := range policyUuids {
+		if !seen[policyUuid] {
+			seen[policyUuid] = true
+			unique = append(unique, policyUuid)
+		}
+	}
+
+	return unique
+}
+
+func (s *service) GetExport(ctx context.Context, req *entities.GetExportRequest) (*entities.Export, error) {
+	return s.repo.GetExport(ctx, req.CompanyUuid, req.ExportUuid)
+}
+
+func (s *service) DownloadExport(ctx context.Context, req *entities.GetExportRequest) (*entities.DownloadExportResponse, error) {
+	export, err := s.repo.GetExport(ctx, req.CompanyUuid, req.ExportUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if export.Status != entities.StatusCompleted {
+		return nil, &appError.ErrValidation{Message: fmt.Sprintf("policy export is %s", export.Status)}
+	}
+
+	content, err := s.s3client.DownloadFile(ctx, export.FileKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.DownloadExportResponse{
+		FileName:    export.FileName,
+		ContentType: zipContentType,
+		Content:     content,
+	}, nil
+}
+
+func (s *service) RunExports(ctx context.Context, now time.Time) error {
+	for {
+		export, err := s.repo.ClaimExport(ctx, now, now.Add(-s.config.Stale()))
+		if err != nil || export == nil {
+			return err
+		}
+
+		policies, err := s.repo.GetApprovedPolicies(ctx, export.CompanyUuid, export.PolicyUuids)
+		if err != nil {
+			return err
+		}
+
+		if err = s.finish(ctx, export, policies); err != nil {
+			s.logger.Errorf("failed to export policies %s: %v", export.ExportUuid, err)
+		}
+	}
+}
+
+// finish the running export, completed with the ZIP of the policies uploaded, or failed.
+func (s *service) finish(ctx context.Context, export *entities.Export, policies []*entities.ApprovedPolicy) error {
+	err := s.build(ctx, export, policies)
+	if err != nil {
+		export.Status = entities.StatusFailed
+		export.Error = err.Error()
+	} else {
+		export.Status = entities.StatusCompleted
+	}
+
+	export.CompletedAt = nullable.NewNullTime(time.Now())
+
+	if finishErr := s.repo.FinishExport(ctx, export); finishErr != nil {
+		return finishErr
+	}
+
+	return err
+}
+
+// build the ZIP of the policies with their manifest and upload it.
+func (s *service) build(ctx context.Context, export *entities.Export, policies []*entities.ApprovedPolicy) error {
+	if len(policies) == 0 {
+		return fmt.Errorf("there are no approved policies to export")
+	}
+
+	c, err := s.companyClient.FindByUUID(ctx, &companyEntities.GetCompanyByIdRequest{CompanyUuid: export.CompanyUuid})
+	if err != nil {
+		return err
+	}
+
+	manifest := &entities.Manifest{
+		ExportUuid:  export.ExportUuid,
+		CompanyUuid: export.CompanyUuid,
+		CompanyName: c.Name,
+		Format:      export.Format,
+		GeneratedAt: time.Now(),
+		Policies:    make([]*entities.ManifestPolicy, 0, len(policies)),
+	}
+
+	var buf bytes.Buffer
+
+	archive := zip.NewWriter(&buf)
+	names := make(map[string]bool, len(policies))
+
+	for _, p := range policies {
+		approver := approverName(p)
+
+		document, err := s.document(p, c.Name, approver, export.Format)
+		if err != nil {
+			return fmt.Errorf("%s: %w", p.Name, err)
+		}
+
+		name := fileName(p, export.Format, names)
+
+		w, err := archive.Create(name)
+		if err != nil {
+			return err
+		}
+
+		if _, err = w.Write(document); err != nil {
+			return err
+		}
+
+		manifest.Policies = append(manifest.Policies, &entities.ManifestPolicy{
+			PolicyUuid: p.PolicyUuid,
+			Name:       p.Name,
+			Version:    p.Version,
+			ApprovedBy: approver,
+			ApprovedAt: p.ApprovedAt,
+			File:       name,
+		})
+	}
+
+	w, err := archive.Create(manifestFileName)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err = encoder.Encode(manifest); err != nil {
+		return err
+	}
+
+	if err = archive.Close(); err != nil {
+		return err
+	}
+
+	export.PolicyCount = len(policies)
+	export.FileName = fmt.Sprintf("policies-%s.zip", manifest.GeneratedAt.Format("2006-01-02"))
+	export.FileKey = path.Join("policy-exports", export.CompanyUuid.String(), export.ExportUuid.String()+".zip")
+
+	return s.s3client.UploadFile(ctx, export.FileKey, export.FileName, buf.Bytes())
+}
+
+// document of the policy with its cover page converted to the format.
+func (s *service) document(p *entities.ApprovedPolicy, companyName, approver, format string) ([]byte, error) {
+	var html bytes.Buffer
+
+	err := coverTemplate.Execute(&html, map[string]interface{}{
+		"name":     p.Name,
+		"company":  companyName,
+		"version":  p.Version,
+		"approver": approver,
+		"date":     p.ApprovedAt.Format("January 2, 2006"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	html.WriteString(p.Document)
+
+	// the html reader of pandoc has no page breaks, they are set in the document tree
+	tree, err := s.convert(html.Bytes(), converter.HTML, converter.JSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if tree, err = breakAfterCover(tree, format); err != nil {
+		return nil, err
+	}
+
+	return s.convert(tree, converter.JSON, "."+format)
+}
+
+// breakAfterCover replaces the rule ending the cover page, the first one of the document tree,
+// with a page break of the format.
+func breakAfterCover(tree []byte, format string) ([]byte, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(tree, &doc); err != nil {
+		return nil, err
+	}
+
+	var blocks []json.RawMessage
+	if err := json.Unmarshal(doc["blocks"], &blocks); err != nil {
+		return nil, err
+	}
+
+	for i, b := range blocks {
+		var block struct {
+			T string `json:"t"`
+		}
+		if err := json.Unmarshal(b, &block); err != nil {
+			return nil, err
+		}
+
+		if block.T == "HorizontalRule" {
+			blocks[i] = pageBreaks[format]
+			break
+		}
+	}
+
+	var err error
+	if doc["blocks"], err = json.Marshal(blocks); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(doc)
+}
+
+// approverName of the policy, its email when the approver has no name.
+func approverName(p *entities.ApprovedPolicy) string {
+	name := strings.TrimSpace(p.ApproverFirstName + " " + p.ApproverLastName)
+	if name == "" {
+		name = p.ApproverEmail
+	}
+
+	if name == "" {
+		name = "unknown"
+	}
+
+	return name
+}
+
+// fileName of the policy in the ZIP, e.g. "Access Control Policy v3.pdf", unique among the
+// names taken.
+func fileName(p *entities.ApprovedPolicy, format string, taken map[string]bool) string {
+	base := strings.TrimSpace(unsafeFileName.ReplaceAllString(p.Name, ""))
+	if base == "" {
+		base = "Policy"
+	}
+
+	base = fmt.Sprintf("%s v%d", base, p.Version)
+	name := base + "." + format
+
+	for i := 2; taken[name]; i++ {
+		name = fmt.Sprintf("%s (%d).%s", base, i, format)
+	}
+
+	taken[name] = true
+
+	return name
+}
+
+func New(
+	repo repository.Repository,
+	companyClient company.Client,
+	s3client s3client.Client,
+	config policiesCfg.Config,
+	logger *zap.SugaredLogger,
+) Service {
+	return &service{
+		repo:          repo,
+		companyClient: companyClient,
+		s3client:      s3client,
+		config:        config.Exports,
+		logger:        logger,
+		convert:       converter.Convert,
+	}
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	s3client "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/s3"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company"
+	companyEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company/entities"
+	policiesCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/converter"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+)
+
+func TestCreateExport(t *testing.T) {
+	Convey("Given the approved policies of a company", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		companyClient := company.NewMockClient(ctrl)
+		s3 := s3client.NewMockClient(ctrl)
+		config := policiesCfg.Config{Exports: policiesCfg.ExportsConfig{SyncMaxPolicies: 2}}
+		svc := New(repo, companyClient, s3, config, zap.NewNop().Sugar()).(*service)
+
+		// html is "read" as raw blocks split by its rules, trees are "written" with the target format
+		svc.convert = func(data []byte, from, to string) ([]byte, error) {
+			if to != converter.JSON {
+				return append([]byte(to+":"), data...), nil
+			}
+
+			blocks := make([]interface{}, 0)
+			for i, html := range strings.Split(string(data), "<hr/>") {
+				if i > 0 {
+					blocks = append(blocks, map[string]string{"t": "HorizontalRule"})
+				}
+
+				blocks = append(blocks, map[string]interface{}{"t": "RawBlock", "c": []string{"html", html}})
+			}
+
+			return json.Marshal(map[string]interface{}{"pandoc-api-version": []int{1, 22, 2}, "meta": map[string]interface{}{}, "blocks": blocks})
+		}
+
+		ctx := context.Background()
+		companyUuid, userUuid := uuid.New(), uuid.New()
+		approvedAt := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+		access := &entities.ApprovedPolicy{
+			PolicyUuid: uuid.New(), Name: "Access Control", Version: 3, Document: "<p>Access</p>",
+			ApprovedAt: approvedAt, ApproverFirstName: "Jane", ApproverLastName: "Doe",
+		}
+		backup := &entities.ApprovedPolicy{
+			PolicyUuid: uuid.New(), Name: "Backup/Restore", Version: 1, Document: "<p>Backup</p>",
+			ApprovedAt: approvedAt, ApproverEmail: "ciso@customer",
+		}
+
+		export := func(body *entities.CreateExportRequestBody) (*entities.Export, error) {
+			return svc.CreateExport(ctx, &entities.CreateExportRequest{CompanyUuid: companyUuid, UserUuid: userUuid, Body: body})
+		}
+
+		Convey("A small export is built right away with a cover page per document and a manifest", func() {
+			var zipped []byte
+
+			repo.EXPECT().GetApprovedPolicies(ctx, companyUuid, nil).Return([]*entities.ApprovedPolicy{access, backup}, nil)
+			repo.EXPECT().CreateExport(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, e *entities.Export) error {
+				So(e.Status, ShouldEqual, entities.StatusRunning)
+				So(e.Format, ShouldEqual, entities.FormatPdf)
+
+				return nil
+			})
+			companyClient.EXPECT().FindByUUID(ctx, &companyEntities.GetCompanyByIdRequest{CompanyUuid: companyUuid}).
+				Return(&companyEntities.Company{Name: "Acme"}, nil)
+			s3.EXPECT().UploadFile(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key, _ string, data []byte) error {
+				So(key, ShouldStartWith, "policy-exports/"+companyUuid.String()+"/")
+				zipped = data
+
+				return nil
+			})
+			repo.EXPECT().FinishExport(ctx, gomock.Any()).Return(nil)
+
+			res, err := export(&entities.CreateExportRequestBody{})
+			So(err, ShouldBeNil)
+			So(res.Status, ShouldEqual, entities.StatusCompleted)
+			So(res.PolicyCount, ShouldEqual, 2)
+			So(res.FileName, ShouldEndWith, ".zip")
+
+			files := unzip(zipped)
+			So(files, ShouldContainKey, "Access Control v3.pdf")
+			So(files, ShouldContainKey, "BackupRestore v1.pdf")
+			So(files["Access Control v3.pdf"], ShouldStartWith, ".pdf:")
+			So(rawBlocks(files["Access Control v3.pdf"][len(".pdf:"):]), ShouldResemble, [][]string{
+				{"html", "<h1>Access Control</h1><p><strong>Acme</strong></p><p>Version 3</p><p>Approved by Jane Doe on March 1, 2023</p>"},
+				{"latex", `\newpage`},
+				{"html", "<p>Access</p>"},
+			})
+			So(rawBlocks(files["BackupRestore v1.pdf"][len(".pdf:"):])[0][1], ShouldContainSubstring, "Approved by ciso@customer")
+
+			var manifest entities.Manifest
+			So(json.Unmarshal([]byte(files[manifestFileName]), &manifest), ShouldBeNil)
+			So(manifest.CompanyName, ShouldEqual, "Acme")
+			So(manifest.Policies, ShouldHaveLength, 2)
+			So(manifest.Policies[0].File, ShouldEqual, "Access Control v3.pdf")
+			So(manifest.Policies[0].ApprovedBy, ShouldEqual, "Jane Doe")
+		})
+
+		Convey("A larger export is left pending for the worker", func() {
+			policyUuids := []uuid.UUID{access.PolicyUuid, backup.PolicyUuid, uuid.New()}
+			policies := []*entities.ApprovedPolicy{access, backup, {PolicyUuid: policyUuids[2], Name: "Risk", Version: 1}}
+
+			repo.EXPECT().GetApprovedPolicies(ctx, companyUuid, entities.PolicyUuids(policyUuids)).Return(policies, nil)
+			repo.EXPECT().CreateExport(ctx, gomock.Any()).Return(nil)
+
+			res, err := export(&entities.CreateExportRequestBody{Format: entities.FormatDocx, PolicyUuids: append(policyUuids, policyUuids[0])})
+			So(err, ShouldBeNil)
+			So(res.Status, ShouldEqual, entities.StatusPending)
+			So(res.Format, ShouldEqual, entities.FormatDocx)
+			So(res.PolicyCount, ShouldEqual, 3)
+		})
+
+		Convey("Chosen policies must all be approved", func() {
+			repo.EXPECT().GetApprovedPolicies(ctx, companyUuid, gomock.Any()).Return([]*entities.ApprovedPolicy{access}, nil)
+
+			_, err := export(&entities.CreateExportRequestBody{PolicyUuids: []uuid.UUID{access.PolicyUuid, uuid.New()}})
+
+			var validationErr *appError.ErrValidation
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+		})
+
+		Convey("The format must be pdf or docx", func() {
+			_, err := export(&entities.CreateExportRequestBody{Format: "odt"})
+
+			var validationErr *appError.ErrValidation
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+		})
+	})
+}
+
+func TestRunExports(t *testing.T) {
+	Convey("Exports which fail to build are marked failed", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		companyClient := company.NewMockClient(ctrl)
+		svc := New(repo, companyClient, s3client.NewMockClient(ctrl), policiesCfg.Config{}, zap.NewNop().Sugar()).(*service)
+		svc.convert = func(data []byte, from, to string) ([]byte, error) {
+			return nil, errors.New("pandoc failed")
+		}
+
+		ctx := context.Background()
+		now := time.Date(2023, 3, 31, 9, 0, 0, 0, time.UTC)
+		pending := &entities.Export{ExportUuid: uuid.New(), CompanyUuid: uuid.New(), Format: entities.FormatPdf, Status: entities.StatusRunning}
+
+		gomock.InOrder(
+			repo.EXPECT().ClaimExport(ctx, now, now.Add(-policiesCfg.DefaultExportTimeout)).Return(pending, nil),
+			repo.EXPECT().GetApprovedPolicies(ctx, pending.CompanyUuid, nil).Return([]*entities.ApprovedPolicy{{Name: "Access Control"}}, nil),
+			companyClient.EXPECT().FindByUUID(ctx, gomock.Any()).Return(&companyEntities.Company{Name: "Acme"}, nil),
+			repo.EXPECT().FinishExport(ctx, pending).Return(nil),
+			repo.EXPECT().ClaimExport(ctx, now, gomock.Any()).Return(nil, nil),
+		)
+
+		So(svc.RunExports(ctx, now), ShouldBeNil)
+		So(pending.Status, ShouldEqual, entities.StatusFailed)
+		So(pending.Error, ShouldEqual, "Access Control: pandoc failed")
+	})
+}
+
+func TestBreakAfterCover(t *testing.T) {
+	Convey("The rule ending the cover page becomes a page break of the format", t, func() {
+		tree := `{"pandoc-api-version":[1,22,2],"meta":{},"blocks":[{"t":"Para","c":[]},{"t":"HorizontalRule"},{"t":"HorizontalRule"}]}`
+
+		docx, err := breakAfterCover([]byte(tree), entities.FormatDocx)
+		So(err, ShouldBeNil)
+		So(rawBlocks(string(docx)), ShouldResemble, [][]string{{}, {"openxml", `<w:p><w:r><w:br w:type="page"/></w:r></w:p>`}, nil})
+	})
+}
+
+func TestFileName(t *testing.T) {
+	Convey("File names are safe and unique", t, func() {
+		taken := make(map[string]bool)
+		p := &entities.ApprovedPolicy{Name: "Access/Control: Remote", Version: 2}
+
+		So(fileName(p, entities.FormatDocx, taken), ShouldEqual, "AccessControl Remote v2.docx")
+		So(fileName(p, entities.FormatDocx, taken), ShouldEqual, "AccessControl Remote v2 (2).docx")
+		So(fileName(&entities.ApprovedPolicy{Name: "../", Version: 1}, entities.FormatPdf, taken), ShouldEqual, "Policy v1.pdf")
+	})
+}
+
+// rawBlocks of a pandoc document tree with their format.
+func rawBlocks(tree string) [][]string {
+	var doc struct {
+		Blocks []struct {
+			T string   `json:"t"`
+			C []string `json:"c"`
+		} `json:"blocks"`
+	}
+	_ = json.Unmarshal([]byte(tree), &doc)
+
+	blocks := make([][]string, 0, len(doc.Blocks))
+	for _, b := range doc.Blocks {
+		blocks = append(blocks, b.C)
+	}
+
+	return blocks
+}
+
+func unzip(data []byte) map[string]string {
+	files := make(map[string]string)
+
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	So(err, ShouldBeNil)
+
+	for _, f := range r.File {
+		rc, err := f.Open()
+		So(err, ShouldBeNil)
+
+		var content strings.Builder
+		_, err = io.Copy(&content, rc)
+		So(err, ShouldBeNil)
+
+		files[f.Name] = content.String()
+	}
+
+	return files
+}
@@ -0,0 +1,50 @@
+// Package http for policy exports.
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports/entities"
+	httpError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors/http"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+	"github.com/pkg/errors"
+)
+
+func decodeCreateExportRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	ids, err := httpTransport.DecodePathIDs(r, "company_id", "user_id")
+	if err != nil {
+		return nil, err
+	}
+
+	body := &entities.CreateExportRequestBody{}
+
+	// the body is optional, all the approved policies are exported as PDF
+	if r.ContentLength != 0 {
+		defer r.Body.Close()
+
+		if err = json.NewDecoder(r.Body).Decode(body); err != nil {
+			return nil, errors.WithMessage(httpError.ErrBadRequestBody, err.Error())
+		}
+	}
+
+	return &entities.CreateExportRequest{
+		CompanyUuid: ids[0],
+		UserUuid:    ids[1],
+		Body:        body,
+	}, nil
+}
+
+func decodeGetExportRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	ids, err := httpTransport.DecodePathIDs(r, "company_id", "user_id", "export_id")
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.GetExportRequest{
+		CompanyUuid: ids[0],
+		UserUuid:    ids[1],
+		ExportUuid:  ids[2],
+	}, nil
+}
@@ -0,0 +1,84 @@
+// Package http for policy exports.
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	goKitEndpoint "github.com/go-kit/kit/endpoint"
+	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports/endpoints"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/exports/entities"
+	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
+	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
+)
+
+// RegisterTransport for http.
+func RegisterTransport(
+	server *httpTransport.Server,
+	ep *endpoints.Endpoints,
+	authClient auth.Client,
+	svcTransportClient svcTransport.Client,
+) {
+	registerCreateExport(server, ep.CreateExportEndpoint, authClient, svcTransportClient)
+	registerGetExport(server, ep.GetExportEndpoint, authClient, svcTransportClient)
+	registerDownloadExport(server, ep.DownloadExportEndpoint, authClient, svcTransportClient)
+}
+
+func registerCreateExport(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policies/exports"
+	method := "POST"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionExport)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeCreateExportRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerGetExport(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policies/exports/{export_id}"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionExport)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetExportRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerDownloadExport(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policies/exports/{export_id}/download"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionExport)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encodeDownloadExportResponse, []string{method})
+	handler := getHandler(securedEp, decodeGetExportRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func encodeDownloadExportResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	file := response.(*entities.DownloadExportResponse) //nolint:errcheck
+
+	w.Header().Set("Content-Disposition", "attachment; filename="+strconv.Quote(file.FileName))
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(file.Content)))
+	_, err := w.Write(file.Content)
+
+	return err
+}
+
+func getHandler(ep goKitEndpoint.Endpoint, dec goKitHTTPTransport.DecodeRequestFunc, enc goKitHTTPTransport.EncodeResponseFunc, atc svcTransport.Client, method string) *goKitHTTPTransport.Server {
+	return goKitHTTPTransport.NewServer(
+		ep,
+		dec,
+		enc,
+		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
+		goKitHTTPTransport.ServerErrorHandler(atc.LogErrorHandler()),
+	)
+}
@@ -1,15 +1,24 @@
 package policies //nolint: predeclared
 
 import (
+	audit "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/address"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/onboarding"
+	policiesCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/config"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/endpoints"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/repository"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/service"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/transport/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/ses"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/userclient"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/cfg"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/periodic"
 	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
@@ -22,17 +31,29 @@ type ModuleParams struct {
 	APPTransport     svcTransport.Client
 	AuthClient       auth.Client
 	OnboardingClient onboarding.Client
+	CompanyClient    company.Client
+	AddressClient    address.Client
+	UserClient       userclient.Client
+	AuditRecorder    audit.Recorder
+	EmailClient      ses.Client
+	Config           policiesCfg.Config
+	CommonConfig     cfg.Config
+	Logger           *zap.SugaredLogger
+	Lifecycle        fx.Lifecycle
 }
 
 // NewModule for redesign.
 // nolint:gocritic
 func NewModule(p ModuleParams) error {
-	repo := repository.New(p.DB)
-	svc := service.New(repo, p.OnboardingClient)
+	repo := repository.New(p.DB, p.AuditRecorder)
+	svc := service.New(repo, p.OnboardingClient, p.AuthClient, p.CompanyClient, p.AddressClient, p.UserClient, p.EmailClient, p.Config, p.CommonConfig, p.Logger)
 	eps := endpoints.New(svc)
 
 	http.RegisterTransport(p.HTTPServer, eps, p.AuthClient, p.APPTransport)
 
+	periodic.Start(p.Lifecycle, "policy review reminders", p.Config.Reviews.Interval(), p.Logger, svc.SendReviewReminders)
+	periodic.Start(p.Lifecycle, "policy template updates", p.Config.Templates.Interval(), p.Logger, svc.SendTemplateUpdateNotifications)
+
 	return nil
 }
 
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"gorm.io/gorm"
+)
+
+// AcquireEditLock of the policy for the user until the timeout passes, or refreshes the lock the
+// user holds already. A live lock of another user is kept. It returns the lock of the policy,
+// whoever holds it.
+func (s *sqlRepository) AcquireEditLock(ctx context.Context, companyUuid, policyUuid, userUuid uuid.UUID, now time.Time, timeout time.Duration) (*entities.PolicyEditLock, error) {
+	var lock *entities.PolicyEditLock
+
+	err := s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := findCompanyPolicy(tx, companyUuid, policyUuid); err != nil {
+			return err
+		}
+
+		// a user refreshing a live lock keeps editing since the time it was acquired
+		query := `
+			insert into policy_edit_locks (policy_uuid, user_uuid, acquired_at, expires_at)
+			values (@policy, @user, @now, @expires)
+			on conflict (policy_uuid) do update
+			set user_uuid = excluded.user_uuid,
+				acquired_at = case when policy_edit_locks.user_uuid = excluded.user_uuid and policy_edit_locks.expires_at > excluded.acquired_at
+					then policy_edit_locks.acquired_at else excluded.acquired_at end,
+				expires_at = excluded.expires_at
+			where policy_edit_locks.user_uuid = excluded.user_uuid or policy_edit_locks.expires_at <= excluded.acquired_at`
+
+		err := tx.Exec(query, map[string]interface{}{
+			"policy":  policyUuid,
+			"user":    userUuid,
+			"now":     now,
+			"expires": now.Add(timeout),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		lock, err = getEditLock(tx, policyUuid, now)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return lock, nil
+}
+
+// GetEditLock returns the live lock of a policy of the company, nil when nobody is editing it.
+func (s *sqlRepository) GetEditLock(ctx context.Context, companyUuid, policyUuid uuid.UUID, now time.Time) (*entities.PolicyEditLock, error) {
+	tx := s.gormDB.WithContext(ctx)
+
+	if err := findCompanyPolicy(tx, companyUuid, policyUuid); err != nil {
+		return nil, err
+	}
+
+	return getEditLock(tx, policyUuid, now)
+}
+
+// ReleaseEditLock the user holds on a policy of the company. Locks of other users are kept.
+func (s *sqlRepository) ReleaseEditLock(ctx context.Context, companyUuid, policyUuid, userUuid uuid.UUID) error {
+	tx := s.gormDB.WithContext(ctx)
+
+	if err := findCompanyPolicy(tx, companyUuid, policyUuid); err != nil {
+		return err
+	}
+
+	return tx.Delete(&entities.PolicyEditLock{}, "policy_uuid = ? AND user_uuid = ?", policyUuid, userUuid).Error
+}
+
+func findCompanyPolicy(tx *gorm.DB, companyUuid, policyUuid uuid.UUID) error {
+	var count int64
+
+	err := tx.Model(&entities.Policy{}).
+		Where("policy_uuid = ? AND company_uuid = ?", policyUuid, companyUuid).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return &appError.ErrNotFound{Message: "policy not found"}
+	}
+
+	return nil
+}
+
+func getEditLock(tx *gorm.DB, policyUuid uuid.UUID, now time.Time) (*entities.PolicyEditLock, error) {
+	var lock entities.PolicyEditLock
+
+	result := tx.Model(&entities.PolicyEditLock{}).
+		Preload("User").
+		Limit(1).
+		Find(&lock, "policy_uuid = ? AND expires_at > ?", policyUuid, now)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &lock, nil
+}
@@ -0,0 +1,557 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repository.go
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	htmldiff "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/htmldiff"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AcquireEditLock mocks base method.
+func (m *MockRepository) AcquireEditLock(ctx context.Context, companyUuid, policyUuid, userUuid uuid.UUID, now time.Time, timeout time.Duration) (*entities.PolicyEditLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireEditLock", ctx, companyUuid, policyUuid, userUuid, now, timeout)
+	ret0, _ := ret[0].(*entities.PolicyEditLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireEditLock indicates an expected call of AcquireEditLock.
+func (mr *MockRepositoryMockRecorder) AcquireEditLock(ctx, companyUuid, policyUuid, userUuid, now, timeout interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireEditLock", reflect.TypeOf((*MockRepository)(nil).AcquireEditLock), ctx, companyUuid, policyUuid, userUuid, now, timeout)
+}
+
+// ClaimReviewReminders mocks base method.
+func (m *MockRepository) ClaimReviewReminders(ctx context.Context, now, dueSoonUntil, overdueRemindedBefore time.Time) ([]*entities.PolicyReviewReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimReviewReminders", ctx, now, dueSoonUntil, overdueRemindedBefore)
+	ret0, _ := ret[0].([]*entities.PolicyReviewReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimReviewReminders indicates an expected call of ClaimReviewReminders.
+func (mr *MockRepositoryMockRecorder) ClaimReviewReminders(ctx, now, dueSoonUntil, overdueRemindedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimReviewReminders", reflect.TypeOf((*MockRepository)(nil).ClaimReviewReminders), ctx, now, dueSoonUntil, overdueRemindedBefore)
+}
+
+// ClaimTemplateUpdateNotifications mocks base method.
+func (m *MockRepository) ClaimTemplateUpdateNotifications(ctx context.Context, now time.Time) ([]*entities.PolicyTemplateUpdateNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimTemplateUpdateNotifications", ctx, now)
+	ret0, _ := ret[0].([]*entities.PolicyTemplateUpdateNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimTemplateUpdateNotifications indicates an expected call of ClaimTemplateUpdateNotifications.
+func (mr *MockRepositoryMockRecorder) ClaimTemplateUpdateNotifications(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimTemplateUpdateNotifications", reflect.TypeOf((*MockRepository)(nil).ClaimTemplateUpdateNotifications), ctx, now)
+}
+
+// CreatePolicy mocks base method.
+func (m *MockRepository) CreatePolicy(ctx context.Context, policy *entities.Policy) (*entities.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePolicy", ctx, policy)
+	ret0, _ := ret[0].(*entities.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePolicy indicates an expected call of CreatePolicy.
+func (mr *MockRepositoryMockRecorder) CreatePolicy(ctx, policy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePolicy", reflect.TypeOf((*MockRepository)(nil).CreatePolicy), ctx, policy)
+}
+
+// CreateTemplate mocks base method.
+func (m *MockRepository) CreateTemplate(ctx context.Context, pt *entities.PolicyTemplates) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", ctx, pt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockRepositoryMockRecorder) CreateTemplate(ctx, pt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockRepository)(nil).CreateTemplate), ctx, pt)
+}
+
+// DeletePolicy mocks base method.
+func (m *MockRepository) DeletePolicy(ctx context.Context, companyUuid, userUuid, policyUuid *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePolicy", ctx, companyUuid, userUuid, policyUuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePolicy indicates an expected call of DeletePolicy.
+func (mr *MockRepositoryMockRecorder) DeletePolicy(ctx, companyUuid, userUuid, policyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePolicy", reflect.TypeOf((*MockRepository)(nil).DeletePolicy), ctx, companyUuid, userUuid, policyUuid)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockRepository) DeleteTemplate(ctx context.Context, policyTemplateUuid uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", ctx, policyTemplateUuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockRepositoryMockRecorder) DeleteTemplate(ctx, policyTemplateUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockRepository)(nil).DeleteTemplate), ctx, policyTemplateUuid)
+}
+
+// DismissTemplateUpdate mocks base method.
+func (m *MockRepository) DismissTemplateUpdate(ctx context.Context, companyUuid, policyUuid, userUuid uuid.UUID, now time.Time) (*entities.PolicyTemplateUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DismissTemplateUpdate", ctx, companyUuid, policyUuid, userUuid, now)
+	ret0, _ := ret[0].(*entities.PolicyTemplateUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DismissTemplateUpdate indicates an expected call of DismissTemplateUpdate.
+func (mr *MockRepositoryMockRecorder) DismissTemplateUpdate(ctx, companyUuid, policyUuid, userUuid, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DismissTemplateUpdate", reflect.TypeOf((*MockRepository)(nil).DismissTemplateUpdate), ctx, companyUuid, policyUuid, userUuid, now)
+}
+
+// GetAllPolicyHistory mocks base method.
+func (m *MockRepository) GetAllPolicyHistory(ctx context.Context, companyUuid *uuid.UUID, keyword, searchMode string, reviewDueBy *time.Time) ([]*entities.GetAllPoliciesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPolicyHistory", ctx, companyUuid, keyword, searchMode, reviewDueBy)
+	ret0, _ := ret[0].([]*entities.GetAllPoliciesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllPolicyHistory indicates an expected call of GetAllPolicyHistory.
+func (mr *MockRepositoryMockRecorder) GetAllPolicyHistory(ctx, companyUuid, keyword, searchMode, reviewDueBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPolicyHistory", reflect.TypeOf((*MockRepository)(nil).GetAllPolicyHistory), ctx, companyUuid, keyword, searchMode, reviewDueBy)
+}
+
+// GetDocument mocks base method.
+func (m *MockRepository) GetDocument(ctx context.Context, companyUuid, userUuid, policyUuid *uuid.UUID, version int) (*entities.PolicyHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDocument", ctx, companyUuid, userUuid, policyUuid, version)
+	ret0, _ := ret[0].(*entities.PolicyHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDocument indicates an expected call of GetDocument.
+func (mr *MockRepositoryMockRecorder) GetDocument(ctx, companyUuid, userUuid, policyUuid, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDocument", reflect.TypeOf((*MockRepository)(nil).GetDocument), ctx, companyUuid, userUuid, policyUuid, version)
+}
+
+// GetEditLock mocks base method.
+func (m *MockRepository) GetEditLock(ctx context.Context, companyUuid, policyUuid uuid.UUID, now time.Time) (*entities.PolicyEditLock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEditLock", ctx, companyUuid, policyUuid, now)
+	ret0, _ := ret[0].(*entities.PolicyEditLock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEditLock indicates an expected call of GetEditLock.
+func (mr *MockRepositoryMockRecorder) GetEditLock(ctx, companyUuid, policyUuid, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEditLock", reflect.TypeOf((*MockRepository)(nil).GetEditLock), ctx, companyUuid, policyUuid, now)
+}
+
+// GetPoliciesStats mocks base method.
+func (m *MockRepository) GetPoliciesStats(ctx context.Context, companyUuid *uuid.UUID) (*entities.GetPoliciesStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPoliciesStats", ctx, companyUuid)
+	ret0, _ := ret[0].(*entities.GetPoliciesStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPoliciesStats indicates an expected call of GetPoliciesStats.
+func (mr *MockRepositoryMockRecorder) GetPoliciesStats(ctx, companyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPoliciesStats", reflect.TypeOf((*MockRepository)(nil).GetPoliciesStats), ctx, companyUuid)
+}
+
+// GetPolicy mocks base method.
+func (m *MockRepository) GetPolicy(ctx context.Context, companyUuid, policyUuid *uuid.UUID) (*entities.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicy", ctx, companyUuid, policyUuid)
+	ret0, _ := ret[0].(*entities.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicy indicates an expected call of GetPolicy.
+func (mr *MockRepositoryMockRecorder) GetPolicy(ctx, companyUuid, policyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicy", reflect.TypeOf((*MockRepository)(nil).GetPolicy), ctx, companyUuid, policyUuid)
+}
+
+// GetPolicyDocument mocks base method.
+func (m *MockRepository) GetPolicyDocument(ctx context.Context, companyUuid, policyUuid *uuid.UUID, version int) (*entities.PolicyHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicyDocument", ctx, companyUuid, policyUuid, version)
+	ret0, _ := ret[0].(*entities.PolicyHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicyDocument indicates an expected call of GetPolicyDocument.
+func (mr *MockRepositoryMockRecorder) GetPolicyDocument(ctx, companyUuid, policyUuid, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyDocument", reflect.TypeOf((*MockRepository)(nil).GetPolicyDocument), ctx, companyUuid, policyUuid, version)
+}
+
+// GetPolicyHistoriesByPolicyUuid mocks base method.
+func (m *MockRepository) GetPolicyHistoriesByPolicyUuid(ctx context.Context, policyUuid *uuid.UUID) ([]*entities.PolicyHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicyHistoriesByPolicyUuid", ctx, policyUuid)
+	ret0, _ := ret[0].([]*entities.PolicyHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicyHistoriesByPolicyUuid indicates an expected call of GetPolicyHistoriesByPolicyUuid.
+func (mr *MockRepositoryMockRecorder) GetPolicyHistoriesByPolicyUuid(ctx, policyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyHistoriesByPolicyUuid", reflect.TypeOf((*MockRepository)(nil).GetPolicyHistoriesByPolicyUuid), ctx, policyUuid)
+}
+
+// GetPolicyReviewStats mocks base method.
+func (m *MockRepository) GetPolicyReviewStats(ctx context.Context, companyUuid *uuid.UUID, today, dueSoonUntil time.Time) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicyReviewStats", ctx, companyUuid, today, dueSoonUntil)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPolicyReviewStats indicates an expected call of GetPolicyReviewStats.
+func (mr *MockRepositoryMockRecorder) GetPolicyReviewStats(ctx, companyUuid, today, dueSoonUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyReviewStats", reflect.TypeOf((*MockRepository)(nil).GetPolicyReviewStats), ctx, companyUuid, today, dueSoonUntil)
+}
+
+// GetPolicyStatusHistory mocks base method.
+func (m *MockRepository) GetPolicyStatusHistory(ctx context.Context, companyUuid, policyUuid *uuid.UUID) ([]*entities.PolicyStatusHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicyStatusHistory", ctx, companyUuid, policyUuid)
+	ret0, _ := ret[0].([]*entities.PolicyStatusHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicyStatusHistory indicates an expected call of GetPolicyStatusHistory.
+func (mr *MockRepositoryMockRecorder) GetPolicyStatusHistory(ctx, companyUuid, policyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyStatusHistory", reflect.TypeOf((*MockRepository)(nil).GetPolicyStatusHistory), ctx, companyUuid, policyUuid)
+}
+
+// GetPolicyWorkflow mocks base method.
+func (m *MockRepository) GetPolicyWorkflow(ctx context.Context, companyUuid, policyUuid *uuid.UUID) (*entities.PolicyWorkflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPolicyWorkflow", ctx, companyUuid, policyUuid)
+	ret0, _ := ret[0].(*entities.PolicyWorkflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPolicyWorkflow indicates an expected call of GetPolicyWorkflow.
+func (mr *MockRepositoryMockRecorder) GetPolicyWorkflow(ctx, companyUuid, policyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPolicyWorkflow", reflect.TypeOf((*MockRepository)(nil).GetPolicyWorkflow), ctx, companyUuid, policyUuid)
+}
+
+// GetTemplate mocks base method.
+func (m *MockRepository) GetTemplate(ctx context.Context, policyTemplateUuid uuid.UUID) (*entities.PolicyTemplates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", ctx, policyTemplateUuid)
+	ret0, _ := ret[0].(*entities.PolicyTemplates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockRepositoryMockRecorder) GetTemplate(ctx, policyTemplateUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockRepository)(nil).GetTemplate), ctx, policyTemplateUuid)
+}
+
+// GetTemplateByUuid mocks base method.
+func (m *MockRepository) GetTemplateByUuid(ctx context.Context, policyTemplateUuid *uuid.UUID) (*entities.PolicyTemplates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateByUuid", ctx, policyTemplateUuid)
+	ret0, _ := ret[0].(*entities.PolicyTemplates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateByUuid indicates an expected call of GetTemplateByUuid.
+func (mr *MockRepositoryMockRecorder) GetTemplateByUuid(ctx, policyTemplateUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateByUuid", reflect.TypeOf((*MockRepository)(nil).GetTemplateByUuid), ctx, policyTemplateUuid)
+}
+
+// GetTemplateUpdate mocks base method.
+func (m *MockRepository) GetTemplateUpdate(ctx context.Context, companyUuid, policyUuid uuid.UUID) (*entities.PolicyTemplateUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateUpdate", ctx, companyUuid, policyUuid)
+	ret0, _ := ret[0].(*entities.PolicyTemplateUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateUpdate indicates an expected call of GetTemplateUpdate.
+func (mr *MockRepositoryMockRecorder) GetTemplateUpdate(ctx, companyUuid, policyUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateUpdate", reflect.TypeOf((*MockRepository)(nil).GetTemplateUpdate), ctx, companyUuid, policyUuid)
+}
+
+// GetTemplateVersion mocks base method.
+func (m *MockRepository) GetTemplateVersion(ctx context.Context, policyTemplateUuid uuid.UUID, version int) (*entities.PolicyTemplateVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateVersion", ctx, policyTemplateUuid, version)
+	ret0, _ := ret[0].(*entities.PolicyTemplateVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateVersion indicates an expected call of GetTemplateVersion.
+func (mr *MockRepositoryMockRecorder) GetTemplateVersion(ctx, policyTemplateUuid, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateVersion", reflect.TypeOf((*MockRepository)(nil).GetTemplateVersion), ctx, policyTemplateUuid, version)
+}
+
+// GetTemplateVersions mocks base method.
+func (m *MockRepository) GetTemplateVersions(ctx context.Context, policyTemplateUuid uuid.UUID) ([]*entities.PolicyTemplateVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateVersions", ctx, policyTemplateUuid)
+	ret0, _ := ret[0].([]*entities.PolicyTemplateVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateVersions indicates an expected call of GetTemplateVersions.
+func (mr *MockRepositoryMockRecorder) GetTemplateVersions(ctx, policyTemplateUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateVersions", reflect.TypeOf((*MockRepository)(nil).GetTemplateVersions), ctx, policyTemplateUuid)
+}
+
+// GetTemplates mocks base method.
+func (m *MockRepository) GetTemplates(ctx context.Context, industryTypes []string, companyType string) ([]*entities.PolicyTemplates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplates", ctx, industryTypes, companyType)
+	ret0, _ := ret[0].([]*entities.PolicyTemplates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplates indicates an expected call of GetTemplates.
+func (mr *MockRepositoryMockRecorder) GetTemplates(ctx, industryTypes, companyType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplates", reflect.TypeOf((*MockRepository)(nil).GetTemplates), ctx, industryTypes, companyType)
+}
+
+// ListTemplateLibrary mocks base method.
+func (m *MockRepository) ListTemplateLibrary(ctx context.Context) ([]*entities.PolicyTemplates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplateLibrary", ctx)
+	ret0, _ := ret[0].([]*entities.PolicyTemplates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplateLibrary indicates an expected call of ListTemplateLibrary.
+func (mr *MockRepositoryMockRecorder) ListTemplateLibrary(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplateLibrary", reflect.TypeOf((*MockRepository)(nil).ListTemplateLibrary), ctx)
+}
+
+// PublishTemplate mocks base method.
+func (m *MockRepository) PublishTemplate(ctx context.Context, policyTemplateUuid, userUuid uuid.UUID, comment string, now time.Time) (*entities.PolicyTemplateVersion, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishTemplate", ctx, policyTemplateUuid, userUuid, comment, now)
+	ret0, _ := ret[0].(*entities.PolicyTemplateVersion)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PublishTemplate indicates an expected call of PublishTemplate.
+func (mr *MockRepositoryMockRecorder) PublishTemplate(ctx, policyTemplateUuid, userUuid, comment, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishTemplate", reflect.TypeOf((*MockRepository)(nil).PublishTemplate), ctx, policyTemplateUuid, userUuid, comment, now)
+}
+
+// ReleaseEditLock mocks base method.
+func (m *MockRepository) ReleaseEditLock(ctx context.Context, companyUuid, policyUuid, userUuid uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseEditLock", ctx, companyUuid, policyUuid, userUuid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseEditLock indicates an expected call of ReleaseEditLock.
+func (mr *MockRepositoryMockRecorder) ReleaseEditLock(ctx, companyUuid, policyUuid, userUuid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseEditLock", reflect.TypeOf((*MockRepository)(nil).ReleaseEditLock), ctx, companyUuid, policyUuid, userUuid)
+}
+
+// ReleaseReviewReminder mocks base method.
+func (m *MockRepository) ReleaseReviewReminder(ctx context.Context, reminder *entities.PolicyReviewReminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseReviewReminder", ctx, reminder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseReviewReminder indicates an expected call of ReleaseReviewReminder.
+func (mr *MockRepositoryMockRecorder) ReleaseReviewReminder(ctx, reminder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReviewReminder", reflect.TypeOf((*MockRepository)(nil).ReleaseReviewReminder), ctx, reminder)
+}
+
+// ReleaseTemplateUpdateNotification mocks base method.
+func (m *MockRepository) ReleaseTemplateUpdateNotification(ctx context.Context, notification *entities.PolicyTemplateUpdateNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseTemplateUpdateNotification", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseTemplateUpdateNotification indicates an expected call of ReleaseTemplateUpdateNotification.
+func (mr *MockRepositoryMockRecorder) ReleaseTemplateUpdateNotification(ctx, notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseTemplateUpdateNotification", reflect.TypeOf((*MockRepository)(nil).ReleaseTemplateUpdateNotification), ctx, notification)
+}
+
+// SaveDocument mocks base method.
+func (m *MockRepository) SaveDocument(ctx context.Context, req *entities.SaveDocumentRequest) (*entities.PolicyHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDocument", ctx, req)
+	ret0, _ := ret[0].(*entities.PolicyHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveDocument indicates an expected call of SaveDocument.
+func (mr *MockRepositoryMockRecorder) SaveDocument(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDocument", reflect.TypeOf((*MockRepository)(nil).SaveDocument), ctx, req)
+}
+
+// SetPolicyReviewers mocks base method.
+func (m *MockRepository) SetPolicyReviewers(ctx context.Context, companyUuid, policyUuid *uuid.UUID, reviewers []*entities.PolicyReviewer, approvalRule string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPolicyReviewers", ctx, companyUuid, policyUuid, reviewers, approvalRule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPolicyReviewers indicates an expected call of SetPolicyReviewers.
+func (mr *MockRepositoryMockRecorder) SetPolicyReviewers(ctx, companyUuid, policyUuid, reviewers, approvalRule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPolicyReviewers", reflect.TypeOf((*MockRepository)(nil).SetPolicyReviewers), ctx, companyUuid, policyUuid, reviewers, approvalRule)
+}
+
+// UpdatePolicyHistoryChanges mocks base method.
+func (m *MockRepository) UpdatePolicyHistoryChanges(ctx context.Context, policyHistoryUuid uuid.UUID, changes *htmldiff.Summary) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePolicyHistoryChanges", ctx, policyHistoryUuid, changes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePolicyHistoryChanges indicates an expected call of UpdatePolicyHistoryChanges.
+func (mr *MockRepositoryMockRecorder) UpdatePolicyHistoryChanges(ctx, policyHistoryUuid, changes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePolicyHistoryChanges", reflect.TypeOf((*MockRepository)(nil).UpdatePolicyHistoryChanges), ctx, policyHistoryUuid, changes)
+}
+
+// UpdatePolicyReviewSchedule mocks base method.
+func (m *MockRepository) UpdatePolicyReviewSchedule(ctx context.Context, companyUuid, policyUuid, ownerUuid *uuid.UUID, intervalMonths int, nextReviewAt *time.Time) (*entities.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePolicyReviewSchedule", ctx, companyUuid, policyUuid, ownerUuid, intervalMonths, nextReviewAt)
+	ret0, _ := ret[0].(*entities.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePolicyReviewSchedule indicates an expected call of UpdatePolicyReviewSchedule.
+func (mr *MockRepositoryMockRecorder) UpdatePolicyReviewSchedule(ctx, companyUuid, policyUuid, ownerUuid, intervalMonths, nextReviewAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePolicyReviewSchedule", reflect.TypeOf((*MockRepository)(nil).UpdatePolicyReviewSchedule), ctx, companyUuid, policyUuid, ownerUuid, intervalMonths, nextReviewAt)
+}
+
+// UpdatePolicyWorkflow mocks base method.
+func (m *MockRepository) UpdatePolicyWorkflow(ctx context.Context, companyUuid, policyUuid *uuid.UUID, decide func(*entities.PolicyWorkflow) (*entities.PolicyWorkflowChange, error)) (*entities.Policy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePolicyWorkflow", ctx, companyUuid, policyUuid, decide)
+	ret0, _ := ret[0].(*entities.Policy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePolicyWorkflow indicates an expected call of UpdatePolicyWorkflow.
+func (mr *MockRepositoryMockRecorder) UpdatePolicyWorkflow(ctx, companyUuid, policyUuid, decide interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePolicyWorkflow", reflect.TypeOf((*MockRepository)(nil).UpdatePolicyWorkflow), ctx, companyUuid, policyUuid, decide)
+}
+
+// UpdateTemplate mocks base method.
+func (m *MockRepository) UpdateTemplate(ctx context.Context, pt *entities.PolicyTemplates) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplate", ctx, pt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate.
+func (mr *MockRepositoryMockRecorder) UpdateTemplate(ctx, pt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockRepository)(nil).UpdateTemplate), ctx, pt)
+}
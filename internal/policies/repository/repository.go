@@ -2,30 +2,57 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	audit "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/htmldiff"
 	"gorm.io/gorm"
 )
 
 // Repository for websites.
 type Repository interface {
-	GetAllPolicyHistory(ctx context.Context, companyUuid *uuid.UUID, keyword string) ([]*entities.GetAllPoliciesResponse, error)
+	GetAllPolicyHistory(ctx context.Context, companyUuid *uuid.UUID, keyword, searchMode string, reviewDueBy *time.Time) ([]*entities.GetAllPoliciesResponse, error)
 	CreatePolicy(ctx context.Context, policy *entities.Policy) (*entities.Policy, error)
 	GetPolicyDocument(ctx context.Context, companyUuid, policyUuid *uuid.UUID, version int) (*entities.PolicyHistory, error)
 	SaveDocument(ctx context.Context, req *entities.SaveDocumentRequest) (*entities.PolicyHistory, error)
 	GetDocument(ctx context.Context, companyUuid, userUuid, policyUuid *uuid.UUID, version int) (*entities.PolicyHistory, error)
 	GetPolicyHistoriesByPolicyUuid(ctx context.Context, policyUuid *uuid.UUID) ([]*entities.PolicyHistory, error)
+	UpdatePolicyHistoryChanges(ctx context.Context, policyHistoryUuid uuid.UUID, changes *htmldiff.Summary) error
 	DeletePolicy(ctx context.Context, companyUuid, userUuid, policyUuid *uuid.UUID) error
-	UpdatePolicyStatus(ctx context.Context, companyUuid, userUuid, policyUuid *uuid.UUID, req *entities.UpdatePolicyDocumentStatusPatchRequestBody) error
+	GetPolicyWorkflow(ctx context.Context, companyUuid, policyUuid *uuid.UUID) (*entities.PolicyWorkflow, error)
+	UpdatePolicyWorkflow(ctx context.Context, companyUuid, policyUuid *uuid.UUID, decide func(*entities.PolicyWorkflow) (*entities.PolicyWorkflowChange, error)) (*entities.Policy, error)
+	SetPolicyReviewers(ctx context.Context, companyUuid, policyUuid *uuid.UUID, reviewers []*entities.PolicyReviewer, approvalRule string) error
+	GetPolicyStatusHistory(ctx context.Context, companyUuid, policyUuid *uuid.UUID) ([]*entities.PolicyStatusHistory, error)
 	GetPoliciesStats(ctx context.Context, companyUuid *uuid.UUID) (*entities.GetPoliciesStatsResponse, error)
-	GetTemplates(ctx context.Context, companyTypes []string) ([]*entities.PolicyTemplates, error)
+	GetPolicy(ctx context.Context, companyUuid, policyUuid *uuid.UUID) (*entities.Policy, error)
+	UpdatePolicyReviewSchedule(ctx context.Context, companyUuid, policyUuid, ownerUuid *uuid.UUID, intervalMonths int, nextReviewAt *time.Time) (*entities.Policy, error)
+	GetPolicyReviewStats(ctx context.Context, companyUuid *uuid.UUID, today, dueSoonUntil time.Time) (dueSoon, overdue int, err error)
+	ClaimReviewReminders(ctx context.Context, now, dueSoonUntil, overdueRemindedBefore time.Time) ([]*entities.PolicyReviewReminder, error)
+	ReleaseReviewReminder(ctx context.Context, reminder *entities.PolicyReviewReminder) error
+	GetTemplates(ctx context.Context, industryTypes []string, companyType string) ([]*entities.PolicyTemplates, error)
 	GetTemplateByUuid(ctx context.Context, policyTemplateUuid *uuid.UUID) (*entities.PolicyTemplates, error)
+	ListTemplateLibrary(ctx context.Context) ([]*entities.PolicyTemplates, error)
+	GetTemplate(ctx context.Context, policyTemplateUuid uuid.UUID) (*entities.PolicyTemplates, error)
+	CreateTemplate(ctx context.Context, pt *entities.PolicyTemplates) error
+	UpdateTemplate(ctx context.Context, pt *entities.PolicyTemplates) error
+	DeleteTemplate(ctx context.Context, policyTemplateUuid uuid.UUID) error
+	PublishTemplate(ctx context.Context, policyTemplateUuid, userUuid uuid.UUID, comment string, now time.Time) (*entities.PolicyTemplateVersion, int, error)
+	GetTemplateVersions(ctx context.Context, policyTemplateUuid uuid.UUID) ([]*entities.PolicyTemplateVersion, error)
+	GetTemplateVersion(ctx context.Context, policyTemplateUuid uuid.UUID, version int) (*entities.PolicyTemplateVersion, error)
+	GetTemplateUpdate(ctx context.Context, companyUuid, policyUuid uuid.UUID) (*entities.PolicyTemplateUpdate, error)
+	DismissTemplateUpdate(ctx context.Context, companyUuid, policyUuid, userUuid uuid.UUID, now time.Time) (*entities.PolicyTemplateUpdate, error)
+	ClaimTemplateUpdateNotifications(ctx context.Context, now time.Time) ([]*entities.PolicyTemplateUpdateNotification, error)
+	ReleaseTemplateUpdateNotification(ctx context.Context, notification *entities.PolicyTemplateUpdateNotification) error
+	AcquireEditLock(ctx context.Context, companyUuid, policyUuid, userUuid uuid.UUID, now time.Time, timeout time.Duration) (*entities.PolicyEditLock, error)
+	GetEditLock(ctx context.Context, companyUuid, policyUuid uuid.UUID, now time.Time) (*entities.PolicyEditLock, error)
+	ReleaseEditLock(ctx context.Context, companyUuid, policyUuid, userUuid uuid.UUID) error
 }
 
 // New repository for tech_info_applications.
-func New(db *gorm.DB) Repository {
-	repo := &sqlRepository{db}
+func New(db *gorm.DB, auditRecorder audit.Recorder) Repository {
+	repo := &sqlRepository{db, auditRecorder}
 
 	return repo
 }
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	auditEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (s *sqlRepository) GetPolicy(ctx context.Context, companyUuid, policyUuid *uuid.UUID) (*entities.Policy, error) {
+	var policy entities.Policy
+
+	result := s.gormDB.WithContext(ctx).Model(&entities.Policy{}).
+		Preload("ReviewOwner").
+		Limit(1).
+		Find(&policy, "policy_uuid = ? AND company_uuid = ?", policyUuid, companyUuid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &appError.ErrNotFound{Message: "policy not found"}
+	}
+
+	return &policy, nil
+}
+
+// UpdatePolicyReviewSchedule of the policy. Without nextReviewAt the next review is due an
+// interval after the last one, or once the policy is approved. Reminders start over.
+func (s *sqlRepository) UpdatePolicyReviewSchedule(
+	ctx context.Context,
+	companyUuid, policyUuid, ownerUuid *uuid.UUID,
+	intervalMonths int,
+	nextReviewAt *time.Time,
+) (*entities.Policy, error) {
+	var before entities.Policy
+
+	err := s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.Policy{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Limit(1).
+			Find(&before, "policy_uuid = ? AND company_uuid = ?", policyUuid, companyUuid)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return &appError.ErrNotFound{Message: "policy not found"}
+		}
+
+		err := CheckCompanyUsers(tx, companyUuid, []uuid.UUID{*ownerUuid}, "owner should be an active user of the company")
+		if err != nil {
+			return err
+		}
+
+		var next interface{} = gorm.Expr("(last_reviewed_at::date + ? * interval '1 month')::date", intervalMonths)
+		if nextReviewAt != nil {
+			next = nextReviewAt.Format(entities.DateLayout)
+		}
+
+		return tx.Model(&entities.Policy{}).Where("policy_uuid = ?", policyUuid).Updates(
+			map[string]interface{}{
+				"review_interval_months":     intervalMonths,
+				"review_owner_uuid":          ownerUuid,
+				"next_review_at":             next,
+				"review_due_reminder_at":     nil,
+				"review_overdue_reminder_at": nil,
+			},
+		).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := s.GetPolicy(ctx, companyUuid, policyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit.Record(ctx, auditEntities.NewChange("policy", after.PolicyUuid.String(), after.CompanyUuid, &before, after))
+
+	return after, nil
+}
+
+// GetPolicyReviewStats counts the active policies of the company whose review is due by
+// dueSoonUntil, and the ones whose review was due before today.
+func (s *sqlRepository) GetPolicyReviewStats(ctx context.Context, companyUuid *uuid.UUID, today, dueSoonUntil time.Time) (dueSoon, overdue int, err error) {
+	var counts struct {
+		DueSoon int
+		Overdue int
+	}
+
+	err = s.gormDB.WithContext(ctx).Model(&entities.Policy{}).
+		Select(
+			"count(*) filter (where next_review_at >= ?) as due_soon, count(*) filter (where next_review_at < ?) as overdue",
+			today.Format(entities.DateLayout), today.Format(entities.DateLayout),
+		).
+		Where("company_uuid = ? AND status <> ? AND next_review_at <= ?", companyUuid, entities.PolicyStatusInactive, dueSoonUntil.Format(entities.DateLayout)).
+		Scan(&counts).Error
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return counts.DueSoon, counts.Overdue, nil
+}
+
+// ClaimReviewReminders marks the reminders to send now and returns them. Owners are reminded
+// once when the review of a policy is due by dueSoonUntil, then every time the last overdue
+// reminder is older than overdueRemindedBefore. Claimed rows are skipped by other instances.
+func (s *sqlRepository) ClaimReviewReminders(ctx context.Context, now, dueSoonUntil, overdueRemindedBefore time.Time) ([]*entities.PolicyReviewReminder, error) {
+	var reminders []*entities.PolicyReviewReminder
+
+	query := `
+		with due as (
+			select p.policy_uuid, p.next_review_at < @today as overdue
+			from policies p
+			where p.next_review_at is not null
+				and p.status <> @inactive
+				and p.review_owner_uuid is not null
+				and (
+					(p.next_review_at >= @today and p.next_review_at <= @due_soon_until and p.review_due_reminder_at is null)
+					or (p.next_review_at < @today and (p.review_overdue_reminder_at is null or p.review_overdue_reminder_at <= @overdue_reminded_before))
+				)
+			for update of p skip locked
+		)
+		update policies p set
+			review_due_reminder_at = case when due.overdue then p.review_due_reminder_at else @now end,
+			review_overdue_reminder_at = case when due.overdue then @now else p.review_overdue_reminder_at end
+		from due, users u
+		where p.policy_uuid = due.policy_uuid and u.user_uuid = p.review_owner_uuid
+		returning p.policy_uuid, p.company_uuid, p.name, p.next_review_at, due.overdue, u.email, u.first_name`
+
+	err := s.gormDB.WithContext(ctx).Raw(query, map[string]interface{}{
+		"today":                   now.UTC().Format(entities.DateLayout),
+		"now":                     now,
+		"inactive":                entities.PolicyStatusInactive,
+		"due_soon_until":          dueSoonUntil.Format(entities.DateLayout),
+		"overdue_reminded_before": overdueRemindedBefore,
+	}).Scan(&reminders).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+// ReleaseReviewReminder that could not be sent, so it is claimed again on the next check.
+func (s *sqlRepository) ReleaseReviewReminder(ctx context.Context, reminder *entities.PolicyReviewReminder) error {
+	column := "review_due_reminder_at"
+	if reminder.Overdue {
+		column = "review_overdue_reminder_at"
+	}
+
+	return s.gormDB.WithContext(ctx).Model(&entities.Policy{}).
+		Where("policy_uuid = ?", reminder.PolicyUuid).
+		Update(column, nil).Error
+}
@@ -4,29 +4,47 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"html"
 	"log"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	auditEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/entities"
+	audit "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/service"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/db"
 	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/htmldiff"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type sqlRepository struct {
 	gormDB *gorm.DB
+	audit  audit.Recorder
 }
 
+// findPolicyByUuid maps a missing policy to ErrNotFound.
+func (s *sqlRepository) findPolicyByUuid(ctx context.Context, policyUuid *uuid.UUID) (*entities.Policy, error) {
+	policy, err := s.getPolicyByUuid(ctx, policyUuid)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, &appError.ErrNotFound{Message: "policy not found"}
+	}
+
+	return policy, err
+}
+
+// GetTemplateByUuid returns the latest published version of the template.
 func (s *sqlRepository) GetTemplateByUuid(ctx context.Context, policyTemplateUuid *uuid.UUID) (*entities.PolicyTemplates, error) {
 	var pt *entities.PolicyTemplates
 
-	result := s.gormDB.WithContext(ctx).Model(&entities.PolicyTemplates{}).
-		Select("policy_template_uuid", "name", "description", "document").
-		Find(&pt, "policy_template_uuid = ?", policyTemplateUuid)
+	result := publishedTemplates(s.gormDB.WithContext(ctx)).
+		Select("t.policy_template_uuid", "v.name", "v.description", "v.document", "t.version").
+		Find(&pt, "t.policy_template_uuid = ?", policyTemplateUuid)
 
 	if result.Error != nil {
 		return nil, result.Error
@@ -39,16 +57,22 @@ func (s *sqlRepository) GetTemplateByUuid(ctx context.Context, policyTemplateUui
 	return pt, nil
 }
 
-func (s *sqlRepository) GetTemplates(ctx context.Context, companyTypes []string) ([]*entities.PolicyTemplates, error) {
+// GetTemplates returns the published templates targeting one of the industry types and the
+// company type. Templates without targeting are returned for all of them.
+func (s *sqlRepository) GetTemplates(ctx context.Context, industryTypes []string, companyType string) ([]*entities.PolicyTemplates, error) {
 	var pts []*entities.PolicyTemplates
 
-	tx := s.gormDB.WithContext(ctx).Model(&entities.PolicyTemplates{}).
-		Select("policy_template_uuid", "name", "description").
-		Order("created_at desc").
-		Order("name")
+	tx := publishedTemplates(s.gormDB.WithContext(ctx)).
+		Select("t.policy_template_uuid", "v.name", "v.description", "t.version").
+		Order("t.created_at desc").
+		Order("v.name")
+
+	if len(industryTypes) > 0 {
+		tx = tx.Where("(coalesce(cardinality(t.industry_type), 0) = 0 OR t.industry_type && ?::industry_type[])", pq.StringArray(industryTypes))
+	}
 
-	for _, ct := range companyTypes {
-		tx.Or("? = any(industry_type)", ct)
+	if companyType != "" {
+		tx = tx.Where("(coalesce(cardinality(t.company_types), 0) = 0 OR ?::company_type = any(t.company_types))", companyType)
 	}
 
 	err := tx.Find(&pts).Error
@@ -67,6 +91,12 @@ func (s *sqlRepository) GetTemplates(ctx context.Context, companyTypes []string)
 	return pts, nil
 }
 
+// publishedTemplates joins the templates which were published to their latest version.
+func publishedTemplates(tx *gorm.DB) *gorm.DB {
+	return tx.Table("policy_templates t").
+		Joins("join policy_template_versions v on v.policy_template_uuid = t.policy_template_uuid and v.version = t.version")
+}
+
 func (s *sqlRepository) GetPolicyHistoriesByPolicyUuid(ctx context.Context, policyUuid *uuid.UUID) ([]*entities.PolicyHistory, error) {
 	var phs []*entities.PolicyHistory
 
@@ -81,13 +111,18 @@ func (s *sqlRepository) GetPolicyHistoriesByPolicyUuid(ctx context.Context, poli
 	return phs, nil
 }
 
-func (s *sqlRepository) SaveDocument(ctx context.Context, req *entities.SaveDocumentRequest) (*entities.PolicyHistory, error) {
-	policy := &entities.Policy{
-		PolicyUuid: req.PolicyUUID,
-		Name:       req.SaveDocumentRequestBody.Name,
-	}
+func (s *sqlRepository) UpdatePolicyHistoryChanges(ctx context.Context, policyHistoryUuid uuid.UUID, changes *htmldiff.Summary) error {
+	return s.gormDB.WithContext(ctx).Model(&entities.PolicyHistory{}).
+		Where("policy_history_uuid = ?", policyHistoryUuid).
+		Update("changes", changes).Error
+}
 
-	err := s.updatePolicyByUuid(ctx, &req.UserUuid, &req.PolicyUUID, policy)
+// SaveDocument stores the document as the next version of the policy. The policy is locked while
+// saving, so a save based on an older version than the latest one is reported as a conflict
+// instead of overwriting the versions saved in between.
+func (s *sqlRepository) SaveDocument(ctx context.Context, req *entities.SaveDocumentRequest) (*entities.PolicyHistory, error) {
+	// the text of the latest document is indexed for content search
+	documentText, err := htmldiff.Text(req.SaveDocumentRequestBody.Document)
 	if err != nil {
 		return nil, err
 	}
@@ -100,38 +135,98 @@ func (s *sqlRepository) SaveDocument(ctx context.Context, req *entities.SaveDocu
 		CreatedBy:         req.UserUuid,
 	}
 
-	result := s.gormDB.WithContext(ctx).Create(ph)
-	if result.Error != nil {
-		err := result.Error
-		if db.IsAlreadyExistError(err) {
-			return nil, errors.WithMessage(err, "policy document already exists")
+	err = s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before entities.Policy
+
+		result := tx.Model(&entities.Policy{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Limit(1).
+			Find(&before, "policy_uuid = ? AND company_uuid = ?", req.PolicyUUID, req.CompanyUuid)
+		if result.Error != nil {
+			return result.Error
 		}
 
-		if db.IsForeignKeyViolationError(err) {
-			if strings.Contains(err.Error(), "fk_policies") {
-				return nil, errors.WithMessage(err, "policy not found")
-			}
+		if result.RowsAffected == 0 {
+			return &appError.ErrNotFound{Message: "policy not found"}
+		}
 
-			if strings.Contains(err.Error(), "fk_created_by_users") {
-				return nil, errors.WithMessage(err, "user not found")
-			}
+		var previous entities.PolicyHistory
+
+		result = tx.Model(&entities.PolicyHistory{}).
+			Where("policy_uuid = ?", req.PolicyUUID).
+			Order("version desc").
+			Limit(1).
+			Find(&previous)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		latest := previous.Version
+		base := *req.SaveDocumentRequestBody.BaseVersion
+		if base < 0 || base > latest {
+			return &appError.ErrValidation{Message: fmt.Sprintf("policy has no version %d", base)}
+		}
 
-			if strings.Contains(err.Error(), "fk_updated_by_users") {
-				return nil, errors.WithMessage(err, "user not found")
+		if base < latest {
+			return &appError.ErrConflict{Message: fmt.Sprintf("policy was saved as version %d since version %d", latest, base)}
+		}
+
+		// the version history reports the changes of each version without diffing on every list
+		if result.RowsAffected > 0 {
+			d, err := htmldiff.Compare(previous.Document, ph.Document)
+			if err != nil {
+				return err
 			}
 
-			return nil, errors.WithMessage(err, "violates foreign key constraint")
+			ph.Changes = &d.Summary
 		}
 
-		return nil, err
-	}
+		err := updatePolicyByUuid(tx, &req.UserUuid, &req.PolicyUUID, &entities.Policy{Name: req.SaveDocumentRequestBody.Name}, documentText)
+		if err != nil {
+			return err
+		}
+
+		if err = tx.Create(ph).Error; err != nil {
+			return policyHistoryError(err)
+		}
 
-	ph, err = s.getPolicyHistoryByUuid(ctx, &ph.PolicyHistoryUuid)
+		// a new version goes back to Draft and has to be submitted for review again
+		if before.Status != entities.PolicyStatusDraft {
+			return createDraftTransition(tx, before.Status, ph)
+		}
+
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return ph, nil
+	return s.getPolicyHistoryByUuid(ctx, &ph.PolicyHistoryUuid)
+}
+
+// policyHistoryError describes the constraint a new version of a policy violates.
+func policyHistoryError(err error) error {
+	if db.IsAlreadyExistError(err) {
+		return errors.WithMessage(err, "policy document already exists")
+	}
+
+	if db.IsForeignKeyViolationError(err) {
+		if strings.Contains(err.Error(), "fk_policies") {
+			return errors.WithMessage(err, "policy not found")
+		}
+
+		if strings.Contains(err.Error(), "fk_created_by_users") {
+			return errors.WithMessage(err, "user not found")
+		}
+
+		if strings.Contains(err.Error(), "fk_updated_by_users") {
+			return errors.WithMessage(err, "user not found")
+		}
+
+		return errors.WithMessage(err, "violates foreign key constraint")
+	}
+
+	return err
 }
 
 func (s *sqlRepository) GetPolicyDocument(ctx context.Context, companyUuid, policyUuid *uuid.UUID, version int) (*entities.PolicyHistory, error) {
@@ -195,7 +290,10 @@ func (s *sqlRepository) CreatePolicy(ctx context.Context, policy *entities.Polic
 	return s.getPolicyByUuid(ctx, &policy.PolicyUuid)
 }
 
-func (s *sqlRepository) GetAllPolicyHistory(ctx context.Context, companyUuid *uuid.UUID, keyword string) ([]*entities.GetAllPoliciesResponse, error) {
+// GetAllPolicyHistory lists the policies of the company with their latest version. Policies found
+// by content are ranked by relevance, with a snippet of their document around the matches. With
+// reviewDueBy, only the active policies whose review is due by then are listed.
+func (s *sqlRepository) GetAllPolicyHistory(ctx context.Context, companyUuid *uuid.UUID, keyword, searchMode string, reviewDueBy *time.Time) ([]*entities.GetAllPoliciesResponse, error) {
 	var rows *sql.Rows
 	var err error
 
@@ -214,7 +312,15 @@ func (s *sqlRepository) GetAllPolicyHistory(ctx context.Context, companyUuid *uu
 			su_user_uuid,
 			su_first_name,
 			su_last_name,
-			su_email
+			su_email,
+			p_review_interval_months,
+			p_last_reviewed_at,
+			p_next_review_at,
+			ro_user_uuid,
+			ro_first_name,
+			ro_last_name,
+			ro_email,
+			%[1]s
 		from (
 				select RANK() OVER (
 						PARTITION BY ph.policy_uuid
@@ -234,25 +340,59 @@ func (s *sqlRepository) GetAllPolicyHistory(ctx context.Context, companyUuid *uu
 					su.user_uuid as su_user_uuid,
 					su.first_name as su_first_name,
 					su.last_name as su_last_name,
-					su.email as su_email
+					su.email as su_email,
+					p.review_interval_months as p_review_interval_months,
+					p.last_reviewed_at as p_last_reviewed_at,
+					p.next_review_at as p_next_review_at,
+					ro.user_uuid as ro_user_uuid,
+					ro.first_name as ro_first_name,
+					ro.last_name as ro_last_name,
+					ro.email as ro_email,
+					p.document_text as p_document_text,
+					p.document_tsv as p_document_tsv
 				from policies p
 					left join policy_histories ph on ph.policy_uuid = p.policy_uuid
 					left join users phu on phu.user_uuid = ph.created_by
 					left join users su on su.user_uuid = p.status_updated_by
-				where p.company_uuid = ?
+					left join users ro on ro.user_uuid = p.review_owner_uuid
+				where p.company_uuid = @company_uuid
 				order by p.created_at desc
-			) sq
+			) sq%[2]s
 		where sq.r_rank = 1`
 
-	if keyword != "" {
+	params := map[string]interface{}{"company_uuid": companyUuid}
+
+	searchColumns, searchFrom, order := "null::float8 as search_rank, null::text as search_snippet", "", ""
+	if searchMode == entities.SearchModeContent {
+		searchColumns = "ts_rank(sq.p_document_tsv, q.query) as search_rank, " +
+			"ts_headline('english', coalesce(sq.p_document_text, ''), q.query, @headline_options) as search_snippet"
+		searchFrom = ", websearch_to_tsquery('english', @keyword) q(query)"
+		order = " order by search_rank desc, p_created_at desc"
+		params["keyword"] = keyword
+		params["headline_options"] = headlineOptions
+	}
+
+	policyHistoryQuery = fmt.Sprintf(policyHistoryQuery, searchColumns, searchFrom)
+
+	if searchMode == entities.SearchModeContent {
+		policyHistoryQuery += " and sq.p_document_tsv @@ q.query"
+	}
+
+	if reviewDueBy != nil {
+		policyHistoryQuery += " and p_status <> @inactive and p_next_review_at <= @review_due_by"
+		params["inactive"] = entities.PolicyStatusInactive
+		params["review_due_by"] = reviewDueBy.Format(entities.DateLayout)
+	}
+
+	if keyword != "" && searchMode != entities.SearchModeContent {
 		filter_search := fmt.Sprintf(
 			"%s and (p_name ilike '%%%[2]v%%' or p_status::text ilike '%%%[2]v%%' or ph_version::text ilike '%%%[2]v%%' or phu_first_name ilike '%%%[2]v%%' or phu_last_name ilike '%%%[2]v%%' or phu_email ilike '%%%[2]v%%' or to_char(ph_last_draft_date, 'mm/dd/yy') ilike '%%%[2]v%%')",
 			policyHistoryQuery, keyword,
 		)
 		log.Println(filter_search)
-		rows, err = s.gormDB.WithContext(ctx).Raw(filter_search, companyUuid).Rows()
+		rows, err = s.gormDB.WithContext(ctx).Raw(filter_search+order, params).Rows()
 	} else {
-		rows, err = s.gormDB.WithContext(ctx).Raw(policyHistoryQuery, companyUuid).Rows()
+		rows, err = s.gormDB.WithContext(ctx).Raw(policyHistoryQuery+order, params).Rows()
 	}
 
 	if err != nil {
@@ -266,7 +406,16 @@ func (s *sqlRepository) GetAllPolicyHistory(ctx context.Context, companyUuid *uu
 		pol := &entities.GetAllPoliciesResponse{
 			Owner:           &entities.UserInfo{},
 			StatusUpdatedBy: &entities.UserInfo{},
+			PolicyReviewSchedule: &entities.PolicyReviewSchedule{
+				ReviewOwner: &entities.UserInfo{},
+			},
 		}
+
+		var lastReviewedAt, nextReviewAt sql.NullTime
+		var reviewOwnerUuid nullable.NullUUID
+		var rank sql.NullFloat64
+		var snippet sql.NullString
+
 		err = rows.Scan(
 			&pol.PolicyUUID,
 			&pol.Name,
@@ -283,68 +432,62 @@ func (s *sqlRepository) GetAllPolicyHistory(ctx context.Context, companyUuid *uu
 			&pol.StatusUpdatedBy.FirstName,
 			&pol.StatusUpdatedBy.LastName,
 			&pol.StatusUpdatedBy.Email,
+			&pol.ReviewIntervalMonths,
+			&lastReviewedAt,
+			&nextReviewAt,
+			&reviewOwnerUuid,
+			&pol.ReviewOwner.FirstName,
+			&pol.ReviewOwner.LastName,
+			&pol.ReviewOwner.Email,
+			&rank,
+			&snippet,
 		)
 		if err != nil {
 			return nil, err
 		}
 
-		response = append(response, pol)
-	}
-
-	return response, nil
-}
-
-func (s *sqlRepository) UpdatePolicyStatus(ctx context.Context, companyUuid, userUuid, policyUuid *uuid.UUID, req *entities.UpdatePolicyDocumentStatusPatchRequestBody) error {
-	now := time.Now()
-
-	result := s.gormDB.WithContext(ctx).Model(&entities.Policy{}).Where("policy_uuid = ?", policyUuid).Updates(
-		map[string]interface{}{
-			"updated_by":        userUuid,
-			"updated_at":        nullable.NewNullTime(now),
-			"status_updated_by": userUuid,
-			"status_updated_at": nullable.NewNullTime(now),
-			"status":            req.Status,
-		},
-	)
+		if rank.Valid {
+			pol.Rank = &rank.Float64
+		}
 
-	if result.Error != nil {
-		err := result.Error
+		if snippet.Valid {
+			marked := markSnippet(snippet.String)
+			pol.Snippet = &marked
+		}
 
-		if db.IsForeignKeyViolationError(err) {
-			return errors.WithMessage(err, "violates foreign key constraint")
+		if lastReviewedAt.Valid {
+			pol.LastReviewedAt = &lastReviewedAt.Time
 		}
 
-		if db.IsInvalidValueError(err) {
-			if strings.Contains(err.Error(), "invalid input value for enum policies_status") {
-				return errors.WithMessage(err, "invalid input value status")
-			}
+		if nextReviewAt.Valid {
+			next := nextReviewAt.Time.Format(entities.DateLayout)
+			pol.NextReviewDate = &next
+		}
 
-			return errors.WithMessage(err, "invalid value")
+		if reviewOwnerUuid.Valid {
+			pol.ReviewOwner.UserUUID = reviewOwnerUuid.UUID
+		} else {
+			pol.ReviewOwner = nil
 		}
 
-		return err
+		response = append(response, pol)
 	}
 
-	if result.RowsAffected == 0 {
-		return &appError.ErrNotFound{Message: "policy not found"}
-	}
+	return response, nil
+}
 
-	if req.Comment != "" && req.Status == "Rejected" {
-		policyHistorySubQuery := s.gormDB.Select("policy_history_uuid").Model(&entities.PolicyHistory{}).Where("policy_uuid = ?", policyUuid).Order("version desc").Limit(1)
+// Matches in snippets are delimited with control characters, which are not in the document text,
+// so the text can be escaped before the matches are marked.
+const (
+	snippetStart = "\x01"
+	snippetStop  = "\x02"
+)
 
-		result = s.gormDB.WithContext(ctx).Model(&entities.PolicyHistory{}).Where("policy_history_uuid = (?)", policyHistorySubQuery).Updates(
-			map[string]interface{}{
-				"updated_by": userUuid,
-				"updated_at": nullable.NewNullTime(time.Now()),
-				"comment":    req.Comment,
-			},
-		)
-		if result.Error != nil {
-			return result.Error
-		}
-	}
+var headlineOptions = fmt.Sprintf(`StartSel=%s, StopSel=%s, MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=" ... "`, snippetStart, snippetStop)
 
-	return nil
+// markSnippet escapes the text of the snippet and marks its matches with mark elements.
+func markSnippet(snippet string) string {
+	return strings.NewReplacer(snippetStart, "<mark>", snippetStop, "</mark>").Replace(html.EscapeString(snippet))
 }
 
 func (s *sqlRepository) getPolicyByUuid(ctx context.Context, policyUuid *uuid.UUID) (*entities.Policy, error) {
@@ -359,15 +502,16 @@ func (s *sqlRepository) getPolicyByUuid(ctx context.Context, policyUuid *uuid.UU
 	return &policy, nil
 }
 
-func (s *sqlRepository) updatePolicyByUuid(ctx context.Context, userUUID, policyUuid *uuid.UUID, policy *entities.Policy) error {
-	result := s.gormDB.WithContext(ctx).Model(&entities.Policy{}).
+func updatePolicyByUuid(tx *gorm.DB, userUUID, policyUuid *uuid.UUID, policy *entities.Policy, documentText string) error {
+	result := tx.Model(&entities.Policy{}).
 		Where("policy_uuid = ?", policyUuid).
 		Updates(
 			map[string]interface{}{
-				"updated_by": userUUID,
-				"updated_at": nullable.NewNullTime(time.Now()),
-				"name":       policy.Name,
-				"status":     "Draft",
+				"updated_by":    userUUID,
+				"updated_at":    nullable.NewNullTime(time.Now()),
+				"name":          policy.Name,
+				"status":        entities.PolicyStatusDraft,
+				"document_text": documentText,
 			})
 	if result.Error != nil {
 		return result.Error
@@ -388,6 +532,10 @@ func (s *sqlRepository) getPolicyHistoryByUuid(ctx context.Context, policyHistor
 }
 
 func (s *sqlRepository) DeletePolicy(ctx context.Context, companyUuid, userUuid, policyUuid *uuid.UUID) error {
+	before, err := s.findPolicyByUuid(ctx, policyUuid)
+	if err != nil {
+		return err
+	}
 
 	result := s.gormDB.WithContext(ctx).Delete(&entities.Policy{}, "policy_uuid = ?", policyUuid)
 	if result.Error != nil {
@@ -398,6 +546,8 @@ func (s *sqlRepository) DeletePolicy(ctx context.Context, companyUuid, userUuid,
 		return &appError.ErrNotFound{Message: "policy not found"}
 	}
 
+	s.audit.Record(ctx, auditEntities.NewChange("policy", before.PolicyUuid.String(), before.CompanyUuid, before, nil))
+
 	return nil
 }
 
@@ -0,0 +1,322 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/db"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListTemplateLibrary returns the working copy of every template, without the documents.
+func (s *sqlRepository) ListTemplateLibrary(ctx context.Context) ([]*entities.PolicyTemplates, error) {
+	pts := make([]*entities.PolicyTemplates, 0)
+
+	err := s.gormDB.WithContext(ctx).Model(&entities.PolicyTemplates{}).
+		Omit("document").
+		Order("name").
+		Find(&pts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return pts, nil
+}
+
+// GetTemplate returns the working copy of the template.
+func (s *sqlRepository) GetTemplate(ctx context.Context, policyTemplateUuid uuid.UUID) (*entities.PolicyTemplates, error) {
+	var pt entities.PolicyTemplates
+
+	result := s.gormDB.WithContext(ctx).Model(&entities.PolicyTemplates{}).
+		Limit(1).
+		Find(&pt, "policy_template_uuid = ?", policyTemplateUuid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &appError.ErrNotFound{Message: "policy template not found"}
+	}
+
+	return &pt, nil
+}
+
+func (s *sqlRepository) CreateTemplate(ctx context.Context, pt *entities.PolicyTemplates) error {
+	return templateError(s.gormDB.WithContext(ctx).Create(pt).Error)
+}
+
+// UpdateTemplate changes the working copy of the template, which is a draft until published again.
+func (s *sqlRepository) UpdateTemplate(ctx context.Context, pt *entities.PolicyTemplates) error {
+	result := s.gormDB.WithContext(ctx).Model(&entities.PolicyTemplates{}).
+		Where("policy_template_uuid = ?", pt.PolicyTemplateUuid).
+		Updates(map[string]interface{}{
+			"name":          pt.Name,
+			"description":   pt.Description,
+			"document":      pt.Document,
+			"industry_type": pt.IndustryType,
+			"company_types": pt.CompanyTypes,
+			"status":        entities.TemplateStatusDraft,
+			"updated_at":    pt.UpdatedAt,
+			"updated_by":    pt.UpdatedBy,
+		})
+	if result.Error != nil {
+		return templateError(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return &appError.ErrNotFound{Message: "policy template not found"}
+	}
+
+	return nil
+}
+
+// DeleteTemplate with its versions. Templates policies were created from cannot be deleted.
+func (s *sqlRepository) DeleteTemplate(ctx context.Context, policyTemplateUuid uuid.UUID) error {
+	result := s.gormDB.WithContext(ctx).Delete(&entities.PolicyTemplates{}, "policy_template_uuid = ?", policyTemplateUuid)
+	if result.Error != nil {
+		if db.IsForeignKeyViolationError(result.Error) {
+			return &appError.ErrValidation{Message: "policy template is used by policies"}
+		}
+
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return &appError.ErrNotFound{Message: "policy template not found"}
+	}
+
+	return nil
+}
+
+// templateError reports unknown industry or company types as a validation error.
+func templateError(err error) error {
+	if err != nil && db.IsInvalidValueError(err) {
+		if strings.Contains(err.Error(), "enum industry_type") {
+			return &appError.ErrValidation{Message: "invalid industry_type"}
+		}
+
+		if strings.Contains(err.Error(), "enum company_type") {
+			return &appError.ErrValidation{Message: "invalid company_types"}
+		}
+	}
+
+	return err
+}
+
+// PublishTemplate saves the working copy of the template as its next version and records a
+// pending update for every active policy created from an older version. It returns the new
+// version and the number of policies to update.
+func (s *sqlRepository) PublishTemplate(ctx context.Context, policyTemplateUuid, userUuid uuid.UUID, comment string, now time.Time) (*entities.PolicyTemplateVersion, int, error) {
+	var (
+		version *entities.PolicyTemplateVersion
+		updated int
+	)
+
+	err := s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var pt entities.PolicyTemplates
+
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Limit(1).
+			Find(&pt, "policy_template_uuid = ?", policyTemplateUuid)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return &appError.ErrNotFound{Message: "policy template not found"}
+		}
+
+		if pt.Status == entities.TemplateStatusPublished {
+			return &appError.ErrValidation{Message: "policy template has no unpublished changes"}
+		}
+
+		version = &entities.PolicyTemplateVersion{
+			PolicyTemplateUuid: policyTemplateUuid,
+			Version:            pt.Version + 1,
+			Name:               pt.Name,
+			Description:        pt.Description,
+			Document:           pt.Document,
+			Comment:            comment,
+			CreatedAt:          now,
+			CreatedBy:          userUuid,
+		}
+
+		if err := tx.Create(version).Error; err != nil {
+			return err
+		}
+
+		err := tx.Model(&entities.PolicyTemplates{}).
+			Where("policy_template_uuid = ?", policyTemplateUuid).
+			Updates(map[string]interface{}{"status": entities.TemplateStatusPublished, "version": version.Version}).Error
+		if err != nil {
+			return err
+		}
+
+		query := `
+			insert into policy_template_updates (policy_template_update_uuid, policy_uuid, policy_template_uuid, from_version, to_version, created_at)
+			select gen_random_uuid(), p.policy_uuid, p.policy_template_uuid, p.policy_template_version, @version, @now
+			from policies p
+			where p.policy_template_uuid = @template
+				and p.status <> @inactive
+				and coalesce(p.policy_template_version, 0) < @version
+			on conflict (policy_uuid) where dismissed_at is null
+			do update set to_version = excluded.to_version, created_at = excluded.created_at, notified_at = null`
+
+		result = tx.Exec(query, map[string]interface{}{
+			"template": policyTemplateUuid,
+			"version":  version.Version,
+			"now":      now,
+			"inactive": entities.PolicyStatusInactive,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		updated = int(result.RowsAffected)
+
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return version, updated, nil
+}
+
+// GetTemplateVersions returns the published versions of the template, latest first and without
+// the documents.
+func (s *sqlRepository) GetTemplateVersions(ctx context.Context, policyTemplateUuid uuid.UUID) ([]*entities.PolicyTemplateVersion, error) {
+	versions := make([]*entities.PolicyTemplateVersion, 0)
+
+	err := s.gormDB.WithContext(ctx).Model(&entities.PolicyTemplateVersion{}).
+		Omit("document").
+		Order("version desc").
+		Find(&versions, "policy_template_uuid = ?", policyTemplateUuid).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+func (s *sqlRepository) GetTemplateVersion(ctx context.Context, policyTemplateUuid uuid.UUID, version int) (*entities.PolicyTemplateVersion, error) {
+	var v entities.PolicyTemplateVersion
+
+	result := s.gormDB.WithContext(ctx).Model(&entities.PolicyTemplateVersion{}).
+		Limit(1).
+		Find(&v, "policy_template_uuid = ? AND version = ?", policyTemplateUuid, version)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &appError.ErrNotFound{Message: "policy template version not found"}
+	}
+
+	return &v, nil
+}
+
+// GetTemplateUpdate returns the pending update of a policy of the company.
+func (s *sqlRepository) GetTemplateUpdate(ctx context.Context, companyUuid, policyUuid uuid.UUID) (*entities.PolicyTemplateUpdate, error) {
+	return getTemplateUpdate(s.gormDB.WithContext(ctx), companyUuid, policyUuid)
+}
+
+func getTemplateUpdate(tx *gorm.DB, companyUuid, policyUuid uuid.UUID) (*entities.PolicyTemplateUpdate, error) {
+	var update entities.PolicyTemplateUpdate
+
+	result := tx.Table("policy_template_updates u").
+		Select("u.*").
+		Joins("join policies p on p.policy_uuid = u.policy_uuid").
+		Where("u.policy_uuid = ? AND p.company_uuid = ? AND u.dismissed_at IS NULL", policyUuid, companyUuid).
+		Limit(1).
+		Find(&update)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &appError.ErrNotFound{Message: "policy template update not found"}
+	}
+
+	return &update, nil
+}
+
+// DismissTemplateUpdate marks the pending update of the policy as handled, so the policy is
+// up to date with the version of the update.
+func (s *sqlRepository) DismissTemplateUpdate(ctx context.Context, companyUuid, policyUuid, userUuid uuid.UUID, now time.Time) (*entities.PolicyTemplateUpdate, error) {
+	var update *entities.PolicyTemplateUpdate
+
+	err := s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+
+		update, err = getTemplateUpdate(tx, companyUuid, policyUuid)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&entities.PolicyTemplateUpdate{}).
+			Where("policy_template_update_uuid = ? AND dismissed_at IS NULL", update.PolicyTemplateUpdateUuid).
+			Updates(map[string]interface{}{"dismissed_at": now, "dismissed_by": userUuid})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return &appError.ErrNotFound{Message: "policy template update not found"}
+		}
+
+		return tx.Model(&entities.Policy{}).
+			Where("policy_uuid = ?", policyUuid).
+			Update("policy_template_version", update.ToVersion).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	update.DismissedAt.Time, update.DismissedAt.Valid = now, true
+	update.DismissedBy.UUID, update.DismissedBy.Valid = userUuid, true
+
+	return update, nil
+}
+
+// ClaimTemplateUpdateNotifications marks the pending updates whose owners were not notified yet
+// and returns them. The owner of a policy is its review owner, or its creator when the policy
+// has none. Claimed rows are skipped by other instances.
+func (s *sqlRepository) ClaimTemplateUpdateNotifications(ctx context.Context, now time.Time) ([]*entities.PolicyTemplateUpdateNotification, error) {
+	var notifications []*entities.PolicyTemplateUpdateNotification
+
+	query := `
+		with pending as (
+			select u.policy_template_update_uuid
+			from policy_template_updates u
+			where u.notified_at is null and u.dismissed_at is null
+			for update of u skip locked
+		)
+		update policy_template_updates u set notified_at = @now
+		from pending, policies p, policy_templates t, users o
+		where u.policy_template_update_uuid = pending.policy_template_update_uuid
+			and p.policy_uuid = u.policy_uuid
+			and t.policy_template_uuid = u.policy_template_uuid
+			and o.user_uuid = coalesce(p.review_owner_uuid, p.created_by)
+		returning u.policy_template_update_uuid, u.policy_uuid, p.name as policy_name, t.name as template_name,
+			u.policy_template_uuid, u.from_version, u.to_version, o.email, o.first_name`
+
+	err := s.gormDB.WithContext(ctx).Raw(query, map[string]interface{}{"now": now}).Scan(&notifications).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// ReleaseTemplateUpdateNotification that could not be sent, so it is claimed again on the next check.
+func (s *sqlRepository) ReleaseTemplateUpdateNotification(ctx context.Context, notification *entities.PolicyTemplateUpdateNotification) error {
+	return s.gormDB.WithContext(ctx).Model(&entities.PolicyTemplateUpdate{}).
+		Where("policy_template_update_uuid = ?", notification.PolicyTemplateUpdateUuid).
+		Update("notified_at", nil).Error
+}
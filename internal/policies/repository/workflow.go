@@ -0,0 +1,293 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	auditEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/audit/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	userEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/db"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (s *sqlRepository) GetPolicyWorkflow(ctx context.Context, companyUuid, policyUuid *uuid.UUID) (*entities.PolicyWorkflow, error) {
+	var policy entities.Policy
+
+	result := s.gormDB.WithContext(ctx).Model(&entities.Policy{}).
+		Limit(1).
+		Find(&policy, "policy_uuid = ? AND company_uuid = ?", policyUuid, companyUuid)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return nil, &appError.ErrNotFound{Message: "policy not found"}
+	}
+
+	return loadPolicyWorkflow(s.gormDB.WithContext(ctx), &policy)
+}
+
+// UpdatePolicyWorkflow locks the policy, asks decide for the change to apply to its workflow
+// and stores it in the same transaction, so concurrent reviews are decided one at a time.
+func (s *sqlRepository) UpdatePolicyWorkflow(
+	ctx context.Context,
+	companyUuid, policyUuid *uuid.UUID,
+	decide func(*entities.PolicyWorkflow) (*entities.PolicyWorkflowChange, error),
+) (*entities.Policy, error) {
+	var before, after *entities.Policy
+	var change *entities.PolicyWorkflowChange
+
+	err := s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var policy entities.Policy
+
+		result := tx.Model(&entities.Policy{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Limit(1).
+			Find(&policy, "policy_uuid = ? AND company_uuid = ?", policyUuid, companyUuid)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return &appError.ErrNotFound{Message: "policy not found"}
+		}
+
+		before = &policy
+
+		workflow, err := loadPolicyWorkflow(tx, &policy)
+		if err != nil {
+			return err
+		}
+
+		change, err = decide(workflow)
+		if err != nil {
+			return err
+		}
+
+		if change.Review != nil {
+			if err = tx.Create(change.Review).Error; err != nil {
+				if db.IsAlreadyExistError(err) {
+					return &appError.ErrValidation{Message: "policy already reviewed"}
+				}
+
+				return err
+			}
+		}
+
+		if change.Transition != nil {
+			if err = applyTransition(tx, change.Transition); err != nil {
+				return err
+			}
+		}
+
+		after = &entities.Policy{}
+
+		return tx.Model(&entities.Policy{}).First(after, "policy_uuid = ?", policyUuid).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if change.Review != nil {
+		s.audit.Record(ctx, &auditEntities.Change{
+			EntityType:  "policy_review",
+			EntityID:    change.Review.PolicyStatusHistoryUuid.String(),
+			CompanyUuid: after.CompanyUuid,
+			Action:      auditEntities.ActionCreate,
+			After:       change.Review,
+		})
+	}
+
+	if change.Transition != nil {
+		s.audit.Record(ctx, auditEntities.NewChange("policy", after.PolicyUuid.String(), after.CompanyUuid, before, after))
+	}
+
+	return after, nil
+}
+
+// applyTransition updates the status of the policy and stores the transition. The comment of
+// a rejection is kept on the rejected version as well.
+func applyTransition(tx *gorm.DB, transition *entities.PolicyStatusHistory) error {
+	updates := map[string]interface{}{
+		"updated_by":        transition.CreatedBy,
+		"updated_at":        nullable.NewNullTime(transition.CreatedAt),
+		"status_updated_by": transition.CreatedBy,
+		"status_updated_at": nullable.NewNullTime(transition.CreatedAt),
+		"status":            transition.ToStatus,
+	}
+
+	// an approval is a review, the next one is due an interval later
+	if transition.ToStatus == entities.PolicyStatusApproved {
+		updates["last_reviewed_at"] = transition.CreatedAt
+		updates["next_review_at"] = gorm.Expr("(?::date + review_interval_months * interval '1 month')::date", transition.CreatedAt)
+		updates["review_due_reminder_at"] = nil
+		updates["review_overdue_reminder_at"] = nil
+	}
+
+	err := tx.Model(&entities.Policy{}).Where("policy_uuid = ?", transition.PolicyUuid).Updates(updates).Error
+	if err != nil {
+		if db.IsInvalidValueError(err) {
+			return errors.WithMessage(err, "invalid input value status")
+		}
+
+		return err
+	}
+
+	if err = tx.Create(transition).Error; err != nil {
+		return err
+	}
+
+	if transition.ToStatus == entities.PolicyStatusRejected && transition.PolicyHistoryUuid.Valid {
+		return tx.Model(&entities.PolicyHistory{}).
+			Where("policy_history_uuid = ?", transition.PolicyHistoryUuid.UUID).
+			Updates(map[string]interface{}{
+				"updated_by": transition.CreatedBy,
+				"updated_at": nullable.NewNullTime(transition.CreatedAt),
+				"comment":    transition.Comment,
+			}).Error
+	}
+
+	return nil
+}
+
+// loadPolicyWorkflow of the policy with the reviews of its last submission.
+func loadPolicyWorkflow(tx *gorm.DB, policy *entities.Policy) (*entities.PolicyWorkflow, error) {
+	workflow := &entities.PolicyWorkflow{Policy: policy}
+
+	var versions []*entities.PolicyHistory
+
+	err := tx.Model(&entities.PolicyHistory{}).
+		Select("policy_history_uuid", "policy_uuid", "version", "created_at", "created_by").
+		Order("version desc").
+		Limit(1).
+		Find(&versions, "policy_uuid = ?", policy.PolicyUuid).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) > 0 {
+		workflow.LatestVersion = versions[0]
+	}
+
+	err = tx.Model(&entities.PolicyReviewer{}).
+		Preload("User").
+		Order("created_at").
+		Find(&workflow.Reviewers, "policy_uuid = ?", policy.PolicyUuid).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var submissions []*entities.PolicyStatusHistory
+
+	err = tx.Model(&entities.PolicyStatusHistory{}).
+		Order("created_at desc").
+		Limit(1).
+		Find(&submissions, "policy_uuid = ? AND to_status = ?", policy.PolicyUuid, entities.PolicyStatusSubmitted).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(submissions) == 0 {
+		return workflow, nil
+	}
+
+	workflow.Submission = submissions[0]
+
+	err = tx.Model(&entities.PolicyReview{}).
+		Order("created_at").
+		Find(&workflow.Reviews, "policy_status_history_uuid = ?", workflow.Submission.PolicyStatusHistoryUuid).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+// SetPolicyReviewers replaces the reviewers of the policy. Reviewers must be active users of
+// the company of the policy.
+func (s *sqlRepository) SetPolicyReviewers(ctx context.Context, companyUuid, policyUuid *uuid.UUID, reviewers []*entities.PolicyReviewer, approvalRule string) error {
+	reviewerUuids := make([]uuid.UUID, 0, len(reviewers))
+	for _, r := range reviewers {
+		reviewerUuids = append(reviewerUuids, r.UserUuid)
+	}
+
+	return s.gormDB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := CheckCompanyUsers(tx, companyUuid, reviewerUuids, "reviewers should be active users of the company")
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&entities.Policy{}).
+			Where("policy_uuid = ? AND company_uuid = ?", policyUuid, companyUuid).
+			Update("approval_rule", approvalRule)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return &appError.ErrNotFound{Message: "policy not found"}
+		}
+
+		if err = tx.Delete(&entities.PolicyReviewer{}, "policy_uuid = ?", policyUuid).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(reviewers).Error
+	})
+}
+
+// CheckCompanyUsers fails with message unless all the users are active users of the company.
+func CheckCompanyUsers(tx *gorm.DB, companyUuid *uuid.UUID, userUuids []uuid.UUID, message string) error {
+	var count int64
+
+	err := tx.Table("public.company_users cu").
+		Where("cu.company_uuid = ? AND "+userEntities.ActiveCompanyUserSQL+" AND cu.user_uuid IN ?", companyUuid, userUuids).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+
+	if int(count) != len(userUuids) {
+		return &appError.ErrValidation{Message: message}
+	}
+
+	return nil
+}
+
+func (s *sqlRepository) GetPolicyStatusHistory(ctx context.Context, companyUuid, policyUuid *uuid.UUID) ([]*entities.PolicyStatusHistory, error) {
+	var transitions []*entities.PolicyStatusHistory
+
+	err := s.gormDB.WithContext(ctx).Model(&entities.PolicyStatusHistory{}).
+		Preload("PolicyHistory", func(tx *gorm.DB) *gorm.DB {
+			return tx.Select("policy_history_uuid", "version")
+		}).
+		Preload("Created").
+		Joins("JOIN policies p ON p.policy_uuid = policy_status_histories.policy_uuid").
+		Where("policy_status_histories.policy_uuid = ? AND p.company_uuid = ?", policyUuid, companyUuid).
+		Order("policy_status_histories.created_at desc").
+		Find(&transitions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return transitions, nil
+}
+
+// createDraftTransition records that saving a new version moved the policy back to Draft.
+func createDraftTransition(tx *gorm.DB, fromStatus string, ph *entities.PolicyHistory) error {
+	return tx.Create(&entities.PolicyStatusHistory{
+		PolicyStatusHistoryUuid: uuid.New(),
+		PolicyUuid:              ph.PolicyUuid,
+		PolicyHistoryUuid:       *nullable.NewNullUUID(ph.PolicyHistoryUuid),
+		FromStatus:              fromStatus,
+		ToStatus:                entities.PolicyStatusDraft,
+		CreatedAt:               time.Now(),
+		CreatedBy:               ph.CreatedBy,
+	}).Error
+}
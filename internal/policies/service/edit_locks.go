@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+)
+
+// AcquireEditLock of the policy for the user, or refresh the lock the user holds. The lock is
+// soft: when another user is editing the policy, that user is returned instead.
+func (s *service) AcquireEditLock(ctx context.Context, req *entities.PolicyEditLockRequest) (*entities.PolicyEditLockResponse, error) {
+	now := time.Now()
+
+	lock, err := s.repo.AcquireEditLock(ctx, req.CompanyUuid, req.PolicyUuid, req.UserUuid, now, s.editLocksConfig.Expiry())
+	if err != nil {
+		return nil, err
+	}
+
+	return newEditLockResponse(req, lock, now), nil
+}
+
+// GetEditLock tells who is editing the policy and for how long.
+func (s *service) GetEditLock(ctx context.Context, req *entities.PolicyEditLockRequest) (*entities.PolicyEditLockResponse, error) {
+	now := time.Now()
+
+	lock, err := s.repo.GetEditLock(ctx, req.CompanyUuid, req.PolicyUuid, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEditLockResponse(req, lock, now), nil
+}
+
+// ReleaseEditLock the user holds once done editing the policy.
+func (s *service) ReleaseEditLock(ctx context.Context, req *entities.PolicyEditLockRequest) error {
+	return s.repo.ReleaseEditLock(ctx, req.CompanyUuid, req.PolicyUuid, req.UserUuid)
+}
+
+func newEditLockResponse(req *entities.PolicyEditLockRequest, lock *entities.PolicyEditLock, now time.Time) *entities.PolicyEditLockResponse {
+	res := &entities.PolicyEditLockResponse{PolicyUuid: req.PolicyUuid}
+	if lock == nil {
+		return res
+	}
+
+	res.Locked = true
+	res.Mine = lock.UserUuid == req.UserUuid
+	res.Editor = entities.NewUserInfo(lock.UserUuid, lock.User.FirstName, lock.User.LastName, "", lock.User.Email)
+	res.AcquiredAt = &lock.AcquiredAt
+	res.ExpiresAt = &lock.ExpiresAt
+	res.EditingSeconds = int64(now.Sub(lock.AcquiredAt).Seconds())
+
+	return res
+}
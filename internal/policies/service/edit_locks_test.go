@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	policiesCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/repository"
+	userEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/cfg"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+)
+
+func TestEditLocks(t *testing.T) {
+	Convey("Given a policy to edit", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		config := policiesCfg.Config{EditLocks: policiesCfg.EditLocksConfig{Timeout: 5 * time.Minute}}
+		svc := New(repo, nil, nil, nil, nil, nil, nil, config, cfg.Config{}, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		companyUuid, userUuid, editorUuid, policyUuid := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+		req := &entities.PolicyEditLockRequest{CompanyUuid: companyUuid, UserUuid: userUuid, PolicyUuid: policyUuid}
+
+		Convey("Another user editing it keeps the lock and is shown with the editing time", func() {
+			acquiredAt := time.Now().Add(-10 * time.Minute)
+
+			repo.EXPECT().AcquireEditLock(ctx, companyUuid, policyUuid, userUuid, gomock.Any(), 5*time.Minute).
+				Return(&entities.PolicyEditLock{
+					PolicyUuid: policyUuid,
+					UserUuid:   editorUuid,
+					User:       userEntities.User{UserUuid: editorUuid, FirstName: "Jane", LastName: "Doe"},
+					AcquiredAt: acquiredAt,
+					ExpiresAt:  time.Now().Add(time.Minute),
+				}, nil)
+
+			res, err := svc.AcquireEditLock(ctx, req)
+			So(err, ShouldBeNil)
+			So(res.Locked, ShouldBeTrue)
+			So(res.Mine, ShouldBeFalse)
+			So(res.Editor.UserUUID, ShouldEqual, editorUuid)
+			So(res.EditingSeconds, ShouldBeGreaterThanOrEqualTo, 600)
+		})
+
+		Convey("Nobody is editing it without a live lock", func() {
+			repo.EXPECT().GetEditLock(ctx, companyUuid, policyUuid, gomock.Any()).Return(nil, nil)
+
+			res, err := svc.GetEditLock(ctx, req)
+			So(err, ShouldBeNil)
+			So(res.Locked, ShouldBeFalse)
+			So(res.Editor, ShouldBeNil)
+			So(res.EditingSeconds, ShouldEqual, 0)
+		})
+	})
+}
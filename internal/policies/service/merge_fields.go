@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	companyEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/pkg/errors"
+)
+
+// Merge fields a template may contain, filled from the company data when a document is
+// created from it.
+const (
+	MergeFieldCompanyName           = "company.name"
+	MergeFieldCompanyPrimaryAddress = "company.address.primary"
+	MergeFieldOwnerFullName         = "owner.full_name"
+	MergeFieldOwnerEmail            = "owner.email"
+	MergeFieldOwnerJobTitle         = "owner.job_title"
+	MergeFieldEffectiveDate         = "effective_date"
+
+	effectiveDateLayout = "January 2, 2006"
+)
+
+var (
+	mergeFields = map[string]bool{
+		MergeFieldCompanyName:           true,
+		MergeFieldCompanyPrimaryAddress: true,
+		MergeFieldOwnerFullName:         true,
+		MergeFieldOwnerEmail:            true,
+		MergeFieldOwnerJobTitle:         true,
+		MergeFieldEffectiveDate:         true,
+	}
+
+	// mergeFieldPattern matches placeholders such as {{company.name}}, spaces inside the braces
+	// aside.
+	mergeFieldPattern = regexp.MustCompile(`{{\s*([^{}]*?)\s*}}`)
+)
+
+// validateMergeFields fails when the document contains placeholders which are not merge fields.
+func validateMergeFields(document string) error {
+	unknown := make(map[string]bool)
+
+	for _, m := range mergeFieldPattern.FindAllStringSubmatch(document, -1) {
+		if !mergeFields[m[1]] {
+			unknown[m[1]] = true
+		}
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(unknown))
+	for name := range unknown {
+		names = append(names, "{{"+name+"}}")
+	}
+
+	sort.Strings(names)
+
+	return &appError.ErrValidation{Message: fmt.Sprintf("unknown merge fields: %s", strings.Join(names, ", "))}
+}
+
+// renderMergeFields replaces the placeholders of the HTML document with the escaped values.
+func renderMergeFields(document string, values map[string]string) (string, error) {
+	if err := validateMergeFields(document); err != nil {
+		return "", err
+	}
+
+	return mergeFieldPattern.ReplaceAllStringFunc(document, func(placeholder string) string {
+		return html.EscapeString(values[mergeFieldPattern.FindStringSubmatch(placeholder)[1]])
+	}), nil
+}
+
+// mergeValues of the company for documents owned by the user and effective on the day of now.
+func (s *service) mergeValues(ctx context.Context, companyUuid, userUuid uuid.UUID, now time.Time) (map[string]string, error) {
+	company, err := s.companyClient.FindByUUID(ctx, &companyEntities.GetCompanyByIdRequest{CompanyUuid: companyUuid})
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.userClient.GetUserByUuid(ctx, userUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := s.primaryAddress(ctx, company)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		MergeFieldCompanyName:           company.Name,
+		MergeFieldCompanyPrimaryAddress: address,
+		MergeFieldOwnerFullName:         strings.TrimSpace(owner.FirstName + " " + owner.LastName),
+		MergeFieldOwnerEmail:            owner.Email,
+		MergeFieldOwnerJobTitle:         owner.JobTitle,
+		MergeFieldEffectiveDate:         now.Format(effectiveDateLayout),
+	}, nil
+}
+
+// primaryAddress of the company on a single line. The first active address of the company
+// settings is used, or the address given when the company was registered if there is none.
+func (s *service) primaryAddress(ctx context.Context, company *companyEntities.Company) (string, error) {
+	address, err := s.addressClient.GetPrimaryAddress(ctx, &company.CompanyUuid)
+	if err == nil {
+		return formatAddress(address.Address1, address.Address2, address.City, address.State, address.Zip, address.Country), nil
+	}
+
+	var notFoundErr *appError.ErrNotFound
+	if !errors.As(err, &notFoundErr) {
+		return "", err
+	}
+
+	if company.Address == nil {
+		return "", nil
+	}
+
+	a := company.Address
+
+	return formatAddress(a.AddressLine1, a.AddressLine2, a.City, a.State, a.ZipCode, a.Country), nil
+}
+
+// formatAddress as "1 Main St, Suite 2, Springfield, IL 62701, US", leaving out blank parts.
+func formatAddress(line1, line2, city, state, zip, country string) string {
+	parts := make([]string, 0, 5)
+
+	for _, part := range []string{line1, line2, city, strings.TrimSpace(state + " " + zip), country} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// renderTemplate fills the merge fields of the template for the company and the user.
+func (s *service) renderTemplate(ctx context.Context, pt *entities.PolicyTemplates, companyUuid, userUuid uuid.UUID) (string, error) {
+	if err := validateMergeFields(pt.Document); err != nil {
+		return "", err
+	}
+
+	values, err := s.mergeValues(ctx, companyUuid, userUuid, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	return renderMergeFields(pt.Document, values)
+}
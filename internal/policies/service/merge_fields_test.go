@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company"
+	companyEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/address"
+	addressEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/address/entities"
+	policiesCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/repository"
+	userEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/userclient"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/cfg"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+)
+
+func TestValidateMergeFields(t *testing.T) {
+	Convey("Documents may only contain known merge fields", t, func() {
+		So(validateMergeFields("<p>{{company.name}} and {{ effective_date }}</p>"), ShouldBeNil)
+		So(validateMergeFields("<p>No merge fields</p>"), ShouldBeNil)
+
+		err := validateMergeFields("<p>{{company.ceo}}, {{ company.name }}, {{company.ceo}} and {{owner.phone}}</p>")
+
+		var validationErr *appError.ErrValidation
+		So(errors.As(err, &validationErr), ShouldBeTrue)
+		So(validationErr.Message, ShouldEqual, "unknown merge fields: {{company.ceo}}, {{owner.phone}}")
+	})
+}
+
+func TestFormatAddress(t *testing.T) {
+	Convey("Blank parts of an address are left out", t, func() {
+		So(formatAddress("1 Main St", "Suite 2", "Springfield", "IL", "62701", "US"), ShouldEqual, "1 Main St, Suite 2, Springfield, IL 62701, US")
+		So(formatAddress("1 Main St", " ", "Springfield", "", "", "US"), ShouldEqual, "1 Main St, Springfield, US")
+		So(formatAddress("", "", "", "", "", ""), ShouldEqual, "")
+	})
+}
+
+func TestPreviewTemplate(t *testing.T) {
+	Convey("Given a template with merge fields", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		companyClient := company.NewMockClient(ctrl)
+		addressClient := address.NewMockClient(ctrl)
+		userClient := userclient.NewMockClient(ctrl)
+		svc := New(repo, nil, nil, companyClient, addressClient, userClient, nil, policiesCfg.Config{}, cfg.Config{}, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		companyUuid, userUuid := uuid.New(), uuid.New()
+		template := &entities.PolicyTemplates{
+			PolicyTemplateUuid: uuid.New(),
+			Name:               "Information Security Policy",
+			Document:           "<p>{{company.name}} is located at {{company.address.primary}}.</p><p>Owner: {{ owner.full_name }}</p>",
+		}
+		req := &entities.PreviewTemplateRequest{CompanyUuid: companyUuid, UserUuid: userUuid, PolicyTemplateUuid: template.PolicyTemplateUuid}
+
+		repo.EXPECT().GetTemplateByUuid(ctx, &template.PolicyTemplateUuid).Return(template, nil)
+
+		Convey("The merge fields are filled from the company, its primary address and the user, escaped", func() {
+			companyClient.EXPECT().FindByUUID(ctx, &companyEntities.GetCompanyByIdRequest{CompanyUuid: companyUuid}).
+				Return(&companyEntities.Company{CompanyUuid: companyUuid, Name: "Smith & Sons"}, nil)
+			userClient.EXPECT().GetUserByUuid(ctx, userUuid).Return(&userEntities.User{FirstName: "Jane", LastName: "Doe"}, nil)
+			addressClient.EXPECT().GetPrimaryAddress(ctx, &companyUuid).
+				Return(&addressEntities.CompanyAddressW{Address1: "1 Main St", City: "Springfield", State: "IL", Zip: "62701", Country: "US"}, nil)
+
+			res, err := svc.PreviewTemplate(ctx, req)
+			So(err, ShouldBeNil)
+			So(res.Name, ShouldEqual, "Information Security Policy")
+			So(res.Document, ShouldEqual, "<p>Smith &amp; Sons is located at 1 Main St, Springfield, IL 62701, US.</p><p>Owner: Jane Doe</p>")
+		})
+
+		Convey("The registered address of the company is used when it has no active address", func() {
+			companyClient.EXPECT().FindByUUID(ctx, gomock.Any()).Return(&companyEntities.Company{
+				CompanyUuid: companyUuid,
+				Name:        "Acme",
+				Address:     &companyEntities.Address{AddressLine1: "2 Elm St", City: "Portland", Country: "US"},
+			}, nil)
+			userClient.EXPECT().GetUserByUuid(ctx, userUuid).Return(&userEntities.User{FirstName: "Jane"}, nil)
+			addressClient.EXPECT().GetPrimaryAddress(ctx, &companyUuid).Return(nil, &appError.ErrNotFound{Message: "company address not found"})
+
+			res, err := svc.PreviewTemplate(ctx, req)
+			So(err, ShouldBeNil)
+			So(res.Document, ShouldEqual, "<p>Acme is located at 2 Elm St, Portland, US.</p><p>Owner: Jane</p>")
+		})
+
+		Convey("Templates with unknown merge fields fail validation", func() {
+			template.Document += "<p>{{company.ceo}}</p>"
+
+			_, err := svc.PreviewTemplate(ctx, req)
+
+			var validationErr *appError.ErrValidation
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+)
+
+// MaxReviewIntervalMonths between two reviews of a policy.
+const MaxReviewIntervalMonths = 60
+
+var reviewReminderTemplate = template.Must(template.New("policy-review").Parse(`<html><head> <style>p{line-height: 22px;}.review{width: 240px; height: 32px; background: #436AF3; border-radius: 4.16px; text-decoration: none; margin-top: 40px; margin-bottom: 40px; font-size: 16px; padding-top: 6.5px; text-align: center; display: block;}</style></head><body> <table width="600" cellpadding="0" cellspacing="0" align="center"> <tr> <td> <img alt="REDESIGN Logo" title="REDESIGN Logo" style="display:block" height="37px" src="https://redesigntrustportal-static-files.s3.us-west-2.amazonaws.com/rdt_logo_small.png"> <p>Hi {{.firstName}},</p>{{if .overdue}}<p>The review of the policy <strong>{{.name}}</strong> was due on {{.date}} and is now overdue.</p>{{else}}<p>The review of the policy <strong>{{.name}}</strong> is due on {{.date}}.</p>{{end}}<p>As its review owner, please review the policy and submit it for approval.</p><p><a class="review" style="color: #FFFFFF" href="{{.policyLink}}">Review policy</a></p><p>Thank you,</p><p>REDESIGN Trust Portal</p></td></tr></table></body></html>`))
+
+func (s *service) GetPolicyReviewSchedule(ctx context.Context, req *entities.GetPolicyReviewScheduleRequest) (*entities.GetPolicyReviewScheduleResponse, error) {
+	policy, err := s.repo.GetPolicy(ctx, &req.CompanyUuid, &req.PolicyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.GetPolicyReviewScheduleResponse{
+		PolicyUuid:           policy.PolicyUuid,
+		PolicyReviewSchedule: s.reviewSchedule(policy, time.Now()),
+	}, nil
+}
+
+func (s *service) UpdatePolicyReviewSchedule(ctx context.Context, req *entities.UpdatePolicyReviewScheduleRequest) (*entities.GetPolicyReviewScheduleResponse, error) {
+	if req.Body.ReviewIntervalMonths < 1 || req.Body.ReviewIntervalMonths > MaxReviewIntervalMonths {
+		return nil, &appError.ErrValidation{Message: fmt.Sprintf("review_interval_months should be between 1 and %d", MaxReviewIntervalMonths)}
+	}
+
+	if req.Body.OwnerUuid == uuid.Nil {
+		return nil, &appError.ErrValidation{Message: "owner_uuid is required"}
+	}
+
+	var nextReviewAt *time.Time
+	if req.Body.NextReviewDate != "" {
+		date, err := time.Parse(entities.DateLayout, req.Body.NextReviewDate)
+		if err != nil {
+			return nil, &appError.ErrValidation{Message: "next_review_date should be a date like 2006-01-02"}
+		}
+
+		nextReviewAt = &date
+	}
+
+	policy, err := s.repo.GetPolicy(ctx, &req.CompanyUuid, &req.PolicyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	// the owner of the policy or of its review schedules it, approvers may change it
+	if policy.CreatedBy != req.UserUuid && !(policy.ReviewOwnerUuid.Valid && policy.ReviewOwnerUuid.UUID == req.UserUuid) {
+		if err = s.authClient.Authorize(ctx, "policies-procedures", permissions.ActionApprove); err != nil {
+			return nil, err
+		}
+	}
+
+	policy, err = s.repo.UpdatePolicyReviewSchedule(ctx, &req.CompanyUuid, &req.PolicyUuid, &req.Body.OwnerUuid, req.Body.ReviewIntervalMonths, nextReviewAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.GetPolicyReviewScheduleResponse{
+		PolicyUuid:           policy.PolicyUuid,
+		PolicyReviewSchedule: s.reviewSchedule(policy, time.Now()),
+	}, nil
+}
+
+func (s *service) reviewSchedule(policy *entities.Policy, now time.Time) *entities.PolicyReviewSchedule {
+	schedule := &entities.PolicyReviewSchedule{ReviewIntervalMonths: policy.ReviewIntervalMonths}
+
+	if policy.LastReviewedAt.Valid {
+		schedule.LastReviewedAt = &policy.LastReviewedAt.Time
+	}
+
+	if policy.NextReviewAt.Valid {
+		next := policy.NextReviewAt.Time.Format(entities.DateLayout)
+		schedule.NextReviewDate = &next
+	}
+
+	if policy.ReviewOwnerUuid.Valid {
+		owner := policy.ReviewOwner
+		schedule.ReviewOwner = entities.NewUserInfo(owner.UserUuid, owner.FirstName, owner.LastName, "", owner.Email)
+	}
+
+	schedule.ReviewStatus = reviewStatus(policy.Status, schedule.NextReviewDate, today(now), s.dueSoonUntil(now))
+
+	return schedule
+}
+
+// reviewStatus of a policy whose next review is due on nextReviewDate. Reviews of inactive
+// policies are never due.
+func reviewStatus(status string, nextReviewDate *string, today, dueSoonUntil time.Time) string {
+	if status == entities.PolicyStatusInactive || nextReviewDate == nil {
+		return ""
+	}
+
+	next, err := time.Parse(entities.DateLayout, *nextReviewDate)
+	if err != nil {
+		return ""
+	}
+
+	switch {
+	case next.Before(today):
+		return entities.ReviewStatusOverdue
+	case !next.After(dueSoonUntil):
+		return entities.ReviewStatusDueSoon
+	default:
+		return ""
+	}
+}
+
+// today is the date of now, in UTC like the review dates.
+func today(now time.Time) time.Time {
+	y, m, d := now.UTC().Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// dueSoonUntil is the last date of a review due soon.
+func (s *service) dueSoonUntil(now time.Time) time.Time {
+	return today(now).Add(s.reviewsConfig.DueSoon())
+}
+
+// SendReviewReminders emails the review owners of the policies whose review is due soon or
+// overdue. A reminder that fails to send is retried on the next call.
+func (s *service) SendReviewReminders(ctx context.Context, now time.Time) error {
+	reminders, err := s.repo.ClaimReviewReminders(ctx, now, s.dueSoonUntil(now), now.Add(-s.reviewsConfig.OverdueReminder()))
+	if err != nil {
+		return err
+	}
+
+	for _, reminder := range reminders {
+		if err = s.sendReviewReminder(ctx, reminder); err == nil {
+			continue
+		}
+
+		s.logger.Errorf("failed to send review reminder of policy %s: %v", reminder.PolicyUuid, err)
+
+		if err = s.repo.ReleaseReviewReminder(ctx, reminder); err != nil {
+			s.logger.Errorf("failed to release review reminder of policy %s: %v", reminder.PolicyUuid, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *service) sendReviewReminder(ctx context.Context, reminder *entities.PolicyReviewReminder) error {
+	subject := fmt.Sprintf("Policy review due soon: %s", reminder.Name)
+	if reminder.Overdue {
+		subject = fmt.Sprintf("Policy review overdue: %s", reminder.Name)
+	}
+
+	var body bytes.Buffer
+
+	err := reviewReminderTemplate.Execute(&body, map[string]interface{}{
+		"firstName":  reminder.OwnerFirstName,
+		"name":       reminder.Name,
+		"date":       reminder.NextReviewAt.Format(entities.DateLayout),
+		"overdue":    reminder.Overdue,
+		"policyLink": fmt.Sprintf("https://%s/%s/%s", s.commonConfig.FrontendDomain, "policies-procedures", reminder.PolicyUuid),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.emailClient.SendEmail(ctx, subject, body.String(), reminder.OwnerEmail)
+}
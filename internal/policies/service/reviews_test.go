@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	policiesCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/ses"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/cfg"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+)
+
+func TestReviewStatus(t *testing.T) {
+	Convey("Given the review window of today", t, func() {
+		today := time.Date(2023, 3, 29, 0, 0, 0, 0, time.UTC)
+		dueSoonUntil := today.AddDate(0, 0, 30)
+		date := func(s string) *string { return &s }
+
+		Convey("Reviews due before today are overdue", func() {
+			So(reviewStatus(entities.PolicyStatusApproved, date("2023-03-28"), today, dueSoonUntil), ShouldEqual, entities.ReviewStatusOverdue)
+		})
+
+		Convey("Reviews due from today to the end of the window are due soon", func() {
+			So(reviewStatus(entities.PolicyStatusApproved, date("2023-03-29"), today, dueSoonUntil), ShouldEqual, entities.ReviewStatusDueSoon)
+			So(reviewStatus(entities.PolicyStatusDraft, date("2023-04-28"), today, dueSoonUntil), ShouldEqual, entities.ReviewStatusDueSoon)
+		})
+
+		Convey("Later reviews, unscheduled reviews and inactive policies need no review", func() {
+			So(reviewStatus(entities.PolicyStatusApproved, date("2023-04-29"), today, dueSoonUntil), ShouldBeEmpty)
+			So(reviewStatus(entities.PolicyStatusDraft, nil, today, dueSoonUntil), ShouldBeEmpty)
+			So(reviewStatus(entities.PolicyStatusInactive, date("2023-01-01"), today, dueSoonUntil), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestSendReviewReminders(t *testing.T) {
+	Convey("Given reminders to send", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		emailClient := ses.NewMockClient(ctrl)
+		config := policiesCfg.Config{Reviews: policiesCfg.ReviewsConfig{DueSoonDays: 30, OverdueReminderDays: 7}}
+		svc := New(repo, nil, nil, nil, nil, nil, emailClient, config, cfg.Config{FrontendDomain: "portal.example.com"}, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		now := time.Date(2023, 3, 29, 9, 0, 0, 0, time.UTC)
+
+		dueSoon := &entities.PolicyReviewReminder{
+			PolicyUuid:   uuid.New(),
+			Name:         "Access Control",
+			NextReviewAt: time.Date(2023, 4, 15, 0, 0, 0, 0, time.UTC),
+			OwnerEmail:   "alice@customer",
+		}
+		overdue := &entities.PolicyReviewReminder{
+			PolicyUuid:   uuid.New(),
+			Name:         "Incident Response",
+			NextReviewAt: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+			Overdue:      true,
+			OwnerEmail:   "bob@customer",
+		}
+
+		repo.EXPECT().
+			ClaimReviewReminders(ctx, now, time.Date(2023, 4, 28, 0, 0, 0, 0, time.UTC), now.AddDate(0, 0, -7)).
+			Return([]*entities.PolicyReviewReminder{dueSoon, overdue}, nil)
+
+		Convey("Owners are emailed whether the review is due soon or overdue", func() {
+			emailClient.EXPECT().SendEmail(ctx, "Policy review due soon: Access Control", gomock.Any(), "alice@customer").
+				DoAndReturn(func(_ context.Context, _, body, _ string) error {
+					So(body, ShouldContainSubstring, "is due on 2023-04-15")
+					So(body, ShouldContainSubstring, "https://portal.example.com/policies-procedures/"+dueSoon.PolicyUuid.String())
+
+					return nil
+				})
+			emailClient.EXPECT().SendEmail(ctx, "Policy review overdue: Incident Response", gomock.Any(), "bob@customer").
+				DoAndReturn(func(_ context.Context, _, body, _ string) error {
+					So(strings.Contains(body, "is now overdue"), ShouldBeTrue)
+
+					return nil
+				})
+
+			So(svc.SendReviewReminders(ctx, now), ShouldBeNil)
+		})
+
+		Convey("Reminders that fail to send are released", func() {
+			emailClient.EXPECT().SendEmail(ctx, gomock.Any(), gomock.Any(), "alice@customer").Return(errors.New("throttled"))
+			emailClient.EXPECT().SendEmail(ctx, gomock.Any(), gomock.Any(), "bob@customer").Return(nil)
+			repo.EXPECT().ReleaseReviewReminder(ctx, dueSoon).Return(nil)
+
+			So(svc.SendReviewReminders(ctx, now), ShouldBeNil)
+		})
+	})
+}
@@ -5,15 +5,26 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company"
+	companyEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/address"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/onboarding"
 	onboardingEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/onboarding/entities"
+	policiesCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/config"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/ses"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/userclient"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/cfg"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/converter"
 	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/htmldiff"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+	"go.uber.org/zap"
 )
 
 type Service interface {
@@ -23,16 +34,46 @@ type Service interface {
 	GetDocument(ctx context.Context, req *entities.GetPolicyDocumentRequest) (*entities.GetDocumentResponse, error)
 	SaveDocument(ctx context.Context, req *entities.SaveDocumentRequest) (*entities.GetPolicyDocumentResponse, error)
 	GetPolicyHistoriesByPolicy(ctx context.Context, req *entities.GetPolicyHistoriesByPolicyRequest) ([]*entities.GetPolicyHistoriesByPolicyResponse, error)
+	GetPolicyDiff(ctx context.Context, req *entities.GetPolicyDiffRequest) (*entities.GetPolicyDiffResponse, error)
 	DeletePolicy(ctx context.Context, companyUuid, userUuid, policyUuid *uuid.UUID) error
 	UpdatePolicyStatus(ctx context.Context, companyUuid, userUuid, policyUuid *uuid.UUID, req *entities.UpdatePolicyDocumentStatusPatchRequestBody) (*entities.UpdatePolicyDocumentResponse, error)
+	SetPolicyReviewers(ctx context.Context, req *entities.SetPolicyReviewersRequest) (*entities.GetPolicyReviewersResponse, error)
+	GetPolicyReviewers(ctx context.Context, req *entities.GetPolicyReviewersRequest) (*entities.GetPolicyReviewersResponse, error)
+	GetPolicyStatusHistory(ctx context.Context, req *entities.GetPolicyStatusHistoryRequest) ([]*entities.GetPolicyStatusHistoryResponse, error)
 	GetPoliciesStats(ctx context.Context, companyUuid *uuid.UUID) (*entities.GetPoliciesStatsResponse, error)
+	GetPolicyReviewSchedule(ctx context.Context, req *entities.GetPolicyReviewScheduleRequest) (*entities.GetPolicyReviewScheduleResponse, error)
+	UpdatePolicyReviewSchedule(ctx context.Context, req *entities.UpdatePolicyReviewScheduleRequest) (*entities.GetPolicyReviewScheduleResponse, error)
+	SendReviewReminders(ctx context.Context, now time.Time) error
 	GetTemplates(ctx context.Context, req *entities.GetTemplatesRequest) ([]*entities.GetTemplatesResponse, error)
 	CreateDocumentFromTemplate(ctx context.Context, req *entities.CreateDocumentFromTemplateRequest) (*entities.GetPolicyDocumentResponse, error)
+	PreviewTemplate(ctx context.Context, req *entities.PreviewTemplateRequest) (*entities.PreviewTemplateResponse, error)
+	ListTemplateLibrary(ctx context.Context, req *entities.GetTemplateLibraryRequest) ([]*entities.PolicyTemplates, error)
+	GetTemplate(ctx context.Context, req *entities.GetTemplateRequest) (*entities.PolicyTemplates, error)
+	CreateTemplate(ctx context.Context, req *entities.CreateTemplateRequest) (*entities.PolicyTemplates, error)
+	UpdateTemplate(ctx context.Context, req *entities.UpdateTemplateRequest) (*entities.PolicyTemplates, error)
+	DeleteTemplate(ctx context.Context, req *entities.GetTemplateRequest) error
+	PublishTemplate(ctx context.Context, req *entities.PublishTemplateRequest) (*entities.PublishTemplateResponse, error)
+	GetTemplateVersions(ctx context.Context, req *entities.GetTemplateRequest) ([]*entities.PolicyTemplateVersion, error)
+	GetTemplateUpdate(ctx context.Context, req *entities.GetTemplateUpdateRequest) (*entities.GetTemplateUpdateResponse, error)
+	DismissTemplateUpdate(ctx context.Context, req *entities.GetTemplateUpdateRequest) (*entities.PolicyTemplateUpdate, error)
+	SendTemplateUpdateNotifications(ctx context.Context, now time.Time) error
+	AcquireEditLock(ctx context.Context, req *entities.PolicyEditLockRequest) (*entities.PolicyEditLockResponse, error)
+	GetEditLock(ctx context.Context, req *entities.PolicyEditLockRequest) (*entities.PolicyEditLockResponse, error)
+	ReleaseEditLock(ctx context.Context, req *entities.PolicyEditLockRequest) error
 }
 
 type service struct {
 	repo             repository.Repository
 	onboardingClient onboarding.Client
+	authClient       auth.Client
+	companyClient    company.Client
+	addressClient    address.Client
+	userClient       userclient.Client
+	emailClient      ses.Client
+	reviewsConfig    policiesCfg.ReviewsConfig
+	editLocksConfig  policiesCfg.EditLocksConfig
+	commonConfig     cfg.Config
+	logger           *zap.SugaredLogger
 }
 
 func (s *service) CreateDocumentFromTemplate(ctx context.Context, req *entities.CreateDocumentFromTemplateRequest) (*entities.GetPolicyDocumentResponse, error) {
@@ -41,19 +82,31 @@ func (s *service) CreateDocumentFromTemplate(ctx context.Context, req *entities.
 		return nil, err
 	}
 
+	document, err := s.renderTemplate(ctx, pt, req.CompanyUuid, req.UserUuid)
+	if err != nil {
+		return nil, err
+	}
+
 	policyTemplateUuid := nullable.NewNullUUID(pt.PolicyTemplateUuid)
-	p, err := s.CreatePolicy(ctx, &req.CompanyUuid, &req.UserUuid, &entities.Policy{Name: pt.Name, PolicyTemplateUuid: *policyTemplateUuid})
+	p, err := s.CreatePolicy(ctx, &req.CompanyUuid, &req.UserUuid, &entities.Policy{
+		Name:                  pt.Name,
+		PolicyTemplateUuid:    *policyTemplateUuid,
+		PolicyTemplateVersion: &pt.Version,
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	// the policy was just created and has no versions yet
+	baseVersion := 0
 	response, err := s.SaveDocument(ctx, &entities.SaveDocumentRequest{
 		CompanyUuid: req.CompanyUuid,
 		UserUuid:    req.UserUuid,
 		PolicyUUID:  p.PolicyUuid,
 		SaveDocumentRequestBody: &entities.SaveDocumentRequestBody{
-			Name:     pt.Name,
-			Document: pt.Document,
+			Name:        pt.Name,
+			Document:    document,
+			BaseVersion: &baseVersion,
 		},
 	})
 	if err != nil {
@@ -63,9 +116,33 @@ func (s *service) CreateDocumentFromTemplate(ctx context.Context, req *entities.
 	return response, nil
 }
 
+func (s *service) PreviewTemplate(ctx context.Context, req *entities.PreviewTemplateRequest) (*entities.PreviewTemplateResponse, error) {
+	pt, err := s.repo.GetTemplateByUuid(ctx, &req.PolicyTemplateUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := s.renderTemplate(ctx, pt, req.CompanyUuid, req.UserUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.PreviewTemplateResponse{
+		PolicyTemplateUuid: pt.PolicyTemplateUuid,
+		Name:               pt.Name,
+		Document:           document,
+	}, nil
+}
+
 func (s *service) GetTemplates(ctx context.Context, req *entities.GetTemplatesRequest) ([]*entities.GetTemplatesResponse, error) {
 	templates := make([]*entities.GetTemplatesResponse, 0)
-	pts, err := s.repo.GetTemplates(ctx, req.CompanyType)
+
+	company, err := s.companyClient.FindByUUID(ctx, &companyEntities.GetCompanyByIdRequest{CompanyUuid: req.CompanyUuid})
+	if err != nil {
+		return nil, err
+	}
+
+	pts, err := s.repo.GetTemplates(ctx, req.CompanyType, company.Type)
 	if err != nil {
 		return nil, err
 	}
@@ -88,21 +165,108 @@ func (s *service) GetPolicyHistoriesByPolicy(ctx context.Context, req *entities.
 	}
 
 	res := make([]*entities.GetPolicyHistoriesByPolicyResponse, 0)
-	for _, ph := range phs {
-		r := &entities.GetPolicyHistoriesByPolicyResponse{
+	for i, ph := range phs {
+		// versions are listed latest first; those saved before their changes were counted on
+		// save are compared to the one after them once
+		if ph.Changes == nil && i+1 < len(phs) {
+			d, err := htmldiff.Compare(phs[i+1].Document, ph.Document)
+			if err != nil {
+				return nil, err
+			}
+
+			if err = s.repo.UpdatePolicyHistoryChanges(ctx, ph.PolicyHistoryUuid, &d.Summary); err != nil {
+				return nil, err
+			}
+
+			ph.Changes = &d.Summary
+		}
+
+		res = append(res, &entities.GetPolicyHistoriesByPolicyResponse{
 			PolicyHistoryUUID: ph.PolicyHistoryUuid,
 			Version:           ph.Version,
 			CreatedAt:         ph.CreatedAt.Time,
 			Owner:             entities.NewUserInfo(ph.Created.UserUuid, ph.Created.FirstName, ph.Created.LastName, "", ph.Created.Email),
+			Changes:           ph.Changes,
+		})
+	}
+
+	return res, nil
+}
+
+func (s *service) GetPolicyDiff(ctx context.Context, req *entities.GetPolicyDiffRequest) (*entities.GetPolicyDiffResponse, error) {
+	to, err := s.repo.GetPolicyDocument(ctx, &req.CompanyUuid, &req.PolicyUuid, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	fromVersion := req.From
+	if fromVersion == 0 {
+		fromVersion = to.Version - 1
+	}
+
+	if fromVersion == to.Version {
+		return nil, &appError.ErrValidation{Message: "from and to should be different versions"}
+	}
+
+	// the first version is compared to an empty document
+	var from *entities.PolicyHistory
+	if fromVersion > 0 {
+		if from, err = s.repo.GetPolicyDocument(ctx, &req.CompanyUuid, &req.PolicyUuid, fromVersion); err != nil {
+			return nil, err
 		}
-		res = append(res, r)
+	}
+
+	fromDocument := ""
+	if from != nil {
+		fromDocument = from.Document
+	}
+
+	d, err := htmldiff.Compare(fromDocument, to.Document)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &entities.GetPolicyDiffResponse{
+		PolicyUuid: req.PolicyUuid,
+		To:         policyVersion(to),
+		Summary:    d.Summary,
+		Changes:    d.Changes,
+		HTML:       d.HTML,
+	}
+
+	if from != nil {
+		res.From = policyVersion(from)
 	}
 
 	return res, nil
 }
 
+func policyVersion(ph *entities.PolicyHistory) *entities.PolicyVersion {
+	return &entities.PolicyVersion{
+		PolicyHistoryUuid: ph.PolicyHistoryUuid,
+		Version:           ph.Version,
+		CreatedAt:         ph.CreatedAt.Time,
+		Owner:             entities.NewUserInfo(ph.Created.UserUuid, ph.Created.FirstName, ph.Created.LastName, "", ph.Created.Email),
+	}
+}
+
+// SaveDocument as the next version of the policy. A save based on an older version than the
+// latest one is a conflict, returned along with the latest version to merge the changes into.
 func (s *service) SaveDocument(ctx context.Context, req *entities.SaveDocumentRequest) (*entities.GetPolicyDocumentResponse, error) {
+	if req.SaveDocumentRequestBody.BaseVersion == nil {
+		return nil, &appError.ErrValidation{Message: "base_version is required"}
+	}
+
 	ph, err := s.repo.SaveDocument(ctx, req)
+	if appError.IsConflictError(err) {
+		latest, latestErr := s.repo.GetPolicyDocument(ctx, &req.CompanyUuid, &req.PolicyUUID, 0)
+		if latestErr != nil {
+			return nil, latestErr
+		}
+
+		return nil, &appError.ErrConflict{Message: err.Error(), Data: newPolicyDocumentResponse(latest)}
+	}
+
 	if err != nil {
 		return nil, err
 	}
@@ -141,7 +305,15 @@ func (s *service) GetPolicyDocument(ctx context.Context, companyUuid, userUuid,
 		return nil, err
 	}
 
-	res := &entities.GetPolicyDocumentResponse{
+	res := newPolicyDocumentResponse(p)
+
+	s.onboardingClient.UpdateOnboardingStatus(ctx, onboardingEntities.UploadPoliciesAndProcedures, *companyUuid, *userUuid)
+
+	return res, nil
+}
+
+func newPolicyDocumentResponse(p *entities.PolicyHistory) *entities.GetPolicyDocumentResponse {
+	return &entities.GetPolicyDocumentResponse{
 		PolicyUUID:      p.Policy.PolicyUuid,
 		Name:            p.Policy.Name,
 		Status:          p.Policy.Status,
@@ -152,48 +324,85 @@ func (s *service) GetPolicyDocument(ctx context.Context, companyUuid, userUuid,
 		Owner:           entities.NewUserInfo(p.Created.UserUuid, p.Created.FirstName, p.Created.LastName, "", p.Created.Email),
 		CreatedAt:       p.CreatedAt.Time,
 	}
-
-	s.onboardingClient.UpdateOnboardingStatus(ctx, onboardingEntities.UploadPoliciesAndProcedures, *companyUuid, *userUuid)
-
-	return res, nil
 }
 
 func (s *service) CreatePolicy(ctx context.Context, companyUuid, userUuid *uuid.UUID, policy *entities.Policy) (*entities.Policy, error) {
 	policy.CompanyUuid = *companyUuid
 	policy.CreatedBy = *userUuid
 	policy.StatusUpdatedBy = *userUuid
+	policy.ReviewOwnerUuid = *nullable.NewNullUUID(*userUuid)
 
 	return s.repo.CreatePolicy(ctx, policy)
 }
 
 func (s *service) GetAllPolicies(ctx context.Context, req *entities.GetAllPoliciesRequest) ([]*entities.GetAllPoliciesResponse, error) {
-	return s.repo.GetAllPolicyHistory(ctx, &req.CompanyUuid, req.Keyword)
+	if req.SearchMode == entities.SearchModeContent && strings.TrimSpace(req.Keyword) == "" {
+		return nil, &appError.ErrValidation{Message: "keyword is required to search the content of policies"}
+	}
+
+	now := time.Now()
+
+	var reviewDueBy *time.Time
+	if req.NeedsReview {
+		dueSoonUntil := s.dueSoonUntil(now)
+		reviewDueBy = &dueSoonUntil
+	}
+
+	policies, err := s.repo.GetAllPolicyHistory(ctx, &req.CompanyUuid, req.Keyword, req.SearchMode, reviewDueBy)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range policies {
+		p.ReviewStatus = reviewStatus(p.Status, p.NextReviewDate, today(now), s.dueSoonUntil(now))
+	}
+
+	return policies, nil
 }
 
 func (s *service) DeletePolicy(ctx context.Context, companyUuid, userUuid, policyUuid *uuid.UUID) error {
 	return s.repo.DeletePolicy(ctx, companyUuid, userUuid, policyUuid)
 }
 
-func (s *service) UpdatePolicyStatus(ctx context.Context, companyUuid, userUuid, policyUuid *uuid.UUID, req *entities.UpdatePolicyDocumentStatusPatchRequestBody) (*entities.UpdatePolicyDocumentResponse, error) {
-	err := s.repo.UpdatePolicyStatus(ctx, companyUuid, userUuid, policyUuid, req)
+func (s *service) GetPoliciesStats(ctx context.Context, companyUuid *uuid.UUID) (*entities.GetPoliciesStatsResponse, error) {
+	stats, err := s.repo.GetPoliciesStats(ctx, companyUuid)
 	if err != nil {
 		return nil, err
 	}
 
-	return &entities.UpdatePolicyDocumentResponse{
-		PolicyUuid: *policyUuid,
-		Status:     req.Status,
-		Comment:    req.Comment,
-	}, nil
-}
+	now := time.Now()
 
-func (s *service) GetPoliciesStats(ctx context.Context, companyUuid *uuid.UUID) (*entities.GetPoliciesStatsResponse, error) {
-	return s.repo.GetPoliciesStats(ctx, companyUuid)
+	stats.ReviewDueSoon, stats.ReviewOverdue, err = s.repo.GetPolicyReviewStats(ctx, companyUuid, today(now), s.dueSoonUntil(now))
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
 }
 
-func New(repo repository.Repository, onboardingClient onboarding.Client) Service {
+func New(
+	repo repository.Repository,
+	onboardingClient onboarding.Client,
+	authClient auth.Client,
+	companyClient company.Client,
+	addressClient address.Client,
+	userClient userclient.Client,
+	emailClient ses.Client,
+	config policiesCfg.Config,
+	commonConfig cfg.Config,
+	logger *zap.SugaredLogger,
+) Service {
 	return &service{
 		repo:             repo,
 		onboardingClient: onboardingClient,
+		authClient:       authClient,
+		companyClient:    companyClient,
+		addressClient:    addressClient,
+		userClient:       userClient,
+		emailClient:      emailClient,
+		reviewsConfig:    config.Reviews,
+		editLocksConfig:  config.EditLocks,
+		commonConfig:     commonConfig,
+		logger:           logger,
 	}
 }
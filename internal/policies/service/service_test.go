@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	policiesCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/cfg"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/htmldiff"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+)
+
+func TestGetAllPolicies(t *testing.T) {
+	Convey("Given the policies of a company", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, nil, nil, nil, nil, nil, nil, policiesCfg.Config{}, cfg.Config{}, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		companyUuid := uuid.New()
+
+		Convey("Policies found by content keep their rank and snippet", func() {
+			rank, snippet := 0.6, "Require <mark>MFA</mark> for remote access"
+			repo.EXPECT().GetAllPolicyHistory(ctx, &companyUuid, "mfa", entities.SearchModeContent, nil).
+				Return([]*entities.GetAllPoliciesResponse{{
+					PolicyUUID:           uuid.New(),
+					Name:                 "Access Control",
+					Status:               entities.PolicyStatusApproved,
+					Rank:                 &rank,
+					Snippet:              &snippet,
+					PolicyReviewSchedule: &entities.PolicyReviewSchedule{},
+				}}, nil)
+
+			res, err := svc.GetAllPolicies(ctx, &entities.GetAllPoliciesRequest{CompanyUuid: companyUuid, Keyword: "mfa", SearchMode: entities.SearchModeContent})
+			So(err, ShouldBeNil)
+			So(res, ShouldHaveLength, 1)
+			So(*res[0].Rank, ShouldEqual, 0.6)
+			So(*res[0].Snippet, ShouldEqual, snippet)
+		})
+
+		Convey("Searching the content needs a keyword", func() {
+			_, err := svc.GetAllPolicies(ctx, &entities.GetAllPoliciesRequest{CompanyUuid: companyUuid, Keyword: " ", SearchMode: entities.SearchModeContent})
+
+			var validationErr *appError.ErrValidation
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+		})
+	})
+}
+
+func TestSaveDocument(t *testing.T) {
+	Convey("Given a policy saved by someone else since it was opened", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, nil, nil, nil, nil, nil, nil, policiesCfg.Config{}, cfg.Config{}, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		companyUuid, policyUuid := uuid.New(), uuid.New()
+		base := 2
+		req := &entities.SaveDocumentRequest{
+			CompanyUuid:             companyUuid,
+			UserUuid:                uuid.New(),
+			PolicyUUID:              policyUuid,
+			SaveDocumentRequestBody: &entities.SaveDocumentRequestBody{Name: "Access Control", Document: "<p>Mine</p>", BaseVersion: &base},
+		}
+
+		repo.EXPECT().SaveDocument(ctx, req).
+			Return(nil, &appError.ErrConflict{Message: "policy was saved as version 3 since version 2"})
+		repo.EXPECT().GetPolicyDocument(ctx, &companyUuid, &policyUuid, 0).Return(&entities.PolicyHistory{
+			Version:  3,
+			Document: "<p>Theirs</p>",
+			Policy:   entities.Policy{PolicyUuid: policyUuid, Name: "Access Control"},
+		}, nil)
+
+		Convey("The save is a conflict returned with the latest version", func() {
+			_, err := svc.SaveDocument(ctx, req)
+
+			var conflictErr *appError.ErrConflict
+			So(errors.As(err, &conflictErr), ShouldBeTrue)
+			So(conflictErr.Message, ShouldEqual, "policy was saved as version 3 since version 2")
+
+			latest, ok := conflictErr.Data.(*entities.GetPolicyDocumentResponse)
+			So(ok, ShouldBeTrue)
+			So(latest.Version, ShouldEqual, 3)
+			So(latest.Document, ShouldEqual, "<p>Theirs</p>")
+		})
+	})
+}
+
+func TestSaveDocumentWithoutBaseVersion(t *testing.T) {
+	Convey("Given a save without the version the document was edited from", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, nil, nil, nil, nil, nil, nil, policiesCfg.Config{}, cfg.Config{}, zap.NewNop().Sugar())
+
+		Convey("It is rejected before reaching the repository", func() {
+			_, err := svc.SaveDocument(context.Background(), &entities.SaveDocumentRequest{
+				CompanyUuid:             uuid.New(),
+				UserUuid:                uuid.New(),
+				PolicyUUID:              uuid.New(),
+				SaveDocumentRequestBody: &entities.SaveDocumentRequestBody{Name: "Access Control", Document: "<p>Mine</p>"},
+			})
+
+			var validationErr *appError.ErrValidation
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+			So(validationErr.Message, ShouldEqual, "base_version is required")
+		})
+	})
+}
+
+func TestGetPolicyHistoriesByPolicy(t *testing.T) {
+	Convey("Given the versions of a policy, the latest counted on save", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, nil, nil, nil, nil, nil, nil, policiesCfg.Config{}, cfg.Config{}, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		policyUuid := uuid.New()
+		counted := &htmldiff.Summary{Modified: 1, Unchanged: 1, Changed: 1}
+		phs := []*entities.PolicyHistory{
+			{PolicyHistoryUuid: uuid.New(), Version: 3, Document: "<p>Scope</p><p>MFA for all</p>", Changes: counted},
+			{PolicyHistoryUuid: uuid.New(), Version: 2, Document: "<p>Scope</p><p>MFA for admins</p>"},
+			{PolicyHistoryUuid: uuid.New(), Version: 1, Document: "<p>Scope</p>"},
+		}
+
+		repo.EXPECT().GetPolicyHistoriesByPolicyUuid(ctx, &policyUuid).Return(phs, nil)
+
+		Convey("Only the versions saved before changes were counted are compared, once", func() {
+			repo.EXPECT().UpdatePolicyHistoryChanges(ctx, phs[1].PolicyHistoryUuid, gomock.Any()).Return(nil)
+
+			res, err := svc.GetPolicyHistoriesByPolicy(ctx, &entities.GetPolicyHistoriesByPolicyRequest{PolicyUUID: policyUuid})
+			So(err, ShouldBeNil)
+			So(res, ShouldHaveLength, 3)
+			So(res[0].Changes, ShouldEqual, counted)
+			So(res[1].Changes.Inserted, ShouldEqual, 1)
+			So(res[1].Changes.Unchanged, ShouldEqual, 1)
+			So(res[2].Changes, ShouldBeNil)
+		})
+	})
+}
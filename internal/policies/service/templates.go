@@ -0,0 +1,219 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/htmldiff"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+)
+
+var templateUpdateTemplate = template.Must(template.New("policy-template-update").Parse(`<html><head> <style>p{line-height: 22px;}.review{width: 240px; height: 32px; background: #436AF3; border-radius: 4.16px; text-decoration: none; margin-top: 40px; margin-bottom: 40px; font-size: 16px; padding-top: 6.5px; text-align: center; display: block;}</style></head><body> <table width="600" cellpadding="0" cellspacing="0" align="center"> <tr> <td> <img alt="REDESIGN Logo" title="REDESIGN Logo" style="display:block" height="37px" src="https://redesigntrustportal-static-files.s3.us-west-2.amazonaws.com/rdt_logo_small.png"> <p>Hi {{.firstName}},</p><p>The template <strong>{{.templateName}}</strong> the policy <strong>{{.name}}</strong> was created from has a new version {{.version}}.</p><p>Please compare the policy to the new version of the template and bring in the changes which apply to your company.</p><p><a class="review" style="color: #FFFFFF" href="{{.policyLink}}">Compare policy</a></p><p>Thank you,</p><p>REDESIGN Trust Portal</p></td></tr></table></body></html>`))
+
+func (s *service) ListTemplateLibrary(ctx context.Context, _ *entities.GetTemplateLibraryRequest) ([]*entities.PolicyTemplates, error) {
+	return s.repo.ListTemplateLibrary(ctx)
+}
+
+func (s *service) GetTemplate(ctx context.Context, req *entities.GetTemplateRequest) (*entities.PolicyTemplates, error) {
+	return s.repo.GetTemplate(ctx, req.PolicyTemplateUuid)
+}
+
+func (s *service) CreateTemplate(ctx context.Context, req *entities.CreateTemplateRequest) (*entities.PolicyTemplates, error) {
+	if err := validateTemplate(req.Body); err != nil {
+		return nil, err
+	}
+
+	now := nullable.NewNullTime(time.Now())
+	pt := &entities.PolicyTemplates{
+		PolicyTemplateUuid: uuid.New(),
+		Name:               strings.TrimSpace(req.Body.Name),
+		Description:        req.Body.Description,
+		Document:           req.Body.Document,
+		IndustryType:       pq.StringArray(req.Body.IndustryType),
+		CompanyTypes:       pq.StringArray(req.Body.CompanyTypes),
+		Status:             entities.TemplateStatusDraft,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		CreatedBy:          req.UserUuid,
+		UpdatedBy:          req.UserUuid,
+	}
+
+	if err := s.repo.CreateTemplate(ctx, pt); err != nil {
+		return nil, err
+	}
+
+	return pt, nil
+}
+
+func (s *service) UpdateTemplate(ctx context.Context, req *entities.UpdateTemplateRequest) (*entities.PolicyTemplates, error) {
+	if err := validateTemplate(req.Body); err != nil {
+		return nil, err
+	}
+
+	err := s.repo.UpdateTemplate(ctx, &entities.PolicyTemplates{
+		PolicyTemplateUuid: req.PolicyTemplateUuid,
+		Name:               strings.TrimSpace(req.Body.Name),
+		Description:        req.Body.Description,
+		Document:           req.Body.Document,
+		IndustryType:       pq.StringArray(req.Body.IndustryType),
+		CompanyTypes:       pq.StringArray(req.Body.CompanyTypes),
+		UpdatedAt:          nullable.NewNullTime(time.Now()),
+		UpdatedBy:          req.UserUuid,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetTemplate(ctx, req.PolicyTemplateUuid)
+}
+
+// validateTemplate requires a name and merge fields companies can be filled with.
+func validateTemplate(body *entities.TemplateRequestBody) error {
+	if strings.TrimSpace(body.Name) == "" {
+		return &appError.ErrValidation{Message: "name is required"}
+	}
+
+	return validateMergeFields(body.Document)
+}
+
+func (s *service) DeleteTemplate(ctx context.Context, req *entities.GetTemplateRequest) error {
+	return s.repo.DeleteTemplate(ctx, req.PolicyTemplateUuid)
+}
+
+// PublishTemplate makes the working copy of the template the version companies see, and lets the
+// owners of the policies created from an older version know about it.
+func (s *service) PublishTemplate(ctx context.Context, req *entities.PublishTemplateRequest) (*entities.PublishTemplateResponse, error) {
+	pt, err := s.repo.GetTemplate(ctx, req.PolicyTemplateUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateMergeFields(pt.Document); err != nil {
+		return nil, err
+	}
+
+	version, updated, err := s.repo.PublishTemplate(ctx, req.PolicyTemplateUuid, req.UserUuid, req.Body.Comment, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.PublishTemplateResponse{
+		PolicyTemplateVersion: version,
+		UpdatedPolicies:       updated,
+	}, nil
+}
+
+func (s *service) GetTemplateVersions(ctx context.Context, req *entities.GetTemplateRequest) ([]*entities.PolicyTemplateVersion, error) {
+	if _, err := s.repo.GetTemplate(ctx, req.PolicyTemplateUuid); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetTemplateVersions(ctx, req.PolicyTemplateUuid)
+}
+
+// GetTemplateUpdate compares the current document of the policy to the new version of its
+// template, filled for the company and the owner of the policy.
+func (s *service) GetTemplateUpdate(ctx context.Context, req *entities.GetTemplateUpdateRequest) (*entities.GetTemplateUpdateResponse, error) {
+	update, err := s.repo.GetTemplateUpdate(ctx, req.CompanyUuid, req.PolicyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := s.repo.GetTemplateVersion(ctx, update.PolicyTemplateUuid, update.ToVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	// a policy without a document yet is compared to an empty one
+	current := ""
+	owner := req.UserUuid
+
+	ph, err := s.repo.GetPolicyDocument(ctx, &req.CompanyUuid, &req.PolicyUuid, 0)
+	if err == nil {
+		current = ph.Document
+		owner = ph.Policy.CreatedBy
+
+		if ph.Policy.ReviewOwnerUuid.Valid {
+			owner = ph.Policy.ReviewOwnerUuid.UUID
+		}
+	} else if !appError.IsNotFoundError(err) {
+		return nil, err
+	}
+
+	values, err := s.mergeValues(ctx, req.CompanyUuid, owner, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := renderMergeFields(version.Document, values)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := htmldiff.Compare(current, document)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.GetTemplateUpdateResponse{
+		PolicyTemplateUpdate: update,
+		TemplateName:         version.Name,
+		Diff:                 diff,
+	}, nil
+}
+
+// DismissTemplateUpdate once the changes of the new version of the template are brought in, or
+// are of no use to the company.
+func (s *service) DismissTemplateUpdate(ctx context.Context, req *entities.GetTemplateUpdateRequest) (*entities.PolicyTemplateUpdate, error) {
+	return s.repo.DismissTemplateUpdate(ctx, req.CompanyUuid, req.PolicyUuid, req.UserUuid, time.Now())
+}
+
+// SendTemplateUpdateNotifications emails the owners of the policies whose template has a new
+// version. A notification that fails to send is retried on the next call.
+func (s *service) SendTemplateUpdateNotifications(ctx context.Context, now time.Time) error {
+	notifications, err := s.repo.ClaimTemplateUpdateNotifications(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, notification := range notifications {
+		if err = s.sendTemplateUpdateNotification(ctx, notification); err == nil {
+			continue
+		}
+
+		s.logger.Errorf("failed to send template update of policy %s: %v", notification.PolicyUuid, err)
+
+		if err = s.repo.ReleaseTemplateUpdateNotification(ctx, notification); err != nil {
+			s.logger.Errorf("failed to release template update of policy %s: %v", notification.PolicyUuid, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *service) sendTemplateUpdateNotification(ctx context.Context, notification *entities.PolicyTemplateUpdateNotification) error {
+	var body bytes.Buffer
+
+	err := templateUpdateTemplate.Execute(&body, map[string]interface{}{
+		"firstName":    notification.OwnerFirstName,
+		"name":         notification.PolicyName,
+		"templateName": notification.TemplateName,
+		"version":      notification.ToVersion,
+		"policyLink":   fmt.Sprintf("https://%s/%s/%s", s.commonConfig.FrontendDomain, "policies-procedures", notification.PolicyUuid),
+	})
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("Policy template updated: %s", notification.TemplateName)
+
+	return s.emailClient.SendEmail(ctx, subject, body.String(), notification.OwnerEmail)
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company"
+	companyEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company_settings/address"
+	policiesCfg "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/config"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/repository"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/ses"
+	userEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/userclient"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/cfg"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/zap"
+)
+
+func TestTemplateLibrary(t *testing.T) {
+	Convey("Given the template library", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		svc := New(repo, nil, nil, nil, nil, nil, nil, policiesCfg.Config{}, cfg.Config{}, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		userUuid := uuid.New()
+
+		Convey("Templates are created as drafts targeting the industry and company types", func() {
+			repo.EXPECT().CreateTemplate(ctx, gomock.Any()).Return(nil)
+
+			pt, err := svc.CreateTemplate(ctx, &entities.CreateTemplateRequest{
+				UserUuid: userUuid,
+				Body: &entities.TemplateRequestBody{
+					Name:         " Access Control ",
+					Document:     "<p>{{company.name}}</p>",
+					IndustryType: []string{"Technology"},
+					CompanyTypes: []string{"customer"},
+				},
+			})
+			So(err, ShouldBeNil)
+			So(pt.Name, ShouldEqual, "Access Control")
+			So(pt.Status, ShouldEqual, entities.TemplateStatusDraft)
+			So(pt.Version, ShouldEqual, 0)
+			So([]string(pt.CompanyTypes), ShouldResemble, []string{"customer"})
+			So(pt.CreatedBy, ShouldEqual, userUuid)
+		})
+
+		Convey("Templates need a name and known merge fields", func() {
+			var validationErr *appError.ErrValidation
+
+			_, err := svc.CreateTemplate(ctx, &entities.CreateTemplateRequest{Body: &entities.TemplateRequestBody{Name: " "}})
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+			So(validationErr.Message, ShouldEqual, "name is required")
+
+			_, err = svc.UpdateTemplate(ctx, &entities.UpdateTemplateRequest{
+				Body: &entities.TemplateRequestBody{Name: "Access Control", Document: "<p>{{company.ceo}}</p>"},
+			})
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+			So(validationErr.Message, ShouldEqual, "unknown merge fields: {{company.ceo}}")
+		})
+
+		Convey("Publishing reports the policies to update", func() {
+			templateUuid := uuid.New()
+			version := &entities.PolicyTemplateVersion{PolicyTemplateUuid: templateUuid, Version: 3, Comment: "New MFA section"}
+
+			repo.EXPECT().GetTemplate(ctx, templateUuid).Return(&entities.PolicyTemplates{PolicyTemplateUuid: templateUuid, Document: "<p>{{company.name}}</p>"}, nil)
+			repo.EXPECT().PublishTemplate(ctx, templateUuid, userUuid, "New MFA section", gomock.Any()).Return(version, 2, nil)
+
+			res, err := svc.PublishTemplate(ctx, &entities.PublishTemplateRequest{
+				UserUuid:           userUuid,
+				PolicyTemplateUuid: templateUuid,
+				Body:               &entities.PublishTemplateRequestBody{Comment: "New MFA section"},
+			})
+			So(err, ShouldBeNil)
+			So(res.Version, ShouldEqual, 3)
+			So(res.UpdatedPolicies, ShouldEqual, 2)
+		})
+	})
+}
+
+func TestGetTemplateUpdate(t *testing.T) {
+	Convey("Given a policy whose template has a new version", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		companyClient := company.NewMockClient(ctrl)
+		addressClient := address.NewMockClient(ctrl)
+		userClient := userclient.NewMockClient(ctrl)
+		svc := New(repo, nil, nil, companyClient, addressClient, userClient, nil, policiesCfg.Config{}, cfg.Config{}, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		companyUuid, userUuid, ownerUuid, policyUuid, templateUuid := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+		from := 1
+		update := &entities.PolicyTemplateUpdate{PolicyUuid: policyUuid, PolicyTemplateUuid: templateUuid, FromVersion: &from, ToVersion: 2}
+		req := &entities.GetTemplateUpdateRequest{CompanyUuid: companyUuid, UserUuid: userUuid, PolicyUuid: policyUuid}
+
+		repo.EXPECT().GetTemplateUpdate(ctx, companyUuid, policyUuid).Return(update, nil)
+		repo.EXPECT().GetTemplateVersion(ctx, templateUuid, 2).Return(&entities.PolicyTemplateVersion{
+			Name:     "Access Control",
+			Document: "<p>{{company.name}} grants access.</p><p>Access is reviewed by {{owner.full_name}}.</p>",
+		}, nil)
+		repo.EXPECT().GetPolicyDocument(ctx, &companyUuid, &policyUuid, 0).Return(&entities.PolicyHistory{
+			Document: "<p>Acme grants access.</p>",
+			Policy:   entities.Policy{CreatedBy: userUuid, ReviewOwnerUuid: *nullable.NewNullUUID(ownerUuid)},
+		}, nil)
+		companyClient.EXPECT().FindByUUID(ctx, gomock.Any()).Return(&companyEntities.Company{CompanyUuid: companyUuid, Name: "Acme"}, nil)
+		userClient.EXPECT().GetUserByUuid(ctx, ownerUuid).Return(&userEntities.User{FirstName: "Jane", LastName: "Doe"}, nil)
+		addressClient.EXPECT().GetPrimaryAddress(ctx, &companyUuid).Return(nil, &appError.ErrNotFound{Message: "company address not found"})
+
+		Convey("The policy is compared to the new version filled for the company and the review owner", func() {
+			res, err := svc.GetTemplateUpdate(ctx, req)
+			So(err, ShouldBeNil)
+			So(res.TemplateName, ShouldEqual, "Access Control")
+			So(res.ToVersion, ShouldEqual, 2)
+			So(res.Diff.Summary.Inserted, ShouldEqual, 1)
+			So(res.Diff.Summary.Deleted, ShouldEqual, 0)
+			So(res.Diff.HTML, ShouldContainSubstring, "Access is reviewed by Jane Doe.")
+		})
+	})
+}
+
+func TestSendTemplateUpdateNotifications(t *testing.T) {
+	Convey("Given template updates to notify", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := repository.NewMockRepository(ctrl)
+		emailClient := ses.NewMockClient(ctrl)
+		svc := New(repo, nil, nil, nil, nil, nil, emailClient, policiesCfg.Config{}, cfg.Config{FrontendDomain: "portal.example.com"}, zap.NewNop().Sugar())
+
+		ctx := context.Background()
+		now := time.Date(2023, 4, 2, 9, 0, 0, 0, time.UTC)
+
+		accessControl := &entities.PolicyTemplateUpdateNotification{
+			PolicyUuid:   uuid.New(),
+			PolicyName:   "Acme Access Control",
+			TemplateName: "Access Control",
+			ToVersion:    2,
+			OwnerEmail:   "alice@customer",
+		}
+		incidentResponse := &entities.PolicyTemplateUpdateNotification{
+			PolicyUuid:   uuid.New(),
+			PolicyName:   "Incident Response",
+			TemplateName: "Incident Response",
+			ToVersion:    4,
+			OwnerEmail:   "bob@customer",
+		}
+
+		repo.EXPECT().ClaimTemplateUpdateNotifications(ctx, now).
+			Return([]*entities.PolicyTemplateUpdateNotification{accessControl, incidentResponse}, nil)
+
+		Convey("Owners are emailed a link to their policy", func() {
+			emailClient.EXPECT().SendEmail(ctx, "Policy template updated: Access Control", gomock.Any(), "alice@customer").
+				DoAndReturn(func(_ context.Context, _, body, _ string) error {
+					So(body, ShouldContainSubstring, "has a new version 2")
+					So(body, ShouldContainSubstring, "https://portal.example.com/policies-procedures/"+accessControl.PolicyUuid.String())
+
+					return nil
+				})
+			emailClient.EXPECT().SendEmail(ctx, "Policy template updated: Incident Response", gomock.Any(), "bob@customer").Return(nil)
+
+			So(svc.SendTemplateUpdateNotifications(ctx, now), ShouldBeNil)
+		})
+
+		Convey("Notifications that fail to send are released", func() {
+			emailClient.EXPECT().SendEmail(ctx, gomock.Any(), gomock.Any(), "alice@customer").Return(errors.New("throttled"))
+			emailClient.EXPECT().SendEmail(ctx, gomock.Any(), gomock.Any(), "bob@customer").Return(nil)
+			repo.EXPECT().ReleaseTemplateUpdateNotification(ctx, accessControl).Return(nil)
+
+			So(svc.SendTemplateUpdateNotifications(ctx, now), ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	authErrors "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+	"github.com/pkg/errors"
+)
+
+// policyTransitions maps a status to the statuses a policy may move to. Saving a new version
+// moves a policy back to Draft whatever its status.
+var policyTransitions = map[string][]string{
+	entities.PolicyStatusDraft:     {entities.PolicyStatusSubmitted, entities.PolicyStatusInactive},
+	entities.PolicyStatusSubmitted: {entities.PolicyStatusApproved, entities.PolicyStatusRejected, entities.PolicyStatusDraft},
+	entities.PolicyStatusApproved:  {entities.PolicyStatusInactive},
+	entities.PolicyStatusRejected:  {entities.PolicyStatusDraft, entities.PolicyStatusSubmitted},
+	entities.PolicyStatusInactive:  {entities.PolicyStatusDraft},
+}
+
+func canTransition(from, to string) bool {
+	for _, status := range policyTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *service) UpdatePolicyStatus(ctx context.Context, companyUuid, userUuid, policyUuid *uuid.UUID, req *entities.UpdatePolicyDocumentStatusPatchRequestBody) (*entities.UpdatePolicyDocumentResponse, error) {
+	// approving or rejecting a policy needs more than the update permission of the endpoint
+	if req.Status == entities.PolicyStatusApproved || req.Status == entities.PolicyStatusRejected {
+		if err := s.authClient.Authorize(ctx, "policies-procedures", permissions.ActionApprove); err != nil {
+			return nil, err
+		}
+	}
+
+	policy, err := s.repo.UpdatePolicyWorkflow(ctx, companyUuid, policyUuid, func(workflow *entities.PolicyWorkflow) (*entities.PolicyWorkflowChange, error) {
+		return decide(workflow, *userUuid, req, time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.UpdatePolicyDocumentResponse{
+		PolicyUuid: *policyUuid,
+		Status:     policy.Status,
+		Comment:    req.Comment,
+	}, nil
+}
+
+// decide the change the actor makes to the workflow of a policy by requesting its status.
+// Approving only records the review of the actor until the approval rule of the policy is met.
+func decide(workflow *entities.PolicyWorkflow, actor uuid.UUID, req *entities.UpdatePolicyDocumentStatusPatchRequestBody, now time.Time) (*entities.PolicyWorkflowChange, error) {
+	from, to := workflow.Policy.Status, req.Status
+
+	if _, ok := policyTransitions[to]; !ok {
+		return nil, &appError.ErrValidation{Message: fmt.Sprintf("invalid policy status %q", to)}
+	}
+
+	if !canTransition(from, to) {
+		return nil, &appError.ErrValidation{Message: fmt.Sprintf("policy can't move from %s to %s", from, to)}
+	}
+
+	transition := &entities.PolicyStatusHistory{
+		PolicyStatusHistoryUuid: uuid.New(),
+		PolicyUuid:              workflow.Policy.PolicyUuid,
+		FromStatus:              from,
+		ToStatus:                to,
+		Comment:                 strings.TrimSpace(req.Comment),
+		CreatedAt:               now,
+		CreatedBy:               actor,
+	}
+
+	if workflow.LatestVersion != nil {
+		transition.PolicyHistoryUuid = *nullable.NewNullUUID(workflow.LatestVersion.PolicyHistoryUuid)
+	}
+
+	switch to {
+	case entities.PolicyStatusSubmitted:
+		if workflow.LatestVersion == nil {
+			return nil, &appError.ErrValidation{Message: "policy document should be saved before it is submitted"}
+		}
+
+		if len(workflow.Reviewers) == 0 {
+			return nil, &appError.ErrValidation{Message: "policy should have reviewers before it is submitted"}
+		}
+	case entities.PolicyStatusApproved, entities.PolicyStatusRejected:
+		return review(workflow, transition, now)
+	}
+
+	return &entities.PolicyWorkflowChange{Transition: transition}, nil
+}
+
+// review of the submitted policy by the actor of the transition.
+func review(workflow *entities.PolicyWorkflow, transition *entities.PolicyStatusHistory, now time.Time) (*entities.PolicyWorkflowChange, error) {
+	actor := transition.CreatedBy
+
+	if !isReviewer(workflow, actor) {
+		return nil, errors.WithMessage(authErrors.ErrNoPermission, "only reviewers of the policy can approve or reject it")
+	}
+
+	if workflow.Submission == nil {
+		return nil, &appError.ErrValidation{Message: "policy should be submitted again for review"}
+	}
+
+	for _, r := range workflow.Reviews {
+		if r.ReviewerUuid == actor {
+			return nil, &appError.ErrValidation{Message: "policy already reviewed"}
+		}
+	}
+
+	if transition.ToStatus == entities.PolicyStatusRejected && transition.Comment == "" {
+		return nil, &appError.ErrValidation{Message: "comment is required to reject a policy"}
+	}
+
+	// the decision applies to the version that was submitted
+	transition.PolicyHistoryUuid = workflow.Submission.PolicyHistoryUuid
+
+	change := &entities.PolicyWorkflowChange{
+		Review: &entities.PolicyReview{
+			PolicyStatusHistoryUuid: workflow.Submission.PolicyStatusHistoryUuid,
+			PolicyHistoryUuid:       workflow.Submission.PolicyHistoryUuid.UUID,
+			ReviewerUuid:            actor,
+			Decision:                transition.ToStatus,
+			Comment:                 transition.Comment,
+			CreatedAt:               now,
+		},
+	}
+
+	if transition.ToStatus == entities.PolicyStatusRejected || isApproved(workflow, actor) {
+		change.Transition = transition
+	}
+
+	return change, nil
+}
+
+func isReviewer(workflow *entities.PolicyWorkflow, userUuid uuid.UUID) bool {
+	for _, r := range workflow.Reviewers {
+		if r.UserUuid == userUuid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isApproved tells if the approval of the actor meets the approval rule of the policy.
+func isApproved(workflow *entities.PolicyWorkflow, actor uuid.UUID) bool {
+	if workflow.Policy.ApprovalRule == entities.ApprovalRuleAny {
+		return true
+	}
+
+	approved := map[uuid.UUID]bool{actor: true}
+	for _, r := range workflow.Reviews {
+		if r.Decision == entities.PolicyStatusApproved {
+			approved[r.ReviewerUuid] = true
+		}
+	}
+
+	for _, r := range workflow.Reviewers {
+		if !approved[r.UserUuid] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *service) SetPolicyReviewers(ctx context.Context, req *entities.SetPolicyReviewersRequest) (*entities.GetPolicyReviewersResponse, error) {
+	workflow, err := s.repo.GetPolicyWorkflow(ctx, &req.CompanyUuid, &req.PolicyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	// the owner picks the reviewers of a policy, approvers may change them
+	if workflow.Policy.CreatedBy != req.UserUuid {
+		if err = s.authClient.Authorize(ctx, "policies-procedures", permissions.ActionApprove); err != nil {
+			return nil, err
+		}
+	}
+
+	status := workflow.Policy.Status
+	if status != entities.PolicyStatusDraft && status != entities.PolicyStatusRejected {
+		return nil, &appError.ErrValidation{Message: fmt.Sprintf("reviewers of a %s policy can't be changed", status)}
+	}
+
+	approvalRule := req.Body.ApprovalRule
+	switch approvalRule {
+	case "":
+		approvalRule = entities.ApprovalRuleAll
+	case entities.ApprovalRuleAll, entities.ApprovalRuleAny:
+	default:
+		return nil, &appError.ErrValidation{Message: "approval_rule should be either all or any"}
+	}
+
+	now := time.Now()
+	seen := make(map[uuid.UUID]bool, len(req.Body.Reviewers))
+	reviewers := make([]*entities.PolicyReviewer, 0, len(req.Body.Reviewers))
+
+	for _, userUuid := range req.Body.Reviewers {
+		if userUuid == uuid.Nil || seen[userUuid] {
+			continue
+		}
+
+		seen[userUuid] = true
+		reviewers = append(reviewers, &entities.PolicyReviewer{
+			PolicyUuid: req.PolicyUuid,
+			UserUuid:   userUuid,
+			CreatedAt:  now,
+			CreatedBy:  req.UserUuid,
+		})
+	}
+
+	if len(reviewers) == 0 {
+		return nil, &appError.ErrValidation{Message: "at least one reviewer is required"}
+	}
+
+	err = s.repo.SetPolicyReviewers(ctx, &req.CompanyUuid, &req.PolicyUuid, reviewers, approvalRule)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetPolicyReviewers(ctx, &entities.GetPolicyReviewersRequest{
+		CompanyUuid: req.CompanyUuid,
+		UserUuid:    req.UserUuid,
+		PolicyUuid:  req.PolicyUuid,
+	})
+}
+
+func (s *service) GetPolicyReviewers(ctx context.Context, req *entities.GetPolicyReviewersRequest) (*entities.GetPolicyReviewersResponse, error) {
+	workflow, err := s.repo.GetPolicyWorkflow(ctx, &req.CompanyUuid, &req.PolicyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &entities.GetPolicyReviewersResponse{
+		PolicyUuid:   workflow.Policy.PolicyUuid,
+		Status:       workflow.Policy.Status,
+		ApprovalRule: workflow.Policy.ApprovalRule,
+		Reviewers:    make([]*entities.PolicyReviewerStatus, 0, len(workflow.Reviewers)),
+	}
+
+	if workflow.LatestVersion != nil {
+		res.Version = workflow.LatestVersion.Version
+	}
+
+	reviews := make(map[uuid.UUID]*entities.PolicyReview, len(workflow.Reviews))
+	for _, r := range workflow.Reviews {
+		reviews[r.ReviewerUuid] = r
+	}
+
+	for _, r := range workflow.Reviewers {
+		status := &entities.PolicyReviewerStatus{
+			Reviewer: entities.NewUserInfo(r.User.UserUuid, r.User.FirstName, r.User.LastName, "", r.User.Email),
+		}
+
+		if review, ok := reviews[r.UserUuid]; ok {
+			status.Decision = review.Decision
+			status.Comment = review.Comment
+			status.DecidedAt = &review.CreatedAt
+		}
+
+		res.Reviewers = append(res.Reviewers, status)
+	}
+
+	return res, nil
+}
+
+func (s *service) GetPolicyStatusHistory(ctx context.Context, req *entities.GetPolicyStatusHistoryRequest) ([]*entities.GetPolicyStatusHistoryResponse, error) {
+	transitions, err := s.repo.GetPolicyStatusHistory(ctx, &req.CompanyUuid, &req.PolicyUuid)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*entities.GetPolicyStatusHistoryResponse, 0, len(transitions))
+	for _, t := range transitions {
+		r := &entities.GetPolicyStatusHistoryResponse{
+			FromStatus: t.FromStatus,
+			ToStatus:   t.ToStatus,
+			Comment:    t.Comment,
+			CreatedAt:  t.CreatedAt,
+			CreatedBy:  entities.NewUserInfo(t.Created.UserUuid, t.Created.FirstName, t.Created.LastName, "", t.Created.Email),
+		}
+
+		if t.PolicyHistoryUuid.Valid {
+			r.PolicyHistoryUuid = &t.PolicyHistoryUuid.UUID
+		}
+
+		if t.PolicyHistory != nil {
+			r.Version = t.PolicyHistory.Version
+		}
+
+		res = append(res, r)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	authErrors "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/entities"
+	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDecide(t *testing.T) {
+	Convey("Given a policy with a saved version and two reviewers", t, func() {
+		now := time.Date(2023, 3, 28, 9, 0, 0, 0, time.UTC)
+		owner, alice, bob := uuid.New(), uuid.New(), uuid.New()
+		version := &entities.PolicyHistory{PolicyHistoryUuid: uuid.New(), Version: 2}
+
+		workflow := &entities.PolicyWorkflow{
+			Policy: &entities.Policy{
+				PolicyUuid:   uuid.New(),
+				Status:       entities.PolicyStatusDraft,
+				ApprovalRule: entities.ApprovalRuleAll,
+				CreatedBy:    owner,
+			},
+			LatestVersion: version,
+			Reviewers:     []*entities.PolicyReviewer{{UserUuid: alice}, {UserUuid: bob}},
+		}
+
+		submitted := func() {
+			workflow.Policy.Status = entities.PolicyStatusSubmitted
+			workflow.Submission = &entities.PolicyStatusHistory{
+				PolicyStatusHistoryUuid: uuid.New(),
+				PolicyHistoryUuid:       *nullable.NewNullUUID(version.PolicyHistoryUuid),
+				ToStatus:                entities.PolicyStatusSubmitted,
+			}
+		}
+
+		status := func(s, comment string) *entities.UpdatePolicyDocumentStatusPatchRequestBody {
+			return &entities.UpdatePolicyDocumentStatusPatchRequestBody{Status: s, Comment: comment}
+		}
+
+		Convey("Submitting it records a transition linked to the latest version", func() {
+			change, err := decide(workflow, owner, status(entities.PolicyStatusSubmitted, ""), now)
+			So(err, ShouldBeNil)
+			So(change.Review, ShouldBeNil)
+			So(change.Transition.FromStatus, ShouldEqual, entities.PolicyStatusDraft)
+			So(change.Transition.ToStatus, ShouldEqual, entities.PolicyStatusSubmitted)
+			So(change.Transition.PolicyHistoryUuid.UUID, ShouldEqual, version.PolicyHistoryUuid)
+			So(change.Transition.CreatedBy, ShouldEqual, owner)
+		})
+
+		Convey("It can't be submitted without reviewers or a saved version", func() {
+			workflow.Reviewers = nil
+			_, err := decide(workflow, owner, status(entities.PolicyStatusSubmitted, ""), now)
+
+			var validationErr *appError.ErrValidation
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+
+			workflow.Reviewers = []*entities.PolicyReviewer{{UserUuid: alice}}
+			workflow.LatestVersion = nil
+			_, err = decide(workflow, owner, status(entities.PolicyStatusSubmitted, ""), now)
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+		})
+
+		Convey("Transitions that aren't allowed or unknown statuses are rejected", func() {
+			var validationErr *appError.ErrValidation
+
+			_, err := decide(workflow, alice, status(entities.PolicyStatusApproved, ""), now)
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+
+			_, err = decide(workflow, alice, status("Published", ""), now)
+			So(errors.As(err, &validationErr), ShouldBeTrue)
+		})
+
+		Convey("Once submitted", func() {
+			submitted()
+
+			Convey("Only reviewers can approve it", func() {
+				_, err := decide(workflow, owner, status(entities.PolicyStatusApproved, ""), now)
+				So(errors.Cause(err), ShouldEqual, authErrors.ErrNoPermission)
+			})
+
+			Convey("The first of two approvals only records the review", func() {
+				change, err := decide(workflow, alice, status(entities.PolicyStatusApproved, ""), now)
+				So(err, ShouldBeNil)
+				So(change.Transition, ShouldBeNil)
+				So(change.Review.ReviewerUuid, ShouldEqual, alice)
+				So(change.Review.PolicyStatusHistoryUuid, ShouldEqual, workflow.Submission.PolicyStatusHistoryUuid)
+				So(change.Review.PolicyHistoryUuid, ShouldEqual, version.PolicyHistoryUuid)
+			})
+
+			Convey("The last approval approves it", func() {
+				workflow.Reviews = []*entities.PolicyReview{{ReviewerUuid: alice, Decision: entities.PolicyStatusApproved}}
+
+				change, err := decide(workflow, bob, status(entities.PolicyStatusApproved, ""), now)
+				So(err, ShouldBeNil)
+				So(change.Review, ShouldNotBeNil)
+				So(change.Transition.ToStatus, ShouldEqual, entities.PolicyStatusApproved)
+			})
+
+			Convey("A single approval approves it when any reviewer may approve", func() {
+				workflow.Policy.ApprovalRule = entities.ApprovalRuleAny
+
+				change, err := decide(workflow, bob, status(entities.PolicyStatusApproved, ""), now)
+				So(err, ShouldBeNil)
+				So(change.Transition.ToStatus, ShouldEqual, entities.PolicyStatusApproved)
+			})
+
+			Convey("Reviewers can't review it twice", func() {
+				workflow.Reviews = []*entities.PolicyReview{{ReviewerUuid: alice, Decision: entities.PolicyStatusApproved}}
+
+				_, err := decide(workflow, alice, status(entities.PolicyStatusApproved, ""), now)
+
+				var validationErr *appError.ErrValidation
+				So(errors.As(err, &validationErr), ShouldBeTrue)
+			})
+
+			Convey("Rejecting it needs a comment", func() {
+				_, err := decide(workflow, bob, status(entities.PolicyStatusRejected, "  "), now)
+
+				var validationErr *appError.ErrValidation
+				So(errors.As(err, &validationErr), ShouldBeTrue)
+
+				change, err := decide(workflow, bob, status(entities.PolicyStatusRejected, "Missing scope"), now)
+				So(err, ShouldBeNil)
+				So(change.Review.Decision, ShouldEqual, entities.PolicyStatusRejected)
+				So(change.Transition.ToStatus, ShouldEqual, entities.PolicyStatusRejected)
+				So(change.Transition.Comment, ShouldEqual, "Missing scope")
+			})
+		})
+	})
+}
@@ -15,7 +15,9 @@ import (
 )
 
 type RequestBodyType interface {
-	entities.Policy | entities.UpdatePolicyDocumentStatusPatchRequestBody | entities.SaveDocumentRequestBody
+	entities.Policy | entities.UpdatePolicyDocumentStatusPatchRequestBody | entities.SaveDocumentRequestBody |
+		entities.SetPolicyReviewersRequestBody | entities.UpdatePolicyReviewScheduleRequestBody |
+		entities.TemplateRequestBody | entities.PublishTemplateRequestBody
 }
 
 func decodeBodyFromRequest[T RequestBodyType](req *T, r *http.Request) error {
@@ -43,10 +45,29 @@ func decodeGetAllPoliciesRequest(_ context.Context, r *http.Request) (interface{
 
 	keyword := r.URL.Query().Get("keyword")
 
+	searchMode := r.URL.Query().Get("search_mode")
+	switch searchMode {
+	case "":
+		searchMode = entities.SearchModeName
+	case entities.SearchModeName, entities.SearchModeContent:
+	default:
+		return nil, httpError.NewErrBadOrInvalidPathParameter("search_mode")
+	}
+
+	var needsReview bool
+	if v := r.URL.Query().Get("needs_review"); v != "" {
+		needsReview, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, httpError.NewErrBadOrInvalidPathParameter("needs_review")
+		}
+	}
+
 	req := &entities.GetAllPoliciesRequest{
 		CompanyUuid: compUUID,
 		UserUuid:    userUUID,
 		Keyword:     keyword,
+		SearchMode:  searchMode,
+		NeedsReview: needsReview,
 	}
 
 	return req, nil
@@ -338,3 +359,389 @@ func decodeCreateDocumentFromTemplateRequest(_ context.Context, r *http.Request)
 
 	return req, nil
 }
+
+func decodePreviewTemplateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	params := mux.Vars(r)
+	compUUID, err := uuid.Parse(params["company_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("company_id")
+	}
+
+	userUUID, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("user_id")
+	}
+
+	policyTemplateUuid, err := uuid.Parse(params["template_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("template_id")
+	}
+
+	req := &entities.PreviewTemplateRequest{
+		CompanyUuid:        compUUID,
+		UserUuid:           userUUID,
+		PolicyTemplateUuid: policyTemplateUuid,
+	}
+
+	return req, nil
+}
+
+func decodeSetPolicyReviewersRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	params := mux.Vars(r)
+	compUUID, err := uuid.Parse(params["company_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("company_id")
+	}
+
+	userUUID, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("user_id")
+	}
+
+	policyUUID, err := uuid.Parse(params["policy_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("policy_id")
+	}
+
+	body := &entities.SetPolicyReviewersRequestBody{}
+
+	err = decodeBodyFromRequest(body, r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &entities.SetPolicyReviewersRequest{
+		CompanyUuid: compUUID,
+		UserUuid:    userUUID,
+		PolicyUuid:  policyUUID,
+		Body:        body,
+	}
+
+	return req, nil
+}
+
+func decodeGetPolicyReviewersRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	params := mux.Vars(r)
+	compUUID, err := uuid.Parse(params["company_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("company_id")
+	}
+
+	userUUID, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("user_id")
+	}
+
+	policyUUID, err := uuid.Parse(params["policy_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("policy_id")
+	}
+
+	req := &entities.GetPolicyReviewersRequest{
+		CompanyUuid: compUUID,
+		UserUuid:    userUUID,
+		PolicyUuid:  policyUUID,
+	}
+
+	return req, nil
+}
+
+func decodeGetPolicyStatusHistoryRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	params := mux.Vars(r)
+	compUUID, err := uuid.Parse(params["company_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("company_id")
+	}
+
+	userUUID, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("user_id")
+	}
+
+	policyUUID, err := uuid.Parse(params["policy_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("policy_id")
+	}
+
+	req := &entities.GetPolicyStatusHistoryRequest{
+		CompanyUuid: compUUID,
+		UserUuid:    userUUID,
+		PolicyUuid:  policyUUID,
+	}
+
+	return req, nil
+}
+
+func decodeGetPolicyDiffRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	params := mux.Vars(r)
+	compUUID, err := uuid.Parse(params["company_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("company_id")
+	}
+
+	userUUID, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("user_id")
+	}
+
+	policyUUID, err := uuid.Parse(params["policy_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("policy_id")
+	}
+
+	req := &entities.GetPolicyDiffRequest{
+		CompanyUuid: compUUID,
+		UserUuid:    userUUID,
+		PolicyUuid:  policyUUID,
+	}
+
+	if req.From, err = decodeVersionQuery(r, "from"); err != nil {
+		return nil, err
+	}
+
+	if req.To, err = decodeVersionQuery(r, "to"); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// decodeVersionQuery of the query parameter name, 0 when it is missing.
+func decodeVersionQuery(r *http.Request, name string) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return 0, nil
+	}
+
+	version, err := strconv.Atoi(v)
+	if err != nil || version < 1 {
+		return 0, httpError.NewErrBadOrInvalidPathParameter(name)
+	}
+
+	return version, nil
+}
+
+func decodeGetPolicyReviewScheduleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	params := mux.Vars(r)
+	compUUID, err := uuid.Parse(params["company_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("company_id")
+	}
+
+	userUUID, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("user_id")
+	}
+
+	policyUUID, err := uuid.Parse(params["policy_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("policy_id")
+	}
+
+	req := &entities.GetPolicyReviewScheduleRequest{
+		CompanyUuid: compUUID,
+		UserUuid:    userUUID,
+		PolicyUuid:  policyUUID,
+	}
+
+	return req, nil
+}
+
+func decodeUpdatePolicyReviewScheduleRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	params := mux.Vars(r)
+	compUUID, err := uuid.Parse(params["company_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("company_id")
+	}
+
+	userUUID, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("user_id")
+	}
+
+	policyUUID, err := uuid.Parse(params["policy_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("policy_id")
+	}
+
+	body := &entities.UpdatePolicyReviewScheduleRequestBody{}
+
+	err = decodeBodyFromRequest(body, r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &entities.UpdatePolicyReviewScheduleRequest{
+		CompanyUuid: compUUID,
+		UserUuid:    userUUID,
+		PolicyUuid:  policyUUID,
+		Body:        body,
+	}
+
+	return req, nil
+}
+
+func decodeGetTemplateLibraryRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	params := mux.Vars(r)
+	compUUID, err := uuid.Parse(params["company_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("company_id")
+	}
+
+	userUUID, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("user_id")
+	}
+
+	req := &entities.GetTemplateLibraryRequest{
+		CompanyUuid: compUUID,
+		UserUuid:    userUUID,
+	}
+
+	return req, nil
+}
+
+func decodeCreateTemplateRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	library, err := decodeGetTemplateLibraryRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &entities.TemplateRequestBody{}
+
+	err = decodeBodyFromRequest(body, r)
+	if err != nil {
+		return nil, err
+	}
+
+	l := library.(*entities.GetTemplateLibraryRequest)
+	req := &entities.CreateTemplateRequest{
+		CompanyUuid: l.CompanyUuid,
+		UserUuid:    l.UserUuid,
+		Body:        body,
+	}
+
+	return req, nil
+}
+
+func decodeGetTemplateRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	library, err := decodeGetTemplateLibraryRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	policyTemplateUuid, err := uuid.Parse(mux.Vars(r)["template_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("template_id")
+	}
+
+	l := library.(*entities.GetTemplateLibraryRequest)
+	req := &entities.GetTemplateRequest{
+		CompanyUuid:        l.CompanyUuid,
+		UserUuid:           l.UserUuid,
+		PolicyTemplateUuid: policyTemplateUuid,
+	}
+
+	return req, nil
+}
+
+func decodeUpdateTemplateRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	template, err := decodeGetTemplateRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &entities.TemplateRequestBody{}
+
+	err = decodeBodyFromRequest(body, r)
+	if err != nil {
+		return nil, err
+	}
+
+	t := template.(*entities.GetTemplateRequest)
+	req := &entities.UpdateTemplateRequest{
+		CompanyUuid:        t.CompanyUuid,
+		UserUuid:           t.UserUuid,
+		PolicyTemplateUuid: t.PolicyTemplateUuid,
+		Body:               body,
+	}
+
+	return req, nil
+}
+
+func decodePublishTemplateRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	template, err := decodeGetTemplateRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	// the comment is optional, so is the body
+	body := &entities.PublishTemplateRequestBody{}
+	if r.ContentLength != 0 {
+		if err = decodeBodyFromRequest(body, r); err != nil {
+			return nil, err
+		}
+	}
+
+	t := template.(*entities.GetTemplateRequest)
+	req := &entities.PublishTemplateRequest{
+		CompanyUuid:        t.CompanyUuid,
+		UserUuid:           t.UserUuid,
+		PolicyTemplateUuid: t.PolicyTemplateUuid,
+		Body:               body,
+	}
+
+	return req, nil
+}
+
+func decodeGetTemplateUpdateRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	params := mux.Vars(r)
+	compUUID, err := uuid.Parse(params["company_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("company_id")
+	}
+
+	userUUID, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("user_id")
+	}
+
+	policyUUID, err := uuid.Parse(params["policy_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("policy_id")
+	}
+
+	req := &entities.GetTemplateUpdateRequest{
+		CompanyUuid: compUUID,
+		UserUuid:    userUUID,
+		PolicyUuid:  policyUUID,
+	}
+
+	return req, nil
+}
+
+func decodeEditLockRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	params := mux.Vars(r)
+	compUUID, err := uuid.Parse(params["company_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("company_id")
+	}
+
+	userUUID, err := uuid.Parse(params["user_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("user_id")
+	}
+
+	policyUUID, err := uuid.Parse(params["policy_id"])
+	if err != nil {
+		return nil, httpError.NewErrBadOrInvalidPathParameter("policy_id")
+	}
+
+	req := &entities.PolicyEditLockRequest{
+		CompanyUuid: compUUID,
+		UserUuid:    userUUID,
+		PolicyUuid:  policyUUID,
+	}
+
+	return req, nil
+}
@@ -5,6 +5,7 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/policies/endpoints"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
@@ -29,12 +30,31 @@ func RegisterTransport(
 	registerGetStats(server, ep.GetPoliciesStatsEndpoint, authClient, svcTransportClient)
 	registerGetTemplates(server, ep.GetTemplatesEndpoint, authClient, svcTransportClient)
 	registerCreateDocumentFromTemplate(server, ep.CreateDocumentFromTemplateEndpoint, authClient, svcTransportClient)
+	registerPreviewTemplate(server, ep.PreviewTemplateEndpoint, authClient, svcTransportClient)
+	registerSetPolicyReviewers(server, ep.SetPolicyReviewersEndpoint, authClient, svcTransportClient)
+	registerGetPolicyReviewers(server, ep.GetPolicyReviewersEndpoint, authClient, svcTransportClient)
+	registerGetPolicyStatusHistory(server, ep.GetPolicyStatusHistoryEndpoint, authClient, svcTransportClient)
+	registerGetPolicyDiff(server, ep.GetPolicyDiffEndpoint, authClient, svcTransportClient)
+	registerGetPolicyReviewSchedule(server, ep.GetPolicyReviewScheduleEndpoint, authClient, svcTransportClient)
+	registerUpdatePolicyReviewSchedule(server, ep.UpdatePolicyReviewScheduleEndpoint, authClient, svcTransportClient)
+	registerListTemplateLibrary(server, ep.ListTemplateLibraryEndpoint, authClient, svcTransportClient)
+	registerCreateTemplate(server, ep.CreateTemplateEndpoint, authClient, svcTransportClient)
+	registerGetTemplate(server, ep.GetTemplateEndpoint, authClient, svcTransportClient)
+	registerUpdateTemplate(server, ep.UpdateTemplateEndpoint, authClient, svcTransportClient)
+	registerDeleteTemplate(server, ep.DeleteTemplateEndpoint, authClient, svcTransportClient)
+	registerPublishTemplate(server, ep.PublishTemplateEndpoint, authClient, svcTransportClient)
+	registerGetTemplateVersions(server, ep.GetTemplateVersionsEndpoint, authClient, svcTransportClient)
+	registerGetTemplateUpdate(server, ep.GetTemplateUpdateEndpoint, authClient, svcTransportClient)
+	registerDismissTemplateUpdate(server, ep.DismissTemplateUpdateEndpoint, authClient, svcTransportClient)
+	registerAcquireEditLock(server, ep.AcquireEditLockEndpoint, authClient, svcTransportClient)
+	registerGetEditLock(server, ep.GetEditLockEndpoint, authClient, svcTransportClient)
+	registerReleaseEditLock(server, ep.ReleaseEditLockEndpoint, authClient, svcTransportClient)
 }
 
 func registerGetAllPolicies(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/policies"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "policies-procedures", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetAllPoliciesRequest, encoder, atc, method)
 
@@ -45,7 +65,7 @@ func registerGetAllPolicies(server *httpTransport.Server, ep goKitEndpoint.Endpo
 func registerCreatePolicy(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/policy"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "policies-procedures", permissions.ActionCreate)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeCreatePolicyRequest, encoder, atc, method)
 
@@ -56,7 +76,7 @@ func registerCreatePolicy(server *httpTransport.Server, ep goKitEndpoint.Endpoin
 func registerGetPolicyDocument(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "policies-procedures", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetPolicyDocumentRequest, encoder, atc, method)
 
@@ -67,7 +87,7 @@ func registerGetPolicyDocument(server *httpTransport.Server, ep goKitEndpoint.En
 func registerSaveDocument(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/document"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "policies-procedures", permissions.ActionCreate)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeSaveDocumentRequest, encoder, atc, method)
 
@@ -78,7 +98,7 @@ func registerSaveDocument(server *httpTransport.Server, ep goKitEndpoint.Endpoin
 func registerGetDocument(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/document"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionExport)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encodeGetDocumentResponse, []string{method})
 	handler := getHandler(securedEp, decodeGetDocumentRequest, encoder, atc, method)
 
@@ -89,7 +109,7 @@ func registerGetDocument(server *httpTransport.Server, ep goKitEndpoint.Endpoint
 func registerGetPolicyHistoriesByPolicy(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/history"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetPolicyHistoriesByPolicyRequest, encoder, atc, method)
 
@@ -100,7 +120,7 @@ func registerGetPolicyHistoriesByPolicy(server *httpTransport.Server, ep goKitEn
 func registerUpdatePolicyDocumentPatch(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}"
 	method := "PATCH"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionUpdate)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeUpdatePolicyDocumentStatusRequest, encoder, atc, method)
 
@@ -111,7 +131,7 @@ func registerUpdatePolicyDocumentPatch(server *httpTransport.Server, ep goKitEnd
 func registerDeletePolicy(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}"
 	method := "DELETE"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionDelete)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeDeletePolicyRequest, encoder, atc, method)
 
@@ -122,7 +142,7 @@ func registerDeletePolicy(server *httpTransport.Server, ep goKitEndpoint.Endpoin
 func registerGetStats(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/policies/stats"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetPoliciesStatsRequest, encoder, atc, method)
 
@@ -133,7 +153,7 @@ func registerGetStats(server *httpTransport.Server, ep goKitEndpoint.Endpoint, a
 func registerGetTemplates(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/policies/templates"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetTemplatesRequest, encoder, atc, method)
 
@@ -144,7 +164,7 @@ func registerGetTemplates(server *httpTransport.Server, ep goKitEndpoint.Endpoin
 func registerCreateDocumentFromTemplate(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/policy/{template_id}"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionCreate)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeCreateDocumentFromTemplateRequest, encoder, atc, method)
 
@@ -152,6 +172,216 @@ func registerCreateDocumentFromTemplate(server *httpTransport.Server, ep goKitEn
 	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
 }
 
+func registerPreviewTemplate(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/policies/templates/{template_id}/preview"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodePreviewTemplateRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerSetPolicyReviewers(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/reviewers"
+	method := "PUT"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionUpdate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeSetPolicyReviewersRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerGetPolicyReviewers(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/reviewers"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetPolicyReviewersRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerGetPolicyStatusHistory(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/status-history"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetPolicyStatusHistoryRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerGetPolicyDiff(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/diff"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetPolicyDiffRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerGetPolicyReviewSchedule(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/review-schedule"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetPolicyReviewScheduleRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerUpdatePolicyReviewSchedule(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/review-schedule"
+	method := "PUT"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionUpdate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeUpdatePolicyReviewScheduleRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+// The template library is managed by engineering users, companies only see published templates.
+func registerListTemplateLibrary(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/policies/template-library"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policy-templates", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetTemplateLibraryRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerCreateTemplate(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/policies/template-library"
+	method := "POST"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policy-templates", permissions.ActionCreate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeCreateTemplateRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerGetTemplate(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/policies/template-library/{template_id}"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policy-templates", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetTemplateRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerUpdateTemplate(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/policies/template-library/{template_id}"
+	method := "PUT"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policy-templates", permissions.ActionUpdate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeUpdateTemplateRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerDeleteTemplate(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/policies/template-library/{template_id}"
+	method := "DELETE"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policy-templates", permissions.ActionDelete)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetTemplateRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerPublishTemplate(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/policies/template-library/{template_id}/publish"
+	method := "POST"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policy-templates", permissions.ActionUpdate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodePublishTemplateRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerGetTemplateVersions(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/policies/template-library/{template_id}/versions"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policy-templates", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetTemplateRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerGetTemplateUpdate(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/template-update"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetTemplateUpdateRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerDismissTemplateUpdate(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/template-update/dismiss"
+	method := "POST"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionUpdate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeGetTemplateUpdateRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerAcquireEditLock(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/edit-lock"
+	method := "PUT"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionUpdate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeEditLockRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerGetEditLock(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/edit-lock"
+	method := "GET"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionRead)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeEditLockRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
+func registerReleaseEditLock(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
+	path := "/companies/{company_id}/users/{user_id}/settings/policy/{policy_id}/edit-lock"
+	method := "DELETE"
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "policies-procedures", permissions.ActionUpdate)
+	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
+	handler := getHandler(securedEp, decodeEditLockRequest, encoder, atc, method)
+
+	server.Handle(method, path, handler)
+	atc.RegisterAccessControlOptionsHandler(server, path, []string{method})
+}
+
 func getHandler(ep goKitEndpoint.Endpoint, dec goKitHTTPTransport.DecodeRequestFunc, enc goKitHTTPTransport.EncodeResponseFunc, atc svcTransport.Client, method string) *goKitHTTPTransport.Server {
 	return goKitHTTPTransport.NewServer(
 		ep,
@@ -96,7 +96,7 @@ func (s *sqlRepository) GetCategories(ctx context.Context, companyUuid, userUuid
 		Joins("left join questionnaires q on q.questionnaires_uuid = fq.questionnaires_uuid").
 		Joins("left join company_frameworks cf on cf.frameworks_uuid = fq.frameworks_uuid").
 		Joins("left join questionnaire_answers qa on qa.questionnaires_uuid = q.questionnaires_uuid and qa.company_uuid = ?", companyUuid).
-		Where("cf.company_uuid = ?", companyUuid).
+		Where("cf.company_uuid = ? AND "+frameworkEntity.QuestionInProfileSQL, companyUuid).
 		Group("q.category").
 		Order("q.category").
 		Find(&cs).Error
@@ -110,15 +110,15 @@ func (s *sqlRepository) GetCategories(ctx context.Context, companyUuid, userUuid
 
 func (s *sqlRepository) GetQuestionnairesByCategory(ctx context.Context, companyUuid, userUuid *uuid.UUID, category string) ([]*entities.Questionnaires, error) {
 	var qs []*entities.Questionnaires
-	err := s.gormDB.WithContext(ctx).Table("questionnaires").
-		Joins("left join frameworks_questionnaires fq on fq.questionnaires_uuid = questionnaires.questionnaires_uuid").
+	err := s.gormDB.WithContext(ctx).Table("questionnaires q").
+		Joins("left join frameworks_questionnaires fq on fq.questionnaires_uuid = q.questionnaires_uuid").
 		Joins("left join company_frameworks cf on cf.frameworks_uuid = fq.frameworks_uuid").
 		Preload("Options").
 		Preload("Answer", "questionnaire_answers.company_uuid = ?", companyUuid).
 		Preload("Answer.Options").
 		Preload("Answer.Created").
-		Where("questionnaires.category = ? AND cf.company_uuid = ?", category, companyUuid).
-		Order("created_at desc").
+		Where("q.category = ? AND cf.company_uuid = ? AND "+frameworkEntity.QuestionInProfileSQL, category, companyUuid).
+		Order("q.created_at desc").
 		Find(&qs).Error
 
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/questionnaires/endpoints"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
@@ -31,7 +32,7 @@ func RegisterTransport(
 func registerGetCategories(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/categories"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "gap-analysis", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetQuestionnairesRequest, encoder, atc, method)
 
@@ -42,7 +43,7 @@ func registerGetCategories(server *httpTransport.Server, ep goKitEndpoint.Endpoi
 func registerGetQuestionnairesByCategory(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/categories/{category}/questionnaires"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "gap-analysis", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetQuestionnairesByCategoryRequest, encoder, atc, method)
 
@@ -53,7 +54,7 @@ func registerGetQuestionnairesByCategory(server *httpTransport.Server, ep goKitE
 func registerPostAnswer(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/questionnaires/answers"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "gap-analysis", permissions.ActionCreate)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeAddAnswerRequest, encoder, atc, method)
 
@@ -64,7 +65,7 @@ func registerPostAnswer(server *httpTransport.Server, ep goKitEndpoint.Endpoint,
 func registerAddEngineerFeedback(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/questionnaires/{questionnaire_id}/answers/{answer_id}/feedback"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "gap-analysis", permissions.ActionCreate)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeAddEngineerFeedbackRequest, encoder, atc, method)
 	server.Handle(method, path, handler)
@@ -74,7 +75,7 @@ func registerAddEngineerFeedback(server *httpTransport.Server, ep goKitEndpoint.
 func registerSubmitQuestionnaires(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/questionnaires/submit"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "gap-analysis", permissions.ActionCreate)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeSubmitQuestionnairesRequest, encoder, atc, method)
 	server.Handle(method, path, handler)
@@ -84,7 +85,7 @@ func registerSubmitQuestionnaires(server *httpTransport.Server, ep goKitEndpoint
 func registerAddAnswerWithEvidence(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/questionnaires/{questionnaire_id}/answer"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "gap-analysis", permissions.ActionCreate)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeUploadEvidencesRequest, encoder, atc, method)
 
@@ -95,7 +96,7 @@ func registerAddAnswerWithEvidence(server *httpTransport.Server, ep goKitEndpoin
 func registerUpdateAnswerWithEvidence(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/questionnaires/{questionnaire_id}/answers/{answer_id}"
 	method := "PUT"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "gap-analysis", permissions.ActionUpdate)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeUpdateAnswerRequest, encoder, atc, method)
 
@@ -106,7 +107,7 @@ func registerUpdateAnswerWithEvidence(server *httpTransport.Server, ep goKitEndp
 func registerDownloadAnswerEvidence(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/questionnaires/{questionnaire_id}/answers/{answer_id}/files/{file_id}"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "gap-analysis", permissions.ActionExport)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.DownloadFileResponse, []string{method})
 	handler := getHandler(securedEp, decodeDownloadEvidenceRequest, encoder, atc, method)
 
@@ -117,7 +118,7 @@ func registerDownloadAnswerEvidence(server *httpTransport.Server, ep goKitEndpoi
 func registerDeleteAnswerEvidence(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/questionnaires/{questionnaire_id}/answers/{answer_id}/files/{file_id}"
 	method := "DELETE"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "gap-analysis", method)
+	securedEp := authClient.SecureServiceWithAPIKeyEndpoint(ep, "gap-analysis", permissions.ActionDelete)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeDeleteEvidenceRequest, encoder, atc, method)
 
@@ -126,6 +126,21 @@ func (mr *MockClientMockRecorder) GetSubscriptionsByID(ctx, session, subscriptio
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionsByID", reflect.TypeOf((*MockClient)(nil).GetSubscriptionsByID), ctx, session, subscriptionsID)
 }
 
+// GetSubscriptionsByIDs mocks base method.
+func (m *MockClient) GetSubscriptionsByIDs(ctx context.Context, session *service.SFSession, subscriptionsIDs []string) ([]entities.Subscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionsByIDs", ctx, session, subscriptionsIDs)
+	ret0, _ := ret[0].([]entities.Subscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionsByIDs indicates an expected call of GetSubscriptionsByIDs.
+func (mr *MockClientMockRecorder) GetSubscriptionsByIDs(ctx, session, subscriptionsIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionsByIDs", reflect.TypeOf((*MockClient)(nil).GetSubscriptionsByIDs), ctx, session, subscriptionsIDs)
+}
+
 // NewSession mocks base method.
 func (m *MockClient) NewSession(ctx context.Context) (*service.SFSession, error) {
 	m.ctrl.T.Helper()
@@ -5,6 +5,7 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/securityawareness/endpoints"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
@@ -25,7 +26,7 @@ func RegisterTransport(
 func registerGetPhishingDetails(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/phishing"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "security-awareness", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "security-awareness", permissions.ActionRead)
 	handler := getHandler(securedEp, decodeGetPhishingDetailsRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -35,7 +36,7 @@ func registerGetPhishingDetails(server *httpTransport.Server, ep goKitEndpoint.E
 func registerGetTrainingDetails(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/training"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "security-awareness", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "security-awareness", permissions.ActionRead)
 	handler := getHandler(securedEp, decodeGetTrainingDetailsRequest, atc, method)
 
 	server.Handle(method, path, handler)
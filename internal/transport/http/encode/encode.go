@@ -20,6 +20,8 @@ import (
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/db"
 	appError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors"
 	httpError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors/http"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/jwt"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/webhook"
 	"github.com/pkg/errors"
 )
 
@@ -86,6 +88,7 @@ func Error(_ context.Context, err error, w http.ResponseWriter) {
 
 	var errCode int
 	var errMsg string
+	var data interface{} = &struct{}{}
 
 	switch {
 	case db.IsAlreadyExistError(err):
@@ -107,6 +110,9 @@ func Error(_ context.Context, err error, w http.ResponseWriter) {
 		} else {
 			errMsg = strings.Split(err.Error(), ":")[1]
 		}
+	case httpError.IsBadInvalidPathParameterError(err):
+		errCode = http.StatusBadRequest
+		errMsg = err.Error()
 	case httpError.IsFileNotSupportedError(err):
 		errCode = http.StatusBadRequest
 		errMsg = err.Error()
@@ -114,6 +120,17 @@ func Error(_ context.Context, err error, w http.ResponseWriter) {
 		errCode = http.StatusNotFound
 		errCause := errors.Cause(err)
 		errMsg = errCause.Error()
+	case appError.IsValidationError(err):
+		errCode = http.StatusBadRequest
+		errCause := errors.Cause(err)
+		errMsg = errCause.Error()
+	case appError.IsConflictError(err):
+		errCode = http.StatusConflict
+		errCause := errors.Cause(err).(*appError.ErrConflict) //nolint:errcheck
+		errMsg = errCause.Error()
+		if errCause.Data != nil {
+			data = errCause.Data
+		}
 	case sfError.IsSalesforceError(err):
 		sfErr := sfError.SalesforceError(err)
 		errCode = sfErr.HttpCode
@@ -122,6 +139,14 @@ func Error(_ context.Context, err error, w http.ResponseWriter) {
 		errCode = http.StatusUnauthorized
 		errCause := errors.Cause(err)
 		errMsg = errCause.Error()
+	case authError.IsInvalidAPIKeyError(err), authError.IsInvalidTokenError(err):
+		errCode = http.StatusUnauthorized
+		errCause := errors.Cause(err)
+		errMsg = errCause.Error()
+	case jwt.IsUnauthorizedError(err), webhook.IsUnauthorizedError(err):
+		errCode = http.StatusUnauthorized
+		errCause := errors.Cause(err)
+		errMsg = errCause.Error()
 	case authError.IsNoPermissionError(err):
 		errCode = http.StatusForbidden
 		errCause := errors.Cause(err)
@@ -138,7 +163,7 @@ func Error(_ context.Context, err error, w http.ResponseWriter) {
 	w.WriteHeader(errCode)
 
 	resp := httpInternal.Response{
-		Data:  &struct{}{},
+		Data:  data,
 		Error: &httpInternal.Error{Code: errCode, Message: errMsg},
 	}
 
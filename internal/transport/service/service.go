@@ -81,6 +81,7 @@ func (s *service) addAccessControlHeaders(w http.ResponseWriter, allowMethods []
 		string(auth.AuthorizationKey),
 		string(auth.RedesignTokenKey),
 		string(auth.Access),
+		string(auth.ImpersonationSessionKey),
 		"Host",
 		"Origin",
 	}, ","))
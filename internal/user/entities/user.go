@@ -5,6 +5,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	authEntity "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/entity"
 	companyEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company/entities"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/nullable"
 )
@@ -57,6 +58,16 @@ func (u *User) MarshalJSON() ([]byte, error) {
 	return json.Marshal(o)
 }
 
+// Statuses of a user in a company: PENDING until the invite is accepted, INACTIVE once deactivated.
+const (
+	CompanyUserStatusActive   = "ACTIVE"
+	CompanyUserStatusPending  = "PENDING"
+	CompanyUserStatusInactive = "INACTIVE"
+)
+
+// ActiveCompanyUserSQL is true when the company user cu is an active member of the company.
+const ActiveCompanyUserSQL = `(cu.status = '` + CompanyUserStatusActive + `')`
+
 type CompanyUser struct {
 	UserUuid    uuid.UUID         `json:"user_uuid,omitempty"`
 	CompanyUuid uuid.UUID         `json:"company_uuid,omitempty"`
@@ -97,6 +108,8 @@ type GetUserCompanyInfoByUserIdResponse struct {
 	IsMfaAppEnabled bool                       `json:"isMfaAppEnabled"`
 	Company         *companyEntities.Company   `json:"company,omitempty"`
 	Companies       []*companyEntities.Company `json:"companies,omitempty"`
+	// Impersonation is set when an engineering or csc user views the portal as this user.
+	Impersonation *authEntity.Impersonation `json:"impersonation,omitempty"`
 }
 
 type GetSFUserAndCompanyInfoRequest struct {
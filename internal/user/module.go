@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	sessions "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/service"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/onboarding"
@@ -34,13 +35,14 @@ type ModuleParams struct {
 	CognitoClient    cognito.Client
 	OnboardingClient onboarding.Client
 	EmailClient      ses.Client
+	SessionService   sessions.Service
 }
 
 // NewModule for redesign.
 // nolint:gocritic
 func NewModule(p ModuleParams) error {
 	repo := repository.New(p.DB)
-	svc := service.New(repo, p.CompanyClient, p.SalesforceClient, p.CognitoClient, p.OnboardingClient, p.EmailClient, p.CommonConfig)
+	svc := service.New(repo, p.CompanyClient, p.SalesforceClient, p.CognitoClient, p.OnboardingClient, p.EmailClient, p.SessionService, p.CommonConfig)
 	eps := endpoints.New(svc)
 
 	http.RegisterTransport(p.HTTPServer, eps, p.AuthClient, p.APPTransport)
@@ -12,6 +12,8 @@ import (
 	"github.com/google/uuid"
 	authError "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/errors"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/meta"
+	sessionsEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/entities"
+	sessions "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/service"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company"
 	companyEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company/entities"
@@ -46,6 +48,7 @@ type Service interface {
 	ResendUserInvite(ctx context.Context, CompanyUUID, UserUUID uuid.UUID, reqBody *entities.CreateUserRequestBody) error
 	UpdateUserDetails(ctx context.Context, reqBody *entities.UpdateUserRequest) (*entities.User, error)
 	GetUserByUuid(ctx context.Context, userUUID uuid.UUID) (*entities.User, error)
+	GetCompanyUser(ctx context.Context, userUUID, companyUUID uuid.UUID) (*entities.CompanyUser, error)
 	ListCompaniesForUser(ctx context.Context, userUUID uuid.UUID, keyword string) ([]*entities.GetCompaniesResponse, error)
 }
 
@@ -56,6 +59,7 @@ type service struct {
 	cognitoClient    cognito.Client
 	onboardingClient onboarding.Client
 	emailClient      ses.Client
+	sessionService   sessions.Service
 	commonConfig     cfg.Config
 }
 
@@ -67,6 +71,7 @@ func New(
 	cognitoClient cognito.Client,
 	onboardingClient onboarding.Client,
 	emailClient ses.Client,
+	sessionService sessions.Service,
 	commonConfig cfg.Config,
 ) Service {
 	svc := &service{
@@ -76,6 +81,7 @@ func New(
 		cognitoClient:    cognitoClient,
 		onboardingClient: onboardingClient,
 		emailClient:      emailClient,
+		sessionService:   sessionService,
 		commonConfig:     commonConfig,
 	}
 
@@ -98,6 +104,20 @@ func (s *service) GetUserByUuid(ctx context.Context, userUUID uuid.UUID) (*entit
 	return s.repo.FindByUUID(ctx, userUUID)
 }
 
+// GetCompanyUser returns the company_users link of the user, or nil when the user is not linked to the company.
+func (s *service) GetCompanyUser(ctx context.Context, userUUID, companyUUID uuid.UUID) (*entities.CompanyUser, error) {
+	companyUser, err := s.repo.GetCompanyUser(ctx, userUUID, companyUUID)
+	if err != nil {
+		if repository.IsUserNotFoundError(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return companyUser, nil
+}
+
 func (s *service) GetUserByUsername(ctx context.Context, username string) (*entities.User, error) {
 	user, err := s.repo.FindByUsername(ctx, username)
 	if err != nil {
@@ -217,8 +237,14 @@ func (s *service) CreateUser(ctx context.Context, companyUUID, userUUID uuid.UUI
 	return createdUser, nil
 }
 
+// DeleteCompanyUserLink removes the user from the company and signs the user out, so
+// tokens issued before the removal stop working at once.
 func (s *service) DeleteCompanyUserLink(ctx context.Context, companyUUID, reqUserUUID, userUUID *uuid.UUID) error {
-	return s.repo.DeleteCompanyUserLink(ctx, companyUUID, reqUserUUID, userUUID)
+	if err := s.repo.DeleteCompanyUserLink(ctx, companyUUID, reqUserUUID, userUUID); err != nil {
+		return err
+	}
+
+	return s.sessionService.RevokeUserSessions(ctx, &sessionsEntities.RevokeUserSessionsRequest{UserUuid: *userUUID})
 }
 
 func (s *service) UpdateCompanyUserLink(ctx context.Context, companyUser *entities.CompanyUser) (*entities.CompanyUser, error) {
@@ -515,6 +541,13 @@ func (s *service) getUserCompanyInfo(ctx context.Context, updateIsFirstLogin boo
 		return nil, &appErrors.ErrNotFound{Message: "User not found"}
 	}
 
+	// an impersonated user is seen in the company of the session and is left untouched
+	impersonation := meta.Impersonation(ctx)
+	if impersonation != nil {
+		user.CurrentCompanyUuid = impersonation.CompanyUuid
+		updateIsFirstLogin = false
+	}
+
 	res, err := s.companyClient.GetUserCompaniesByUserUuid(ctx, &companyEntities.GetCompaniesByUserIdRequest{
 		UserUuid: user.UserUuid,
 	})
@@ -579,7 +612,10 @@ func (s *service) getUserCompanyInfo(ctx context.Context, updateIsFirstLogin boo
 		companies = res.Companies
 	}
 
-	return s.getUserInfoResponse(user, companies, user.CurrentCompanyUuid.String()), nil
+	info := s.getUserInfoResponse(user, companies, user.CurrentCompanyUuid.String())
+	info.Impersonation = impersonation
+
+	return info, nil
 }
 
 func (s *service) ResendUserInvite(ctx context.Context, companyUUID, UserUUID uuid.UUID, reqBody *entities.CreateUserRequestBody) error {
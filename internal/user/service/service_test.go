@@ -9,7 +9,6 @@ import (
 	"github.com/google/uuid"
 	cognitoService "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito/service"
 	companyMockClient "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company"
-	companyEntities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company/entities"
 	onboardingMockClient "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/onboarding"
 	salesforceMockClient "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/salesforce"
 	emailMockClient "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/ses"
@@ -37,16 +36,6 @@ func Test_service_CreateUser(t *testing.T) {
 	companyUUID := uuid.New()
 	userUUID := uuid.New()
 
-	companyDetails := &companyEntities.Company{
-		CompanyUuid:  companyUUID,
-		Name:         "",
-		UserRole:     "",
-		IndustryType: &companyEntities.IndustryType{"Entertainment"},
-		Onboarding:   []companyEntities.Onboarding{},
-		Address:      &companyEntities.Address{},
-		ExternalId:   "",
-	}
-
 	userDetails := &entities.User{
 		UserUuid:           userUUID,
 		CurrentCompanyUuid: companyUUID,
@@ -65,11 +54,12 @@ func Test_service_CreateUser(t *testing.T) {
 		CompanyStatus:      "",
 	}
 
-	companyClient.EXPECT().FindByUUID(gomock.Any(), gomock.Any()).Return(companyDetails, nil)
-	cognitoClient.EXPECT().InviteUser(gomock.Any(), gomock.Any(), false).Return(nil)
-	mockRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).Return(userDetails, nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(userDetails, nil)
+	mockRepo.EXPECT().LinkCompanyAndUser(gomock.Any(), gomock.Any()).Return(nil, nil)
+	emailClient.EXPECT().SendEmail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	onboardingClient.EXPECT().UpdateOnboardingStatus(gomock.Any(), gomock.Any(), companyUUID, userUUID)
 
-	svc := New(mockRepo, companyClient, salesforceClient, cognitoClient, onboardingClient, emailClient, config)
+	svc := New(mockRepo, companyClient, salesforceClient, cognitoClient, onboardingClient, emailClient, nil, config)
 	Convey("Given company_id, user_id and request body details", t, func() {
 		reqBody := &entities.CreateUserRequestBody{
 			Email: gofakeit.Email(),
@@ -103,20 +93,10 @@ func Test_service_ResendUserInvite(t *testing.T) {
 	companyUUID := uuid.New()
 	userUUID := uuid.New()
 
-	companyDetails := &companyEntities.Company{
-		CompanyUuid:  companyUUID,
-		Name:         "",
-		UserRole:     "",
-		IndustryType: &companyEntities.IndustryType{"Entertainment"},
-		Onboarding:   []companyEntities.Onboarding{},
-		Address:      &companyEntities.Address{},
-		ExternalId:   "",
-	}
-
-	companyClient.EXPECT().FindByUUID(gomock.Any(), gomock.Any()).Return(companyDetails, nil)
-	cognitoClient.EXPECT().InviteUser(gomock.Any(), gomock.Any(), true).Return(nil)
+	emailClient.EXPECT().SendEmail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	onboardingClient.EXPECT().UpdateOnboardingStatus(gomock.Any(), gomock.Any(), companyUUID, userUUID)
 
-	svc := New(mockRepo, companyClient, salesforceClient, cognitoClient, onboardingClient, emailClient, config)
+	svc := New(mockRepo, companyClient, salesforceClient, cognitoClient, onboardingClient, emailClient, nil, config)
 	Convey("Given company_id, user_id and request body details", t, func() {
 		reqBody := &entities.CreateUserRequestBody{
 			Email: gofakeit.Email(),
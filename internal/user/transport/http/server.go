@@ -5,6 +5,7 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/endpoints"
@@ -52,7 +53,7 @@ func registerGetContextUserCompany(server *httpTransport.Server, ep goKitEndpoin
 	method := "GET"
 	path := "/users/me"
 	handler := goKitHTTPTransport.NewServer(
-		authClient.SecureServiceWithCognitoEndpoint(ep, "all", method),
+		authClient.SecureServiceWithCognitoEndpoint(ep, "all", permissions.ActionRead),
 		decodeGetContextUserCompanyRequest,
 		atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method}),
 		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
@@ -67,7 +68,7 @@ func registerUpdateUser(server *httpTransport.Server, ep goKitEndpoint.Endpoint,
 	method := "PUT"
 	path := "/users/me"
 	handler := goKitHTTPTransport.NewServer(
-		authClient.SecureServiceWithCognitoEndpoint(ep, "all", method),
+		authClient.SecureServiceWithCognitoEndpoint(ep, "all", permissions.ActionUpdate),
 		decodeUpdateUserRequest,
 		atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method}),
 		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
@@ -124,7 +125,7 @@ func registerGetSFUserAndCompanyInfo(server *httpTransport.Server, ep goKitEndpo
 func registerGetCompanyUsers(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	method := "GET"
 	handler := goKitHTTPTransport.NewServer(
-		authClient.SecureServiceWithCognitoEndpoint(ep, "account-management", method),
+		authClient.SecureServiceWithCognitoEndpoint(ep, "account-management", permissions.ActionRead),
 		decodeGetCompanyUsersRequest,
 		atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method}),
 		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
@@ -138,7 +139,7 @@ func registerGetCompanyUsers(server *httpTransport.Server, ep goKitEndpoint.Endp
 func registerCreateUser(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	method := "POST"
 	handler := goKitHTTPTransport.NewServer(
-		authClient.SecureServiceWithCognitoEndpoint(ep, "account-management", method),
+		authClient.SecureServiceWithCognitoEndpoint(ep, "account-management", permissions.ActionCreate),
 		decodeCreateUserRequest,
 		atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method}),
 		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
@@ -152,7 +153,7 @@ func registerCreateUser(server *httpTransport.Server, ep goKitEndpoint.Endpoint,
 func registerUpdateCompanyUserLink(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	method := "POST"
 	handler := goKitHTTPTransport.NewServer(
-		authClient.SecureServiceWithCognitoEndpoint(ep, "account-management", method),
+		authClient.SecureServiceWithCognitoEndpoint(ep, "account-management", permissions.ActionUpdate),
 		decodeUpdateCompanyUserLinkRequest,
 		atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method}),
 		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
@@ -166,7 +167,7 @@ func registerUpdateCompanyUserLink(server *httpTransport.Server, ep goKitEndpoin
 func registerDeleteCompanyUserLink(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	method := "GET"
 	handler := goKitHTTPTransport.NewServer(
-		authClient.SecureServiceWithCognitoEndpoint(ep, "account-management", method),
+		authClient.SecureServiceWithCognitoEndpoint(ep, "account-management", permissions.ActionDelete),
 		decodeDecodeCompanyUserLinkRequest,
 		atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method}),
 		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
@@ -181,7 +182,7 @@ func registerResendUserInvite(server *httpTransport.Server, ep goKitEndpoint.End
 	method := "POST"
 	path := "/companies/{company_id}/users/{user_id}/settings/users/invite"
 	handler := goKitHTTPTransport.NewServer(
-		authClient.SecureServiceWithCognitoEndpoint(ep, "account-management", method),
+		authClient.SecureServiceWithCognitoEndpoint(ep, "account-management", permissions.ActionCreate),
 		decodeResendUserInviteRequest,
 		atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method}),
 		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
@@ -195,7 +196,7 @@ func registerResendUserInvite(server *httpTransport.Server, ep goKitEndpoint.End
 func registerSwitchCompany(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	method := "PATCH"
 	handler := goKitHTTPTransport.NewServer(
-		authClient.SecureServiceWithCognitoEndpoint(ep, "account-management", method),
+		authClient.SecureServiceWithCognitoEndpoint(ep, "account-management", permissions.ActionUpdate),
 		decodeSwitchCompanyRequest,
 		atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{"PATCH"}),
 		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
@@ -209,7 +210,7 @@ func registerSwitchCompany(server *httpTransport.Server, ep goKitEndpoint.Endpoi
 func registerListCompanies(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	method := "GET"
 	handler := goKitHTTPTransport.NewServer(
-		authClient.SecureServiceWithCognitoEndpoint(ep, "all", method),
+		authClient.SecureServiceWithCognitoEndpoint(ep, "all", permissions.ActionRead),
 		decodeListCompaniesRequest,
 		atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method}),
 		goKitHTTPTransport.ServerErrorEncoder(atc.EncodeErrorControlHeadersWrapper(encode.Error, []string{method})),
@@ -14,6 +14,7 @@ type Client interface {
 	GetUserByUsername(ctx context.Context, username string) (*entities.User, error)
 	GetUserByUuid(ctx context.Context, userUUID uuid.UUID) (*entities.User, error)
 	GetContextUserCompanyInfoInternal(ctx context.Context) (*entities.GetUserCompanyInfoByUserIdResponse, error)
+	GetCompanyUser(ctx context.Context, userUUID, companyUUID uuid.UUID) (*entities.CompanyUser, error)
 }
 
 func NewClient(ep *endpoints.Endpoints, svc service.Service) Client {
@@ -29,6 +30,10 @@ func (l localClient) GetContextUserCompanyInfoInternal(ctx context.Context) (*en
 	return l.svc.GetContextUserCompanyInfoInternal(ctx)
 }
 
+func (l localClient) GetCompanyUser(ctx context.Context, userUUID, companyUUID uuid.UUID) (*entities.CompanyUser, error) {
+	return l.svc.GetCompanyUser(ctx, userUUID, companyUUID)
+}
+
 func (l localClient) GetUserByUuid(ctx context.Context, userUUID uuid.UUID) (*entities.User, error) {
 	return l.svc.GetUserByUuid(ctx, userUUID)
 }
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./client.go
+
+// Package userclient is a generated GoMock package.
+package userclient
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
+	entities "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/user/entities"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// CreateUser mocks base method.
+func (m *MockClient) CreateUser(ctx context.Context, user *entities.User) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, user)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockClientMockRecorder) CreateUser(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockClient)(nil).CreateUser), ctx, user)
+}
+
+// GetCompanyUser mocks base method.
+func (m *MockClient) GetCompanyUser(ctx context.Context, userUUID, companyUUID uuid.UUID) (*entities.CompanyUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanyUser", ctx, userUUID, companyUUID)
+	ret0, _ := ret[0].(*entities.CompanyUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanyUser indicates an expected call of GetCompanyUser.
+func (mr *MockClientMockRecorder) GetCompanyUser(ctx, userUUID, companyUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanyUser", reflect.TypeOf((*MockClient)(nil).GetCompanyUser), ctx, userUUID, companyUUID)
+}
+
+// GetContextUserCompanyInfoInternal mocks base method.
+func (m *MockClient) GetContextUserCompanyInfoInternal(ctx context.Context) (*entities.GetUserCompanyInfoByUserIdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContextUserCompanyInfoInternal", ctx)
+	ret0, _ := ret[0].(*entities.GetUserCompanyInfoByUserIdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContextUserCompanyInfoInternal indicates an expected call of GetContextUserCompanyInfoInternal.
+func (mr *MockClientMockRecorder) GetContextUserCompanyInfoInternal(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContextUserCompanyInfoInternal", reflect.TypeOf((*MockClient)(nil).GetContextUserCompanyInfoInternal), ctx)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockClient) GetUserByUsername(ctx context.Context, username string) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", ctx, username)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockClientMockRecorder) GetUserByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockClient)(nil).GetUserByUsername), ctx, username)
+}
+
+// GetUserByUuid mocks base method.
+func (m *MockClient) GetUserByUuid(ctx context.Context, userUUID uuid.UUID) (*entities.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUuid", ctx, userUUID)
+	ret0, _ := ret[0].(*entities.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUuid indicates an expected call of GetUserByUuid.
+func (mr *MockClientMockRecorder) GetUserByUuid(ctx, userUUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUuid", reflect.TypeOf((*MockClient)(nil).GetUserByUuid), ctx, userUUID)
+}
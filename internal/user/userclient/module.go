@@ -3,6 +3,7 @@ package userclient
 import (
 	"database/sql"
 
+	sessions "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/sessions/service"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/aws_client/cognito"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/company"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/onboarding"
@@ -26,13 +27,14 @@ type ModuleClientParams struct {
 	CognitoClient    cognito.Client
 	OnboardingClient onboarding.Client
 	EmailClient      ses.Client
+	SessionService   sessions.Service
 }
 
 // NewClientModule for user.
 // nolint:gocritic
 func NewClientModule(p ModuleClientParams) Client {
 	repo := repository.New(p.DB)
-	svc := service.New(repo, p.CompanyClient, p.SalesforceClient, p.CognitoClient, p.OnboardingClient, p.EmailClient, p.CommonConfig)
+	svc := service.New(repo, p.CompanyClient, p.SalesforceClient, p.CognitoClient, p.OnboardingClient, p.EmailClient, p.SessionService, p.CommonConfig)
 	eps := endpoints.New(svc)
 
 	client := NewClient(eps, svc)
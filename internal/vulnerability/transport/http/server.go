@@ -5,6 +5,7 @@ import (
 	goKitEndpoint "github.com/go-kit/kit/endpoint"
 	goKitHTTPTransport "github.com/go-kit/kit/transport/http"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/vulnerability/endpoints"
@@ -25,7 +26,7 @@ func RegisterTransport(
 func registerGetVulnerabilities(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/vulnerabilities/topremediation"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "vulnerability", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "vulnerability", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetVulnerabilitiesRequest, encoder, atc, method)
 
@@ -36,7 +37,7 @@ func registerGetVulnerabilities(server *httpTransport.Server, ep goKitEndpoint.E
 func registerGetVulnerabilityStats(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/companies/{company_id}/users/{user_id}/settings/vulnerabilities/stats"
 	method := "GET"
-	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "vulnerability", method)
+	securedEp := authClient.SecureServiceWithCognitoEndpoint(ep, "vulnerability", permissions.ActionRead)
 	encoder := atc.EncodeAccessControlHeadersWrapper(encode.Response, []string{method})
 	handler := getHandler(securedEp, decodeGetVulnerabilityStatsRequest, encoder, atc, method)
 
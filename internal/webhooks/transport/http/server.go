@@ -8,6 +8,7 @@ import (
 	svcTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/transport/http/encode"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/webhooks/endpoints"
+	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/jwt"
 	httpTransport "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/transport/http"
 )
 
@@ -25,7 +26,7 @@ func RegisterTransport(
 func registerSFAccountWebhook(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/webhook/sf/account"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithRedesignWebhookEndpoint(ep)
+	securedEp := authClient.SecureServiceWithRedesignWebhookEndpoint(ep, jwt.AudienceSalesforce)
 	handler := getHandler(securedEp, decodeSFAccountWebhookRequest, atc, method)
 
 	server.Handle(method, path, handler)
@@ -35,7 +36,7 @@ func registerSFAccountWebhook(server *httpTransport.Server, ep goKitEndpoint.End
 func registerSFAccountSubscriptionsWebhook(server *httpTransport.Server, ep goKitEndpoint.Endpoint, authClient auth.Client, atc svcTransport.Client) {
 	path := "/webhook/sf/account/subscriptions"
 	method := "POST"
-	securedEp := authClient.SecureServiceWithRedesignWebhookEndpoint(ep)
+	securedEp := authClient.SecureServiceWithRedesignWebhookEndpoint(ep, jwt.AudienceSalesforce)
 	handler := getHandler(securedEp, decodeSFAccountSubscriptionsWebhookRequest, atc, method)
 
 	server.Handle(method, path, handler)
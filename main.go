@@ -8,8 +8,6 @@ import (
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/cmd"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/auth/permissions"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/internal/swagger/static"
-	"github.com/opentracing/opentracing-go/log"
-	"gopkg.in/yaml.v3"
 )
 
 //go:embed docs/swagger
@@ -26,14 +24,7 @@ func execute() error {
 
 func main() {
 	static.StaticFiles = staticFiles
-
-	data := make(map[string]map[string]string)
-	err := yaml.Unmarshal(permissoinFile, &data)
-	if err != nil {
-		log.Error(err)
-		os.Exit(exitCode)
-	}
-	permissions.Permissions = data
+	permissions.DefaultPolicy = permissoinFile
 
 	if err := execute(); err != nil {
 		os.Exit(exitCode)
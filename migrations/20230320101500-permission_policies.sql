@@ -0,0 +1,13 @@
+-- +migrate Up
+CREATE TABLE public.permission_policies (
+    version integer NOT NULL,
+    document text NOT NULL,
+    created_at timestamptz NULL DEFAULT now(),
+    created_by uuid NULL,
+    CONSTRAINT permission_policies_pkey PRIMARY KEY (version)
+);
+
+ALTER TABLE public.permission_policies ADD CONSTRAINT fk_created_by_users FOREIGN KEY (created_by) REFERENCES public.users(user_uuid);
+
+-- +migrate Down
+DROP TABLE IF EXISTS public.permission_policies;
@@ -0,0 +1,25 @@
+-- +migrate Up
+CREATE TABLE public.api_keys (
+    api_key_uuid uuid NOT NULL,
+    company_uuid uuid NOT NULL,
+    company_type varchar(50) NOT NULL,
+    "name" varchar(255) NOT NULL,
+    prefix varchar(16) NOT NULL,
+    key_hash varchar(64) NOT NULL,
+    scopes text[] NOT NULL DEFAULT '{}',
+    expires_at timestamptz NOT NULL,
+    last_used_at timestamptz NULL,
+    revoked_at timestamptz NULL,
+    revoked_by uuid NULL,
+    created_at timestamptz NULL DEFAULT now(),
+    created_by uuid NOT NULL,
+    CONSTRAINT api_keys_pkey PRIMARY KEY (api_key_uuid),
+    CONSTRAINT api_keys_prefix_key UNIQUE (prefix)
+);
+
+ALTER TABLE public.api_keys ADD CONSTRAINT fk_companies FOREIGN KEY (company_uuid) REFERENCES public.companies(company_uuid);
+ALTER TABLE public.api_keys ADD CONSTRAINT fk_created_by_users FOREIGN KEY (created_by) REFERENCES public.users(user_uuid);
+ALTER TABLE public.api_keys ADD CONSTRAINT fk_revoked_by_users FOREIGN KEY (revoked_by) REFERENCES public.users(user_uuid);
+
+-- +migrate Down
+DROP TABLE IF EXISTS public.api_keys;
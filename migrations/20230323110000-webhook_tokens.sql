@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE public.webhook_tokens (
+    jti uuid NOT NULL,
+    audience varchar(50) NOT NULL,
+    description varchar(255) NULL,
+    kid varchar(100) NOT NULL,
+    issued_at timestamptz NULL DEFAULT now(),
+    expires_at timestamptz NULL,
+    revoked_at timestamptz NULL,
+    CONSTRAINT webhook_tokens_pkey PRIMARY KEY (jti)
+);
+
+-- +migrate Down
+DROP TABLE IF EXISTS public.webhook_tokens;
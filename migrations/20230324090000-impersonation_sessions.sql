@@ -0,0 +1,44 @@
+-- +migrate Up
+CREATE TABLE public.impersonation_sessions (
+    session_uuid uuid NOT NULL,
+    impersonator_uuid uuid NOT NULL,
+    user_uuid uuid NOT NULL,
+    company_uuid uuid NOT NULL,
+    reason text NOT NULL,
+    read_only bool NOT NULL DEFAULT true,
+    expires_at timestamptz NOT NULL,
+    ended_at timestamptz NULL,
+    created_at timestamptz NULL DEFAULT now(),
+    CONSTRAINT impersonation_sessions_pkey PRIMARY KEY (session_uuid)
+);
+
+CREATE INDEX impersonation_sessions_impersonator_uuid_idx ON public.impersonation_sessions (impersonator_uuid);
+
+ALTER TABLE public.impersonation_sessions ADD CONSTRAINT fk_impersonator_users FOREIGN KEY (impersonator_uuid) REFERENCES public.users(user_uuid);
+ALTER TABLE public.impersonation_sessions ADD CONSTRAINT fk_users FOREIGN KEY (user_uuid) REFERENCES public.users(user_uuid);
+ALTER TABLE public.impersonation_sessions ADD CONSTRAINT fk_companies FOREIGN KEY (company_uuid) REFERENCES public.companies(company_uuid);
+
+CREATE TABLE public.impersonation_audit_logs (
+    audit_log_uuid uuid NOT NULL,
+    session_uuid uuid NOT NULL,
+    impersonator_uuid uuid NOT NULL,
+    user_uuid uuid NOT NULL,
+    company_uuid uuid NOT NULL,
+    feature varchar(100) NOT NULL,
+    "action" varchar(20) NOT NULL,
+    "method" varchar(10) NULL,
+    "path" text NULL,
+    request_id varchar(100) NULL,
+    client_ip varchar(64) NULL,
+    "error" text NULL,
+    created_at timestamptz NULL DEFAULT now(),
+    CONSTRAINT impersonation_audit_logs_pkey PRIMARY KEY (audit_log_uuid)
+);
+
+CREATE INDEX impersonation_audit_logs_session_uuid_idx ON public.impersonation_audit_logs (session_uuid, created_at);
+
+ALTER TABLE public.impersonation_audit_logs ADD CONSTRAINT fk_impersonation_sessions FOREIGN KEY (session_uuid) REFERENCES public.impersonation_sessions(session_uuid);
+
+-- +migrate Down
+DROP TABLE IF EXISTS public.impersonation_audit_logs;
+DROP TABLE IF EXISTS public.impersonation_sessions;
@@ -0,0 +1,33 @@
+-- +migrate Up
+CREATE TABLE public.user_sessions (
+    session_id varchar(255) NOT NULL,
+    user_uuid uuid NOT NULL,
+    issuer text NULL,
+    subject varchar(255) NULL,
+    client_ip varchar(64) NULL,
+    issued_at timestamptz NULL,
+    expires_at timestamptz NOT NULL,
+    revoked_at timestamptz NULL,
+    revoked_by uuid NULL,
+    created_at timestamptz NULL DEFAULT now(),
+    CONSTRAINT user_sessions_pkey PRIMARY KEY (session_id)
+);
+
+CREATE INDEX user_sessions_user_uuid_idx ON public.user_sessions (user_uuid, created_at);
+CREATE INDEX user_sessions_revoked_idx ON public.user_sessions (expires_at) WHERE revoked_at IS NOT NULL;
+
+ALTER TABLE public.user_sessions ADD CONSTRAINT fk_users FOREIGN KEY (user_uuid) REFERENCES public.users(user_uuid);
+
+CREATE TABLE public.user_session_revocations (
+    user_uuid uuid NOT NULL,
+    revoked_before timestamptz NOT NULL,
+    revoked_by uuid NULL,
+    created_at timestamptz NULL DEFAULT now(),
+    CONSTRAINT user_session_revocations_pkey PRIMARY KEY (user_uuid)
+);
+
+ALTER TABLE public.user_session_revocations ADD CONSTRAINT fk_users FOREIGN KEY (user_uuid) REFERENCES public.users(user_uuid);
+
+-- +migrate Down
+DROP TABLE IF EXISTS public.user_session_revocations;
+DROP TABLE IF EXISTS public.user_sessions;
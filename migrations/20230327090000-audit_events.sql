@@ -0,0 +1,28 @@
+-- +migrate Up
+CREATE TABLE public.audit_events (
+    event_uuid uuid NOT NULL,
+    actor_uuid uuid NULL,
+    actor_email varchar(255) NULL,
+    impersonator_uuid uuid NULL,
+    company_uuid uuid NULL,
+    entity_type varchar(100) NOT NULL,
+    entity_id varchar(255) NULL,
+    "action" varchar(50) NOT NULL,
+    "before" jsonb NULL,
+    "after" jsonb NULL,
+    diff jsonb NULL,
+    request_id varchar(100) NULL,
+    client_ip varchar(64) NULL,
+    "method" varchar(10) NULL,
+    "path" text NULL,
+    created_at timestamptz NULL DEFAULT now(),
+    CONSTRAINT audit_events_pkey PRIMARY KEY (event_uuid)
+);
+
+CREATE INDEX audit_events_company_uuid_idx ON public.audit_events (company_uuid, created_at);
+CREATE INDEX audit_events_entity_idx ON public.audit_events (company_uuid, entity_type, entity_id);
+CREATE INDEX audit_events_actor_uuid_idx ON public.audit_events (actor_uuid);
+CREATE INDEX audit_events_request_id_idx ON public.audit_events (request_id);
+
+-- +migrate Down
+DROP TABLE IF EXISTS public.audit_events;
@@ -0,0 +1,56 @@
+-- +migrate Up
+ALTER TABLE public.policies ADD COLUMN approval_rule varchar(8) NOT NULL DEFAULT 'all';
+ALTER TABLE public.policies ADD CONSTRAINT policies_approval_rule_check CHECK (approval_rule IN ('all', 'any'));
+
+CREATE TABLE public.policy_reviewers (
+    policy_uuid uuid NOT NULL,
+    user_uuid uuid NOT NULL,
+    created_at timestamptz NULL DEFAULT now(),
+    created_by uuid NULL,
+    CONSTRAINT policy_reviewers_pkey PRIMARY KEY (policy_uuid, user_uuid)
+);
+
+ALTER TABLE public.policy_reviewers ADD CONSTRAINT fk_policies FOREIGN KEY (policy_uuid) REFERENCES public.policies(policy_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_reviewers ADD CONSTRAINT fk_users FOREIGN KEY (user_uuid) REFERENCES public.users(user_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_reviewers ADD CONSTRAINT fk_created_by_users FOREIGN KEY (created_by) REFERENCES public.users(user_uuid);
+
+
+CREATE TABLE public.policy_status_histories (
+    policy_status_history_uuid uuid NOT NULL,
+    policy_uuid uuid NOT NULL,
+    policy_history_uuid uuid NULL,
+    from_status policies_status NOT NULL,
+    to_status policies_status NOT NULL,
+    comment text NULL,
+    created_at timestamptz NULL DEFAULT now(),
+    created_by uuid NULL,
+    CONSTRAINT policy_status_histories_pkey PRIMARY KEY (policy_status_history_uuid)
+);
+
+CREATE INDEX policy_status_histories_policy_uuid_idx ON public.policy_status_histories (policy_uuid, created_at);
+
+ALTER TABLE public.policy_status_histories ADD CONSTRAINT fk_policies FOREIGN KEY (policy_uuid) REFERENCES public.policies(policy_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_status_histories ADD CONSTRAINT fk_policy_histories FOREIGN KEY (policy_history_uuid) REFERENCES public.policy_histories(policy_history_uuid) ON DELETE SET NULL;
+ALTER TABLE public.policy_status_histories ADD CONSTRAINT fk_created_by_users FOREIGN KEY (created_by) REFERENCES public.users(user_uuid);
+
+CREATE TABLE public.policy_reviews (
+    policy_status_history_uuid uuid NOT NULL,
+    policy_history_uuid uuid NOT NULL,
+    reviewer_uuid uuid NOT NULL,
+    decision policies_status NOT NULL,
+    comment text NULL,
+    created_at timestamptz NULL DEFAULT now(),
+    CONSTRAINT policy_reviews_pkey PRIMARY KEY (policy_status_history_uuid, reviewer_uuid)
+);
+
+ALTER TABLE public.policy_reviews ADD CONSTRAINT fk_policy_status_histories FOREIGN KEY (policy_status_history_uuid) REFERENCES public.policy_status_histories(policy_status_history_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_reviews ADD CONSTRAINT fk_policy_histories FOREIGN KEY (policy_history_uuid) REFERENCES public.policy_histories(policy_history_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_reviews ADD CONSTRAINT fk_users FOREIGN KEY (reviewer_uuid) REFERENCES public.users(user_uuid);
+
+-- +migrate Down
+DROP TABLE IF EXISTS public.policy_reviews;
+DROP TABLE IF EXISTS public.policy_status_histories;
+DROP TABLE IF EXISTS public.policy_reviewers;
+
+ALTER TABLE public.policies DROP CONSTRAINT IF EXISTS policies_approval_rule_check;
+ALTER TABLE public.policies DROP COLUMN IF EXISTS approval_rule;
@@ -0,0 +1,30 @@
+-- +migrate Up
+ALTER TABLE public.policies ADD COLUMN review_interval_months integer NOT NULL DEFAULT 12;
+ALTER TABLE public.policies ADD CONSTRAINT policies_review_interval_months_check CHECK (review_interval_months > 0);
+ALTER TABLE public.policies ADD COLUMN review_owner_uuid uuid NULL;
+ALTER TABLE public.policies ADD COLUMN last_reviewed_at timestamptz NULL;
+ALTER TABLE public.policies ADD COLUMN next_review_at date NULL;
+ALTER TABLE public.policies ADD COLUMN review_due_reminder_at timestamptz NULL;
+ALTER TABLE public.policies ADD COLUMN review_overdue_reminder_at timestamptz NULL;
+
+ALTER TABLE public.policies ADD CONSTRAINT fk_review_owner_users FOREIGN KEY (review_owner_uuid) REFERENCES public.users(user_uuid) ON DELETE SET NULL;
+
+CREATE INDEX policies_next_review_at_idx ON public.policies (next_review_at) WHERE next_review_at IS NOT NULL;
+
+-- approved policies are due a year after their approval, owned by their author
+UPDATE public.policies SET review_owner_uuid = created_by;
+UPDATE public.policies SET last_reviewed_at = status_updated_at,
+    next_review_at = (status_updated_at + interval '12 months')::date
+WHERE status = 'Approved';
+
+-- +migrate Down
+DROP INDEX IF EXISTS public.policies_next_review_at_idx;
+
+ALTER TABLE public.policies DROP CONSTRAINT IF EXISTS fk_review_owner_users;
+ALTER TABLE public.policies DROP CONSTRAINT IF EXISTS policies_review_interval_months_check;
+ALTER TABLE public.policies DROP COLUMN IF EXISTS review_overdue_reminder_at;
+ALTER TABLE public.policies DROP COLUMN IF EXISTS review_due_reminder_at;
+ALTER TABLE public.policies DROP COLUMN IF EXISTS next_review_at;
+ALTER TABLE public.policies DROP COLUMN IF EXISTS last_reviewed_at;
+ALTER TABLE public.policies DROP COLUMN IF EXISTS review_owner_uuid;
+ALTER TABLE public.policies DROP COLUMN IF EXISTS review_interval_months;
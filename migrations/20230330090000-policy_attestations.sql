@@ -0,0 +1,47 @@
+-- +migrate Up
+CREATE TABLE public.policy_publications (
+    publication_uuid uuid NOT NULL,
+    policy_uuid uuid NOT NULL,
+    policy_history_uuid uuid NOT NULL,
+    company_uuid uuid NOT NULL,
+    audience varchar(8) NOT NULL DEFAULT 'all',
+    published_at timestamptz NOT NULL DEFAULT now(),
+    published_by uuid NULL,
+    superseded_at timestamptz NULL,
+    CONSTRAINT policy_publications_pkey PRIMARY KEY (publication_uuid),
+    CONSTRAINT policy_publications_audience_check CHECK (audience IN ('all', 'selected'))
+);
+
+-- a policy has a single current publication
+CREATE UNIQUE INDEX policy_publications_current_idx ON public.policy_publications (policy_uuid) WHERE superseded_at IS NULL;
+CREATE INDEX policy_publications_company_uuid_idx ON public.policy_publications (company_uuid);
+
+ALTER TABLE public.policy_publications ADD CONSTRAINT fk_policies FOREIGN KEY (policy_uuid) REFERENCES public.policies(policy_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_publications ADD CONSTRAINT fk_policy_histories FOREIGN KEY (policy_history_uuid) REFERENCES public.policy_histories(policy_history_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_publications ADD CONSTRAINT fk_companies FOREIGN KEY (company_uuid) REFERENCES public.companies(company_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_publications ADD CONSTRAINT fk_published_by_users FOREIGN KEY (published_by) REFERENCES public.users(user_uuid) ON DELETE SET NULL;
+
+CREATE TABLE public.policy_attestations (
+    attestation_uuid uuid NOT NULL,
+    publication_uuid uuid NOT NULL,
+    user_uuid uuid NOT NULL,
+    status varchar(16) NOT NULL DEFAULT 'pending',
+    acknowledged_at timestamptz NULL,
+    ip_address varchar(64) NULL,
+    reminded_at timestamptz NULL,
+    reminder_count integer NOT NULL DEFAULT 0,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT policy_attestations_pkey PRIMARY KEY (attestation_uuid),
+    CONSTRAINT policy_attestations_publication_user_key UNIQUE (publication_uuid, user_uuid),
+    CONSTRAINT policy_attestations_status_check CHECK (status IN ('pending', 'acknowledged', 'superseded'))
+);
+
+CREATE INDEX policy_attestations_user_uuid_idx ON public.policy_attestations (user_uuid, status);
+CREATE INDEX policy_attestations_pending_idx ON public.policy_attestations (created_at) WHERE status = 'pending';
+
+ALTER TABLE public.policy_attestations ADD CONSTRAINT fk_policy_publications FOREIGN KEY (publication_uuid) REFERENCES public.policy_publications(publication_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_attestations ADD CONSTRAINT fk_users FOREIGN KEY (user_uuid) REFERENCES public.users(user_uuid) ON DELETE CASCADE;
+
+-- +migrate Down
+DROP TABLE IF EXISTS public.policy_attestations;
+DROP TABLE IF EXISTS public.policy_publications;
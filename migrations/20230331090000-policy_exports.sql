@@ -0,0 +1,29 @@
+-- +migrate Up
+CREATE TABLE public.policy_exports (
+    export_uuid uuid NOT NULL,
+    company_uuid uuid NOT NULL,
+    requested_by uuid NULL,
+    format varchar(8) NOT NULL,
+    policy_uuids jsonb NULL,
+    status varchar(16) NOT NULL DEFAULT 'pending',
+    policy_count integer NOT NULL DEFAULT 0,
+    file_key text NULL,
+    file_name text NULL,
+    error text NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    started_at timestamptz NULL,
+    completed_at timestamptz NULL,
+    CONSTRAINT policy_exports_pkey PRIMARY KEY (export_uuid),
+    CONSTRAINT policy_exports_format_check CHECK (format IN ('docx', 'pdf')),
+    CONSTRAINT policy_exports_status_check CHECK (status IN ('pending', 'running', 'completed', 'failed'))
+);
+
+CREATE INDEX policy_exports_company_uuid_idx ON public.policy_exports (company_uuid, created_at);
+-- the worker looks for pending exports and the ones left running
+CREATE INDEX policy_exports_status_idx ON public.policy_exports (status, created_at) WHERE status IN ('pending', 'running');
+
+ALTER TABLE public.policy_exports ADD CONSTRAINT fk_companies FOREIGN KEY (company_uuid) REFERENCES public.companies(company_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_exports ADD CONSTRAINT fk_requested_by_users FOREIGN KEY (requested_by) REFERENCES public.users(user_uuid) ON DELETE SET NULL;
+
+-- +migrate Down
+DROP TABLE IF EXISTS public.policy_exports;
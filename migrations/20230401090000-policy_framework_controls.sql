@@ -0,0 +1,32 @@
+-- +migrate Up
+CREATE TABLE public.policy_template_framework_controls (
+    policy_template_uuid uuid NOT NULL,
+    framework_control_uuid uuid NOT NULL,
+    created_at timestamptz NULL DEFAULT now(),
+    created_by uuid NULL,
+    CONSTRAINT policy_template_framework_controls_pkey PRIMARY KEY (policy_template_uuid, framework_control_uuid)
+);
+
+CREATE INDEX policy_template_framework_controls_control_idx ON public.policy_template_framework_controls (framework_control_uuid);
+
+ALTER TABLE public.policy_template_framework_controls ADD CONSTRAINT fk_policy_templates FOREIGN KEY (policy_template_uuid) REFERENCES public.policy_templates(policy_template_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_template_framework_controls ADD CONSTRAINT fk_framework_controls FOREIGN KEY (framework_control_uuid) REFERENCES public.framework_controls(framework_control_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_template_framework_controls ADD CONSTRAINT fk_created_by_users FOREIGN KEY (created_by) REFERENCES public.users(user_uuid);
+
+CREATE TABLE public.policy_framework_controls (
+    policy_uuid uuid NOT NULL,
+    framework_control_uuid uuid NOT NULL,
+    created_at timestamptz NULL DEFAULT now(),
+    created_by uuid NULL,
+    CONSTRAINT policy_framework_controls_pkey PRIMARY KEY (policy_uuid, framework_control_uuid)
+);
+
+CREATE INDEX policy_framework_controls_control_idx ON public.policy_framework_controls (framework_control_uuid);
+
+ALTER TABLE public.policy_framework_controls ADD CONSTRAINT fk_policies FOREIGN KEY (policy_uuid) REFERENCES public.policies(policy_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_framework_controls ADD CONSTRAINT fk_framework_controls FOREIGN KEY (framework_control_uuid) REFERENCES public.framework_controls(framework_control_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_framework_controls ADD CONSTRAINT fk_created_by_users FOREIGN KEY (created_by) REFERENCES public.users(user_uuid);
+
+-- +migrate Down
+DROP TABLE IF EXISTS public.policy_framework_controls;
+DROP TABLE IF EXISTS public.policy_template_framework_controls;
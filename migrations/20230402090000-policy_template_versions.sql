@@ -0,0 +1,64 @@
+-- +migrate Up
+ALTER TABLE public.policy_templates ADD COLUMN status varchar(16) NOT NULL DEFAULT 'draft';
+ALTER TABLE public.policy_templates ADD CONSTRAINT policy_templates_status_check CHECK (status IN ('draft', 'published'));
+ALTER TABLE public.policy_templates ADD COLUMN version smallint NOT NULL DEFAULT 0;
+ALTER TABLE public.policy_templates ADD COLUMN company_types company_type[] NULL;
+
+CREATE TABLE public.policy_template_versions (
+    policy_template_uuid uuid NOT NULL,
+    version smallint NOT NULL,
+    "name" text NOT NULL,
+    description text NULL,
+    document text NULL,
+    comment text NULL,
+    created_at timestamptz NULL DEFAULT now(),
+    created_by uuid NULL,
+    CONSTRAINT policy_template_versions_pkey PRIMARY KEY (policy_template_uuid, version)
+);
+
+ALTER TABLE public.policy_template_versions ADD CONSTRAINT fk_policy_templates FOREIGN KEY (policy_template_uuid) REFERENCES public.policy_templates(policy_template_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_template_versions ADD CONSTRAINT fk_created_by_users FOREIGN KEY (created_by) REFERENCES public.users(user_uuid);
+
+-- the seeded templates are the first published version
+INSERT INTO public.policy_template_versions (policy_template_uuid, version, "name", description, document, created_at, created_by)
+SELECT policy_template_uuid, 1, "name", description, document, coalesce(updated_at, created_at, now()), coalesce(updated_by, created_by)
+FROM public.policy_templates;
+
+UPDATE public.policy_templates SET status = 'published', version = 1;
+
+ALTER TABLE public.policies ADD COLUMN policy_template_version smallint NULL;
+
+UPDATE public.policies SET policy_template_version = 1 WHERE policy_template_uuid IS NOT NULL;
+
+CREATE TABLE public.policy_template_updates (
+    policy_template_update_uuid uuid NOT NULL,
+    policy_uuid uuid NOT NULL,
+    policy_template_uuid uuid NOT NULL,
+    from_version smallint NULL,
+    to_version smallint NOT NULL,
+    created_at timestamptz NULL DEFAULT now(),
+    notified_at timestamptz NULL,
+    dismissed_at timestamptz NULL,
+    dismissed_by uuid NULL,
+    CONSTRAINT policy_template_updates_pkey PRIMARY KEY (policy_template_update_uuid)
+);
+
+-- a policy has at most one pending update, to the latest version of its template
+CREATE UNIQUE INDEX policy_template_updates_pending_idx ON public.policy_template_updates (policy_uuid) WHERE dismissed_at IS NULL;
+CREATE INDEX policy_template_updates_notify_idx ON public.policy_template_updates (created_at) WHERE notified_at IS NULL AND dismissed_at IS NULL;
+
+ALTER TABLE public.policy_template_updates ADD CONSTRAINT fk_policies FOREIGN KEY (policy_uuid) REFERENCES public.policies(policy_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_template_updates ADD CONSTRAINT fk_policy_templates FOREIGN KEY (policy_template_uuid) REFERENCES public.policy_templates(policy_template_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_template_updates ADD CONSTRAINT fk_dismissed_by_users FOREIGN KEY (dismissed_by) REFERENCES public.users(user_uuid);
+
+-- +migrate Down
+DROP TABLE IF EXISTS public.policy_template_updates;
+
+ALTER TABLE public.policies DROP COLUMN IF EXISTS policy_template_version;
+
+DROP TABLE IF EXISTS public.policy_template_versions;
+
+ALTER TABLE public.policy_templates DROP COLUMN IF EXISTS company_types;
+ALTER TABLE public.policy_templates DROP COLUMN IF EXISTS version;
+ALTER TABLE public.policy_templates DROP CONSTRAINT IF EXISTS policy_templates_status_check;
+ALTER TABLE public.policy_templates DROP COLUMN IF EXISTS status;
@@ -0,0 +1,23 @@
+-- +migrate Up
+-- text of the latest document of the policy, without markup, set when a document is saved
+ALTER TABLE public.policies ADD COLUMN document_text text NULL;
+ALTER TABLE public.policies ADD COLUMN document_tsv tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce("name", '')), 'A') || setweight(to_tsvector('english', coalesce(document_text, '')), 'B')
+) STORED;
+
+CREATE INDEX policies_document_tsv_idx ON public.policies USING gin (document_tsv);
+
+-- tags are stripped approximately until the next document of the policy is saved
+UPDATE public.policies p SET document_text = trim(regexp_replace(regexp_replace(ph.document, '<[^>]*>', ' ', 'g'), '\s+', ' ', 'g'))
+FROM (
+    SELECT DISTINCT ON (policy_uuid) policy_uuid, document
+    FROM public.policy_histories
+    ORDER BY policy_uuid, version DESC
+) ph
+WHERE ph.policy_uuid = p.policy_uuid;
+
+-- +migrate Down
+DROP INDEX IF EXISTS public.policies_document_tsv_idx;
+
+ALTER TABLE public.policies DROP COLUMN IF EXISTS document_tsv;
+ALTER TABLE public.policies DROP COLUMN IF EXISTS document_text;
@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE public.policy_edit_locks (
+    policy_uuid uuid NOT NULL,
+    user_uuid uuid NOT NULL,
+    acquired_at timestamptz NOT NULL DEFAULT now(),
+    expires_at timestamptz NOT NULL,
+    CONSTRAINT policy_edit_locks_pkey PRIMARY KEY (policy_uuid)
+);
+
+ALTER TABLE public.policy_edit_locks ADD CONSTRAINT fk_policies FOREIGN KEY (policy_uuid) REFERENCES public.policies(policy_uuid) ON DELETE CASCADE;
+ALTER TABLE public.policy_edit_locks ADD CONSTRAINT fk_users FOREIGN KEY (user_uuid) REFERENCES public.users(user_uuid) ON DELETE CASCADE;
+
+-- +migrate Down
+DROP TABLE IF EXISTS public.policy_edit_locks;
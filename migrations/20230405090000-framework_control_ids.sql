@@ -0,0 +1,186 @@
+-- +migrate Up
+ALTER TABLE public.framework_controls ADD COLUMN control_id text NULL;
+ALTER TABLE public.framework_controls ADD COLUMN retired_at timestamptz NULL;
+
+-- the CIS safeguards of a control share its number as name, their ids come from the catalog
+-- in default_data/frameworks/cis.csv where each safeguard title (best_practices) is unique
+UPDATE public.framework_controls fc SET control_id = cis.control_id
+FROM public.frameworks f, (VALUES
+    ('1', 'Establish and Maintain Detailed Enterprise Asset Inventory', '1.1'),
+    ('1', 'Address Unauthorized Assets', '1.2'),
+    ('1', 'Utilize an Active Discovery Tool', '1.3'),
+    ('1', 'Use Dynamic Host Configuration Protocol (DHCP) Logging to Update Enterprise Asset Inventory', '1.4'),
+    ('1', 'Use a Passive Asset Discovery Tool', '1.5'),
+    ('2', 'Establish and Maintain a Software Inventory', '2.1'),
+    ('2', 'Ensure Authorized Software is Currently Supported', '2.2'),
+    ('2', 'Address Unauthorized Software', '2.3'),
+    ('2', 'Utilize Automated Software Inventory Tools', '2.4'),
+    ('2', 'Allowlist Authorized Software', '2.5'),
+    ('2', 'Allowlist Authorized Libraries', '2.6'),
+    ('2', 'Allowlist Authorized Scripts', '2.7'),
+    ('3', 'Establish and Maintain a Data Management Process', '3.1'),
+    ('3', 'Establish and Maintain a Data Inventory', '3.2'),
+    ('3', 'Configure Data Access Control Lists', '3.3'),
+    ('3', 'Enforce Data Retention', '3.4'),
+    ('3', 'Securely Dispose of Data', '3.5'),
+    ('3', 'Encrypt Data on End-User Devices', '3.6'),
+    ('3', 'Establish and Maintain a Data Classification Scheme', '3.7'),
+    ('3', 'Document Data Flows', '3.8'),
+    ('3', 'Encrypt Data on Removable Media', '3.9'),
+    ('3', 'Encrypt Sensitive Data in Transit', '3.10'),
+    ('3', 'Encrypt Sensitive Data at Rest', '3.11'),
+    ('3', 'Segment Data Processing and Storage Based on Sensitivity', '3.12'),
+    ('3', 'Deploy a Data Loss Prevention Solution', '3.13'),
+    ('3', 'Log Sensitive Data Access', '3.14'),
+    ('4', 'Establish and Maintain a Secure Configuration Process', '4.1'),
+    ('4', 'Establish and Maintain a Secure Configuration Process for Network Infrastructure', '4.2'),
+    ('4', 'Configure Automatic Session Locking on Enterprise Assets', '4.3'),
+    ('4', 'Implement and Manage a Firewall on Servers', '4.4'),
+    ('4', 'Implement and Manage a Firewall on End-User Devices', '4.5'),
+    ('4', 'Securely Manage Enterprise Assets and Software', '4.6'),
+    ('4', 'Manage Default Accounts on Enterprise Assets and Software', '4.7'),
+    ('4', 'Uninstall or Disable Unnecessary Services on Enterprise Assets and Software', '4.8'),
+    ('4', 'Configure Trusted DNS Servers on Enterprise Assets', '4.9'),
+    ('4', 'Enforce Automatic Device Lockout on Portable End-User Devices', '4.10'),
+    ('4', 'Enforce Remote Wipe Capability on Portable End-User Devices', '4.11'),
+    ('4', 'Separate Enterprise Workspaces on Mobile End-User Devices', '4.12'),
+    ('5', 'Establish and Maintain an Inventory of Accounts', '5.1'),
+    ('5', 'Use Unique Passwords', '5.2'),
+    ('5', 'Disable Dormant Accounts', '5.3'),
+    ('5', 'Restrict Administrator Privileges to Dedicated Administrator Accounts', '5.4'),
+    ('5', 'Establish and Maintain an Inventory of Service Accounts', '5.5'),
+    ('5', 'Centralize Account Management', '5.6'),
+    ('6', 'Establish an Access Granting Process', '6.1'),
+    ('6', 'Establish an Access Revoking Process', '6.2'),
+    ('6', 'Require MFA for Externally-Exposed Applications', '6.3'),
+    ('6', 'Require MFA for Remote Network Access', '6.4'),
+    ('6', 'Require MFA for Administrative Access', '6.5'),
+    ('6', 'Establish and Maintain an Inventory of Authentication and Authorization Systems', '6.6'),
+    ('6', 'Centralize Access Control', '6.7'),
+    ('6', 'Define and Maintain Role-Based Access Control', '6.8'),
+    ('7', 'Establish and Maintain a Vulnerability Management Process', '7.1'),
+    ('7', 'Establish and Maintain a Remediation Process', '7.2'),
+    ('7', 'Perform Automated Operating System Patch Management', '7.3'),
+    ('7', 'Perform Automated Application Patch Management', '7.4'),
+    ('7', 'Perform Automated Vulnerability Scans of Internal Enterprise Assets', '7.5'),
+    ('7', 'Perform Automated Vulnerability Scans of Externally-Exposed Enterprise Assets', '7.6'),
+    ('7', 'Remediate Detected Vulnerabilities', '7.7'),
+    ('8', 'Establish and Maintain an Audit Log Management Process', '8.1'),
+    ('8', 'Collect Audit Logs', '8.2'),
+    ('8', 'Ensure Adequate Audit Log Storage', '8.3'),
+    ('8', 'Standardize Time Synchronization', '8.4'),
+    ('8', 'Collect Detailed Audit Logs', '8.5'),
+    ('8', 'Collect DNS Query Audit Logs', '8.6'),
+    ('8', 'Collect URL Request Audit Logs', '8.7'),
+    ('8', 'Collect Command-Line Audit Logs', '8.8'),
+    ('8', 'Centralize Audit Logs', '8.9'),
+    ('8', 'Retain Audit Logs', '8.10'),
+    ('8', 'Conduct Audit Log Reviews', '8.11'),
+    ('8', 'Collect Service Provider Logs', '8.12'),
+    ('9', 'Ensure Use of Only Fully Supported Browsers and Email Clients', '9.1'),
+    ('9', 'Use DNS Filtering Services', '9.2'),
+    ('9', 'Maintain and Enforce Network-Based URL Filters', '9.3'),
+    ('9', 'Restrict Unnecessary or Unauthorized Browser and Email Client Extensions', '9.4'),
+    ('9', 'Implement DMARC', '9.5'),
+    ('9', 'Block Unnecessary File Types', '9.6'),
+    ('9', 'Deploy and Maintain Email Server Anti-Malware Protections', '9.7'),
+    ('10', 'Deploy and Maintain Anti-Malware Software', '10.1'),
+    ('10', 'Configure Automatic Anti-Malware Signature Updates', '10.2'),
+    ('10', 'Disable Autorun and Autoplay for Removable Media', '10.3'),
+    ('10', 'Configure Automatic Anti-Malware Scanning of Removable Media', '10.4'),
+    ('10', 'Enable Anti-Exploitation Features', '10.5'),
+    ('10', 'Centrally Manage Anti-Malware Software', '10.6'),
+    ('10', 'Use Behavior-Based Anti-Malware Software', '10.7'),
+    ('11', 'Establish and Maintain a Data Recovery Process', '11.1'),
+    ('11', 'Perform Automated Backups', '11.2'),
+    ('11', 'Protect Recovery Data', '11.3'),
+    ('11', 'Establish and Maintain an Isolated Instance of Recovery Data', '11.4'),
+    ('11', 'Test Data Recovery', '11.5'),
+    ('12', 'Ensure Network Infrastructure is Up-to-Date', '12.1'),
+    ('12', 'Establish and Maintain a Secure Network Architecture', '12.2'),
+    ('12', 'Securely Manage Network Infrastructure', '12.3'),
+    ('12', 'Establish and Maintain Architecture Diagram(s)', '12.4'),
+    ('12', 'Centralize Network Authentication, Authorization, and Auditing (AAA)', '12.5'),
+    ('12', 'Use of Secure Network Management and Communication Protocols', '12.6'),
+    ('12', 'Ensure Remote Devices Utilize a VPN and are Connecting to an Enterprise’s AAA Infrastructure', '12.7'),
+    ('12', 'Establish and Maintain Dedicated Computing Resources for All Administrative Work', '12.8'),
+    ('13', 'Centralize Security Event Alerting', '13.1'),
+    ('13', 'Deploy a Host-Based Intrusion Detection Solution', '13.2'),
+    ('13', 'Deploy a Network Intrusion Detection Solution', '13.3'),
+    ('13', 'Perform Traffic Filtering Between Network Segments', '13.4'),
+    ('13', 'Manage Access Control for Remote Assets', '13.5'),
+    ('13', 'Collect Network Traffic Flow Logs', '13.6'),
+    ('13', 'Deploy a Host-Based Intrusion Prevention Solution', '13.7'),
+    ('13', 'Deploy a Network Intrusion Prevention Solution', '13.8'),
+    ('13', 'Deploy Port-Level Access Control', '13.9'),
+    ('13', 'Perform Application Layer Filtering', '13.10'),
+    ('13', 'Tune Security Event Alerting Thresholds', '13.11'),
+    ('14', 'Establish and Maintain a Security Awareness Program', '14.1'),
+    ('14', 'Train Workforce Members to Recognize Social Engineering Attacks', '14.2'),
+    ('14', 'Train Workforce Members on Authentication Best Practices', '14.3'),
+    ('14', 'Train Workforce on Data Handling Best Practices', '14.4'),
+    ('14', 'Train Workforce Members on Causes of Unintentional Data Exposure', '14.5'),
+    ('14', 'Train Workforce Members on Recognizing and Reporting Security Incidents', '14.6'),
+    ('14', 'Train Workforce on How to Identify and Report if Their Enterprise Assets are Missing Security Updates', '14.7'),
+    ('14', 'Train Workforce on the Dangers of Connecting to and Transmitting Enterprise Data Over Insecure Networks', '14.8'),
+    ('14', 'Conduct Role-Specific Security Awareness and Skills Training', '14.9'),
+    ('15', 'Establish and Maintain an Inventory of Service Providers', '15.1'),
+    ('15', 'Establish and Maintain a Service Provider Management Policy', '15.2'),
+    ('15', 'Classify Service Providers', '15.3'),
+    ('15', 'Ensure Service Provider Contracts Include Security Requirements', '15.4'),
+    ('15', 'Assess Service Providers', '15.5'),
+    ('15', 'Monitor Service Providers', '15.6'),
+    ('15', 'Securely Decommission Service Providers', '15.7'),
+    ('16', 'Establish and Maintain a Secure Application Development Process', '16.1'),
+    ('16', 'Establish and Maintain a Process to Accept and Address Software Vulnerabilities', '16.2'),
+    ('16', 'Perform Root Cause Analysis on Security Vulnerabilities', '16.3'),
+    ('16', 'Establish and Manage an Inventory of Third-Party Software Components', '16.4'),
+    ('16', 'Use Up-to-Date and Trusted Third-Party Software Components', '16.5'),
+    ('16', 'Establish and Maintain a Severity Rating System and Process for Application Vulnerabilities', '16.6'),
+    ('16', 'Use Standard Hardening Configuration Templates for Application Infrastructure', '16.7'),
+    ('16', 'Separate Production and Non-Production Systems', '16.8'),
+    ('16', 'Train Developers in Application Security Concepts and Secure Coding', '16.9'),
+    ('16', 'Apply Secure Design Principles in Application Architectures', '16.10'),
+    ('16', 'Leverage Vetted Modules or Services for Application Security Components', '16.11'),
+    ('16', 'Implement Code-Level Security Checks', '16.12'),
+    ('16', 'Conduct Application Penetration Testing', '16.13'),
+    ('16', 'Conduct Threat Modeling', '16.14'),
+    ('17', 'Designate Personnel to Manage Incident Handling', '17.1'),
+    ('17', 'Establish and Maintain Contact Information for Reporting Security Incidents', '17.2'),
+    ('17', 'Establish and Maintain an Enterprise Process for Reporting Incidents', '17.3'),
+    ('17', 'Establish and Maintain an Incident Response Process', '17.4'),
+    ('17', 'Assign Key Roles and Responsibilities', '17.5'),
+    ('17', 'Define Mechanisms for Communicating During Incident Response', '17.6'),
+    ('17', 'Conduct Routine Incident Response Exercises', '17.7'),
+    ('17', 'Conduct Post-Incident Reviews', '17.8'),
+    ('17', 'Establish and Maintain Security Incident Thresholds', '17.9'),
+    ('18', 'Establish and Maintain a Penetration Testing Program', '18.1'),
+    ('18', 'Perform Periodic External Penetration Tests', '18.2'),
+    ('18', 'Remediate Penetration Test Findings', '18.3'),
+    ('18', 'Validate Security Measures', '18.4'),
+    ('18', 'Perform Periodic Internal Penetration Tests', '18.5')
+) AS cis ("name", best_practices, control_id)
+WHERE f.frameworks_uuid = fc.frameworks_uuid AND f."name" = 'CIS'
+    AND trim(fc."name") = cis."name" AND trim(fc.best_practices) = cis.best_practices;
+
+-- names of the other controls are unique already, the rare duplicate keeps its uuid
+UPDATE public.framework_controls fc SET control_id = c.control_id
+FROM (
+    SELECT framework_control_uuid,
+        CASE WHEN "name" IS NOT NULL AND count(*) OVER (PARTITION BY frameworks_uuid, "name") = 1 THEN "name"
+        ELSE framework_control_uuid::text
+        END AS control_id
+    FROM public.framework_controls
+    WHERE control_id IS NULL
+) c
+WHERE c.framework_control_uuid = fc.framework_control_uuid;
+
+ALTER TABLE public.framework_controls ALTER COLUMN control_id SET NOT NULL;
+
+CREATE UNIQUE INDEX framework_controls_control_id_idx ON public.framework_controls (frameworks_uuid, control_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS public.framework_controls_control_id_idx;
+
+ALTER TABLE public.framework_controls DROP COLUMN IF EXISTS retired_at;
+ALTER TABLE public.framework_controls DROP COLUMN IF EXISTS control_id;
@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE public.framework_control_mappings (
+    control_mapping_uuid uuid NOT NULL,
+    framework_control_uuid uuid NOT NULL,
+    mapped_control_uuid uuid NOT NULL,
+    created_at timestamptz NULL,
+    CONSTRAINT framework_control_mappings_pkey PRIMARY KEY (control_mapping_uuid),
+    CONSTRAINT framework_control_mappings_distinct CHECK (framework_control_uuid <> mapped_control_uuid)
+);
+
+ALTER TABLE public.framework_control_mappings ADD CONSTRAINT fk_framework_controls FOREIGN KEY (framework_control_uuid) REFERENCES public.framework_controls(framework_control_uuid) ON DELETE CASCADE;
+ALTER TABLE public.framework_control_mappings ADD CONSTRAINT fk_mapped_controls FOREIGN KEY (mapped_control_uuid) REFERENCES public.framework_controls(framework_control_uuid) ON DELETE CASCADE;
+
+-- a mapping goes both ways, so a pair of controls is mapped once whatever its order
+CREATE UNIQUE INDEX framework_control_mappings_pair_idx ON public.framework_control_mappings (
+    least(framework_control_uuid, mapped_control_uuid), greatest(framework_control_uuid, mapped_control_uuid)
+);
+CREATE INDEX framework_control_mappings_mapped_control_idx ON public.framework_control_mappings (mapped_control_uuid);
+
+CREATE INDEX control_remediations_company_control_idx ON public.control_remediations (company_uuid, framework_control_uuid);
+
+-- +migrate Down
+DROP INDEX IF EXISTS public.control_remediations_company_control_idx;
+DROP TABLE IF EXISTS public.framework_control_mappings;
@@ -0,0 +1,96 @@
+-- +migrate Up
+-- a company remediates a control once; of the remediations seeded twice keep a completed one,
+-- else the one updated last, so no progress is lost
+DELETE FROM public.control_remediations WHERE control_remediation_uuid IN (
+    SELECT control_remediation_uuid FROM (
+        SELECT control_remediation_uuid,
+            row_number() OVER (
+                PARTITION BY company_uuid, framework_control_uuid
+                ORDER BY CASE WHEN status = 'completed' THEN 0 ELSE 1 END, updated_at DESC NULLS LAST, created_at NULLS LAST, control_remediation_uuid
+            ) AS n
+        FROM public.control_remediations
+    ) r
+    WHERE r.n > 1
+);
+
+DROP INDEX IF EXISTS public.control_remediations_company_control_idx;
+CREATE UNIQUE INDEX control_remediations_company_control_idx ON public.control_remediations (company_uuid, framework_control_uuid);
+
+ALTER TABLE public.control_remediations ALTER COLUMN status TYPE text USING status::text;
+DROP TYPE IF EXISTS public.control_remediations_status_type;
+
+UPDATE public.control_remediations SET status = 'not_started' WHERE status IS NULL OR status = 'pending';
+
+ALTER TABLE public.control_remediations ALTER COLUMN status SET DEFAULT 'not_started';
+ALTER TABLE public.control_remediations ALTER COLUMN status SET NOT NULL;
+ALTER TABLE public.control_remediations ADD CONSTRAINT control_remediations_status_check
+    CHECK (status IN ('not_started', 'in_progress', 'blocked', 'in_review', 'completed', 'not_applicable'));
+
+ALTER TABLE public.control_remediations ADD COLUMN priority text NOT NULL DEFAULT 'medium';
+ALTER TABLE public.control_remediations ADD CONSTRAINT control_remediations_priority_check
+    CHECK (priority IN ('low', 'medium', 'high', 'critical'));
+ALTER TABLE public.control_remediations ADD COLUMN owner_uuid uuid NULL;
+ALTER TABLE public.control_remediations ADD COLUMN due_date date NULL;
+ALTER TABLE public.control_remediations ADD COLUMN completed_at timestamptz NULL;
+
+ALTER TABLE public.control_remediations ADD CONSTRAINT fk_owner_users FOREIGN KEY (owner_uuid) REFERENCES public.users(user_uuid) ON DELETE SET NULL;
+
+UPDATE public.control_remediations SET completed_at = coalesce(updated_at, created_at) WHERE status = 'completed';
+
+CREATE INDEX control_remediations_owner_uuid_idx ON public.control_remediations (owner_uuid) WHERE owner_uuid IS NOT NULL;
+
+CREATE TABLE public.control_remediation_evidences (
+    evidence_uuid uuid NOT NULL,
+    control_remediation_uuid uuid NOT NULL,
+    file_name text NOT NULL,
+    content_type text NULL,
+    "size" bigint NOT NULL DEFAULT 0,
+    s3_key text NOT NULL,
+    created_at timestamptz NULL DEFAULT now(),
+    created_by uuid NULL,
+    CONSTRAINT control_remediation_evidences_pkey PRIMARY KEY (evidence_uuid)
+);
+
+CREATE INDEX control_remediation_evidences_remediation_idx ON public.control_remediation_evidences (control_remediation_uuid, created_at);
+
+ALTER TABLE public.control_remediation_evidences ADD CONSTRAINT fk_control_remediations FOREIGN KEY (control_remediation_uuid) REFERENCES public.control_remediations(control_remediation_uuid) ON DELETE CASCADE;
+ALTER TABLE public.control_remediation_evidences ADD CONSTRAINT fk_created_by_users FOREIGN KEY (created_by) REFERENCES public.users(user_uuid);
+
+CREATE TABLE public.control_remediation_comments (
+    comment_uuid uuid NOT NULL,
+    control_remediation_uuid uuid NOT NULL,
+    body text NOT NULL,
+    created_at timestamptz NULL DEFAULT now(),
+    created_by uuid NULL,
+    CONSTRAINT control_remediation_comments_pkey PRIMARY KEY (comment_uuid)
+);
+
+CREATE INDEX control_remediation_comments_remediation_idx ON public.control_remediation_comments (control_remediation_uuid, created_at);
+
+ALTER TABLE public.control_remediation_comments ADD CONSTRAINT fk_control_remediations FOREIGN KEY (control_remediation_uuid) REFERENCES public.control_remediations(control_remediation_uuid) ON DELETE CASCADE;
+ALTER TABLE public.control_remediation_comments ADD CONSTRAINT fk_created_by_users FOREIGN KEY (created_by) REFERENCES public.users(user_uuid);
+
+-- +migrate Down
+DROP TABLE IF EXISTS public.control_remediation_comments;
+DROP TABLE IF EXISTS public.control_remediation_evidences;
+
+DROP INDEX IF EXISTS public.control_remediations_owner_uuid_idx;
+ALTER TABLE public.control_remediations DROP CONSTRAINT IF EXISTS fk_owner_users;
+ALTER TABLE public.control_remediations DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE public.control_remediations DROP COLUMN IF EXISTS due_date;
+ALTER TABLE public.control_remediations DROP COLUMN IF EXISTS owner_uuid;
+ALTER TABLE public.control_remediations DROP CONSTRAINT IF EXISTS control_remediations_priority_check;
+ALTER TABLE public.control_remediations DROP COLUMN IF EXISTS priority;
+
+ALTER TABLE public.control_remediations DROP CONSTRAINT IF EXISTS control_remediations_status_check;
+ALTER TABLE public.control_remediations ALTER COLUMN status DROP NOT NULL;
+ALTER TABLE public.control_remediations ALTER COLUMN status DROP DEFAULT;
+UPDATE public.control_remediations SET status = 'pending' WHERE status <> 'completed';
+
+CREATE TYPE public.control_remediations_status_type AS ENUM(
+    'pending', 'completed'
+);
+ALTER TABLE public.control_remediations ALTER COLUMN status TYPE control_remediations_status_type USING status::control_remediations_status_type;
+
+DROP INDEX IF EXISTS public.control_remediations_company_control_idx;
+CREATE INDEX control_remediations_company_control_idx ON public.control_remediations (company_uuid, framework_control_uuid);
@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE public.compliance_snapshots (
+    snapshot_uuid uuid NOT NULL,
+    company_uuid uuid NOT NULL,
+    frameworks_uuid uuid NOT NULL,
+    snapshot_date date NOT NULL,
+    controls_total integer NOT NULL DEFAULT 0,
+    controls_by_status jsonb NOT NULL DEFAULT '{}'::jsonb,
+    policy_covered integer NOT NULL DEFAULT 0,
+    questionnaire_total integer NOT NULL DEFAULT 0,
+    questionnaire_answered integer NOT NULL DEFAULT 0,
+    backfilled boolean NOT NULL DEFAULT false,
+    created_at timestamptz NULL DEFAULT now(),
+    updated_at timestamptz NULL DEFAULT now(),
+    CONSTRAINT compliance_snapshots_pkey PRIMARY KEY (snapshot_uuid)
+);
+
+CREATE UNIQUE INDEX compliance_snapshots_company_framework_date_idx ON public.compliance_snapshots (company_uuid, frameworks_uuid, snapshot_date);
+
+ALTER TABLE public.compliance_snapshots ADD CONSTRAINT fk_companies FOREIGN KEY (company_uuid) REFERENCES public.companies(company_uuid) ON DELETE CASCADE;
+ALTER TABLE public.compliance_snapshots ADD CONSTRAINT fk_frameworks FOREIGN KEY (frameworks_uuid) REFERENCES public.frameworks(frameworks_uuid) ON DELETE CASCADE;
+
+-- +migrate Down
+DROP TABLE IF EXISTS public.compliance_snapshots;
@@ -0,0 +1,11 @@
+-- +migrate Up
+-- profile targeted by the company, one of the groups of the controls of the framework; all the controls when null
+ALTER TABLE public.company_frameworks ADD COLUMN profile text NULL;
+-- groups of a question, listed in brackets at the end of its category like 'Identify - [IG1,IG2,IG3]'
+ALTER TABLE public.questionnaires ADD COLUMN "groups" text[] GENERATED ALWAYS AS (
+    regexp_split_to_array(substring(category from '\[([^]]*)\]'), '\s*,\s*')
+) STORED;
+
+-- +migrate Down
+ALTER TABLE public.questionnaires DROP COLUMN IF EXISTS "groups";
+ALTER TABLE public.company_frameworks DROP COLUMN IF EXISTS profile;
@@ -0,0 +1,7 @@
+-- +migrate Up
+-- paragraphs a version inserted, deleted and modified compared to the previous one, counted on save;
+-- versions saved before are counted when the history of their policy is first listed
+ALTER TABLE public.policy_histories ADD COLUMN changes jsonb NULL;
+
+-- +migrate Down
+ALTER TABLE public.policy_histories DROP COLUMN IF EXISTS changes;
@@ -13,6 +13,9 @@ const (
 	AuthorizationKey headerKey = headerKey("Authorization")
 	Access           headerKey = headerKey("Access")
 	RedesignTokenKey headerKey = headerKey("Redesign-Access-Token")
+	APIKeyKey        headerKey = headerKey("X-Api-Key")
+	// ImpersonationSessionKey selects the impersonation session a request is made under.
+	ImpersonationSessionKey headerKey = headerKey("X-Impersonation-Session")
 
 	RedesignWebhookTokenKey queryPathKey = queryPathKey("token")
 )
@@ -6,11 +6,14 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 )
 
 const (
 	DOCX               = ".docx"
 	HTML               = ".html"
+	JSON               = ".json" // document tree of pandoc
+	PDF                = ".pdf"
 	TEMPORARY_FILENAME = "temp"
 )
 
@@ -92,3 +95,27 @@ func (f *File) HTMLToDocx() ([]byte, error) {
 	defer Cleanup()
 	return data, nil
 }
+
+// Convert the data from one format to another, e.g. HTML to PDF. Unlike the methods of File the
+// conversion runs in a temporary directory of its own, so conversions may run concurrently.
+func Convert(data []byte, from, to string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "converter")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	in := filepath.Join(dir, TEMPORARY_FILENAME+from)
+	out := filepath.Join(dir, TEMPORARY_FILENAME+to)
+
+	if err = os.WriteFile(in, data, 0o600); err != nil {
+		return nil, err
+	}
+
+	output, err := exec.Command("pandoc", in, "-o", out).CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("pandoc: %w: %s", err, output)
+	}
+
+	return os.ReadFile(out)
+}
@@ -21,6 +21,48 @@ func IsNotFoundError(err error) bool {
 	}
 }
 
+// ErrValidation when a request is well formed but its values are not acceptable.
+type ErrValidation struct {
+	Message string
+}
+
+func (e *ErrValidation) Error() string {
+	return e.Message
+}
+
+func IsValidationError(err error) bool {
+	cause := errors.Cause(err)
+
+	switch cause.(type) {
+	case *ErrValidation:
+		return true
+	default:
+		return false
+	}
+}
+
+// ErrConflict when a request is based on a state which changed since. Data is the current
+// state, returned along with the error.
+type ErrConflict struct {
+	Message string
+	Data    interface{}
+}
+
+func (e *ErrConflict) Error() string {
+	return e.Message
+}
+
+func IsConflictError(err error) bool {
+	cause := errors.Cause(err)
+
+	switch cause.(type) {
+	case *ErrConflict:
+		return true
+	default:
+		return false
+	}
+}
+
 // ErrBadRouting in the log.
 var ErrBadRouting = errors.New("inconsistent mapping between route and handler (programmer error)")
 
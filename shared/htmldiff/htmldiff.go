@@ -0,0 +1,518 @@
+// Package htmldiff compares HTML documents, such as the ones produced by the docx converter,
+// block by block.
+package htmldiff
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	OpEqual  = "equal"
+	OpInsert = "insert"
+	OpDelete = "delete"
+	OpModify = "modify"
+)
+
+// minSimilarity of a deleted and an inserted block to report them as a modified block.
+const minSimilarity = 0.5
+
+// blockTags are compared as a whole when they hold no other block.
+var blockTags = map[atom.Atom]bool{
+	atom.P: true, atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Li: true, atom.Dt: true, atom.Dd: true, atom.Blockquote: true, atom.Figcaption: true, atom.Div: true,
+	atom.Ul: true, atom.Ol: true, atom.Dl: true, atom.Section: true, atom.Article: true,
+	atom.Header: true, atom.Footer: true, atom.Figure: true, atom.Body: true,
+}
+
+// wholeTags are compared as a single block, whatever they hold.
+var wholeTags = map[atom.Atom]bool{
+	atom.Table: true, atom.Pre: true, atom.Hr: true, atom.Img: true,
+}
+
+// Block of a document. List is the tag of the list holding a list item, if any.
+type Block struct {
+	Tag  string `json:"tag"`
+	List string `json:"-"`
+	Text string `json:"text"`
+	HTML string `json:"-"`
+	// inner HTML of the block, empty for tables, images and rules
+	inner string
+}
+
+// Change of a block between two documents. Modified blocks hold both the old and the new block.
+type Change struct {
+	Op   string `json:"op"`
+	Old  *Block `json:"old,omitempty"`
+	New  *Block `json:"new,omitempty"`
+	HTML string `json:"html"`
+}
+
+// Summary counts the blocks of a diff by operation. Changed counts the inserted, deleted and
+// modified blocks.
+type Summary struct {
+	Inserted  int `json:"inserted"`
+	Deleted   int `json:"deleted"`
+	Modified  int `json:"modified"`
+	Unchanged int `json:"unchanged"`
+	Changed   int `json:"changed"`
+}
+
+// Diff of two documents.
+type Diff struct {
+	Changes []*Change `json:"changes"`
+	Summary Summary   `json:"summary"`
+	HTML    string    `json:"html"`
+}
+
+// Blocks of the HTML document, in order. Blocks without text, such as empty paragraphs, are
+// skipped unless they are tables, images or rules.
+func Blocks(document string) ([]*Block, error) {
+	doc, err := html.Parse(strings.NewReader(document))
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []*Block
+
+	var walk func(n *html.Node, list string)
+	walk = func(n *html.Node, list string) {
+		if n.Type == html.ElementNode && wholeTags[n.DataAtom] {
+			blocks = appendBlock(blocks, newBlock(n, list, n), true)
+
+			return
+		}
+
+		if n.Type == html.ElementNode && blockTags[n.DataAtom] && !hasBlock(n) {
+			if n.DataAtom != atom.Body && n.DataAtom != atom.Ul && n.DataAtom != atom.Ol {
+				blocks = appendBlock(blocks, newBlock(n, list, n), false)
+
+				return
+			}
+		}
+
+		childList := list
+		if n.DataAtom == atom.Ul || n.DataAtom == atom.Ol {
+			childList = n.Data
+		}
+
+		// inline content next to blocks, such as the text of a list item holding a nested
+		// list, is a block of its own
+		var inline []*html.Node
+
+		flush := func() {
+			if len(inline) > 0 && n.Type == html.ElementNode {
+				blocks = appendBlock(blocks, newInlineBlock(n, list, inline), false)
+			}
+
+			inline = nil
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			switch {
+			case c.Type == html.CommentNode || c.DataAtom == atom.Head:
+			case c.Type == html.TextNode || c.Type == html.ElementNode && !isBlock(c) && !hasBlock(c):
+				inline = append(inline, c)
+			default:
+				flush()
+				walk(c, childList)
+			}
+		}
+
+		flush()
+	}
+
+	walk(doc, "")
+
+	return blocks, nil
+}
+
+// Text of the HTML document without markup, one block per line, such as to index it for search.
+func Text(document string) (string, error) {
+	blocks, err := Blocks(document)
+	if err != nil {
+		return "", err
+	}
+
+	lines := make([]string, 0, len(blocks))
+
+	for _, b := range blocks {
+		// tables, images and rules without text only have markup
+		if wholeTags[atom.Lookup([]byte(b.Tag))] && b.Text == b.HTML {
+			continue
+		}
+
+		lines = append(lines, b.Text)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func appendBlock(blocks []*Block, b *Block, keepEmpty bool) []*Block {
+	if b.Text == "" && !keepEmpty {
+		return blocks
+	}
+
+	return append(blocks, b)
+}
+
+func isBlock(n *html.Node) bool {
+	return n.Type == html.ElementNode && (blockTags[n.DataAtom] || wholeTags[n.DataAtom])
+}
+
+func hasBlock(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if isBlock(c) || hasBlock(c) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newBlock(n *html.Node, list string, nodes ...*html.Node) *Block {
+	b := &Block{Tag: n.Data, List: list}
+	if n.DataAtom != atom.Li {
+		b.List = ""
+	}
+
+	var buf bytes.Buffer
+	for _, node := range nodes {
+		_ = html.Render(&buf, node) // nolint: errcheck
+	}
+
+	b.HTML = buf.String()
+	b.Text = text(nodes...)
+
+	if wholeTags[n.DataAtom] {
+		if b.Text == "" {
+			b.Text = b.HTML
+		}
+
+		return b
+	}
+
+	buf.Reset()
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		_ = html.Render(&buf, c) // nolint: errcheck
+	}
+
+	b.inner = buf.String()
+
+	return b
+}
+
+// newInlineBlock of the inline children of n, rendered as a paragraph unless n is a list item.
+func newInlineBlock(n *html.Node, list string, nodes []*html.Node) *Block {
+	b := &Block{Tag: "p"}
+
+	switch n.DataAtom {
+	case atom.Li:
+		b.Tag, b.List = n.Data, list
+	case atom.Dt, atom.Dd:
+		b.Tag = n.Data
+	}
+
+	var buf bytes.Buffer
+	for _, node := range nodes {
+		_ = html.Render(&buf, node) // nolint: errcheck
+	}
+
+	b.inner = buf.String()
+	b.HTML = "<" + b.Tag + ">" + b.inner + "</" + b.Tag + ">"
+	b.Text = text(nodes...)
+
+	return b
+}
+
+// text of the nodes with collapsed white space.
+func text(nodes ...*html.Node) string {
+	var sb strings.Builder
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+			sb.WriteByte(' ')
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+
+	for _, n := range nodes {
+		walk(n)
+	}
+
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// Compare the HTML documents from and to.
+func Compare(from, to string) (*Diff, error) {
+	oldBlocks, err := Blocks(from)
+	if err != nil {
+		return nil, err
+	}
+
+	newBlocks, err := Blocks(to)
+	if err != nil {
+		return nil, err
+	}
+
+	return CompareBlocks(oldBlocks, newBlocks), nil
+}
+
+// CompareBlocks of two documents. Blocks are matched on their tag and text; a deleted block
+// followed by a similar inserted block is reported as modified.
+func CompareBlocks(from, to []*Block) *Diff {
+	ops := lcs(len(from), len(to), func(i, j int) bool {
+		return from[i].Tag == to[j].Tag && from[i].Text == to[j].Text
+	})
+
+	d := &Diff{Changes: make([]*Change, 0, len(ops))}
+
+	var deleted, inserted []*Block
+
+	flush := func() {
+		d.Changes = append(d.Changes, pair(deleted, inserted)...)
+		deleted, inserted = nil, nil
+	}
+
+	for _, o := range ops {
+		switch o.op {
+		case OpDelete:
+			deleted = append(deleted, from[o.i])
+		case OpInsert:
+			inserted = append(inserted, to[o.j])
+		default:
+			flush()
+			d.Changes = append(d.Changes, &Change{Op: OpEqual, Old: from[o.i], New: to[o.j], HTML: to[o.j].HTML})
+		}
+	}
+
+	flush()
+
+	for _, c := range d.Changes {
+		switch c.Op {
+		case OpInsert:
+			d.Summary.Inserted++
+		case OpDelete:
+			d.Summary.Deleted++
+		case OpModify:
+			d.Summary.Modified++
+		default:
+			d.Summary.Unchanged++
+		}
+	}
+
+	d.Summary.Changed = d.Summary.Inserted + d.Summary.Deleted + d.Summary.Modified
+	d.HTML = render(d.Changes)
+
+	return d
+}
+
+// pair the deleted blocks of a hunk with the inserted ones, in order, as modified blocks when
+// they are similar enough.
+func pair(deleted, inserted []*Block) []*Change {
+	changes := make([]*Change, 0, len(deleted)+len(inserted))
+
+	j := 0
+	for _, old := range deleted {
+		if j < len(inserted) && old.Tag == inserted[j].Tag && similarity(old.Text, inserted[j].Text) >= minSimilarity {
+			changes = append(changes, modify(old, inserted[j]))
+			j++
+
+			continue
+		}
+
+		changes = append(changes, &Change{Op: OpDelete, Old: old, HTML: mark("del", old)})
+	}
+
+	for ; j < len(inserted); j++ {
+		changes = append(changes, &Change{Op: OpInsert, New: inserted[j], HTML: mark("ins", inserted[j])})
+	}
+
+	return changes
+}
+
+// modify renders the words inserted and deleted in the block. Inline formatting of modified
+// blocks is not kept.
+func modify(old, new *Block) *Change {
+	oldWords, newWords := strings.Fields(old.Text), strings.Fields(new.Text)
+	ops := lcs(len(oldWords), len(newWords), func(i, j int) bool { return oldWords[i] == newWords[j] })
+
+	var sb strings.Builder
+
+	last := ""
+	for _, o := range ops {
+		if o.op != last {
+			if last == OpDelete || last == OpInsert {
+				sb.WriteString(closing(last))
+			}
+
+			if sb.Len() > 0 {
+				sb.WriteByte(' ')
+			}
+
+			if o.op == OpDelete || o.op == OpInsert {
+				sb.WriteString(opening(o.op))
+			}
+		} else {
+			sb.WriteByte(' ')
+		}
+
+		if o.op == OpDelete {
+			sb.WriteString(html.EscapeString(oldWords[o.i]))
+		} else {
+			sb.WriteString(html.EscapeString(newWords[o.j]))
+		}
+
+		last = o.op
+	}
+
+	if last == OpDelete || last == OpInsert {
+		sb.WriteString(closing(last))
+	}
+
+	return &Change{
+		Op:   OpModify,
+		Old:  old,
+		New:  new,
+		HTML: "<" + new.Tag + ">" + sb.String() + "</" + new.Tag + ">",
+	}
+}
+
+func opening(op string) string {
+	if op == OpDelete {
+		return "<del>"
+	}
+
+	return "<ins>"
+}
+
+func closing(op string) string {
+	if op == OpDelete {
+		return "</del>"
+	}
+
+	return "</ins>"
+}
+
+// mark a whole block as inserted or deleted, within the block so list items stay in their list.
+func mark(tag string, b *Block) string {
+	if b.inner == "" {
+		return "<" + tag + ">" + b.HTML + "</" + tag + ">"
+	}
+
+	return "<" + b.Tag + "><" + tag + ">" + b.inner + "</" + tag + "></" + b.Tag + ">"
+}
+
+// render the changes as a document, with list items back in their lists.
+func render(changes []*Change) string {
+	var sb strings.Builder
+
+	list := ""
+	for _, c := range changes {
+		b := c.New
+		if b == nil {
+			b = c.Old
+		}
+
+		if b.List != list {
+			if list != "" {
+				sb.WriteString("</" + list + ">")
+			}
+
+			if b.List != "" {
+				sb.WriteString("<" + b.List + ">")
+			}
+
+			list = b.List
+		}
+
+		sb.WriteString(c.HTML)
+		sb.WriteByte('\n')
+	}
+
+	if list != "" {
+		sb.WriteString("</" + list + ">")
+	}
+
+	return sb.String()
+}
+
+// similarity of two texts, as the share of their words in common.
+func similarity(a, b string) float64 {
+	aWords, bWords := strings.Fields(a), strings.Fields(b)
+	if len(aWords)+len(bWords) == 0 {
+		return 1
+	}
+
+	common := 0
+	for _, o := range lcs(len(aWords), len(bWords), func(i, j int) bool { return aWords[i] == bWords[j] }) {
+		if o.op == OpEqual {
+			common++
+		}
+	}
+
+	return 2 * float64(common) / float64(len(aWords)+len(bWords))
+}
+
+type edit struct {
+	op   string
+	i, j int
+}
+
+// lcs edits turning a sequence of n elements into one of m elements, deletions first.
+func lcs(n, m int, equal func(i, j int) bool) []edit {
+	// lengths[i][j] is the length of the longest common subsequence of the suffixes at i and j
+	lengths := make([][]int, n+1)
+	for i := range lengths {
+		lengths[i] = make([]int, m+1)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case equal(i, j):
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			case lengths[i+1][j] >= lengths[i][j+1]:
+				lengths[i][j] = lengths[i+1][j]
+			default:
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	edits := make([]edit, 0, n+m)
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case equal(i, j):
+			edits = append(edits, edit{OpEqual, i, j})
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			edits = append(edits, edit{OpDelete, i, j})
+			i++
+		default:
+			edits = append(edits, edit{OpInsert, i, j})
+			j++
+		}
+	}
+
+	for ; i < n; i++ {
+		edits = append(edits, edit{OpDelete, i, j})
+	}
+
+	for ; j < m; j++ {
+		edits = append(edits, edit{OpInsert, i, j})
+	}
+
+	return edits
+}
@@ -0,0 +1,82 @@
+package htmldiff
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBlocks(t *testing.T) {
+	Convey("Given a document converted from docx", t, func() {
+		document := `<h1 id="scope">Scope</h1>
+<p>This policy applies to <strong>all</strong> employees.</p>
+<p></p>
+<ul>
+<li>Laptops<ul><li>Encrypted disks</li></ul></li>
+<li>Phones</li>
+</ul>
+<table><tr><td>Owner</td><td>CISO</td></tr></table>`
+
+		blocks, err := Blocks(document)
+		So(err, ShouldBeNil)
+
+		Convey("Paragraphs, headings, list items and tables are blocks and empty paragraphs are skipped", func() {
+			So(blocks, ShouldHaveLength, 6)
+			So(blocks[0].Tag, ShouldEqual, "h1")
+			So(blocks[1].Text, ShouldEqual, "This policy applies to all employees.")
+			So(blocks[1].HTML, ShouldEqual, "<p>This policy applies to <strong>all</strong> employees.</p>")
+			So(blocks[5].Tag, ShouldEqual, "table")
+		})
+
+		Convey("The text of a list item holding a nested list is a block of its own", func() {
+			So(blocks[2].Tag, ShouldEqual, "li")
+			So(blocks[2].Text, ShouldEqual, "Laptops")
+			So(blocks[2].List, ShouldEqual, "ul")
+			So(blocks[3].Text, ShouldEqual, "Encrypted disks")
+		})
+	})
+}
+
+func TestText(t *testing.T) {
+	Convey("The text of a document has a line per block and leaves out markup", t, func() {
+		text, err := Text(`<h1>Access &amp; Identity</h1><p>Use <strong>MFA</strong> everywhere.</p><p><img src="logo.png"></p><table><tr><td>Owner</td><td>CISO</td></tr></table>`)
+		So(err, ShouldBeNil)
+		So(text, ShouldEqual, "Access & Identity\nUse MFA everywhere.\nOwner CISO")
+	})
+}
+
+func TestCompare(t *testing.T) {
+	Convey("Given two versions of a document", t, func() {
+		from := `<h1>Scope</h1>
+<p>This policy applies to all employees.</p>
+<p>Passwords are rotated every 90 days.</p>
+<ul><li>Laptops</li><li>Phones</li></ul>`
+		to := `<h1>Scope</h1>
+<p>This policy applies to all employees and contractors.</p>
+<ul><li>Laptops</li><li>Phones</li><li>Tablets</li></ul>
+<p>Access is reviewed quarterly.</p>`
+
+		d, err := Compare(from, to)
+		So(err, ShouldBeNil)
+
+		Convey("Similar paragraphs are modified, others inserted or deleted", func() {
+			So(d.Summary, ShouldResemble, Summary{Inserted: 2, Deleted: 1, Modified: 1, Unchanged: 3, Changed: 4})
+
+			So(d.Changes[1].Op, ShouldEqual, OpModify)
+			So(d.Changes[1].HTML, ShouldEqual, "<p>This policy applies to all <del>employees.</del> <ins>employees and contractors.</ins></p>")
+			So(d.Changes[2].Op, ShouldEqual, OpDelete)
+			So(d.Changes[2].HTML, ShouldEqual, "<p><del>Passwords are rotated every 90 days.</del></p>")
+		})
+
+		Convey("The rendered view keeps list items in their list", func() {
+			So(d.HTML, ShouldContainSubstring, "<ul><li>Laptops</li>\n<li>Phones</li>\n<li><ins>Tablets</ins></li>\n</ul>")
+		})
+
+		Convey("Identical documents have no changes", func() {
+			d, err := Compare(from, from)
+			So(err, ShouldBeNil)
+			So(d.Summary.Changed, ShouldEqual, 0)
+			So(d.Summary.Unchanged, ShouldEqual, 5)
+		})
+	})
+}
@@ -24,3 +24,36 @@ func newRedesignClaims(username string, email string) *RedesignClaims {
 	}
 	return claims
 }
+
+const (
+	// AudienceSalesforce for the salesforce webhooks.
+	AudienceSalesforce = "salesforce"
+	// AudienceInternalMeetings for the internal meetings endpoint.
+	AudienceInternalMeetings = "internal-meetings"
+)
+
+// Audiences webhook tokens can be issued for.
+var Audiences = []string{AudienceSalesforce, AudienceInternalMeetings}
+
+type WebhookClaims struct {
+	jwt.StandardClaims
+	// Legacy tokens were signed before the key ring and have no jti.
+	Legacy bool `json:"-"`
+}
+
+func newWebhookClaims(audience, jti string, expiresAt time.Time) *WebhookClaims {
+	claims := &WebhookClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:       jti,
+			Audience: audience,
+			Issuer:   "redesign",
+			IssuedAt: time.Now().Unix(),
+		},
+	}
+
+	if !expiresAt.IsZero() {
+		claims.ExpiresAt = expiresAt.Unix()
+	}
+
+	return claims
+}
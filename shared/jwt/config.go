@@ -0,0 +1,104 @@
+package jwt
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// AlgorithmHS256 signs with a shared secret.
+	AlgorithmHS256 = "HS256"
+	// AlgorithmRS256 signs with an RSA private key.
+	AlgorithmRS256 = "RS256"
+	// AlgorithmEdDSA signs with an Ed25519 private key.
+	AlgorithmEdDSA = "EdDSA"
+
+	dateLayout = "2006-01-02"
+)
+
+// Config of the key ring.
+//
+// Every key is used to verify tokens carrying its ID in the kid header, SigningKeyID names the one
+// used to sign new tokens. To rotate, add the new key, then switch SigningKeyID to it once every
+// instance knows it, and drop the old key once its tokens are gone.
+//
+// Tokens signed before the key ring have no kid header. LegacyKeyID names the key verifying them,
+// the HS256 secret they were signed with, until they are retired by leaving it empty. Legacy
+// webhook tokens have no audience nor jti to revoke them by, they are accepted until the day
+// LegacyWebhookTokensUntil, formatted 2006-01-02, included.
+type Config struct {
+	SigningKeyID             string
+	Keys                     []KeyConfig
+	LegacyKeyID              string
+	LegacyWebhookTokensUntil string
+}
+
+// KeyConfig of a single key. Secret is used by HS256, the PEM encoded keys by RS256 and EdDSA;
+// each of them can be given inline or read from a file. A key with only a public key can verify but not sign.
+type KeyConfig struct {
+	ID             string
+	Algorithm      string
+	Secret         string
+	SecretFile     string
+	PrivateKey     string
+	PrivateKeyFile string
+	PublicKey      string
+	PublicKeyFile  string
+}
+
+// Validate config
+func (c *Config) Validate() error {
+	var errs []string
+
+	if len(c.Keys) == 0 {
+		errs = append(errs, "JWT Keys shouldn't be empty")
+	}
+
+	ids := make(map[string]bool, len(c.Keys))
+	for _, k := range c.Keys {
+		if k.ID == "" {
+			errs = append(errs, "JWT key ID shouldn't be empty")
+		}
+
+		if ids[k.ID] {
+			errs = append(errs, fmt.Sprintf("JWT key %s is defined twice", k.ID))
+		}
+		ids[k.ID] = true
+
+		switch k.Algorithm {
+		case AlgorithmHS256:
+			if k.Secret == "" && k.SecretFile == "" {
+				errs = append(errs, fmt.Sprintf("JWT key %s Secret shouldn't be empty", k.ID))
+			}
+		case AlgorithmRS256, AlgorithmEdDSA:
+			if k.PrivateKey == "" && k.PrivateKeyFile == "" && k.PublicKey == "" && k.PublicKeyFile == "" {
+				errs = append(errs, fmt.Sprintf("JWT key %s needs a private or public key", k.ID))
+			}
+		default:
+			errs = append(errs, fmt.Sprintf("JWT key %s Algorithm should be one of HS256, RS256, EdDSA", k.ID))
+		}
+	}
+
+	if !ids[c.SigningKeyID] {
+		errs = append(errs, "JWT SigningKeyID should name one of the Keys")
+	}
+
+	if c.LegacyKeyID != "" && !ids[c.LegacyKeyID] {
+		errs = append(errs, "JWT LegacyKeyID should name one of the Keys")
+	}
+
+	if c.LegacyWebhookTokensUntil != "" {
+		if _, err := time.Parse(dateLayout, c.LegacyWebhookTokensUntil); err != nil {
+			errs = append(errs, "JWT LegacyWebhookTokensUntil should be a date like "+dateLayout)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Errorf(strings.Join(errs, ","))
+	}
+
+	return nil
+}
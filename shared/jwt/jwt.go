@@ -1,88 +1,59 @@
 package jwt
 
 import (
-	"github.com/dgrijalva/jwt-go"
-	"github.com/pkg/errors"
+	"time"
 )
 
-const jwtSecret = "g6VT7Z8mY4EIoSD2XgPmjHWKPcPR1nKj"
-
-func CreateToken(username string, email string) (string, error) {
-	claims := newRedesignClaims(username, email)
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(jwtSecret))
+// CreateToken signs a short lived redesign token for the user.
+func (r *KeyRing) CreateToken(username string, email string) (string, error) {
+	return r.Sign(newRedesignClaims(username, email))
 }
 
-func VerifyToken(signedToken string) (*RedesignClaims, error) {
-	if signedToken == "" {
-		return nil, ErrInvalidToken
-	}
+// VerifyToken verifies a redesign token and returns its claims. Webhook tokens are signed by the
+// same ring, so tokens with an audience or a jti, or without expiry, are rejected.
+func (r *KeyRing) VerifyToken(signedToken string) (*RedesignClaims, error) {
 	claims := &RedesignClaims{}
-
-	keyFunc := func(token *jwt.Token) (interface{}, error) {
-		_, ok := token.Method.(*jwt.SigningMethodHMAC)
-		if !ok {
-			return nil, ErrInvalidToken
-		}
-		return []byte(jwtSecret), nil
+	if err := r.Parse(signedToken, claims); err != nil {
+		return nil, err
 	}
 
-	tkn, err := jwt.ParseWithClaims(
-		signedToken,
-		claims,
-		keyFunc)
-	if err != nil {
-		if err == jwt.ErrSignatureInvalid {
-			return nil, ErrSignatureInvalid
-		}
-		return nil, errors.Wrap(ErrBadRequest, err.Error())
-	}
-	if !tkn.Valid {
+	if claims.Audience != "" || claims.Id != "" || claims.ExpiresAt == 0 {
 		return nil, ErrInvalidToken
 	}
 
 	return claims, nil
 }
 
-func GenerateWebhookToken() (string, error) {
-	token := jwt.New(jwt.SigningMethodHS256)
-	claims := token.Claims.(jwt.MapClaims)
-	claims["exp"] = 0
-	tokenString, err := token.SignedString([]byte(jwtSecret))
-	if err != nil {
-		return "Webhook token signing Error", err
-	}
-
-	return tokenString, nil
+// CreateWebhookToken signs a webhook token for the audience, identified by jti.
+// A zero expiresAt issues a token that does not expire.
+func (r *KeyRing) CreateWebhookToken(audience, jti string, expiresAt time.Time) (string, error) {
+	return r.Sign(newWebhookClaims(audience, jti, expiresAt))
 }
 
-func VerifyWebhookToken(token string) error {
-	if token == "" {
-		return ErrInvalidToken
+// VerifyWebhookToken verifies a webhook token was issued for the audience and returns its claims.
+// Whether its jti was revoked is up to the caller. Legacy tokens, without audience nor jti, are
+// accepted for every audience until the configured cutoff.
+func (r *KeyRing) VerifyWebhookToken(token, audience string) (*WebhookClaims, error) {
+	claims := &WebhookClaims{}
+
+	legacy, err := r.parse(token, claims)
+	if err != nil {
+		return nil, err
 	}
-	claims := &RedesignClaims{}
 
-	keyFunc := func(token *jwt.Token) (interface{}, error) {
-		_, ok := token.Method.(*jwt.SigningMethodHMAC)
-		if !ok {
+	if legacy && claims.Id == "" && claims.Audience == "" {
+		if !r.acceptsLegacyWebhookTokens(time.Now()) {
 			return nil, ErrInvalidToken
 		}
-		return []byte(jwtSecret), nil
-	}
 
-	tkn, err := jwt.ParseWithClaims(
-		token,
-		claims,
-		keyFunc)
-	if err != nil {
-		if err == jwt.ErrSignatureInvalid {
-			return ErrSignatureInvalid
-		}
-		return errors.Wrap(ErrBadRequest, err.Error())
+		claims.Legacy = true
+
+		return claims, nil
 	}
-	if !tkn.Valid {
-		return ErrInvalidToken
+
+	if claims.Id == "" || !claims.VerifyAudience(audience, true) {
+		return nil, ErrInvalidToken
 	}
 
-	return nil
+	return claims, nil
 }
@@ -1,17 +1,36 @@
 package jwt
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"testing"
+	"time"
 
+	"github.com/dgrijalva/jwt-go"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
+func testKeyRing() *KeyRing {
+	ring, err := NewKeyRing(Config{
+		SigningKeyID: "test",
+		Keys:         []KeyConfig{{ID: "test", Algorithm: AlgorithmHS256, Secret: "secret"}},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return ring
+}
+
 func TestCreateToken(t *testing.T) {
 	Convey("Given username and email", t, func() {
 		username := "testuser"
 		email := "test@email.com"
 		Convey("Call the CreateToken function", func() {
-			token, err := CreateToken(username, email)
+			token, err := testKeyRing().CreateToken(username, email)
 			Convey("Error should be nil and the token value should not be empty", func() {
 				So(err, ShouldBeNil)
 				So(token, ShouldNotBeEmpty)
@@ -24,10 +43,11 @@ func TestVerifyToken(t *testing.T) {
 	Convey("Given username and email", t, func() {
 		username := "testuser"
 		email := "test@email.com"
+		ring := testKeyRing()
 		Convey("Call the CreateToken function", func() {
-			token, _ := CreateToken(username, email)
+			token, _ := ring.CreateToken(username, email)
 			Convey("Call the VerifyToken function", func() {
-				claims, err := VerifyToken(token)
+				claims, err := ring.VerifyToken(token)
 				Convey("Error should be nil", func() {
 					So(err, ShouldBeNil)
 					Convey("Claims should contain expected values", func() {
@@ -40,3 +60,146 @@ func TestVerifyToken(t *testing.T) {
 		})
 	})
 }
+
+func TestVerifyToken_WebhookToken(t *testing.T) {
+	Convey("Given webhook tokens signed by the same ring", t, func() {
+		ring := testKeyRing()
+		expiring, _ := ring.CreateWebhookToken(AudienceSalesforce, "jti-1", time.Now().Add(time.Hour))
+		nonExpiring, _ := ring.CreateWebhookToken(AudienceInternalMeetings, "jti-2", time.Time{})
+
+		Convey("They are rejected as redesign tokens", func() {
+			_, err := ring.VerifyToken(expiring)
+			So(err, ShouldEqual, ErrInvalidToken)
+
+			_, err = ring.VerifyToken(nonExpiring)
+			So(err, ShouldEqual, ErrInvalidToken)
+		})
+
+		Convey("A redesign token without expiry is rejected", func() {
+			token, _ := ring.Sign(&RedesignClaims{Username: "user", StandardClaims: jwt.StandardClaims{Issuer: "redesign"}})
+			_, err := ring.VerifyToken(token)
+			So(err, ShouldEqual, ErrInvalidToken)
+		})
+	})
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	rsaPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	edDER, _ := x509.MarshalPKCS8PrivateKey(edKey)
+	edPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: edDER})
+
+	keys := []KeyConfig{
+		{ID: "old", Algorithm: AlgorithmHS256, Secret: "secret"},
+		{ID: "rsa", Algorithm: AlgorithmRS256, PrivateKey: string(rsaPEM)},
+		{ID: "ed", Algorithm: AlgorithmEdDSA, PrivateKey: string(edPEM)},
+	}
+
+	Convey("Given tokens signed by every key of the ring", t, func() {
+		var tokens []string
+		for _, k := range keys {
+			ring, err := NewKeyRing(Config{SigningKeyID: k.ID, Keys: keys})
+			So(err, ShouldBeNil)
+
+			token, err := ring.CreateToken("user", "user@email.com")
+			So(err, ShouldBeNil)
+			tokens = append(tokens, token)
+		}
+
+		Convey("A ring signing with the newest key still verifies all of them", func() {
+			ring, _ := NewKeyRing(Config{SigningKeyID: "ed", Keys: keys})
+			for _, token := range tokens {
+				_, err := ring.VerifyToken(token)
+				So(err, ShouldBeNil)
+			}
+		})
+
+		Convey("Tokens of a dropped key are rejected", func() {
+			ring, _ := NewKeyRing(Config{SigningKeyID: "ed", Keys: keys[1:]})
+			_, err := ring.VerifyToken(tokens[0])
+			So(err, ShouldEqual, ErrInvalidToken)
+		})
+	})
+}
+
+func TestVerifyWebhookToken(t *testing.T) {
+	Convey("Given a salesforce webhook token", t, func() {
+		ring := testKeyRing()
+		token, err := ring.CreateWebhookToken(AudienceSalesforce, "jti-1", time.Time{})
+		So(err, ShouldBeNil)
+
+		Convey("It is accepted for salesforce and carries its jti", func() {
+			claims, err := ring.VerifyWebhookToken(token, AudienceSalesforce)
+			So(err, ShouldBeNil)
+			So(claims.Id, ShouldEqual, "jti-1")
+		})
+
+		Convey("It is rejected for internal meetings", func() {
+			_, err := ring.VerifyWebhookToken(token, AudienceInternalMeetings)
+			So(err, ShouldEqual, ErrInvalidToken)
+		})
+	})
+
+	Convey("Given an expired webhook token", t, func() {
+		ring := testKeyRing()
+		token, _ := ring.CreateWebhookToken(AudienceSalesforce, "jti-2", time.Now().Add(-time.Minute))
+
+		Convey("It is rejected", func() {
+			_, err := ring.VerifyWebhookToken(token, AudienceSalesforce)
+			So(err, ShouldEqual, ErrExpiredToken)
+		})
+	})
+}
+
+// legacyToken is signed like the tokens issued before the key ring: HS256 with the shared
+// secret and no kid header.
+func legacyToken(claims jwt.Claims) string {
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("legacy-secret"))
+
+	return token
+}
+
+func TestKeyRing_Legacy(t *testing.T) {
+	keys := []KeyConfig{
+		{ID: "legacy", Algorithm: AlgorithmHS256, Secret: "legacy-secret"},
+		{ID: "current", Algorithm: AlgorithmHS256, Secret: "secret"},
+	}
+
+	Convey("Given tokens signed before the key ring", t, func() {
+		token := legacyToken(newRedesignClaims("user", "user@email.com"))
+		webhookToken := legacyToken(jwt.MapClaims{"exp": 0})
+
+		Convey("They are verified by the legacy key", func() {
+			ring, err := NewKeyRing(Config{SigningKeyID: "current", Keys: keys, LegacyKeyID: "legacy"})
+			So(err, ShouldBeNil)
+
+			claims, err := ring.VerifyToken(token)
+			So(err, ShouldBeNil)
+			So(claims.Email, ShouldEqual, "user@email.com")
+		})
+
+		Convey("They are rejected once the legacy key is retired", func() {
+			ring, _ := NewKeyRing(Config{SigningKeyID: "current", Keys: keys})
+
+			_, err := ring.VerifyToken(token)
+			So(err, ShouldEqual, ErrInvalidToken)
+		})
+
+		Convey("Webhook tokens without jti are accepted until the cutoff", func() {
+			ring, _ := NewKeyRing(Config{SigningKeyID: "current", Keys: keys, LegacyKeyID: "legacy",
+				LegacyWebhookTokensUntil: time.Now().Format(dateLayout)})
+
+			claims, err := ring.VerifyWebhookToken(webhookToken, AudienceSalesforce)
+			So(err, ShouldBeNil)
+			So(claims.Legacy, ShouldBeTrue)
+
+			ring, _ = NewKeyRing(Config{SigningKeyID: "current", Keys: keys, LegacyKeyID: "legacy",
+				LegacyWebhookTokensUntil: time.Now().AddDate(0, 0, -1).Format(dateLayout)})
+
+			_, err = ring.VerifyWebhookToken(webhookToken, AudienceSalesforce)
+			So(err, ShouldEqual, ErrInvalidToken)
+		})
+	})
+}
@@ -0,0 +1,259 @@
+package jwt
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+)
+
+const kidHeader = "kid"
+
+type key struct {
+	id        string
+	method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// KeyRing signs tokens with the configured signing key and verifies them with the key named by their kid header,
+// or with the legacy key when they have none.
+type KeyRing struct {
+	signing *key
+	keys    map[string]*key
+	legacy  *key
+	// legacyWebhookUntil is the end of the last day legacy webhook tokens are accepted, zero when they are not.
+	legacyWebhookUntil time.Time
+}
+
+// NewKeyRing loads the keys of the config.
+// nolint:gocritic
+func NewKeyRing(cfg Config) (*KeyRing, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	ring := &KeyRing{keys: make(map[string]*key, len(cfg.Keys))}
+
+	for _, kc := range cfg.Keys {
+		k, err := loadKey(kc)
+		if err != nil {
+			return nil, errors.Wrapf(err, "loading jwt key %s", kc.ID)
+		}
+
+		ring.keys[k.id] = k
+	}
+
+	ring.signing = ring.keys[cfg.SigningKeyID]
+	if ring.signing.signKey == nil {
+		return nil, errors.Errorf("jwt signing key %s has no private key", cfg.SigningKeyID)
+	}
+
+	if cfg.LegacyKeyID != "" {
+		ring.legacy = ring.keys[cfg.LegacyKeyID]
+	}
+
+	if cfg.LegacyWebhookTokensUntil != "" {
+		until, err := time.Parse(dateLayout, cfg.LegacyWebhookTokensUntil)
+		if err != nil {
+			return nil, err
+		}
+
+		ring.legacyWebhookUntil = until.AddDate(0, 0, 1)
+	}
+
+	return ring, nil
+}
+
+// SigningKeyID is the kid of newly signed tokens.
+func (r *KeyRing) SigningKeyID() string {
+	return r.signing.id
+}
+
+// Sign the claims with the signing key.
+func (r *KeyRing) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(r.signing.method, claims)
+	token.Header[kidHeader] = r.signing.id
+
+	return token.SignedString(r.signing.signKey)
+}
+
+// Parse verifies the token with the key named by its kid header and fills claims.
+func (r *KeyRing) Parse(signedToken string, claims jwt.Claims) error {
+	_, err := r.parse(signedToken, claims)
+
+	return err
+}
+
+// parse is Parse, also telling whether the token was verified by the legacy key.
+func (r *KeyRing) parse(signedToken string, claims jwt.Claims) (bool, error) {
+	if signedToken == "" {
+		return false, ErrInvalidToken
+	}
+
+	legacy := false
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header[kidHeader].(string) //nolint:errcheck
+		k, ok := r.keys[kid]
+
+		if kid == "" {
+			k, ok, legacy = r.legacy, r.legacy != nil, true
+		}
+
+		if !ok || token.Method.Alg() != k.method.Alg() {
+			return nil, ErrInvalidToken
+		}
+
+		return k.verifyKey, nil
+	}
+
+	tkn, err := jwt.ParseWithClaims(signedToken, claims, keyFunc)
+	if err != nil {
+		if ve, ok := err.(*jwt.ValidationError); ok {
+			switch {
+			case ve.Errors&jwt.ValidationErrorSignatureInvalid != 0:
+				return false, ErrSignatureInvalid
+			case ve.Errors&jwt.ValidationErrorExpired != 0:
+				return false, ErrExpiredToken
+			case ve.Inner == ErrInvalidToken:
+				return false, ErrInvalidToken
+			}
+		}
+
+		return false, errors.Wrap(ErrBadRequest, err.Error())
+	}
+
+	if !tkn.Valid {
+		return false, ErrInvalidToken
+	}
+
+	return legacy, nil
+}
+
+// acceptsLegacyWebhookTokens at t.
+func (r *KeyRing) acceptsLegacyWebhookTokens(t time.Time) bool {
+	return t.Before(r.legacyWebhookUntil)
+}
+
+func loadKey(kc KeyConfig) (*key, error) {
+	k := &key{id: kc.ID}
+
+	if kc.Algorithm == AlgorithmHS256 {
+		secret, err := readKey(kc.Secret, kc.SecretFile)
+		if err != nil {
+			return nil, err
+		}
+
+		k.method = jwt.SigningMethodHS256
+		k.signKey = bytes.TrimSpace(secret)
+		k.verifyKey = k.signKey
+
+		return k, nil
+	}
+
+	privatePEM, err := readKey(kc.PrivateKey, kc.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	publicPEM, err := readKey(kc.PublicKey, kc.PublicKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kc.Algorithm {
+	case AlgorithmRS256:
+		k.method = jwt.SigningMethodRS256
+
+		if privatePEM != nil {
+			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privatePEM)
+			if err != nil {
+				return nil, err
+			}
+
+			k.signKey = privateKey
+			k.verifyKey = &privateKey.PublicKey
+		}
+
+		if publicPEM != nil {
+			if k.verifyKey, err = jwt.ParseRSAPublicKeyFromPEM(publicPEM); err != nil {
+				return nil, err
+			}
+		}
+	case AlgorithmEdDSA:
+		k.method = SigningMethodEdDSA
+
+		if privatePEM != nil {
+			privateKey, err := parseEd25519PrivateKey(privatePEM)
+			if err != nil {
+				return nil, err
+			}
+
+			k.signKey = privateKey
+			k.verifyKey = privateKey.Public()
+		}
+
+		if publicPEM != nil {
+			if k.verifyKey, err = parseEd25519PublicKey(publicPEM); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return k, nil
+}
+
+func readKey(inline, file string) ([]byte, error) {
+	if inline != "" {
+		return []byte(inline), nil
+	}
+
+	if file == "" {
+		return nil, nil
+	}
+
+	return os.ReadFile(file)
+}
+
+func parseEd25519PrivateKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid PEM private key")
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an Ed25519 key")
+	}
+
+	return privateKey, nil
+}
+
+func parseEd25519PublicKey(data []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid PEM public key")
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not an Ed25519 key")
+	}
+
+	return publicKey, nil
+}
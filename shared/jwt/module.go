@@ -0,0 +1,23 @@
+package jwt
+
+import (
+	"go.uber.org/fx"
+)
+
+// ModuleParams for jwt.
+type ModuleParams struct {
+	fx.In
+
+	Config Config
+}
+
+// NewModule for jwt.
+// nolint:gocritic
+func NewModule(p ModuleParams) (*KeyRing, error) {
+	return NewKeyRing(p.Config)
+}
+
+var (
+	// Module for uber fx.
+	Module = fx.Options(fx.Provide(NewModule))
+)
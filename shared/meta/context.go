@@ -20,6 +20,11 @@ var (
 	contextKeyAPIKey               = contextKey("api_key")
 	contextKeyClaims               = contextKey("claims")
 	contextKeyUserGroups           = contextKey("user_groups")
+	contextKeyPathParams           = contextKey("path_params")
+	contextKeyImpersonationSession = contextKey("impersonation_session")
+	contextKeyRequestMethod        = contextKey("request_method")
+	contextKeyRequestPath          = contextKey("request_path")
+	contextKeyClientIP             = contextKey("client_ip")
 )
 
 func (c contextKey) String() string { return string(c) }
@@ -213,3 +218,73 @@ func UserEmail(ctx context.Context) string {
 
 	return ""
 }
+
+// PathParams extracts the matched route path parameters from the context.
+func PathParams(ctx context.Context) map[string]string {
+	if val, ok := ctx.Value(contextKeyPathParams).(map[string]string); ok {
+		return val
+	}
+
+	return nil
+}
+
+// WithPathParams injects the matched route path parameters to the context
+func WithPathParams(ctx context.Context, params map[string]string) context.Context {
+	return context.WithValue(ctx, contextKeyPathParams, params)
+}
+
+// ImpersonationSession extracts the requested impersonation session id from the context.
+func ImpersonationSession(ctx context.Context) string {
+	if val, ok := ctx.Value(contextKeyImpersonationSession).(string); ok {
+		return val
+	}
+
+	return ""
+}
+
+// WithImpersonationSession injects the requested impersonation session id to the context
+func WithImpersonationSession(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, contextKeyImpersonationSession, sessionID)
+}
+
+// RequestMethod extracts the http method of the request from the context.
+func RequestMethod(ctx context.Context) string {
+	if val, ok := ctx.Value(contextKeyRequestMethod).(string); ok {
+		return val
+	}
+
+	return ""
+}
+
+// WithRequestMethod injects the http method of the request to the context
+func WithRequestMethod(ctx context.Context, method string) context.Context {
+	return context.WithValue(ctx, contextKeyRequestMethod, method)
+}
+
+// RequestPath extracts the url path of the request from the context.
+func RequestPath(ctx context.Context) string {
+	if val, ok := ctx.Value(contextKeyRequestPath).(string); ok {
+		return val
+	}
+
+	return ""
+}
+
+// WithRequestPath injects the url path of the request to the context
+func WithRequestPath(ctx context.Context, path string) context.Context {
+	return context.WithValue(ctx, contextKeyRequestPath, path)
+}
+
+// ClientIP extracts the ip address of the client from the context.
+func ClientIP(ctx context.Context) string {
+	if val, ok := ctx.Value(contextKeyClientIP).(string); ok {
+		return val
+	}
+
+	return ""
+}
+
+// WithClientIP injects the ip address of the client to the context
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, contextKeyClientIP, ip)
+}
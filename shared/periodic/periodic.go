@@ -0,0 +1,55 @@
+// Package periodic runs background jobs while the application runs.
+package periodic
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Job runs once per tick with the time of the tick.
+type Job func(ctx context.Context, now time.Time) error
+
+// Start runs the job on start and then every interval until the application stops. Errors are
+// logged with the name of the job and the job runs again on the next tick.
+func Start(lc fx.Lifecycle, name string, interval time.Duration, logger *zap.SugaredLogger, job Job) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	lc.Append(fx.Hook{
+		OnStart: func(_ context.Context) error {
+			go func() {
+				defer close(done)
+
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+
+				for {
+					if err := job(ctx, time.Now()); err != nil && ctx.Err() == nil {
+						logger.Errorf("%s failed: %v", name, err)
+					}
+
+					select {
+					case <-ctx.Done():
+						return
+					case <-ticker.C:
+					}
+				}
+			}()
+
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,27 @@
+// Package http contains http client/server with all necessary interceptor for logging, tracing, etc
+package http
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	httpError "github.com/nurdsoft/redesign-grp-trust-portal-api/shared/errors/http"
+)
+
+// DecodePathIDs parses the named uuid path parameters of the request in order.
+func DecodePathIDs(r *http.Request, names ...string) ([]uuid.UUID, error) {
+	params := mux.Vars(r)
+	ids := make([]uuid.UUID, 0, len(names))
+
+	for _, name := range names {
+		id, err := uuid.Parse(params[name])
+		if err != nil {
+			return nil, httpError.NewErrBadOrInvalidPathParameter(name)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
@@ -28,6 +28,16 @@ func (h *authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ctx = meta.WithRedesignToken(ctx, redesignToken)
 	}
 
+	apiKey := r.Header.Get(string(auth.APIKeyKey))
+	if apiKey != "" {
+		ctx = meta.WithAPIKey(ctx, apiKey)
+	}
+
+	impersonationSession := r.Header.Get(string(auth.ImpersonationSessionKey))
+	if impersonationSession != "" {
+		ctx = meta.WithImpersonationSession(ctx, impersonationSession)
+	}
+
 	redesignWebhookToken := r.URL.Query().Get(string(auth.RedesignWebhookTokenKey))
 	if redesignWebhookToken != "" {
 		ctx = meta.WithRedesignWebhookToken(ctx, redesignWebhookToken)
@@ -2,8 +2,11 @@
 package meta
 
 import (
+	"net"
 	"net/http"
+	"strings"
 
+	"github.com/gorilla/mux"
 	"github.com/nurdsoft/redesign-grp-trust-portal-api/shared/meta"
 
 	"github.com/google/uuid"
@@ -67,3 +70,60 @@ func (h *userAgentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 func UserAgentServerHandler(next http.Handler) http.Handler {
 	return &userAgentHandler{next}
 }
+
+type requestHandler struct {
+	next http.Handler
+}
+
+func (h *requestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = meta.WithRequestMethod(ctx, r.Method)
+	ctx = meta.WithRequestPath(ctx, r.URL.Path)
+	ctx = meta.WithClientIP(ctx, clientIP(r))
+
+	h.next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// clientIP is the last address of X-Forwarded-For, the one appended by the load balancer, or the
+// remote address. The addresses before it are set by the client and can't be trusted.
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		hops := strings.Split(forwarded[len(forwarded)-1], ",")
+		if ip := strings.TrimSpace(hops[len(hops)-1]); ip != "" {
+			return ip
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// RequestServerHandler injects the method, path and client ip of the request into context
+func RequestServerHandler(next http.Handler) http.Handler {
+	return &requestHandler{next}
+}
+
+type pathParamsHandler struct {
+	next http.Handler
+}
+
+func (h *pathParamsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if vars := mux.Vars(r); len(vars) > 0 {
+		ctx = meta.WithPathParams(ctx, vars)
+	}
+
+	h.next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PathParamsServerHandler injects the matched route path parameters into context.
+// It has to run as a router middleware, after the route has been matched.
+func PathParamsServerHandler(next http.Handler) http.Handler {
+	return &pathParamsHandler{next}
+}
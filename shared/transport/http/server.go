@@ -37,6 +37,8 @@ func (s *Server) Stop(ctx context.Context) error {
 }
 
 func (s *Server) registerHandlers() {
+	s.router.Use(meta.PathParamsServerHandler)
+
 	var next http.Handler = s.router
 
 	if s.options.logger != nil {
@@ -45,6 +47,7 @@ func (s *Server) registerHandlers() {
 
 	next = auth.ServerHandler(next)
 	next = meta.UserAgentServerHandler(next)
+	next = meta.RequestServerHandler(next)
 	next = meta.RequestIDServerHandler(next)
 
 	s.server.Handler = next
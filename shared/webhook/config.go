@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultTolerance bounds the age of a delivery when none is configured.
+const DefaultTolerance = 5 * time.Minute
+
+// Config of the signing keys of the webhooks we receive.
+type Config struct {
+	DocuSign SigningConfig
+	Calendly SigningConfig
+}
+
+// SigningConfig of a webhook provider. A delivery signed with any of the Secrets is accepted,
+// so a new key can be added before the provider switches to it. Deliveries older than
+// Tolerance are rejected as replays; DocuSign retries keep their original generatedDateTime,
+// so its tolerance should cover the retries that should still be accepted.
+type SigningConfig struct {
+	Secrets   []string
+	Tolerance time.Duration
+}
+
+func (c SigningConfig) tolerance() time.Duration {
+	if c.Tolerance <= 0 {
+		return DefaultTolerance
+	}
+
+	return c.Tolerance
+}
+
+// Validate config
+func (c *Config) Validate() error {
+	var errs []string
+
+	providers := []struct {
+		name string
+		cfg  SigningConfig
+	}{{"DocuSign", c.DocuSign}, {"Calendly", c.Calendly}}
+
+	for _, p := range providers {
+		name, sc := p.name, p.cfg
+
+		if len(sc.Secrets) == 0 {
+			errs = append(errs, fmt.Sprintf("Webhooks %s secrets shouldn't be empty", name))
+		}
+
+		for _, s := range sc.Secrets {
+			if s == "" {
+				errs = append(errs, fmt.Sprintf("Webhooks %s secret shouldn't be empty", name))
+				break
+			}
+		}
+
+		if sc.Tolerance < 0 {
+			errs = append(errs, fmt.Sprintf("Webhooks %s tolerance shouldn't be negative", name))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Errorf(strings.Join(errs, ","))
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package webhook
+
+import "github.com/pkg/errors"
+
+var (
+	ErrSignatureInvalid = errors.New("webhook signature is invalid")
+	ErrSignatureExpired = errors.New("webhook delivery is too old")
+)
+
+// IsUnauthorizedError checks if it's any of the above webhook errors.
+func IsUnauthorizedError(err error) bool {
+	cause := errors.Cause(err)
+
+	switch cause {
+	case ErrSignatureInvalid, ErrSignatureExpired:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,106 @@
+// Package webhook verifies the signatures of the webhooks sent by DocuSign and Calendly.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// docuSignSignatureHeader is followed by the number of the key, DocuSign Connect sends
+	// one header per active key.
+	docuSignSignatureHeader = "X-DocuSign-Signature-"
+	calendlySignatureHeader = "Calendly-Webhook-Signature"
+	// maxDocuSignSignatures DocuSign Connect allows per account.
+	maxDocuSignSignatures = 100
+)
+
+// VerifyDocuSign checks the HMAC signatures of a DocuSign Connect delivery: the base64 encoded
+// HMAC-SHA256 of the body in the X-DocuSign-Signature-N headers. Connect sends no timestamp
+// header; the generatedDateTime of the payload is checked with CheckTimestamp once decoded.
+func VerifyDocuSign(cfg SigningConfig, header http.Header, body []byte) error {
+	for i := 1; i <= maxDocuSignSignatures; i++ {
+		signature := header.Get(docuSignSignatureHeader + strconv.Itoa(i))
+		if signature == "" {
+			break
+		}
+
+		sig, err := base64.StdEncoding.DecodeString(signature)
+		if err != nil {
+			continue
+		}
+
+		for _, secret := range cfg.Secrets {
+			if hmac.Equal(sig, sign(secret, body)) {
+				return nil
+			}
+		}
+	}
+
+	return ErrSignatureInvalid
+}
+
+// VerifyCalendly checks the Calendly-Webhook-Signature header, "t=<unix time>,v1=<signature>",
+// where the signature is the hex encoded HMAC-SHA256 of the time, a dot and the body.
+func VerifyCalendly(cfg SigningConfig, header http.Header, body []byte, now time.Time) error {
+	var t, v1 string
+	for _, part := range strings.Split(header.Get(calendlySignatureHeader), ",") {
+		k, v, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch k {
+		case "t":
+			t = v
+		case "v1":
+			v1 = v
+		}
+	}
+
+	unix, err := strconv.ParseInt(t, 10, 64)
+	if err != nil {
+		return errors.Wrap(ErrSignatureInvalid, "missing timestamp")
+	}
+
+	sig, err := hex.DecodeString(v1)
+	if err != nil || len(sig) == 0 {
+		return errors.Wrap(ErrSignatureInvalid, "missing signature")
+	}
+
+	payload := append([]byte(t+"."), body...)
+
+	for _, secret := range cfg.Secrets {
+		if hmac.Equal(sig, sign(secret, payload)) {
+			return CheckTimestamp(cfg, time.Unix(unix, 0), now)
+		}
+	}
+
+	return ErrSignatureInvalid
+}
+
+// CheckTimestamp rejects deliveries generated more than the tolerance away from now.
+func CheckTimestamp(cfg SigningConfig, generatedAt, now time.Time) error {
+	age := now.Sub(generatedAt)
+	if age < 0 {
+		age = -age
+	}
+
+	if age > cfg.tolerance() {
+		return errors.Wrap(ErrSignatureExpired, fmt.Sprintf("generated at %s", generatedAt.UTC().Format(time.RFC3339)))
+	}
+
+	return nil
+}
+
+func sign(secret string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload) // nolint: errcheck
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestVerifyDocuSign(t *testing.T) {
+	Convey("Given a DocuSign Connect delivery", t, func() {
+		cfg := SigningConfig{Secrets: []string{"old", "new"}}
+		body := []byte(`{"event":"envelope-completed"}`)
+		header := http.Header{}
+
+		Convey("A signature of any configured key is accepted", func() {
+			header.Set("X-DocuSign-Signature-1", base64.StdEncoding.EncodeToString(sign("unknown", body)))
+			header.Set("X-DocuSign-Signature-2", base64.StdEncoding.EncodeToString(sign("new", body)))
+
+			So(VerifyDocuSign(cfg, header, body), ShouldBeNil)
+		})
+
+		Convey("A tampered body is rejected", func() {
+			header.Set("X-DocuSign-Signature-1", base64.StdEncoding.EncodeToString(sign("old", body)))
+
+			err := VerifyDocuSign(cfg, header, []byte(`{"event":"envelope-voided"}`))
+			So(errors.Cause(err), ShouldEqual, ErrSignatureInvalid)
+		})
+
+		Convey("An unsigned delivery is rejected", func() {
+			So(errors.Cause(VerifyDocuSign(cfg, header, body)), ShouldEqual, ErrSignatureInvalid)
+		})
+	})
+}
+
+func TestVerifyCalendly(t *testing.T) {
+	Convey("Given a Calendly delivery", t, func() {
+		cfg := SigningConfig{Secrets: []string{"key"}, Tolerance: time.Minute}
+		body := []byte(`{"event":"invitee.created"}`)
+		now := time.Unix(1679900000, 0)
+
+		signed := func(secret string, at time.Time) http.Header {
+			ts := fmt.Sprint(at.Unix())
+			header := http.Header{}
+			header.Set("Calendly-Webhook-Signature", "t="+ts+",v1="+hex.EncodeToString(sign(secret, append([]byte(ts+"."), body...))))
+
+			return header
+		}
+
+		Convey("A recent delivery signed with the key is accepted", func() {
+			So(VerifyCalendly(cfg, signed("key", now.Add(-30*time.Second)), body, now), ShouldBeNil)
+		})
+
+		Convey("A delivery signed with another key is rejected", func() {
+			err := VerifyCalendly(cfg, signed("other", now), body, now)
+			So(errors.Cause(err), ShouldEqual, ErrSignatureInvalid)
+		})
+
+		Convey("A replayed delivery is rejected", func() {
+			err := VerifyCalendly(cfg, signed("key", now.Add(-2*time.Minute)), body, now)
+			So(errors.Cause(err), ShouldEqual, ErrSignatureExpired)
+		})
+
+		Convey("A malformed header is rejected", func() {
+			header := http.Header{}
+			header.Set("Calendly-Webhook-Signature", "v1=zz")
+
+			So(errors.Cause(VerifyCalendly(cfg, header, body, now)), ShouldEqual, ErrSignatureInvalid)
+		})
+	})
+}
+
+func TestCheckTimestamp(t *testing.T) {
+	Convey("The tolerance defaults to 5 minutes", t, func() {
+		now := time.Now()
+
+		So(CheckTimestamp(SigningConfig{}, now.Add(-4*time.Minute), now), ShouldBeNil)
+		So(errors.Cause(CheckTimestamp(SigningConfig{}, now.Add(-6*time.Minute), now)), ShouldEqual, ErrSignatureExpired)
+		So(errors.Cause(CheckTimestamp(SigningConfig{}, now.Add(6*time.Minute), now)), ShouldEqual, ErrSignatureExpired)
+	})
+}